@@ -22,6 +22,14 @@ func main() {
 		}
 		return
 	}
+	// MCP stdio mode: expose Mycelis tools to a local MCP client.
+	// Example: server mcp
+	if len(os.Args) > 1 && os.Args[1] == "mcp" {
+		if err := runMCPStdio(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	log.Println("Starting Mycelis Core [System]...")
 
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// mcpServePath matches the streamable HTTP MCP endpoint of a running core.
+const mcpServePath = "/api/v1/mcp/serve"
+
+// runMCPStdio serves the Mycelis MCP tools on stdin/stdout for IDE agents.
+// It bridges to the HTTP MCP endpoint of a running core with the action CLI
+// credentials, so scopes and audit records are enforced server side.
+// Example: server mcp
+func runMCPStdio() error {
+	cfg, err := loadActionRuntimeConfig()
+	if err != nil {
+		return err
+	}
+	endpoint, err := resolveActionURL(cfg.APIBaseURL, mcpServePath)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{}
+	for k, v := range cfg.Headers {
+		headers[k] = v
+	}
+	if cfg.APIKey != "" && headers["Authorization"] == "" {
+		headers["Authorization"] = "Bearer " + cfg.APIKey
+	}
+
+	remote, err := client.NewStreamableHttpClient(endpoint,
+		transport.WithHTTPHeaders(headers),
+		transport.WithHTTPTimeout(time.Duration(cfg.TimeoutSeconds)*time.Second),
+	)
+	if err != nil {
+		return fmt.Errorf("mcp client for %s: %w", endpoint, err)
+	}
+	defer remote.Close()
+
+	ctx := context.Background()
+	if err := remote.Start(ctx); err != nil {
+		return fmt.Errorf("connect %s: %w", endpoint, err)
+	}
+	initReq := mcp.InitializeRequest{}
+	initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initReq.Params.ClientInfo = mcp.Implementation{Name: "mycelis-stdio", Version: "1.0.0"}
+	initResult, err := remote.Initialize(ctx, initReq)
+	if err != nil {
+		return fmt.Errorf("initialize %s: %w", endpoint, err)
+	}
+	tools, err := remote.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		return fmt.Errorf("list tools from %s: %w", endpoint, err)
+	}
+
+	local := mcpserver.NewMCPServer(initResult.ServerInfo.Name, initResult.ServerInfo.Version,
+		mcpserver.WithToolCapabilities(false),
+		mcpserver.WithInstructions(initResult.Instructions),
+	)
+	for _, tool := range tools.Tools {
+		local.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return remote.CallTool(ctx, req)
+		})
+	}
+	return mcpserver.ServeStdio(local)
+}
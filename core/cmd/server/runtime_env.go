@@ -65,6 +65,15 @@ func defaultMCPBootstrapEnabled() bool {
 	}
 }
 
+func durableDeliveryRequested() bool {
+	switch strings.TrimSpace(strings.ToLower(os.Getenv("MYCELIS_NATS_JETSTREAM"))) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
 func resolveLocalAuthRuntimeConfig() localAuthRuntimeConfig {
 	return localAuthRuntimeConfig{
 		PrimaryAPIKey:      envOrDefault("MYCELIS_API_KEY", ""),
@@ -9,6 +9,7 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/mycelis/core/internal/cognitive"
 	"github.com/mycelis/core/internal/governance"
+	"github.com/mycelis/core/internal/identity"
 	"github.com/mycelis/core/internal/memory"
 	"github.com/mycelis/core/internal/router"
 	mycelis_nats "github.com/mycelis/core/internal/transport/nats"
@@ -16,6 +17,9 @@ import (
 	"github.com/nats-io/nats.go"
 )
 
+// governanceSweepInterval is how often expired approvals are settled.
+const governanceSweepInterval = 30 * time.Second
+
 type coreRuntime struct {
 	SharedDB         *sql.DB
 	DBURL            string
@@ -36,6 +40,7 @@ func startCoreRuntime(ctx context.Context, natsURL string) *coreRuntime {
 	sharedDB := openSharedDB(ctx, dbURL)
 	cogRouter := loadCognitiveRouter(sharedDB)
 	guard := loadGovernanceGuard()
+	startGovernanceApprovals(ctx, guard, sharedDB)
 	memService := startMemoryService(ctx, dbURL)
 	natsRuntime := connectNATSLanes(natsURL)
 
@@ -115,6 +120,25 @@ func loadGovernanceGuard() *governance.Guard {
 	return guard
 }
 
+// startGovernanceApprovals makes approvals durable and audited, restores the
+// requests pending at shutdown, starts the expiry/escalation sweeper, and
+// logs intercept decisions for policy simulation.
+func startGovernanceApprovals(ctx context.Context, guard *governance.Guard, sharedDB *sql.DB) {
+	if guard == nil {
+		return
+	}
+	if sharedDB != nil {
+		if err := guard.SetApprovalStore(ctx, governance.NewApprovalRepository(sharedDB)); err != nil {
+			log.Printf("WARN: Governance approvals not restored: %v", err)
+		}
+		guard.SetAuditLog(identity.NewStore(sharedDB))
+		guard.SetDecisionLog(ctx, governance.NewDecisionRepository(sharedDB))
+		log.Println("Governance Approval Store Active.")
+		log.Println("Governance Decision Log Active.")
+	}
+	guard.StartExpirySweeper(ctx, governanceSweepInterval)
+}
+
 func startMemoryService(ctx context.Context, dbURL string) *memory.Service {
 	memService, err := memory.NewService(dbURL)
 	if err != nil {
@@ -220,3 +244,18 @@ func connectNATSWithRetry(url, connectionName string) (*mycelis_nats.Client, err
 	}
 	return nil, err
 }
+
+// enableDurableDelivery turns on JetStream-backed team delivery when
+// MYCELIS_NATS_JETSTREAM is set. Servers without JetStream keep core NATS.
+func enableDurableDelivery(nc *nats.Conn) *mycelis_nats.Durable {
+	if nc == nil || !durableDeliveryRequested() {
+		return nil
+	}
+	durable, err := mycelis_nats.EnableDurable(nc, mycelis_nats.DurableConfig{})
+	if err != nil {
+		log.Printf("WARN: JetStream durable delivery unavailable: %v. Teams use core NATS.", err)
+		return nil
+	}
+	log.Printf("[nats] JetStream durable delivery enabled on stream %s", mycelis_nats.DefaultDurableStream)
+	return durable
+}
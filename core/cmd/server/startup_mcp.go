@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mycelis/core/internal/exchange"
+	"github.com/mycelis/core/internal/mcp"
+	"github.com/mycelis/core/internal/secrets"
+	mycelisSignal "github.com/mycelis/core/internal/signal"
+)
+
+func startMCPRuntime(ctx context.Context, sharedDB *sql.DB) (*mcp.Service, *mcp.ClientPool, *mcp.ToolSetService, *secrets.Store) {
+	mcpService := mcp.NewService(sharedDB)
+	mcpToolSets := mcp.NewToolSetService(sharedDB)
+	mcpService.ToolSets = mcpToolSets
+	mcpPool := mcp.NewClientPool(mcpService)
+	secretStore, err := secrets.NewStoreFromEnv(ctx, sharedDB)
+	if err != nil {
+		log.Printf("WARN: Secret store disabled, secret: references will not resolve: %v", err)
+	} else {
+		if n, err := secretStore.Migrate(ctx); err != nil {
+			log.Printf("WARN: Secret store migration incomplete: %v", err)
+		} else if n > 0 {
+			log.Printf("Secret Store: re-sealed %d value(s) under the derived key.", n)
+		}
+		mcpPool.SetSecretStore(secretStore)
+		log.Println("Secret Store Active.")
+	}
+	if servers, err := mcpService.List(ctx); err == nil {
+		for i, server := range servers {
+			normalized, err := mcpService.EnsureRuntimeDefaults(ctx, server)
+			if err != nil {
+				log.Printf("WARN: Failed to normalize MCP runtime defaults for %s: %v", server.Name, err)
+				continue
+			}
+			servers[i] = normalized
+		}
+		mcpPool.ReconnectAll(ctx, servers)
+	} else {
+		log.Printf("WARN: Failed to list MCP servers for reconnect: %v", err)
+	}
+	log.Println("MCP Ingress Active.")
+	return mcpService, mcpPool, mcpToolSets, secretStore
+}
+
+// watchMCPResourceUpdates forwards subscribed MCP resource changes to the
+// signal stream so agents and the UI can re-read them.
+func watchMCPResourceUpdates(pool *mcp.ClientPool, stream *mycelisSignal.StreamHandler) {
+	if stream == nil {
+		return
+	}
+	pool.OnResourceUpdated(func(serverID uuid.UUID, uri string) {
+		payload, _ := json.Marshal(map[string]any{
+			"type":      "mcp_resource_updated",
+			"server_id": serverID.String(),
+			"uri":       uri,
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+		})
+		stream.Broadcast(string(payload))
+	})
+}
+
+// superviseMCPServers health-checks connected MCP servers, reconnects the
+// ones that fail, and reports status changes to the MCP activity feed and
+// the signal stream.
+func superviseMCPServers(ctx context.Context, pool *mcp.ClientPool, exchangeSvc *exchange.Service, stream *mycelisSignal.StreamHandler) {
+	cfg := mcp.DefaultSupervisorConfig()
+	if raw := strings.TrimSpace(os.Getenv("MYCELIS_MCP_HEALTH_INTERVAL_SECONDS")); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			cfg.Interval = time.Duration(n) * time.Second
+		}
+	}
+	pool.OnStatusChange(func(change mcp.StatusChange) {
+		summary := fmt.Sprintf("MCP server %s is %s (was %s).", change.ServerName, change.Status, change.Previous)
+		if exchangeSvc != nil {
+			_, err := exchangeSvc.PublishMCPResult(context.Background(), exchange.MCPNormalizationInput{
+				ServerID:      change.ServerID.String(),
+				ServerName:    change.ServerName,
+				ToolName:      "server_status",
+				Summary:       summary,
+				ResultPreview: strings.TrimSpace(summary + " " + change.Detail),
+				Status:        change.Status,
+				Result:        map[string]any{"previous": change.Previous, "status": change.Status, "detail": change.Detail, "attempts": change.Attempts},
+			})
+			if err != nil {
+				log.Printf("WARN: Failed to record MCP status change for %s: %v", change.ServerName, err)
+			}
+		}
+		if stream != nil {
+			payload, _ := json.Marshal(map[string]any{
+				"type":        "mcp_server_status",
+				"server_id":   change.ServerID.String(),
+				"server_name": change.ServerName,
+				"status":      change.Status,
+				"previous":    change.Previous,
+				"detail":      change.Detail,
+				"timestamp":   change.Timestamp.UTC().Format(time.RFC3339),
+			})
+			stream.Broadcast(string(payload))
+		}
+	})
+	go pool.Supervise(ctx, cfg)
+	log.Printf("MCP Supervisor Active. (health check every %s)", cfg.Interval)
+}
+
+// reportMCPSandboxViolations records sandboxed servers hitting their limits
+// in the MCP activity feed and on the signal stream.
+func reportMCPSandboxViolations(pool *mcp.ClientPool, exchangeSvc *exchange.Service, stream *mycelisSignal.StreamHandler) {
+	pool.OnSandboxViolation(func(v mcp.SandboxViolation) {
+		summary := fmt.Sprintf("MCP server %s violated its sandbox (%s).", v.ServerName, v.Kind)
+		if exchangeSvc != nil {
+			_, err := exchangeSvc.PublishMCPResult(context.Background(), exchange.MCPNormalizationInput{
+				ServerID:      v.ServerID.String(),
+				ServerName:    v.ServerName,
+				ToolName:      "sandbox_violation",
+				Summary:       summary,
+				ResultPreview: strings.TrimSpace(summary + " " + v.Detail),
+				Status:        "violation",
+				Result:        map[string]any{"kind": v.Kind, "detail": v.Detail},
+			})
+			if err != nil {
+				log.Printf("WARN: Failed to record MCP sandbox violation for %s: %v", v.ServerName, err)
+			}
+		}
+		if stream != nil {
+			payload, _ := json.Marshal(map[string]any{
+				"type":        "mcp_sandbox_violation",
+				"server_id":   v.ServerID.String(),
+				"server_name": v.ServerName,
+				"kind":        v.Kind,
+				"detail":      v.Detail,
+				"timestamp":   v.Timestamp.UTC().Format(time.RFC3339),
+			})
+			stream.Broadcast(string(payload))
+		}
+	})
+}
@@ -21,11 +21,14 @@ import (
 	"github.com/mycelis/core/internal/memory"
 	"github.com/mycelis/core/internal/provisioning"
 	"github.com/mycelis/core/internal/registry"
+	"github.com/mycelis/core/internal/responsecache"
 	"github.com/mycelis/core/internal/runs"
 	"github.com/mycelis/core/internal/searchcap"
+	"github.com/mycelis/core/internal/secrets"
 	"github.com/mycelis/core/internal/server"
 	mycelisSignal "github.com/mycelis/core/internal/signal"
 	"github.com/mycelis/core/internal/swarm"
+	"github.com/mycelis/core/internal/usage"
 )
 
 type productServices struct {
@@ -34,6 +37,8 @@ type productServices struct {
 	MCP             *mcp.Service
 	MCPPool         *mcp.ClientPool
 	MCPToolSets     *mcp.ToolSetService
+	Secrets         *secrets.Store
+	Embeddings      *memory.EmbeddingMigrator
 	Catalogue       *catalogue.Service
 	Artifacts       *artifacts.Service
 	Exchange        *exchange.Service
@@ -50,6 +55,7 @@ type productServices struct {
 	RunsManager     *runs.Manager
 	ConversationLog *conversations.Store
 	Capabilities    *capabilities.Service
+	UsageLedger     *usage.Ledger
 }
 
 func startProductRuntime(ctx context.Context, mux *http.ServeMux, core *coreRuntime) *productRuntime {
@@ -141,6 +147,14 @@ func startProductServices(ctx context.Context, core *coreRuntime) productService
 	if memService != nil && cogRouter != nil {
 		services.Archivist = memory.NewArchivist(memService, cogRouter)
 		log.Println("Archivist Engine Active.")
+		memService.SetEmbedder(cogRouter)
+		services.Embeddings = memory.NewEmbeddingMigrator(memService)
+		services.Embeddings.Start(ctx)
+		log.Printf("Memory Embeddings Active. (model %s)", cogRouter.EmbedModel())
+		if profile := envOrDefault("MYCELIS_MEMORY_RERANK_PROFILE", ""); profile != "" {
+			memService.SetReranker(memory.NewCognitiveReranker(cogRouter, profile))
+			log.Printf("Memory Reranker Active. (profile %s)", profile)
+		}
 	}
 	if sharedDB != nil {
 		if services.Search != nil {
@@ -154,12 +168,24 @@ func startProductServices(ctx context.Context, core *coreRuntime) productService
 		services.RunsManager = runs.NewManager(sharedDB)
 		services.EventStore = events.NewStore(sharedDB, core.NC)
 		services.ConversationLog = conversations.NewStore(sharedDB)
+		services.UsageLedger = usage.NewLedger(sharedDB)
+		if cogRouter != nil {
+			cogRouter.SetUsageRecorder(services.UsageLedger)
+			budgets := usage.NewEnforcer(services.UsageLedger, core.Guard, services.EventStore)
+			if err := budgets.SetStore(ctx, usage.NewBudgetRepository(sharedDB)); err != nil {
+				log.Printf("WARN: Budget pause state not restored: %v", err)
+			}
+			cogRouter.SetBudgetGate(budgets)
+			cogRouter.SetResponseCache(responsecache.NewStore(sharedDB))
+		}
 		log.Println("Registry Service Active.")
 		log.Println("Agent Catalogue Active.")
 		log.Println("V7 Inception Recipe Store Active.")
 		log.Println("V7 Event Spine Active. (runs + events stores ready)")
 		log.Println("V7 Conversation Store Active.")
-		services.MCP, services.MCPPool, services.MCPToolSets = startMCPRuntime(ctx, sharedDB)
+		log.Println("Inference Usage Ledger Active.")
+		log.Println("Inference Response Cache Active.")
+		services.MCP, services.MCPPool, services.MCPToolSets, services.Secrets = startMCPRuntime(ctx, sharedDB)
 		services.Artifacts = startArtifactRuntime(ctx, sharedDB)
 		services.Exchange = startExchangeRuntime(ctx, sharedDB, cogRouter, memService)
 	}
@@ -167,8 +193,13 @@ func startProductServices(ctx context.Context, core *coreRuntime) productService
 		services.MetaArchitect = cognitive.NewMetaArchitect(cogRouter)
 		log.Println("Meta-Architect Active.")
 	}
+	var mcpResources swarm.MCPResourceProvider
 	if services.MCP != nil && services.MCPPool != nil {
-		services.ToolExecutor = mcp.NewToolExecutorAdapter(services.MCP, services.MCPPool)
+		adapter := mcp.NewToolExecutorAdapter(services.MCP, services.MCPPool)
+		services.ToolExecutor, mcpResources = adapter, adapter
+		watchMCPResourceUpdates(services.MCPPool, services.Stream)
+		superviseMCPServers(ctx, services.MCPPool, services.Exchange, services.Stream)
+		reportMCPSandboxViolations(services.MCPPool, services.Exchange, services.Stream)
 	}
 	if providers := services.Comms.ListProviders(); len(providers) > 0 {
 		ready := 0
@@ -192,6 +223,8 @@ func startProductServices(ctx context.Context, core *coreRuntime) productService
 			DB:        sharedDB,
 			Exchange:  services.Exchange,
 			Search:    services.Search,
+
+			MCPResources: mcpResources,
 		})
 	}
 	if core.NC != nil {
@@ -203,28 +236,6 @@ func startProductServices(ctx context.Context, core *coreRuntime) productService
 	return services
 }
 
-func startMCPRuntime(ctx context.Context, sharedDB *sql.DB) (*mcp.Service, *mcp.ClientPool, *mcp.ToolSetService) {
-	mcpService := mcp.NewService(sharedDB)
-	mcpToolSets := mcp.NewToolSetService(sharedDB)
-	mcpService.ToolSets = mcpToolSets
-	mcpPool := mcp.NewClientPool(mcpService)
-	if servers, err := mcpService.List(ctx); err == nil {
-		for i, server := range servers {
-			normalized, err := mcpService.EnsureRuntimeDefaults(ctx, server)
-			if err != nil {
-				log.Printf("WARN: Failed to normalize MCP runtime defaults for %s: %v", server.Name, err)
-				continue
-			}
-			servers[i] = normalized
-		}
-		mcpPool.ReconnectAll(ctx, servers)
-	} else {
-		log.Printf("WARN: Failed to list MCP servers for reconnect: %v", err)
-	}
-	log.Println("MCP Ingress Active.")
-	return mcpService, mcpPool, mcpToolSets
-}
-
 func startArtifactRuntime(ctx context.Context, sharedDB *sql.DB) *artifacts.Service {
 	dataDir := resolveArtifactRoot()
 	if err := ensureStorageLayout(resolveWorkspaceRoot(), dataDir); err != nil {
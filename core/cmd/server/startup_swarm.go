@@ -39,6 +39,9 @@ func startSomaRuntime(
 
 	log.Printf("Soma startup instantiating runtime organization from bootstrap template bundle %s", selection.Bundle.ID)
 	soma := swarm.NewSoma(core.NC, core.Guard, registry, core.CogRouter, services.Stream, services.ToolExecutor, services.InternalTools)
+	if durable := enableDurableDelivery(core.NC); durable != nil {
+		soma.SetDurableDelivery(durable)
+	}
 	startupRouting := resolveStartupProviderRouting(
 		selection,
 		os.Getenv("MYCELIS_TEAM_PROVIDER_MAP"),
@@ -60,6 +63,9 @@ func startSomaRuntime(
 	if services.ConversationLog != nil {
 		soma.SetConversationLogger(services.ConversationLog)
 	}
+	if core.SharedDB != nil {
+		soma.SetTeamScheduleStore(triggers.NewStore(core.SharedDB))
+	}
 	wireSomaMCPDescriptions(ctx, soma, services.MCP)
 	if err := soma.Start(); err != nil {
 		log.Printf("WARN: Failed to start Soma: %v", err)
@@ -167,9 +173,16 @@ func wireAdminServices(ctx context.Context, mux *http.ServeMux, core *coreRuntim
 	adminSrv.Comms = services.Comms
 	adminSrv.Search = services.Search
 	adminSrv.Conversations = services.ConversationLog
+	adminSrv.Usage = services.UsageLedger
 	adminSrv.Inception = services.Inception
 	adminSrv.MCPToolSets = services.MCPToolSets
+	adminSrv.Secrets = services.Secrets
+	adminSrv.Embeddings = services.Embeddings
 	adminSrv.Capabilities = services.Capabilities
+	adminSrv.InternalTools = services.InternalTools
+	if adminSrv.Cognitive != nil {
+		adminSrv.Cognitive.SetOrganizationSampling(adminSrv.Organizations)
+	}
 	adminSrv.RegisterRoutes(mux)
 	adminSrv.StartLoopScheduler(ctx)
 	startTriggerEngine(ctx, core.SharedDB, core.NC, adminSrv, services.EventStore, services.RunsManager)
@@ -121,6 +121,47 @@ func manifestFromMCPTool(tool mcp.ToolDef) Manifest {
 	}
 }
 
+func manifestFromMCPResource(res mcp.ResourceDef) Manifest {
+	return Manifest{
+		ID:                  "mcp_resource:" + res.ID.String(),
+		DisplayName:         displayName(res.Name, res.URI),
+		Kind:                "mcp_resource",
+		Source:              "mcp",
+		Status:              "installed",
+		RiskClass:           "medium-risk",
+		Description:         res.Description,
+		ToolRefs:            []string{"read_mcp_resource", "subscribe_mcp_resource"},
+		DefaultAllowedRoles: []string{"soma", "team_lead", "mcp"},
+		AuditRequired:       true,
+		Metadata: map[string]any{
+			"server_id":   res.ServerID.String(),
+			"server_name": res.ServerName,
+			"uri":         res.URI,
+			"mime_type":   res.MIMEType,
+		},
+	}
+}
+
+func manifestFromMCPPrompt(prompt mcp.PromptDef) Manifest {
+	return Manifest{
+		ID:                  "mcp_prompt:" + prompt.ID.String(),
+		DisplayName:         prompt.Name,
+		Kind:                "mcp_prompt",
+		Source:              "mcp",
+		Status:              "installed",
+		RiskClass:           "medium-risk",
+		Description:         prompt.Description,
+		ToolRefs:            []string{"get_mcp_prompt"},
+		DefaultAllowedRoles: []string{"soma", "team_lead", "mcp"},
+		AuditRequired:       true,
+		Metadata: map[string]any{
+			"server_id":   prompt.ServerID.String(),
+			"server_name": prompt.ServerName,
+			"arguments":   prompt.Arguments,
+		},
+	}
+}
+
 func manifestFromMCPLibraryEntry(category string, entry mcp.LibraryEntry) Manifest {
 	return Manifest{
 		ID:                  "mcp_library:" + entry.Name,
@@ -152,7 +193,7 @@ func riskForInternalTool(name string) string {
 	switch {
 	case strings.Contains(lower, "local_command"):
 		return "high-risk"
-	case strings.Contains(lower, "publish"), strings.Contains(lower, "exchange"), strings.Contains(lower, "write"):
+	case strings.Contains(lower, "publish"), strings.Contains(lower, "exchange"), strings.Contains(lower, "write"), strings.Contains(lower, "mcp"):
 		return "medium-risk"
 	default:
 		return "low-risk"
@@ -20,6 +20,13 @@ type MCPRegistry interface {
 	ListAllTools(context.Context) ([]mcp.ToolDef, error)
 }
 
+// MCPResourceRegistry is implemented by MCP registries that also cache the
+// resources and prompts of connected servers.
+type MCPResourceRegistry interface {
+	ListAllResources(context.Context) ([]mcp.ResourceDef, error)
+	ListAllPrompts(context.Context) ([]mcp.PromptDef, error)
+}
+
 type InternalToolLister interface {
 	ListDescriptions() map[string]string
 }
@@ -239,6 +246,18 @@ func (s *Service) derive(ctx context.Context, derivedAt time.Time) []Manifest {
 				add(manifestFromMCPTool(tool))
 			}
 		}
+		if resources, ok := s.deps.MCP.(MCPResourceRegistry); ok {
+			if list, err := resources.ListAllResources(ctx); err == nil {
+				for _, res := range list {
+					add(manifestFromMCPResource(res))
+				}
+			}
+			if list, err := resources.ListAllPrompts(ctx); err == nil {
+				for _, prompt := range list {
+					add(manifestFromMCPPrompt(prompt))
+				}
+			}
+		}
 	}
 	if s.deps.MCPLibrary != nil {
 		for _, cat := range s.deps.MCPLibrary.Categories {
@@ -25,6 +25,20 @@ func (f fakeMCPRegistry) ListAllTools(context.Context) ([]mcp.ToolDef, error) {
 	return f.tools, nil
 }
 
+type fakeMCPResourceRegistry struct {
+	fakeMCPRegistry
+	resources []mcp.ResourceDef
+	prompts   []mcp.PromptDef
+}
+
+func (f fakeMCPResourceRegistry) ListAllResources(context.Context) ([]mcp.ResourceDef, error) {
+	return f.resources, nil
+}
+
+func (f fakeMCPResourceRegistry) ListAllPrompts(context.Context) ([]mcp.PromptDef, error) {
+	return f.prompts, nil
+}
+
 type fakeToolLister map[string]string
 
 func (f fakeToolLister) ListDescriptions() map[string]string {
@@ -125,6 +139,33 @@ func TestServiceDerivesCapabilityManifestSnapshot(t *testing.T) {
 	}
 }
 
+func TestServiceDerivesMCPResourceAndPromptManifests(t *testing.T) {
+	serverID := uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa")
+	resourceID := uuid.MustParse("cccccccc-cccc-cccc-cccc-cccccccccccc")
+	promptID := uuid.MustParse("dddddddd-dddd-dddd-dddd-dddddddddddd")
+	svc := NewService(Dependencies{
+		ExchangeCapabilities: []exchange.CapabilityDefinition{},
+		MCP: fakeMCPResourceRegistry{
+			resources: []mcp.ResourceDef{{ID: resourceID, ServerID: serverID, ServerName: "postgres", URI: "postgres://main/schema", Name: "main schema"}},
+			prompts:   []mcp.PromptDef{{ID: promptID, ServerID: serverID, ServerName: "postgres", Name: "explain_query"}},
+		},
+		HostCommands: func() []string { return nil },
+	})
+
+	snap, err := svc.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	resource := assertManifest(t, snap, "mcp_resource:"+resourceID.String(), "mcp_resource", "installed")
+	if resource.Metadata["uri"] != "postgres://main/schema" || resource.ToolRefs[0] != "read_mcp_resource" {
+		t.Fatalf("resource manifest = %+v", resource)
+	}
+	prompt := assertManifest(t, snap, "mcp_prompt:"+promptID.String(), "mcp_prompt", "installed")
+	if !prompt.AuditRequired || prompt.ToolRefs[0] != "get_mcp_prompt" {
+		t.Fatalf("prompt manifest = %+v", prompt)
+	}
+}
+
 func TestServiceGetUsesCachedSnapshot(t *testing.T) {
 	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
 	svc := NewService(Dependencies{
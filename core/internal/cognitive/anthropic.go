@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strings"
 )
 
 const (
@@ -48,8 +49,10 @@ func NewAnthropicAdapter(config ProviderConfig) (*AnthropicAdapter, error) {
 // --- Request/Response Structs ---
 
 type anthropicMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role string `json:"role"`
+	// Content is a string, or []anthropicInputBlock for turns that carry
+	// tool_use or tool_result blocks.
+	Content any `json:"content"`
 }
 
 type anthropicRequest struct {
@@ -57,25 +60,94 @@ type anthropicRequest struct {
 	Messages  []anthropicMessage `json:"messages"`
 	System    string             `json:"system,omitempty"`
 	MaxTokens int                `json:"max_tokens,omitempty"`
+	Stream    bool               `json:"stream,omitempty"`
+	Tools     []anthropicTool    `json:"tools,omitempty"`
+
+	Temperature   *float64 `json:"temperature,omitempty"`
+	TopP          *float64 `json:"top_p,omitempty"`
+	StopSequences []string `json:"stop_sequences,omitempty"`
+}
+
+type anthropicTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+// anthropicContentBlock is a text or tool_use block of a Messages response.
+type anthropicContentBlock struct {
+	Type  string         `json:"type"`
+	Text  string         `json:"text"`
+	ID    string         `json:"id,omitempty"`
+	Name  string         `json:"name,omitempty"`
+	Input map[string]any `json:"input,omitempty"`
+}
+
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
 }
 
 type anthropicResponse struct {
-	ID      string `json:"id"`
-	Content []struct {
-		Text string `json:"text"`
-	} `json:"content"`
-	Error *struct {
+	ID      string                  `json:"id"`
+	Content []anthropicContentBlock `json:"content"`
+	Usage   anthropicUsage          `json:"usage"`
+	Error   *struct {
 		Type    string `json:"type"`
 		Message string `json:"message"`
 	} `json:"error,omitempty"`
 }
 
-func (a *AnthropicAdapter) Infer(ctx context.Context, prompt string, opts InferOptions) (*InferResponse, error) {
-	// 1. Prepare Request Payload
+// anthropicMessages maps structured chat messages onto the Messages API:
+// system turns move to the top-level system field, assistant turns keep
+// their role, tool results become tool_result blocks in a user turn, and
+// everything else is sent as user content.
+func anthropicMessages(prompt string, messages []ChatMessage) (string, []anthropicMessage) {
+	if len(messages) == 0 {
+		return "", []anthropicMessage{{Role: "user", Content: prompt}}
+	}
+	var system []string
+	out := make([]anthropicMessage, 0, len(messages))
+	for _, m := range messages {
+		switch m.Role {
+		case "system":
+			system = append(system, m.Content)
+		case "assistant":
+			out = append(out, anthropicAssistantTurn(m))
+		case "tool":
+			out = appendAnthropicToolResult(out, m)
+		default:
+			out = append(out, anthropicMessage{Role: "user", Content: m.Content})
+		}
+	}
+	return strings.Join(system, "\n\n"), out
+}
+
+func (a *AnthropicAdapter) buildRequest(ctx context.Context, prompt string, opts InferOptions, stream bool) (*http.Request, error) {
+	system, messages := anthropicMessages(prompt, opts.Messages)
 	payload := anthropicRequest{
 		Model:     a.model,
-		Messages:  []anthropicMessage{{Role: "user", Content: prompt}},
+		Messages:  messages,
+		System:    system,
 		MaxTokens: opts.MaxTokens,
+		Stream:    stream,
+
+		StopSequences: opts.Stop,
+	}
+	// The Messages API accepts temperatures in [0, 1].
+	if opts.Temperature > 0 {
+		temperature := min(opts.Temperature, 1)
+		payload.Temperature = &temperature
+	}
+	if opts.TopP > 0 {
+		payload.TopP = &opts.TopP
+	}
+	for _, tool := range opts.Tools {
+		payload.Tools = append(payload.Tools, anthropicTool{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: toolParametersSchema(tool.Parameters),
+		})
 	}
 
 	// Default MaxTokens if not set (Anthropic requires it)
@@ -88,7 +160,6 @@ func (a *AnthropicAdapter) Infer(ctx context.Context, prompt string, opts InferO
 		return nil, fmt.Errorf("failed to marshal anthropic request: %w", err)
 	}
 
-	// 2. Build HTTP Request
 	req, err := http.NewRequestWithContext(ctx, "POST", a.endpoint, bytes.NewBuffer(payloadBytes))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -97,8 +168,17 @@ func (a *AnthropicAdapter) Infer(ctx context.Context, prompt string, opts InferO
 	req.Header.Set("x-api-key", a.apiKey)
 	req.Header.Set("anthropic-version", AnthropicVersion)
 	req.Header.Set("content-type", "application/json")
+	return req, nil
+}
 
-	// 3. Execute
+func (a *AnthropicAdapter) Infer(ctx context.Context, prompt string, opts InferOptions) (*InferResponse, error) {
+	// 1. Build HTTP Request
+	req, err := a.buildRequest(ctx, prompt, opts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Execute
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
@@ -106,11 +186,11 @@ func (a *AnthropicAdapter) Infer(ctx context.Context, prompt string, opts InferO
 	}
 	defer resp.Body.Close()
 
-	// 4. Decode Response
+	// 3. Decode Response
 	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("anthropic error %d: %s", resp.StatusCode, string(body))
+		return nil, &ProviderHTTPError{Provider: "anthropic", StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result anthropicResponse
@@ -122,17 +202,41 @@ func (a *AnthropicAdapter) Infer(ctx context.Context, prompt string, opts InferO
 		return nil, fmt.Errorf("anthropic api error: %s - %s", result.Error.Type, result.Error.Message)
 	}
 
-	if len(result.Content) == 0 {
+	text, toolCalls := anthropicContent(result.Content)
+	if text == "" && len(toolCalls) == 0 {
 		return nil, fmt.Errorf("anthropic returned empty content")
 	}
 
 	return &InferResponse{
-		Text:      result.Content[0].Text,
-		ModelUsed: a.model,
-		Provider:  "anthropic",
+		Text:             text,
+		ModelUsed:        a.model,
+		Provider:         "anthropic",
+		TokensUsed:       result.Usage.InputTokens + result.Usage.OutputTokens,
+		PromptTokens:     result.Usage.InputTokens,
+		CompletionTokens: result.Usage.OutputTokens,
+		ToolCalls:        toolCalls,
 	}, nil
 }
 
+// anthropicContent joins text blocks and converts tool_use blocks to ToolCalls.
+func anthropicContent(blocks []anthropicContentBlock) (string, []ToolCall) {
+	var text strings.Builder
+	var calls []ToolCall
+	for _, block := range blocks {
+		switch block.Type {
+		case "tool_use":
+			args := block.Input
+			if args == nil {
+				args = map[string]any{}
+			}
+			calls = append(calls, ToolCall{ID: block.ID, Name: block.Name, Arguments: args})
+		default:
+			text.WriteString(block.Text)
+		}
+	}
+	return text.String(), calls
+}
+
 func (a *AnthropicAdapter) Probe(ctx context.Context) (bool, error) {
 	// Probe via dummy interference for now
 	opts := InferOptions{MaxTokens: 1}
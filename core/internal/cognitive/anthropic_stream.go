@@ -0,0 +1,117 @@
+package cognitive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// anthropicStreamEvent covers the SSE event shapes used for streaming:
+// content_block_start opens text/tool_use blocks, content_block_delta carries
+// text or partial tool input JSON, error carries a terminal failure.
+type anthropicStreamEvent struct {
+	Type         string                `json:"type"`
+	Index        int                   `json:"index"`
+	ContentBlock anthropicContentBlock `json:"content_block"`
+	Delta        struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+	} `json:"delta"`
+	// Message carries input usage on message_start; Usage carries the
+	// cumulative output count on message_delta.
+	Message struct {
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	Usage *anthropicUsage `json:"usage,omitempty"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// InferStream uses the Messages API SSE stream and forwards text_delta events.
+func (a *AnthropicAdapter) InferStream(ctx context.Context, prompt string, opts InferOptions, onDelta func(delta string)) (*InferResponse, error) {
+	req, err := a.buildRequest(ctx, prompt, opts, true)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("accept", "text/event-stream")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("anthropic connection failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &ProviderHTTPError{Provider: "anthropic", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var text strings.Builder
+	var toolCalls []ToolCall
+	toolInputs := map[int]*strings.Builder{}
+	toolIndex := map[int]int{}
+	var usage anthropicUsage
+	err = readSSEData(resp.Body, func(data []byte) error {
+		var event anthropicStreamEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return fmt.Errorf("failed to decode anthropic stream event: %w", err)
+		}
+		if event.Error != nil {
+			return fmt.Errorf("anthropic api error: %s - %s", event.Error.Type, event.Error.Message)
+		}
+		switch event.Type {
+		case "message_start":
+			usage.InputTokens = event.Message.Usage.InputTokens
+		case "message_delta":
+			if event.Usage != nil {
+				usage.OutputTokens = event.Usage.OutputTokens
+			}
+		case "content_block_start":
+			if event.ContentBlock.Type == "tool_use" {
+				toolIndex[event.Index] = len(toolCalls)
+				toolInputs[event.Index] = &strings.Builder{}
+				toolCalls = append(toolCalls, ToolCall{ID: event.ContentBlock.ID, Name: event.ContentBlock.Name})
+			}
+		case "content_block_delta":
+			switch event.Delta.Type {
+			case "text_delta":
+				if event.Delta.Text != "" {
+					text.WriteString(event.Delta.Text)
+					onDelta(event.Delta.Text)
+				}
+			case "input_json_delta":
+				if input, ok := toolInputs[event.Index]; ok {
+					input.WriteString(event.Delta.PartialJSON)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for blockIndex, callIndex := range toolIndex {
+		toolCalls[callIndex].Arguments = parseToolArguments(toolInputs[blockIndex].String())
+	}
+
+	if text.Len() == 0 && len(toolCalls) == 0 {
+		return nil, fmt.Errorf("anthropic returned empty content")
+	}
+
+	return &InferResponse{
+		Text:             text.String(),
+		ModelUsed:        a.model,
+		Provider:         "anthropic",
+		TokensUsed:       usage.InputTokens + usage.OutputTokens,
+		PromptTokens:     usage.InputTokens,
+		CompletionTokens: usage.OutputTokens,
+		ToolCalls:        toolCalls,
+	}, nil
+}
@@ -105,19 +105,20 @@ RULES:
 Return ONLY valid JSON. No markdown fences.`, intent, capBlock)
 
 	req := InferRequest{
-		Profile: "architect",
-		Prompt:  prompt,
+		Profile:        "architect",
+		Prompt:         prompt,
+		ResponseSchema: blueprintSchema,
 	}
 
+	// 3. Infer; the router validates the reply against blueprintSchema and
+	// re-prompts with the violations until it conforms.
 	resp, err := m.brain.InferWithContract(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("meta-architect inference failed: %w", err)
 	}
+	text := resp.Text
 
-	// 3. Extract JSON from LLM response (strip fences, preamble, trailing text)
-	text := extractJSON(resp.Text)
-
-	// 4. Unmarshal and validate
+	// 4. Unmarshal
 	var blueprint protocol.MissionBlueprint
 	if err := json.Unmarshal([]byte(text), &blueprint); err != nil {
 		// Truncate raw text in error to avoid breaking downstream JSON serialization
@@ -0,0 +1,66 @@
+package cognitive
+
+// blueprintSchema is the JSON Schema a Meta-Architect reply must satisfy.
+// It checks the shape protocol.MissionBlueprint decodes; mission_id and
+// intent are filled in by GenerateBlueprint when missing.
+var blueprintSchema = map[string]any{
+	"type":     "object",
+	"required": []any{"teams"},
+	"properties": map[string]any{
+		"mission_id": map[string]any{"type": "string"},
+		"intent":     map[string]any{"type": "string"},
+		"teams": map[string]any{
+			"type":     "array",
+			"minItems": 1,
+			"items": map[string]any{
+				"type":     "object",
+				"required": []any{"name", "agents"},
+				"properties": map[string]any{
+					"name": map[string]any{"type": "string", "minLength": 1},
+					"role": map[string]any{"type": "string"},
+					"agents": map[string]any{
+						"type":     "array",
+						"minItems": 1,
+						"items":    blueprintAgentSchema,
+					},
+				},
+			},
+		},
+		"constraints": map[string]any{
+			"type": "array",
+			"items": map[string]any{"anyOf": []any{
+				map[string]any{"type": "string"},
+				map[string]any{"type": "object", "required": []any{"description"}},
+			}},
+		},
+		"requirements": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type":     "object",
+				"required": []any{"type", "name"},
+				"properties": map[string]any{
+					"type":        map[string]any{"enum": []any{"mcp_server", "api_key", "env_var", "credential"}},
+					"name":        map[string]any{"type": "string", "minLength": 1},
+					"description": map[string]any{"type": "string"},
+					"required":    map[string]any{"type": "boolean"},
+				},
+			},
+		},
+	},
+}
+
+var blueprintAgentSchema = map[string]any{
+	"type":     "object",
+	"required": []any{"id", "role"},
+	"properties": map[string]any{
+		"id":            map[string]any{"type": "string", "minLength": 1},
+		"role":          map[string]any{"type": "string", "minLength": 1},
+		"model":         map[string]any{"type": "string"},
+		"system_prompt": map[string]any{"type": "string"},
+		"tools":         stringArraySchema,
+		"inputs":        stringArraySchema,
+		"outputs":       stringArraySchema,
+	},
+}
+
+var stringArraySchema = map[string]any{"type": "array", "items": map[string]any{"type": "string"}}
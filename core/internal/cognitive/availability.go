@@ -1,6 +1,9 @@
 package cognitive
 
-import "strings"
+import (
+	"sort"
+	"strings"
+)
 
 var defaultExecutionProfiles = []string{
 	"admin",
@@ -21,6 +24,7 @@ const (
 	ExecutionProviderOffline    = "provider_uninitialized"
 	ExecutionModelMissing       = "model_missing"
 	ExecutionRouterUnavailable  = "router_unavailable"
+	ExecutionBudgetExceeded     = "budget_exceeded"
 	DefaultExecutionSetupPath   = "/settings"
 	DefaultExecutionProfileName = "chat"
 )
@@ -207,37 +211,49 @@ func (r *Router) executionFallbackProvider(excludeProviderID string) (string, Pr
 }
 
 func (r *Router) preferredFallbackProviderID() string {
+	if ids := r.fallbackProviderIDs(); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// fallbackProviderIDs lists executable providers in fallback order: the
+// well-known local engines, then other local providers, then remote ones.
+func (r *Router) fallbackProviderIDs() []string {
 	if r == nil || r.Config == nil || len(r.Config.Providers) == 0 {
-		return ""
+		return nil
 	}
 
-	candidates := []string{
+	var ordered []string
+	seen := make(map[string]bool)
+	add := func(providerID string) {
+		if !seen[providerID] && r.providerConfiguredForExecution(providerID) {
+			seen[providerID] = true
+			ordered = append(ordered, providerID)
+		}
+	}
+	for _, candidate := range []string{
 		"ollama",
 		"emergency-ollama",
 		"local-ollama-dev",
 		"local-sovereign",
 		"lmstudio",
-	}
-	for _, candidate := range candidates {
-		if r.providerConfiguredForExecution(candidate) {
-			return candidate
-		}
-	}
-
-	for providerID, provider := range r.Config.Providers {
-		if provider.Location == "remote" {
-			continue
-		}
-		if r.providerConfiguredForExecution(providerID) {
-			return providerID
-		}
+	} {
+		add(candidate)
 	}
 
+	ids := make([]string, 0, len(r.Config.Providers))
 	for providerID := range r.Config.Providers {
-		if r.providerConfiguredForExecution(providerID) {
-			return providerID
+		ids = append(ids, providerID)
+	}
+	sort.Strings(ids)
+	for _, providerID := range ids {
+		if r.Config.Providers[providerID].Location != "remote" {
+			add(providerID)
 		}
 	}
-
-	return ""
+	for _, providerID := range ids {
+		add(providerID)
+	}
+	return ordered
 }
@@ -0,0 +1,164 @@
+package cognitive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Budget scopes, checked in this order before every inference.
+const (
+	BudgetScopeRun          = "run"          // lifetime of one mission run
+	BudgetScopeTeam         = "team"         // one team, per UTC day
+	BudgetScopeOrganization = "organization" // one organization, per UTC day
+	BudgetScopeDaily        = "daily"        // all inference, per UTC day
+)
+
+// BudgetLimit caps spend in one scope. Zero fields are unlimited.
+type BudgetLimit struct {
+	MaxTokens int64   `yaml:"max_tokens,omitempty" json:"max_tokens,omitempty"`
+	MaxCost   float64 `yaml:"max_cost,omitempty" json:"max_cost,omitempty"`
+}
+
+// IsZero reports whether the limit caps nothing.
+func (l BudgetLimit) IsZero() bool {
+	return l.MaxTokens <= 0 && l.MaxCost <= 0
+}
+
+// BudgetPolicy holds the spend limits enforced before dispatch. Teams and
+// Organizations override the scope defaults for specific IDs.
+type BudgetPolicy struct {
+	Currency      string                 `yaml:"currency,omitempty" json:"currency,omitempty"`
+	Run           BudgetLimit            `yaml:"run,omitempty" json:"run,omitempty"`
+	Team          BudgetLimit            `yaml:"team,omitempty" json:"team,omitempty"`
+	Organization  BudgetLimit            `yaml:"organization,omitempty" json:"organization,omitempty"`
+	Daily         BudgetLimit            `yaml:"daily,omitempty" json:"daily,omitempty"`
+	Teams         map[string]BudgetLimit `yaml:"teams,omitempty" json:"teams,omitempty"`
+	Organizations map[string]BudgetLimit `yaml:"organizations,omitempty" json:"organizations,omitempty"`
+}
+
+// CostCurrency returns the currency MaxCost limits are expressed in.
+func (p BudgetPolicy) CostCurrency() string {
+	if p.Currency == "" {
+		return DefaultUsageCurrency
+	}
+	return p.Currency
+}
+
+// TeamLimit returns the limit for teamID, preferring a per-team override.
+func (p BudgetPolicy) TeamLimit(teamID string) BudgetLimit {
+	if limit, ok := p.Teams[teamID]; ok {
+		return limit
+	}
+	return p.Team
+}
+
+// OrganizationLimit returns the limit for orgID, preferring a per-organization override.
+func (p BudgetPolicy) OrganizationLimit(orgID string) BudgetLimit {
+	if limit, ok := p.Organizations[orgID]; ok {
+		return limit
+	}
+	return p.Organization
+}
+
+// BudgetCheck is what the router asks a BudgetGate before dispatching.
+// Projected* is the worst case for this call: the estimated prompt plus the
+// provider's MaxOutputTokens, priced in the policy currency.
+type BudgetCheck struct {
+	Attribution     UsageAttribution
+	Policy          BudgetPolicy
+	ProviderID      string
+	ProjectedTokens int64
+	ProjectedCost   float64
+}
+
+// BudgetGate decides whether an inference may be dispatched. Implemented by
+// usage.Enforcer; returns *BudgetExceededError when a hard stop applies.
+type BudgetGate interface {
+	CheckBudget(ctx context.Context, check BudgetCheck) error
+}
+
+// BudgetExceededError stops an inference that would cross a budget. When
+// ApprovalID is set the scope is paused on a governance approval; approving
+// it extends the budget.
+type BudgetExceededError struct {
+	Scope       string      `json:"scope"`
+	Key         string      `json:"key"`
+	Limit       BudgetLimit `json:"limit"`
+	SpentTokens int64       `json:"spent_tokens"`
+	SpentCost   float64     `json:"spent_cost"`
+	Currency    string      `json:"currency"`
+	ApprovalID  string      `json:"approval_id,omitempty"`
+	Denied      bool        `json:"denied,omitempty"`
+}
+
+func (e *BudgetExceededError) Error() string {
+	var spent []string
+	if e.Limit.MaxTokens > 0 {
+		spent = append(spent, fmt.Sprintf("%d/%d tokens", e.SpentTokens, e.Limit.MaxTokens))
+	}
+	if e.Limit.MaxCost > 0 {
+		spent = append(spent, fmt.Sprintf("%.4f/%.4f %s", e.SpentCost, e.Limit.MaxCost, e.Currency))
+	}
+	msg := fmt.Sprintf("%s budget exceeded for %s (%s)", e.Scope, e.Key, strings.Join(spent, ", "))
+	switch {
+	case e.ApprovalID != "":
+		msg += "; paused pending governance approval " + e.ApprovalID
+	case e.Denied:
+		msg += "; extension denied"
+	}
+	return msg
+}
+
+// Availability describes the stop in the same shape as provider availability
+// so chat surfaces can show why the run paused and how to resume it.
+func (e *BudgetExceededError) Availability(profile string) ExecutionAvailability {
+	action := "Raise the " + e.Scope + " limit under budgets in cognitive.yaml to resume."
+	if e.ApprovalID != "" {
+		action = "Approve governance request " + e.ApprovalID + " to extend the " + e.Scope + " budget, or raise the limit under budgets in cognitive.yaml."
+	}
+	return ExecutionAvailability{
+		Available:         false,
+		Code:              ExecutionBudgetExceeded,
+		Summary:           e.Error(),
+		RecommendedAction: action,
+		Profile:           profile,
+	}
+}
+
+// SetBudgetGate attaches the enforcer consulted before every inference.
+// nil disables enforcement.
+func (r *Router) SetBudgetGate(gate BudgetGate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.budget = gate
+}
+
+// checkBudget asks the budget gate whether req may be dispatched to providerID.
+func (r *Router) checkBudget(ctx context.Context, req InferRequest, opts InferOptions, providerID string, cfg ProviderConfig) error {
+	r.mu.RLock()
+	gate := r.budget
+	var policy *BudgetPolicy
+	if r.Config != nil {
+		policy = r.Config.Budgets
+	}
+	r.mu.RUnlock()
+	if gate == nil || policy == nil {
+		return nil
+	}
+
+	prompt := estimateTokens(req.Prompt)
+	for _, m := range opts.Messages {
+		prompt += estimateTokens(m.Content)
+	}
+	check := BudgetCheck{
+		Attribution:     req.Attribution,
+		Policy:          *policy,
+		ProviderID:      providerID,
+		ProjectedTokens: int64(prompt + opts.MaxTokens),
+	}
+	if cost, currency := cfg.Pricing.Cost(cfg.ModelID, prompt, opts.MaxTokens); currency == policy.CostCurrency() {
+		check.ProjectedCost = cost
+	}
+	return gate.CheckBudget(ctx, check)
+}
@@ -0,0 +1,95 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubBudgetGate struct {
+	checks []BudgetCheck
+	err    error
+}
+
+func (g *stubBudgetGate) CheckBudget(_ context.Context, check BudgetCheck) error {
+	g.checks = append(g.checks, check)
+	return g.err
+}
+
+type countingAdapter struct {
+	calls int
+}
+
+func (a *countingAdapter) Infer(context.Context, string, InferOptions) (*InferResponse, error) {
+	a.calls++
+	return &InferResponse{Text: "ok", ModelUsed: "gpt-test"}, nil
+}
+
+func (a *countingAdapter) Probe(context.Context) (bool, error) {
+	return true, nil
+}
+
+// pricedRemote charges one unit per token and caps output at 100 tokens.
+var pricedRemote = ProviderConfig{
+	Type: "openai", ModelID: "gpt-test", Location: "remote", Enabled: true, MaxOutputTokens: 100,
+	Pricing: &ProviderPricing{TokenPrice: TokenPrice{InputPerMillion: 1_000_000, OutputPerMillion: 1_000_000}},
+}
+
+func withBudgets(budgets *BudgetPolicy) routerOption {
+	return withConfig(func(c *BrainConfig) { c.Budgets = budgets })
+}
+
+func TestInferWithContract_BudgetGateProjectsWorstCase(t *testing.T) {
+	adapter := &countingAdapter{}
+	gate := &stubBudgetGate{}
+	r := newTestRouter(adapter, withProvider("remote", pricedRemote, adapter), withBudgets(&BudgetPolicy{Run: BudgetLimit{MaxTokens: 5000}}))
+	r.SetBudgetGate(gate)
+
+	req := InferRequest{Profile: "chat", Prompt: "twenty characters!!!", Attribution: UsageAttribution{RunID: "run-1"}}
+	if _, err := r.InferWithContract(context.Background(), req); err != nil {
+		t.Fatalf("InferWithContract: %v", err)
+	}
+	if len(gate.checks) != 1 {
+		t.Fatalf("gate consulted %d times, want 1", len(gate.checks))
+	}
+	check := gate.checks[0]
+	if check.ProjectedTokens != 105 || check.ProjectedCost != 105 {
+		t.Fatalf("projected = %d tokens / %v cost, want prompt 5 + max output 100", check.ProjectedTokens, check.ProjectedCost)
+	}
+	if check.Attribution.RunID != "run-1" || check.Policy.Run.MaxTokens != 5000 || check.ProviderID != "remote" {
+		t.Fatalf("unexpected check %+v", check)
+	}
+}
+
+func TestInferWithContract_BudgetStopBlocksDispatch(t *testing.T) {
+	adapter := &countingAdapter{}
+	stop := &BudgetExceededError{Scope: BudgetScopeRun, Key: "run-1", Limit: BudgetLimit{MaxTokens: 10}, SpentTokens: 12, ApprovalID: "req-1"}
+	r := newTestRouter(adapter, withProvider("remote", pricedRemote, adapter), withBudgets(&BudgetPolicy{Run: BudgetLimit{MaxTokens: 10}}))
+	r.SetBudgetGate(&stubBudgetGate{err: stop})
+
+	_, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi"})
+	var exceeded *BudgetExceededError
+	if !errors.As(err, &exceeded) || exceeded.ApprovalID != "req-1" {
+		t.Fatalf("expected budget stop, got %v", err)
+	}
+	if adapter.calls != 0 {
+		t.Fatalf("adapter called %d times after a budget stop", adapter.calls)
+	}
+	availability := exceeded.Availability("chat")
+	if availability.Available || availability.Code != ExecutionBudgetExceeded {
+		t.Fatalf("unexpected availability %+v", availability)
+	}
+}
+
+func TestInferWithContract_NoBudgetPolicySkipsGate(t *testing.T) {
+	gate := &stubBudgetGate{err: errors.New("should not be called")}
+	r := newTestRouter(&countingAdapter{})
+	r.SetBudgetGate(gate)
+
+	if _, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi"}); err != nil {
+		t.Fatalf("InferWithContract: %v", err)
+	}
+	if len(gate.checks) != 0 {
+		t.Fatalf("gate consulted without a budget policy")
+	}
+}
@@ -0,0 +1,247 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Response cache tiers, reported on InferResponse.CacheTier.
+const (
+	CacheTierExact    = "exact"    // identical prompt, messages and tools
+	CacheTierSemantic = "semantic" // embedding similarity above MinSimilarity
+)
+
+// Response cache defaults, applied when BrainConfig.Cache omits a field.
+const (
+	DefaultCacheTTLSeconds    = 3600
+	DefaultCacheMinSimilarity = 0.97
+	DefaultCacheTenant        = "default"
+
+	cacheEmbedMaxChars = 8000
+)
+
+// ErrCacheDisabled is returned by InvalidateCache when no cache is attached.
+var ErrCacheDisabled = errors.New("response cache is not enabled")
+
+// CachePolicy enables the response cache in front of the router. The exact
+// tier always applies and keys on the resolved sampling options too; the
+// semantic tier also serves near-identical prompts and is skipped for
+// requests that offer tools or set a seed.
+type CachePolicy struct {
+	Enabled       bool     `yaml:"enabled" json:"enabled"`
+	TTLSeconds    int      `yaml:"ttl_seconds,omitempty" json:"ttl_seconds,omitempty"`
+	Semantic      bool     `yaml:"semantic,omitempty" json:"semantic,omitempty"`
+	MinSimilarity float64  `yaml:"min_similarity,omitempty" json:"min_similarity,omitempty"` // cosine similarity for a semantic hit
+	EmbedModel    string   `yaml:"embed_model,omitempty" json:"embed_model,omitempty"`
+	Profiles      []string `yaml:"profiles,omitempty" json:"profiles,omitempty"` // profiles to cache; empty caches all
+}
+
+// withDefaults fills unset fields.
+func (p CachePolicy) withDefaults() CachePolicy {
+	if p.TTLSeconds <= 0 {
+		p.TTLSeconds = DefaultCacheTTLSeconds
+	}
+	if p.MinSimilarity <= 0 || p.MinSimilarity > 1 {
+		p.MinSimilarity = DefaultCacheMinSimilarity
+	}
+	if p.EmbedModel == "" {
+		p.EmbedModel = DefaultEmbedModel
+	}
+	return p
+}
+
+// CacheScope partitions cached responses. Entries never match across tenants,
+// profiles or providers. For invalidation, empty fields match everything.
+type CacheScope struct {
+	TenantID   string `json:"tenant_id,omitempty"`
+	Profile    string `json:"profile,omitempty"`
+	ProviderID string `json:"provider_id,omitempty"`
+}
+
+// CacheLookup asks the cache for a response. Embedding is nil when only the
+// exact tier applies. EmbedModel is the model that produced Embedding; it is
+// empty when the semantic tier is off, and entries never match across models.
+type CacheLookup struct {
+	Scope         CacheScope
+	Key           string
+	EmbedModel    string
+	Embedding     []float64
+	MinSimilarity float64
+}
+
+// CacheHit is a cached response and the tier that matched.
+type CacheHit struct {
+	Response   InferResponse
+	Tier       string
+	Similarity float64
+}
+
+// CacheEntry is a response to cache under Scope and Key.
+type CacheEntry struct {
+	Scope      CacheScope
+	Key        string
+	EmbedModel string
+	Embedding  []float64
+	Response   InferResponse
+	ExpiresAt  time.Time
+}
+
+// ResponseCache stores inference responses. Implemented by responsecache.Store.
+// Lookup returns nil, nil on a miss.
+type ResponseCache interface {
+	Lookup(ctx context.Context, q CacheLookup) (*CacheHit, error)
+	Store(ctx context.Context, entry CacheEntry) error
+	Invalidate(ctx context.Context, scope CacheScope) (int64, error)
+}
+
+// cacheTicket carries a missed lookup through dispatch so the response can be
+// stored under the same key.
+type cacheTicket struct {
+	cache  ResponseCache
+	policy CachePolicy
+	entry  CacheEntry
+}
+
+// SetResponseCache attaches the response cache. It is consulted only while
+// cache.enabled is set in cognitive.yaml; nil detaches it.
+func (r *Router) SetResponseCache(cache ResponseCache) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache = cache
+}
+
+// InvalidateCache drops cached responses in scope and returns how many were removed.
+func (r *Router) InvalidateCache(ctx context.Context, scope CacheScope) (int64, error) {
+	r.mu.RLock()
+	cache := r.cache
+	r.mu.RUnlock()
+	if cache == nil {
+		return 0, ErrCacheDisabled
+	}
+	return cache.Invalidate(ctx, scope)
+}
+
+// lookupCache serves req from the cache when possible. On a miss it returns a
+// ticket for storeCache; both are nil when caching does not apply.
+func (r *Router) lookupCache(ctx context.Context, req InferRequest, opts InferOptions, providerID string) (*InferResponse, *cacheTicket) {
+	r.mu.RLock()
+	cache := r.cache
+	var policy *CachePolicy
+	if r.Config != nil {
+		policy = r.Config.Cache
+	}
+	r.mu.RUnlock()
+	if cache == nil || policy == nil || !policy.Enabled {
+		return nil, nil
+	}
+	if len(policy.Profiles) > 0 && !slices.Contains(policy.Profiles, req.Profile) {
+		return nil, nil
+	}
+
+	p := policy.withDefaults()
+	key, err := requestKey(newCacheKey(req, opts))
+	if err != nil {
+		return nil, nil
+	}
+	tenant := req.Attribution.OrganizationID
+	if tenant == "" {
+		tenant = DefaultCacheTenant
+	}
+	ticket := &cacheTicket{cache: cache, policy: p, entry: CacheEntry{
+		Scope: CacheScope{TenantID: tenant, Profile: req.Profile, ProviderID: providerID},
+		Key:   key,
+	}}
+	if p.Semantic {
+		ticket.entry.EmbedModel = p.EmbedModel
+	}
+	// A seed asks for one reproducible reply, which only an exact hit gives.
+	if p.Semantic && len(req.Tools) == 0 && opts.Seed == nil {
+		if vec, err := r.Embed(ctx, cacheEmbedText(req), p.EmbedModel); err == nil {
+			ticket.entry.Embedding = vec
+		}
+	}
+
+	hit, err := cache.Lookup(ctx, CacheLookup{
+		Scope:         ticket.entry.Scope,
+		Key:           key,
+		EmbedModel:    ticket.entry.EmbedModel,
+		Embedding:     ticket.entry.Embedding,
+		MinSimilarity: p.MinSimilarity,
+	})
+	if err != nil {
+		log.Printf("WARN: response cache lookup failed: %v", err)
+		return nil, ticket
+	}
+	if hit == nil {
+		return nil, ticket
+	}
+	resp := hit.Response
+	resp.CacheHit = true
+	resp.CacheTier = hit.Tier
+	if req.OnStream != nil {
+		if resp.Text != "" {
+			req.OnStream(StreamChunk{Delta: resp.Text})
+		}
+		req.OnStream(StreamChunk{Done: true})
+	}
+	return &resp, nil
+}
+
+// cacheKey identifies a cached reply: the request as the cassette keys it
+// plus the sampling it was generated with, so a reply sampled at one
+// temperature or seed is never served for another.
+type cacheKey struct {
+	keyedRequest
+	Temperature float64  `json:"temperature"`
+	TopP        float64  `json:"top_p,omitempty"`
+	MaxTokens   int      `json:"max_tokens,omitempty"`
+	Stop        []string `json:"stop,omitempty"`
+	Seed        *int     `json:"seed,omitempty"`
+}
+
+func newCacheKey(req InferRequest, opts InferOptions) cacheKey {
+	keyed := InferOptions{Messages: req.Messages, Tools: req.Tools, JSONMode: opts.JSONMode, ResponseSchema: opts.ResponseSchema}
+	return cacheKey{
+		keyedRequest: newKeyedRequest(req.Prompt, keyed),
+		Temperature:  opts.Temperature,
+		TopP:         opts.TopP,
+		MaxTokens:    opts.MaxTokens,
+		Stop:         opts.Stop,
+		Seed:         opts.Seed,
+	}
+}
+
+// storeCache caches a provider response for a missed lookup.
+func (r *Router) storeCache(ctx context.Context, ticket *cacheTicket, resp *InferResponse) {
+	if ticket == nil || resp == nil || (strings.TrimSpace(resp.Text) == "" && len(resp.ToolCalls) == 0) {
+		return
+	}
+	entry := ticket.entry
+	entry.Response = *resp
+	entry.ExpiresAt = time.Now().Add(time.Duration(ticket.policy.TTLSeconds) * time.Second)
+	if err := ticket.cache.Store(ctx, entry); err != nil {
+		log.Printf("WARN: response cache store failed: %v", err)
+	}
+}
+
+// cacheEmbedText is the text embedded for the semantic tier: the whole
+// conversation, keeping the most recent content when it is too long.
+func cacheEmbedText(req InferRequest) string {
+	var sb strings.Builder
+	sb.WriteString(req.Prompt)
+	for _, m := range req.Messages {
+		sb.WriteString("\n")
+		sb.WriteString(m.Role)
+		sb.WriteString(": ")
+		sb.WriteString(m.Content)
+	}
+	text := sb.String()
+	if len(text) > cacheEmbedMaxChars {
+		text = strings.ToValidUTF8(text[len(text)-cacheEmbedMaxChars:], "")
+	}
+	return text
+}
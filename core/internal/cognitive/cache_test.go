@@ -0,0 +1,188 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// memoryCache is an in-process ResponseCache keyed by scope and request key.
+type memoryCache struct {
+	entries map[CacheScope]map[string]CacheEntry
+	lookups []CacheLookup
+}
+
+func (c *memoryCache) Lookup(_ context.Context, q CacheLookup) (*CacheHit, error) {
+	c.lookups = append(c.lookups, q)
+	if entry, ok := c.entries[q.Scope][q.Key]; ok {
+		return &CacheHit{Response: entry.Response, Tier: CacheTierExact, Similarity: 1}, nil
+	}
+	if len(q.Embedding) > 0 {
+		for _, entry := range c.entries[q.Scope] {
+			if entry.EmbedModel == q.EmbedModel && len(entry.Embedding) > 0 && entry.Embedding[0] == q.Embedding[0] {
+				return &CacheHit{Response: entry.Response, Tier: CacheTierSemantic, Similarity: 0.99}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (c *memoryCache) Store(_ context.Context, entry CacheEntry) error {
+	if c.entries == nil {
+		c.entries = map[CacheScope]map[string]CacheEntry{}
+	}
+	if c.entries[entry.Scope] == nil {
+		c.entries[entry.Scope] = map[string]CacheEntry{}
+	}
+	c.entries[entry.Scope][entry.Key] = entry
+	return nil
+}
+
+func (c *memoryCache) Invalidate(_ context.Context, scope CacheScope) (int64, error) {
+	n := int64(len(c.entries[scope]))
+	delete(c.entries, scope)
+	return n, nil
+}
+
+// embeddingAdapter embeds every text to the same vector, so any two
+// tool-free requests are semantically "near-identical".
+type embeddingAdapter struct {
+	countingAdapter
+}
+
+func (a *embeddingAdapter) Embed(context.Context, string, string) ([]float64, error) {
+	return []float64{0.5, 0.5}, nil
+}
+
+func withCache(policy *CachePolicy, cache ResponseCache) routerOption {
+	return func(r *Router) {
+		r.Config.Cache = policy
+		r.SetResponseCache(cache)
+	}
+}
+
+func TestInferWithContract_ExactCacheHitSkipsProvider(t *testing.T) {
+	adapter := &countingAdapter{}
+	cache := &memoryCache{}
+	r := newTestRouter(adapter, withCache(&CachePolicy{Enabled: true}, cache))
+	req := InferRequest{Profile: "chat", Messages: []ChatMessage{{Role: "user", Content: "is the council ready?"}}}
+
+	first, err := r.InferWithContract(context.Background(), req)
+	if err != nil || first.CacheHit {
+		t.Fatalf("first call = %+v, %v; want provider response", first, err)
+	}
+
+	var streamed []StreamChunk
+	req.OnStream = func(chunk StreamChunk) { streamed = append(streamed, chunk) }
+	second, err := r.InferWithContract(context.Background(), req)
+	if err != nil {
+		t.Fatalf("second call: %v", err)
+	}
+	if !second.CacheHit || second.CacheTier != CacheTierExact || second.Text != "ok" {
+		t.Fatalf("expected exact cache hit, got %+v", second)
+	}
+	if adapter.calls != 1 {
+		t.Fatalf("adapter called %d times, want 1", adapter.calls)
+	}
+	if len(streamed) != 2 || streamed[0].Delta != "ok" || !streamed[1].Done {
+		t.Fatalf("cached hit should stream as one completion, got %+v", streamed)
+	}
+	if scope := cache.lookups[0].Scope; scope != (CacheScope{TenantID: DefaultCacheTenant, Profile: "chat", ProviderID: "remote"}) {
+		t.Fatalf("unexpected scope %+v", scope)
+	}
+}
+
+func TestInferWithContract_CacheIsScopedPerTenantAndProfile(t *testing.T) {
+	adapter := &countingAdapter{}
+	r := newTestRouter(adapter, withCache(&CachePolicy{Enabled: true, Profiles: []string{"chat", "review"}}, &memoryCache{}))
+	base := InferRequest{Profile: "chat", Prompt: "readiness review", Attribution: UsageAttribution{OrganizationID: "org-a"}}
+
+	calls := []InferRequest{base, base}
+	other := base
+	other.Attribution.OrganizationID = "org-b"
+	review := base
+	review.Profile = "review"
+	calls = append(calls, other, review)
+	for _, req := range calls {
+		if _, err := r.InferWithContract(context.Background(), req); err != nil {
+			t.Fatalf("InferWithContract: %v", err)
+		}
+	}
+	if adapter.calls != 3 {
+		t.Fatalf("adapter called %d times, want 3 (only the repeat in org-a/chat is cached)", adapter.calls)
+	}
+}
+
+func TestInferWithContract_SemanticTierSkipsToolRequests(t *testing.T) {
+	adapter := &embeddingAdapter{}
+	cache := &memoryCache{}
+	r := newTestRouter(adapter, withCache(&CachePolicy{Enabled: true, Semantic: true}, cache))
+	ctx := context.Background()
+
+	if _, err := r.InferWithContract(ctx, InferRequest{Profile: "chat", Prompt: "Is the team ready to launch?"}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := r.InferWithContract(ctx, InferRequest{Profile: "chat", Prompt: "Is the team ready for launch?"})
+	if err != nil || !resp.CacheHit || resp.CacheTier != CacheTierSemantic {
+		t.Fatalf("expected semantic hit, got %+v, %v", resp, err)
+	}
+	if model := cache.lookups[0].EmbedModel; model != DefaultEmbedModel {
+		t.Fatalf("lookup embed model = %q, want %q", model, DefaultEmbedModel)
+	}
+
+	withTools := InferRequest{Profile: "chat", Prompt: "Is the team ready?", Tools: []ToolDefinition{{Name: "read_file"}}}
+	if _, err := r.InferWithContract(ctx, withTools); err != nil {
+		t.Fatal(err)
+	}
+	if last := cache.lookups[len(cache.lookups)-1]; last.Embedding != nil {
+		t.Fatal("tool requests must use the exact tier only")
+	}
+	if adapter.calls != 2 {
+		t.Fatalf("adapter called %d times, want 2", adapter.calls)
+	}
+}
+
+func TestInferWithContract_CacheDisabledByPolicy(t *testing.T) {
+	adapter := &countingAdapter{}
+	cache := &memoryCache{}
+	r := newTestRouter(adapter, withCache(&CachePolicy{Enabled: false}, cache))
+	for i := 0; i < 2; i++ {
+		if _, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if adapter.calls != 2 || len(cache.lookups) != 0 {
+		t.Fatalf("disabled cache consulted: calls=%d lookups=%d", adapter.calls, len(cache.lookups))
+	}
+}
+
+func TestInvalidateCache_RequiresAttachedCache(t *testing.T) {
+	r := &Router{Config: &BrainConfig{}}
+	if _, err := r.InvalidateCache(context.Background(), CacheScope{}); !errors.Is(err, ErrCacheDisabled) {
+		t.Fatalf("expected ErrCacheDisabled, got %v", err)
+	}
+}
+
+func TestInferWithContract_CacheKeysOnSampling(t *testing.T) {
+	adapter := &embeddingAdapter{}
+	cache := &memoryCache{}
+	r := newTestRouter(adapter, withCache(&CachePolicy{Enabled: true, Semantic: true}, cache))
+	sampled := func(temperature float64, seed int) InferRequest {
+		return InferRequest{Profile: "chat", Prompt: "draft a release note",
+			Sampling: &SamplingParams{Temperature: &temperature, Seed: &seed}}
+	}
+
+	for _, req := range []InferRequest{sampled(0.2, 7), sampled(0.9, 7), sampled(0.2, 8), sampled(0.2, 7)} {
+		if _, err := r.InferWithContract(context.Background(), req); err != nil {
+			t.Fatalf("InferWithContract: %v", err)
+		}
+	}
+	if adapter.calls != 3 {
+		t.Fatalf("adapter called %d times, want 3 (only the repeated temperature and seed is cached)", adapter.calls)
+	}
+	for _, q := range cache.lookups {
+		if len(q.Embedding) > 0 {
+			t.Fatal("seeded requests must not use the semantic tier")
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package cognitive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cassette modes.
+const (
+	CassetteReplay = "replay" // serve recorded responses; a miss is an error
+	CassetteRecord = "record" // call the upstream provider and rewrite the fixture
+)
+
+// CassetteConfig configures a provider of type "cassette".
+type CassetteConfig struct {
+	Path     string `yaml:"path" json:"path"`                             // fixture file (JSON)
+	Mode     string `yaml:"mode,omitempty" json:"mode,omitempty"`         // replay (default) | record
+	Upstream string `yaml:"upstream,omitempty" json:"upstream,omitempty"` // provider ID called while recording
+}
+
+// ErrCassetteMiss is returned in replay mode when no recorded interaction
+// matches the request.
+var ErrCassetteMiss = errors.New("cassette miss")
+
+// CassetteInteraction is one recorded request/response pair. Request is kept
+// for readable diffs; matching uses Key only.
+type CassetteInteraction struct {
+	Key      string        `json:"key"`
+	Request  keyedRequest  `json:"request"`
+	Response InferResponse `json:"response"`
+}
+
+type cassetteFile struct {
+	Version      int                   `json:"version"`
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// keyedRequest is the part of a call that identifies it. Sampling options
+// are deliberately excluded so tuning them doesn't invalidate fixtures; the
+// required response format is kept since it changes the shape of the reply.
+type keyedRequest struct {
+	Prompt   string         `json:"prompt,omitempty"`
+	Messages []ChatMessage  `json:"messages,omitempty"`
+	Tools    []string       `json:"tools,omitempty"`
+	JSONMode bool           `json:"json_mode,omitempty"`
+	Schema   map[string]any `json:"schema,omitempty"`
+}
+
+// CassetteAdapter records real provider responses to a fixture file and
+// replays them deterministically by prompt and message hash. Identical
+// requests replay in recorded order; the last recording repeats once a
+// sequence is exhausted.
+type CassetteAdapter struct {
+	path     string
+	mode     string
+	upstream LLMProvider
+
+	mu           sync.Mutex
+	interactions []CassetteInteraction
+	cursor       map[string]int
+}
+
+// NewCassetteAdapter opens the cassette described by config.Cassette. Replay
+// mode loads the fixture; record mode starts an empty fixture and requires
+// upstream.
+func NewCassetteAdapter(config ProviderConfig, upstream LLMProvider) (*CassetteAdapter, error) {
+	cc := config.Cassette
+	if cc == nil || cc.Path == "" {
+		return nil, fmt.Errorf("cassette provider requires cassette.path")
+	}
+	a := &CassetteAdapter{path: cc.Path, mode: cc.Mode, cursor: make(map[string]int)}
+	if a.mode == "" {
+		a.mode = CassetteReplay
+	}
+
+	switch a.mode {
+	case CassetteReplay:
+		data, err := os.ReadFile(cc.Path)
+		if err != nil {
+			return nil, fmt.Errorf("load cassette: %w", err)
+		}
+		var file cassetteFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("parse cassette %s: %w", cc.Path, err)
+		}
+		a.interactions = file.Interactions
+	case CassetteRecord:
+		if upstream == nil {
+			return nil, fmt.Errorf("cassette record mode requires an upstream provider (cassette.upstream=%q)", cc.Upstream)
+		}
+		a.upstream = upstream
+	default:
+		return nil, fmt.Errorf("unknown cassette mode: %s", cc.Mode)
+	}
+	return a, nil
+}
+
+// Interactions returns a copy of the recorded interactions.
+func (a *CassetteAdapter) Interactions() []CassetteInteraction {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]CassetteInteraction(nil), a.interactions...)
+}
+
+func (a *CassetteAdapter) Infer(ctx context.Context, prompt string, opts InferOptions) (*InferResponse, error) {
+	req := newKeyedRequest(prompt, opts)
+	key, err := requestKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.mode == CassetteRecord {
+		return a.record(ctx, key, req, prompt, opts)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var matches []int
+	for i, in := range a.interactions {
+		if in.Key == key {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: no interaction for key %s in %s (re-record with cassette.mode=record): %.80q",
+			ErrCassetteMiss, key[:12], a.path, lastMessage(req))
+	}
+	n := a.cursor[key]
+	if n >= len(matches) {
+		n = len(matches) - 1
+	} else {
+		a.cursor[key] = n + 1
+	}
+	resp := a.interactions[matches[n]].Response
+	resp.ToolCalls = append([]ToolCall(nil), resp.ToolCalls...)
+	return &resp, nil
+}
+
+func (a *CassetteAdapter) record(ctx context.Context, key string, req keyedRequest, prompt string, opts InferOptions) (*InferResponse, error) {
+	resp, err := a.upstream.Infer(ctx, prompt, opts)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.interactions = append(a.interactions, CassetteInteraction{Key: key, Request: req, Response: *resp})
+	if err := a.save(); err != nil {
+		return nil, fmt.Errorf("write cassette: %w", err)
+	}
+	return resp, nil
+}
+
+// save rewrites the fixture atomically. Callers hold a.mu.
+func (a *CassetteAdapter) save() error {
+	data, err := json.MarshalIndent(cassetteFile{Version: 1, Interactions: a.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(a.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".cassette-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), a.path)
+}
+
+func (a *CassetteAdapter) Probe(ctx context.Context) (bool, error) {
+	if a.mode == CassetteRecord {
+		return a.upstream.Probe(ctx)
+	}
+	return true, nil
+}
+
+func newKeyedRequest(prompt string, opts InferOptions) keyedRequest {
+	req := keyedRequest{Prompt: prompt, Messages: opts.Messages, JSONMode: opts.JSONMode, Schema: opts.ResponseSchema}
+	for _, tool := range opts.Tools {
+		req.Tools = append(req.Tools, tool.Name)
+	}
+	return req
+}
+
+// requestKey hashes the identifying part of a request. Shared by the cassette
+// provider and the response cache.
+func requestKey(req any) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("hash request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func lastMessage(req keyedRequest) string {
+	if n := len(req.Messages); n > 0 {
+		return req.Messages[n-1].Content
+	}
+	return req.Prompt
+}
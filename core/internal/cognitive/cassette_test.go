@@ -0,0 +1,121 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// sequenceAdapter answers with numbered responses so replay order is visible.
+type sequenceAdapter struct {
+	calls int
+}
+
+func (a *sequenceAdapter) Infer(_ context.Context, prompt string, _ InferOptions) (*InferResponse, error) {
+	a.calls++
+	return &InferResponse{Text: prompt + " #" + string(rune('0'+a.calls)), ModelUsed: "upstream-model"}, nil
+}
+
+func (a *sequenceAdapter) Probe(context.Context) (bool, error) {
+	return true, nil
+}
+
+func TestCassette_RecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chat.json")
+	upstream := &sequenceAdapter{}
+	recorder, err := NewCassetteAdapter(ProviderConfig{Cassette: &CassetteConfig{Path: path, Mode: CassetteRecord}}, upstream)
+	if err != nil {
+		t.Fatalf("NewCassetteAdapter(record): %v", err)
+	}
+	ctx := context.Background()
+	tools := InferOptions{Tools: []ToolDefinition{{Name: "read_file"}}}
+	for _, call := range []struct {
+		prompt string
+		opts   InferOptions
+	}{{"hello", InferOptions{}}, {"hello", InferOptions{}}, {"hello", tools}} {
+		if _, err := recorder.Infer(ctx, call.prompt, call.opts); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+
+	player, err := NewCassetteAdapter(ProviderConfig{Cassette: &CassetteConfig{Path: path}}, nil)
+	if err != nil {
+		t.Fatalf("NewCassetteAdapter(replay): %v", err)
+	}
+	// Identical requests replay in recorded order, then the last one repeats.
+	for _, want := range []string{"hello #1", "hello #2", "hello #2"} {
+		resp, err := player.Infer(ctx, "hello", InferOptions{Temperature: 0.9})
+		if err != nil || resp.Text != want {
+			t.Fatalf("replay = %v, %v; want %q", resp, err, want)
+		}
+	}
+	// The offered tool set is part of the key.
+	if resp, err := player.Infer(ctx, "hello", tools); err != nil || resp.Text != "hello #3" {
+		t.Fatalf("replay with tools = %v, %v", resp, err)
+	}
+	if upstream.calls != 3 {
+		t.Fatalf("upstream called %d times, want 3 (recording only)", upstream.calls)
+	}
+
+	_, err = player.Infer(ctx, "never recorded", InferOptions{})
+	if !errors.Is(err, ErrCassetteMiss) {
+		t.Fatalf("expected ErrCassetteMiss, got %v", err)
+	}
+}
+
+func TestCassette_MessagesAreMatchedNotPrompt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chat.json")
+	recorder, _ := NewCassetteAdapter(ProviderConfig{Cassette: &CassetteConfig{Path: path, Mode: CassetteRecord}}, &sequenceAdapter{})
+	ctx := context.Background()
+	first := InferOptions{Messages: []ChatMessage{{Role: "user", Content: "a"}}}
+	if _, err := recorder.Infer(ctx, "", first); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	player, err := NewCassetteAdapter(ProviderConfig{Cassette: &CassetteConfig{Path: path}}, nil)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if _, err := player.Infer(ctx, "", first); err != nil {
+		t.Fatalf("expected hit for identical messages: %v", err)
+	}
+	second := InferOptions{Messages: []ChatMessage{{Role: "user", Content: "b"}}}
+	if _, err := player.Infer(ctx, "", second); !errors.Is(err, ErrCassetteMiss) {
+		t.Fatalf("expected miss for different messages, got %v", err)
+	}
+}
+
+func TestCassette_ConfigErrors(t *testing.T) {
+	if _, err := NewCassetteAdapter(ProviderConfig{}, nil); err == nil {
+		t.Error("expected error without cassette.path")
+	}
+	if _, err := NewCassetteAdapter(ProviderConfig{Cassette: &CassetteConfig{Path: "x.json", Mode: CassetteRecord}}, nil); err == nil {
+		t.Error("expected error recording without an upstream")
+	}
+	if _, err := NewCassetteAdapter(ProviderConfig{Cassette: &CassetteConfig{Path: filepath.Join(t.TempDir(), "missing.json")}}, nil); err == nil {
+		t.Error("expected error replaying a missing fixture")
+	}
+}
+
+func TestNewRouter_CassetteProviderType(t *testing.T) {
+	dir := t.TempDir()
+	fixture := filepath.Join(dir, "soma.json")
+	if err := os.WriteFile(fixture, []byte(`{"version":1,"interactions":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(dir, "cognitive.yaml")
+	config := "providers:\n  replay:\n    type: cassette\n    enabled: true\n    cassette:\n      path: " + fixture + "\nprofiles:\n  chat: replay\n"
+	if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewRouter(configPath, nil)
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+	if _, ok := r.Adapters["replay"].(*CassetteAdapter); !ok {
+		t.Fatalf("expected cassette adapter, got %T", r.Adapters["replay"])
+	}
+}
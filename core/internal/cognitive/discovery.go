@@ -2,6 +2,8 @@ package cognitive
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -24,6 +26,46 @@ type ValidationResult struct {
 	Error      error
 }
 
+// ModelInfo is metadata for one model installed on a provider.
+type ModelInfo struct {
+	Name          string   `json:"name"`
+	Family        string   `json:"family,omitempty"`
+	ParameterSize string   `json:"parameter_size,omitempty"`
+	Quantization  string   `json:"quantization,omitempty"`
+	SizeBytes     int64    `json:"size_bytes,omitempty"`
+	ContextLength int      `json:"context_length,omitempty"`
+	Capabilities  []string `json:"capabilities,omitempty"`
+	Embedding     bool     `json:"embedding"`
+	Tier          Tier     `json:"tier"`
+}
+
+// ModelCatalog is implemented by providers that can report and install
+// their own models (currently Ollama).
+type ModelCatalog interface {
+	ConfiguredModel() string
+	ListModels(ctx context.Context) ([]ModelInfo, error)
+	PullModel(ctx context.Context, name string) (*ModelInfo, error)
+}
+
+// FindModel looks name up in models. A name without a tag matches ":latest".
+func FindModel(models []ModelInfo, name string) (ModelInfo, bool) {
+	want := normalizeModelTag(name)
+	for _, m := range models {
+		if normalizeModelTag(m.Name) == want {
+			return m, true
+		}
+	}
+	return ModelInfo{}, false
+}
+
+func normalizeModelTag(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name != "" && !strings.Contains(name, ":") {
+		name += ":latest"
+	}
+	return name
+}
+
 // ServiceDiscovery manages probing and grading of providers
 type ServiceDiscovery struct {
 	Providers map[string]LLMProvider
@@ -41,27 +83,89 @@ func (s *ServiceDiscovery) DiscoverAll(ctx context.Context) map[string]Validatio
 		// 10s timeout for probing (Kind->Host LAN can be slow)
 		ctxProbe, cancel := context.WithTimeout(ctx, 10*time.Second)
 		healthy, err := provider.Probe(ctxProbe)
-		cancel()
-
-		// Get Model ID? The provider struct has it, but interface doesn't expose it.
-		// For now, we assume Config provided ModelID is the one we use.
-		// In future: Probe() could return metadata.
 
-		tier := TierU
-		// Simple Mock Grading based on Provider ID/Config if readable?
-		// Limitation: Provider interface doesn't expose Config.
-		// Ideally, we pass the Config map into Discovery.
-
-		results[id] = ValidationResult{
+		result := ValidationResult{
 			ProviderID: id,
 			Healthy:    healthy,
 			Error:      err,
-			Tier:       tier, // Filled by Grader later
+			Tier:       TierU, // Filled by Grader later unless the provider reports metadata
+		}
+		// Providers with a model catalog report the real model and its metadata;
+		// a reachable daemon without the configured model cannot serve it.
+		if catalog, ok := provider.(ModelCatalog); ok && healthy {
+			result = gradeFromCatalog(ctxProbe, result, catalog)
 		}
+		cancel()
+
+		results[id] = result
 	}
 	return results
 }
 
+func gradeFromCatalog(ctx context.Context, result ValidationResult, catalog ModelCatalog) ValidationResult {
+	models, err := catalog.ListModels(ctx)
+	if err != nil {
+		result.Healthy, result.Error = false, err
+		return result
+	}
+	info, found := FindModel(models, catalog.ConfiguredModel())
+	if !found {
+		result.Healthy = false
+		result.Error = fmt.Errorf("model %q is not installed", catalog.ConfiguredModel())
+		return result
+	}
+	result.ModelID, result.Tier = info.Name, info.Tier
+	return result
+}
+
+// GradeModelInfo assigns a Tier from installed-model metadata, falling back
+// to GradeModel when the parameter count is unknown. Embedding-only models
+// grade as TierC since they cannot serve completions.
+func GradeModelInfo(info ModelInfo) Tier {
+	if info.Embedding && !hasCapability(info.Capabilities, "completion") {
+		return TierC
+	}
+	billions := parseParameterSize(info.ParameterSize)
+	switch {
+	case billions <= 0:
+		return GradeModel(info.Name)
+	case billions >= 30:
+		return TierA
+	case billions >= 4:
+		return TierB
+	default:
+		return TierC
+	}
+}
+
+func hasCapability(capabilities []string, want string) bool {
+	for _, c := range capabilities {
+		if c == want {
+			return true
+		}
+	}
+	return false
+}
+
+// parseParameterSize converts Ollama's "14.8B" / "567M" into billions.
+func parseParameterSize(size string) float64 {
+	size = strings.ToUpper(strings.TrimSpace(size))
+	scale := 1.0
+	switch {
+	case strings.HasSuffix(size, "B"):
+		size = strings.TrimSuffix(size, "B")
+	case strings.HasSuffix(size, "M"):
+		size, scale = strings.TrimSuffix(size, "M"), 0.001
+	default:
+		return 0
+	}
+	n, err := strconv.ParseFloat(size, 64)
+	if err != nil {
+		return 0
+	}
+	return n * scale
+}
+
 // GradeModel assigns a Tier based on the Model ID string
 func GradeModel(modelID string) Tier {
 	m := strings.ToLower(modelID)
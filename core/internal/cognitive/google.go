@@ -48,7 +48,9 @@ func NewGoogleAdapter(config ProviderConfig) (*GoogleAdapter, error) {
 // --- Request/Response Structs ---
 
 type googlePart struct {
-	Text string `json:"text"`
+	Text             string                  `json:"text,omitempty"`
+	FunctionCall     *googleFunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *googleFunctionResponse `json:"functionResponse,omitempty"`
 }
 
 type googleContent struct {
@@ -57,25 +59,59 @@ type googleContent struct {
 }
 
 type googleRequest struct {
-	Contents []googleContent `json:"contents"`
+	Contents          []googleContent `json:"contents"`
+	SystemInstruction *googleContent  `json:"systemInstruction,omitempty"`
+	Tools             []googleTool    `json:"tools,omitempty"`
 
 	// Safety, Generation Config could go here
 	GenerationConfig struct {
-		Temperature     float64 `json:"temperature,omitempty"`
-		MaxOutputTokens int     `json:"maxOutputTokens,omitempty"`
+		Temperature      float64        `json:"temperature,omitempty"`
+		TopP             float64        `json:"topP,omitempty"`
+		MaxOutputTokens  int            `json:"maxOutputTokens,omitempty"`
+		StopSequences    []string       `json:"stopSequences,omitempty"`
+		Seed             *int           `json:"seed,omitempty"`
+		ResponseMimeType string         `json:"responseMimeType,omitempty"`
+		ResponseSchema   map[string]any `json:"responseSchema,omitempty"`
 	} `json:"generationConfig,omitempty"`
 }
 
+type googleTool struct {
+	FunctionDeclarations []googleFunctionDeclaration `json:"functionDeclarations"`
+}
+
+type googleFunctionDeclaration struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+type googleFunctionCall struct {
+	Name string         `json:"name"`
+	Args map[string]any `json:"args"`
+}
+
+type googleFunctionResponse struct {
+	Name     string         `json:"name"`
+	Response map[string]any `json:"response"`
+}
+
 type googleResponse struct {
 	Candidates []struct {
 		Content struct {
 			Parts []struct {
-				Text string `json:"text"`
+				Text         string              `json:"text"`
+				FunctionCall *googleFunctionCall `json:"functionCall,omitempty"`
 			} `json:"parts"`
 		} `json:"content"`
 		FinishReason string `json:"finishReason"`
 	} `json:"candidates"`
 
+	UsageMetadata *struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+		TotalTokenCount      int `json:"totalTokenCount"`
+	} `json:"usageMetadata,omitempty"`
+
 	Error *struct {
 		Code    int    `json:"code"`
 		Message string `json:"message"`
@@ -83,20 +119,63 @@ type googleResponse struct {
 	} `json:"error,omitempty"`
 }
 
-func (g *GoogleAdapter) Infer(ctx context.Context, prompt string, opts InferOptions) (*InferResponse, error) {
+// googleContents maps structured chat messages onto Gemini contents: system
+// turns become the systemInstruction, assistant turns use the "model" role and
+// tool results become functionResponse parts.
+func googleContents(prompt string, messages []ChatMessage) (*googleContent, []googleContent) {
+	if len(messages) == 0 {
+		return nil, []googleContent{{Role: "user", Parts: []googlePart{{Text: prompt}}}}
+	}
+	var system []googlePart
+	contents := make([]googleContent, 0, len(messages))
+	callNames := map[string]string{}
+	for _, m := range messages {
+		switch m.Role {
+		case "system":
+			system = append(system, googlePart{Text: m.Content})
+		case "assistant":
+			contents = append(contents, googleModelTurn(m, callNames))
+		case "tool":
+			contents = appendGoogleToolResult(contents, m, callNames[m.ToolCallID])
+		default:
+			contents = append(contents, googleContent{Role: "user", Parts: []googlePart{{Text: m.Content}}})
+		}
+	}
+	if len(system) == 0 {
+		return nil, contents
+	}
+	return &googleContent{Parts: system}, contents
+}
+
+func (g *GoogleAdapter) buildRequest(ctx context.Context, prompt string, opts InferOptions, method string) (*http.Request, error) {
 	// 1. Prepare Payload
+	system, contents := googleContents(prompt, opts.Messages)
 	payload := googleRequest{
-		Contents: []googleContent{
-			{
-				Role:  "user",
-				Parts: []googlePart{{Text: prompt}},
-			},
-		},
+		Contents:          contents,
+		SystemInstruction: system,
+	}
+	if len(opts.Tools) > 0 {
+		decls := make([]googleFunctionDeclaration, 0, len(opts.Tools))
+		for _, tool := range opts.Tools {
+			decls = append(decls, googleFunctionDeclaration{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  toolParametersSchema(tool.Parameters),
+			})
+		}
+		payload.Tools = []googleTool{{FunctionDeclarations: decls}}
 	}
 
 	// Apply Options
 	payload.GenerationConfig.Temperature = opts.Temperature
 	payload.GenerationConfig.MaxOutputTokens = opts.MaxTokens
+	payload.GenerationConfig.TopP = opts.TopP
+	payload.GenerationConfig.StopSequences = opts.Stop
+	payload.GenerationConfig.Seed = opts.Seed
+	if opts.JSONMode || len(opts.ResponseSchema) > 0 {
+		payload.GenerationConfig.ResponseMimeType = "application/json"
+		payload.GenerationConfig.ResponseSchema = opts.ResponseSchema
+	}
 
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -105,7 +184,7 @@ func (g *GoogleAdapter) Infer(ctx context.Context, prompt string, opts InferOpti
 
 	// 2. Build URL and authenticate with the documented API-key header.
 	endpoint := strings.TrimRight(g.endpoint, "/")
-	url := fmt.Sprintf("%s/%s:generateContent", endpoint, g.model)
+	url := fmt.Sprintf("%s/%s:%s", endpoint, g.model, method)
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payloadBytes))
 	if err != nil {
@@ -113,6 +192,14 @@ func (g *GoogleAdapter) Infer(ctx context.Context, prompt string, opts InferOpti
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-goog-api-key", g.apiKey)
+	return req, nil
+}
+
+func (g *GoogleAdapter) Infer(ctx context.Context, prompt string, opts InferOptions) (*InferResponse, error) {
+	req, err := g.buildRequest(ctx, prompt, opts, "generateContent")
+	if err != nil {
+		return nil, err
+	}
 
 	// 3. Execute
 	client := &http.Client{}
@@ -126,7 +213,7 @@ func (g *GoogleAdapter) Infer(ctx context.Context, prompt string, opts InferOpti
 	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("google error %d: %s", resp.StatusCode, string(body))
+		return nil, &ProviderHTTPError{Provider: "google", StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result googleResponse
@@ -146,11 +233,49 @@ func (g *GoogleAdapter) Infer(ctx context.Context, prompt string, opts InferOpti
 		return nil, fmt.Errorf("google returned empty content parts")
 	}
 
-	return &InferResponse{
-		Text:      result.Candidates[0].Content.Parts[0].Text,
+	text, toolCalls := googleParts(result)
+
+	out := &InferResponse{
+		Text:      text,
 		ModelUsed: g.model,
 		Provider:  "google",
-	}, nil
+		ToolCalls: toolCalls,
+	}
+	applyGoogleUsage(out, result)
+	return out, nil
+}
+
+// applyGoogleUsage copies usageMetadata token counts onto resp. Streamed
+// chunks report cumulative counts, so the last chunk wins.
+func applyGoogleUsage(resp *InferResponse, result googleResponse) {
+	if result.UsageMetadata == nil {
+		return
+	}
+	resp.PromptTokens = result.UsageMetadata.PromptTokenCount
+	resp.CompletionTokens = result.UsageMetadata.CandidatesTokenCount
+	resp.TokensUsed = result.UsageMetadata.TotalTokenCount
+}
+
+// googleParts joins the text parts of the first candidate and converts
+// functionCall parts to ToolCalls.
+func googleParts(result googleResponse) (string, []ToolCall) {
+	if len(result.Candidates) == 0 {
+		return "", nil
+	}
+	var text strings.Builder
+	var calls []ToolCall
+	for _, part := range result.Candidates[0].Content.Parts {
+		if part.FunctionCall != nil && part.FunctionCall.Name != "" {
+			args := part.FunctionCall.Args
+			if args == nil {
+				args = map[string]any{}
+			}
+			calls = append(calls, ToolCall{Name: part.FunctionCall.Name, Arguments: args})
+			continue
+		}
+		text.WriteString(part.Text)
+	}
+	return text.String(), calls
 }
 
 func (g *GoogleAdapter) Probe(ctx context.Context) (bool, error) {
@@ -0,0 +1,78 @@
+package cognitive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// InferStream calls streamGenerateContent with alt=sse; every event carries a
+// partial googleResponse whose text parts are forwarded in order.
+func (g *GoogleAdapter) InferStream(ctx context.Context, prompt string, opts InferOptions, onDelta func(delta string)) (*InferResponse, error) {
+	req, err := g.buildRequest(ctx, prompt, opts, "streamGenerateContent")
+	if err != nil {
+		return nil, err
+	}
+	query := req.URL.Query()
+	query.Set("alt", "sse")
+	req.URL.RawQuery = query.Encode()
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("google connection failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &ProviderHTTPError{Provider: "google", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var text strings.Builder
+	var toolCalls []ToolCall
+	var last googleResponse
+	candidates := 0
+	err = readSSEData(resp.Body, func(data []byte) error {
+		var chunk googleResponse
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return fmt.Errorf("failed to decode google stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return fmt.Errorf("google api error %d: %s", chunk.Error.Code, chunk.Error.Message)
+		}
+		if chunk.UsageMetadata != nil {
+			last = chunk
+		}
+		if len(chunk.Candidates) == 0 {
+			return nil
+		}
+		candidates++
+		delta, calls := googleParts(chunk)
+		toolCalls = append(toolCalls, calls...)
+		if delta != "" {
+			text.WriteString(delta)
+			onDelta(delta)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if candidates == 0 {
+		return nil, fmt.Errorf("google returned no candidates")
+	}
+
+	out := &InferResponse{
+		Text:      text.String(),
+		ModelUsed: g.model,
+		Provider:  "google",
+		ToolCalls: toolCalls,
+	}
+	applyGoogleUsage(out, last)
+	return out, nil
+}
@@ -69,6 +69,38 @@ func setupRouter() *Router {
 	return r
 }
 
+// routerOption adjusts a router built by newTestRouter.
+type routerOption func(*Router)
+
+// newTestRouter returns a router whose "chat" and "review" profiles route to
+// one remote provider backed by adapter.
+func newTestRouter(adapter LLMProvider, opts ...routerOption) *Router {
+	r := &Router{
+		Config: &BrainConfig{
+			Providers: map[string]ProviderConfig{"remote": {Type: "openai", ModelID: "gpt-test", Location: "remote", Enabled: true}},
+			Profiles:  map[string]string{"chat": "remote", "review": "remote"},
+		},
+		Adapters: map[string]LLMProvider{"remote": adapter},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// withProvider adds or replaces a provider and its adapter.
+func withProvider(id string, cfg ProviderConfig, adapter LLMProvider) routerOption {
+	return func(r *Router) {
+		r.Config.Providers[id] = cfg
+		r.Adapters[id] = adapter
+	}
+}
+
+// withConfig edits the router config.
+func withConfig(edit func(*BrainConfig)) routerOption {
+	return func(r *Router) { edit(r.Config) }
+}
+
 // NOTE: Since V2 Router simplified logic (removed Middleware loop for now or it's inside InferWithContract?),
 // Let's verify Router.go.
 // V2 Router.go:
@@ -0,0 +1,209 @@
+package cognitive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// OllamaDefaultEndpoint is the native API root of a local Ollama daemon.
+const OllamaDefaultEndpoint = "http://127.0.0.1:11434"
+
+// OllamaAdapter serves completions, streaming and embeddings through Ollama's
+// OpenAI-compatible /v1 surface and uses the native /api surface for model
+// listing, capability discovery and pulls.
+type OllamaAdapter struct {
+	*OpenAIAdapter
+	baseURL string
+	model   string
+	client  *http.Client
+}
+
+func NewOllamaAdapter(config ProviderConfig) (*OllamaAdapter, error) {
+	baseURL := strings.TrimSuffix(strings.TrimRight(config.Endpoint, "/"), "/v1")
+	if baseURL == "" {
+		baseURL = OllamaDefaultEndpoint
+	}
+
+	compat := config
+	compat.Type = "openai_compatible"
+	compat.Endpoint = baseURL + "/v1"
+	inner, err := NewOpenAIAdapter(compat)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OllamaAdapter{
+		OpenAIAdapter: inner,
+		baseURL:       baseURL,
+		model:         config.ModelID,
+		client:        &http.Client{},
+	}, nil
+}
+
+// --- Native API Structs ---
+
+type ollamaModelDetails struct {
+	Family            string `json:"family"`
+	ParameterSize     string `json:"parameter_size"`
+	QuantizationLevel string `json:"quantization_level"`
+}
+
+type ollamaTagsResponse struct {
+	Models []struct {
+		Name    string             `json:"name"`
+		Size    int64              `json:"size"`
+		Details ollamaModelDetails `json:"details"`
+	} `json:"models"`
+}
+
+type ollamaShowResponse struct {
+	Capabilities []string           `json:"capabilities"`
+	ModelInfo    map[string]any     `json:"model_info"`
+	Details      ollamaModelDetails `json:"details"`
+}
+
+type ollamaPullResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// ConfiguredModel returns the model ID this provider is routed to.
+func (a *OllamaAdapter) ConfiguredModel() string {
+	return a.model
+}
+
+// Probe checks that the daemon answers on the native API.
+// Whether the configured model is installed is reported by ListModels.
+func (a *OllamaAdapter) Probe(ctx context.Context) (bool, error) {
+	var tags ollamaTagsResponse
+	if err := a.call(ctx, http.MethodGet, "/api/tags", nil, &tags); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListModels returns the installed models with context length, capabilities
+// and a tier graded from their metadata.
+func (a *OllamaAdapter) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	var tags ollamaTagsResponse
+	if err := a.call(ctx, http.MethodGet, "/api/tags", nil, &tags); err != nil {
+		return nil, err
+	}
+	models := make([]ModelInfo, 0, len(tags.Models))
+	for _, m := range tags.Models {
+		info := ModelInfo{
+			Name:          m.Name,
+			Family:        m.Details.Family,
+			ParameterSize: m.Details.ParameterSize,
+			Quantization:  m.Details.QuantizationLevel,
+			SizeBytes:     m.Size,
+		}
+		// Capabilities are best effort: a failed show still lists the model.
+		if show, err := a.show(ctx, m.Name); err == nil {
+			applyOllamaShow(&info, show)
+		}
+		info.Tier = GradeModelInfo(info)
+		models = append(models, info)
+	}
+	return models, nil
+}
+
+// PullModel downloads name (the configured model when empty) and returns its
+// metadata once the pull completes. Blocks for the length of the download.
+func (a *OllamaAdapter) PullModel(ctx context.Context, name string) (*ModelInfo, error) {
+	if name == "" {
+		name = a.model
+	}
+	if name == "" {
+		return nil, fmt.Errorf("ollama pull: no model named")
+	}
+	var pulled ollamaPullResponse
+	if err := a.call(ctx, http.MethodPost, "/api/pull", map[string]any{"model": name, "stream": false}, &pulled); err != nil {
+		return nil, err
+	}
+	if pulled.Error != "" {
+		return nil, fmt.Errorf("ollama pull %s: %s", name, pulled.Error)
+	}
+
+	info := ModelInfo{Name: name}
+	if show, err := a.show(ctx, name); err == nil {
+		applyOllamaShow(&info, show)
+	}
+	info.Tier = GradeModelInfo(info)
+	return &info, nil
+}
+
+func (a *OllamaAdapter) show(ctx context.Context, name string) (*ollamaShowResponse, error) {
+	var show ollamaShowResponse
+	if err := a.call(ctx, http.MethodPost, "/api/show", map[string]string{"model": name}, &show); err != nil {
+		return nil, err
+	}
+	return &show, nil
+}
+
+// call performs one native API request and decodes the JSON reply into out.
+func (a *OllamaAdapter) call(ctx context.Context, method, path string, payload any, out any) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("marshal ollama request: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, a.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ollama request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(resp.Body)
+		return &ProviderHTTPError{Provider: "ollama", StatusCode: resp.StatusCode, Body: string(raw)}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode ollama response: %w", err)
+	}
+	return nil
+}
+
+// applyOllamaShow copies capabilities and the architecture's context length
+// (model_info["<arch>.context_length"]) into info.
+func applyOllamaShow(info *ModelInfo, show *ollamaShowResponse) {
+	info.Capabilities = show.Capabilities
+	for _, c := range show.Capabilities {
+		if c == "embedding" {
+			info.Embedding = true
+		}
+	}
+	if info.Family == "" {
+		info.Family = show.Details.Family
+	}
+	if info.ParameterSize == "" {
+		info.ParameterSize = show.Details.ParameterSize
+	}
+	if info.Quantization == "" {
+		info.Quantization = show.Details.QuantizationLevel
+	}
+	for key, value := range show.ModelInfo {
+		if !strings.HasSuffix(key, ".context_length") {
+			continue
+		}
+		if n, ok := value.(float64); ok {
+			info.ContextLength = int(n)
+		}
+	}
+}
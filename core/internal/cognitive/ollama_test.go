@@ -0,0 +1,128 @@
+package cognitive
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeOllama serves the native /api surface with a fixed set of installed models.
+func fakeOllama(t *testing.T, installed ...string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var pulled []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model string `json:"model"`
+		}
+		if r.Method == http.MethodPost {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		switch r.URL.Path {
+		case "/api/tags":
+			models := []map[string]any{}
+			for _, name := range installed {
+				models = append(models, map[string]any{
+					"name": name, "size": 9276198565,
+					"details": map[string]string{"family": "qwen3", "parameter_size": "14.8B", "quantization_level": "Q4_K_M"},
+				})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"models": models})
+		case "/api/show":
+			if strings.HasPrefix(body.Model, "nomic-embed-text") {
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"capabilities": []string{"embedding"},
+					"model_info":   map[string]any{"nomic-bert.context_length": 2048},
+					"details":      map[string]string{"parameter_size": "137M"},
+				})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"capabilities": []string{"completion", "tools"},
+				"model_info":   map[string]any{"general.architecture": "qwen3", "qwen3.context_length": 40960},
+			})
+		case "/api/pull":
+			pulled = append(pulled, body.Model)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &pulled
+}
+
+func TestOllamaAdapter_ListModelsReportsMetadata(t *testing.T) {
+	srv, _ := fakeOllama(t, "qwen3:14b", "nomic-embed-text:latest")
+	adapter, err := NewOllamaAdapter(ProviderConfig{Type: "ollama", Endpoint: srv.URL + "/v1", ModelID: "qwen3:14b"})
+	if err != nil {
+		t.Fatalf("NewOllamaAdapter: %v", err)
+	}
+
+	models, err := adapter.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels: %v", err)
+	}
+	chat, ok := FindModel(models, "qwen3:14b")
+	if !ok || chat.ContextLength != 40960 || chat.Embedding || chat.Tier != TierB {
+		t.Fatalf("unexpected chat model %+v", chat)
+	}
+	embed, ok := FindModel(models, "nomic-embed-text")
+	if !ok || !embed.Embedding || embed.ContextLength != 2048 || embed.Tier != TierC {
+		t.Fatalf("unexpected embedding model %+v", embed)
+	}
+}
+
+func TestOllamaAdapter_PullDefaultsToConfiguredModel(t *testing.T) {
+	srv, pulled := fakeOllama(t)
+	adapter, err := NewOllamaAdapter(ProviderConfig{Endpoint: srv.URL, ModelID: "qwen3:14b"})
+	if err != nil {
+		t.Fatalf("NewOllamaAdapter: %v", err)
+	}
+
+	info, err := adapter.PullModel(context.Background(), "")
+	if err != nil {
+		t.Fatalf("PullModel: %v", err)
+	}
+	if len(*pulled) != 1 || (*pulled)[0] != "qwen3:14b" {
+		t.Fatalf("pulled %v, want [qwen3:14b]", *pulled)
+	}
+	if info.Name != "qwen3:14b" || info.ContextLength != 40960 {
+		t.Fatalf("unexpected pull result %+v", info)
+	}
+}
+
+func TestDiscoverAll_UsesCatalogForOllama(t *testing.T) {
+	srv, _ := fakeOllama(t, "qwen3:14b")
+	installed, _ := NewOllamaAdapter(ProviderConfig{Endpoint: srv.URL + "/v1", ModelID: "qwen3:14b"})
+	missing, _ := NewOllamaAdapter(ProviderConfig{Endpoint: srv.URL + "/v1", ModelID: "llama3.3:70b"})
+
+	results := NewServiceDiscovery(map[string]LLMProvider{"local": installed, "big": missing}).DiscoverAll(context.Background())
+
+	if got := results["local"]; !got.Healthy || got.ModelID != "qwen3:14b" || got.Tier != TierB {
+		t.Fatalf("unexpected result for installed model: %+v", got)
+	}
+	if got := results["big"]; got.Healthy || got.Error == nil || !strings.Contains(got.Error.Error(), "not installed") {
+		t.Fatalf("missing model should be unhealthy, got %+v", got)
+	}
+}
+
+func TestGradeModelInfo(t *testing.T) {
+	tests := []struct {
+		info ModelInfo
+		want Tier
+	}{
+		{ModelInfo{Name: "llama3.3:70b", ParameterSize: "70.6B"}, TierA},
+		{ModelInfo{Name: "qwen3:14b", ParameterSize: "14.8B"}, TierB},
+		{ModelInfo{Name: "qwen3:1.7b", ParameterSize: "2.0B"}, TierC},
+		{ModelInfo{Name: "nomic-embed-text", ParameterSize: "137M", Embedding: true, Capabilities: []string{"embedding"}}, TierC},
+		{ModelInfo{Name: "gpt-4o"}, TierS}, // no metadata: name-based grading
+	}
+	for _, tt := range tests {
+		if got := GradeModelInfo(tt.info); got != tt.want {
+			t.Errorf("GradeModelInfo(%s) = %v, want %v", tt.info.Name, got, tt.want)
+		}
+	}
+}
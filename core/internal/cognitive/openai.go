@@ -3,7 +3,9 @@ package cognitive
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -11,8 +13,9 @@ import (
 )
 
 type OpenAIAdapter struct {
-	client *openai.Client
-	model  string
+	client      *openai.Client
+	model       string
+	streamUsage bool
 }
 
 func NewOpenAIAdapter(config ProviderConfig) (*OpenAIAdapter, error) {
@@ -38,21 +41,19 @@ func NewOpenAIAdapter(config ProviderConfig) (*OpenAIAdapter, error) {
 	}
 
 	return &OpenAIAdapter{
-		client: openai.NewClientWithConfig(clientConfig),
-		model:  config.ModelID,
+		client:      openai.NewClientWithConfig(clientConfig),
+		model:       config.ModelID,
+		streamUsage: config.StreamUsageEnabled(),
 	}, nil
 }
 
-func (a *OpenAIAdapter) Infer(ctx context.Context, prompt string, opts InferOptions) (*InferResponse, error) {
+func (a *OpenAIAdapter) buildChatRequest(prompt string, opts InferOptions) openai.ChatCompletionRequest {
 	// Map abstract ChatMessage to openai.ChatCompletionMessage
 	var messages []openai.ChatCompletionMessage
 	if len(opts.Messages) > 0 {
 		messages = make([]openai.ChatCompletionMessage, len(opts.Messages))
 		for i, m := range opts.Messages {
-			messages[i] = openai.ChatCompletionMessage{
-				Role:    m.Role,
-				Content: m.Content,
-			}
+			messages[i] = openAIMessage(m)
 		}
 	} else {
 		// Fallback for legacy Prompt field
@@ -61,13 +62,40 @@ func (a *OpenAIAdapter) Infer(ctx context.Context, prompt string, opts InferOpti
 		}
 	}
 
-	reqBody := openai.ChatCompletionRequest{
+	req := openai.ChatCompletionRequest{
 		Model:       a.model,
 		Messages:    messages,
 		Temperature: float32(opts.Temperature),
 		MaxTokens:   opts.MaxTokens,
 		Stop:        opts.Stop,
+		TopP:        float32(opts.TopP),
+		Seed:        opts.Seed,
+	}
+	switch {
+	case len(opts.ResponseSchema) > 0:
+		schema, _ := json.Marshal(opts.ResponseSchema)
+		req.ResponseFormat = &openai.ChatCompletionResponseFormat{
+			Type:       openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{Name: "response", Schema: json.RawMessage(schema)},
+		}
+	case opts.JSONMode:
+		req.ResponseFormat = &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	}
+	for _, tool := range opts.Tools {
+		req.Tools = append(req.Tools, openai.Tool{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  toolParametersSchema(tool.Parameters),
+			},
+		})
 	}
+	return req
+}
+
+func (a *OpenAIAdapter) Infer(ctx context.Context, prompt string, opts InferOptions) (*InferResponse, error) {
+	reqBody := a.buildChatRequest(prompt, opts)
 
 	resp, err := a.client.CreateChatCompletion(ctx, reqBody)
 	if err != nil {
@@ -78,26 +106,101 @@ func (a *OpenAIAdapter) Infer(ctx context.Context, prompt string, opts InferOpti
 		return nil, fmt.Errorf("no choices returned")
 	}
 
-	text := normalizeOpenAIMessage(resp.Choices[0].Message)
+	msg := resp.Choices[0].Message
 
 	return &InferResponse{
-		Text:      text,
-		ModelUsed: a.model,
-		Provider:  "openai",
+		Text:             normalizeOpenAIMessage(msg),
+		ModelUsed:        a.model,
+		Provider:         "openai",
+		TokensUsed:       resp.Usage.TotalTokens,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+		ToolCalls:        openAIToolCalls(msg),
 	}, nil
 }
 
-func normalizeOpenAIMessage(msg openai.ChatCompletionMessage) string {
-	if len(msg.ToolCalls) > 0 {
-		if payload, ok := synthesizeToolCallPayload(msg.ToolCalls[0].Function.Name, msg.ToolCalls[0].Function.Arguments); ok {
-			return payload
-		}
+// InferStream streams content deltas from the chat completions endpoint.
+// Tool-call fragments are accumulated silently and folded into the final
+// response the same way Infer normalizes them. Usage is requested only when
+// the provider accepts stream_options; otherwise the router estimates it.
+func (a *OpenAIAdapter) InferStream(ctx context.Context, prompt string, opts InferOptions, onDelta func(delta string)) (*InferResponse, error) {
+	reqBody := a.buildChatRequest(prompt, opts)
+	reqBody.Stream = true
+	if a.streamUsage {
+		reqBody.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
 	}
-	if msg.FunctionCall != nil {
-		if payload, ok := synthesizeToolCallPayload(msg.FunctionCall.Name, msg.FunctionCall.Arguments); ok {
-			return payload
+
+	stream, err := a.client.CreateChatCompletionStream(ctx, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("openai stream failed: %w", err)
+	}
+	defer stream.Close()
+
+	var content, refusal strings.Builder
+	var toolCalls []openai.ToolCall
+	var usage openai.Usage
+	choices := 0
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("openai stream failed: %w", err)
 		}
+		if chunk.Usage != nil {
+			usage = *chunk.Usage
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+		choices++
+		delta := chunk.Choices[0].Delta
+		if delta.Content != "" {
+			content.WriteString(delta.Content)
+			onDelta(delta.Content)
+		}
+		refusal.WriteString(delta.Refusal)
+		for _, tc := range delta.ToolCalls {
+			idx := len(toolCalls)
+			if tc.Index != nil {
+				idx = *tc.Index
+			}
+			for len(toolCalls) <= idx {
+				toolCalls = append(toolCalls, openai.ToolCall{})
+			}
+			if tc.ID != "" {
+				toolCalls[idx].ID = tc.ID
+			}
+			toolCalls[idx].Function.Name += tc.Function.Name
+			toolCalls[idx].Function.Arguments += tc.Function.Arguments
+		}
+	}
+
+	if choices == 0 {
+		return nil, fmt.Errorf("no choices returned")
 	}
+
+	msg := openai.ChatCompletionMessage{
+		Content:   content.String(),
+		Refusal:   refusal.String(),
+		ToolCalls: toolCalls,
+	}
+
+	return &InferResponse{
+		Text:             normalizeOpenAIMessage(msg),
+		ModelUsed:        a.model,
+		Provider:         "openai",
+		TokensUsed:       usage.TotalTokens,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		ToolCalls:        openAIToolCalls(msg),
+	}, nil
+}
+
+// normalizeOpenAIMessage returns the readable text of a completion message.
+// Tool calls are returned separately by openAIToolCalls.
+func normalizeOpenAIMessage(msg openai.ChatCompletionMessage) string {
 	if strings.TrimSpace(msg.Content) != "" {
 		return msg.Content
 	}
@@ -107,29 +210,21 @@ func normalizeOpenAIMessage(msg openai.ChatCompletionMessage) string {
 	return msg.Content
 }
 
-func synthesizeToolCallPayload(name, arguments string) (string, bool) {
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return "", false
-	}
-
-	args := map[string]any{}
-	if rawArgs := strings.TrimSpace(arguments); rawArgs != "" {
-		if err := json.Unmarshal([]byte(rawArgs), &args); err != nil {
-			args["raw_arguments"] = rawArgs
+// openAIToolCalls converts native tool calls (and the legacy function_call
+// field) into structured ToolCalls.
+func openAIToolCalls(msg openai.ChatCompletionMessage) []ToolCall {
+	var calls []ToolCall
+	for _, tc := range msg.ToolCalls {
+		if name := strings.TrimSpace(tc.Function.Name); name != "" {
+			calls = append(calls, ToolCall{ID: tc.ID, Name: name, Arguments: parseToolArguments(tc.Function.Arguments)})
 		}
 	}
-
-	payload, err := json.Marshal(map[string]any{
-		"tool_call": map[string]any{
-			"name":      name,
-			"arguments": args,
-		},
-	})
-	if err != nil {
-		return "", false
+	if len(calls) == 0 && msg.FunctionCall != nil {
+		if name := strings.TrimSpace(msg.FunctionCall.Name); name != "" {
+			calls = append(calls, ToolCall{Name: name, Arguments: parseToolArguments(msg.FunctionCall.Arguments)})
+		}
 	}
-	return string(payload), true
+	return calls
 }
 
 // Embed generates a vector embedding for the given text using the OpenAI-compatible
@@ -1,15 +1,22 @@
 package cognitive
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	openai "github.com/sashabaranov/go-openai"
 )
 
-func TestNormalizeOpenAIMessage_PrefersStructuredToolCallsWhenContentEmpty(t *testing.T) {
+func TestOpenAIToolCalls_ReturnsStructuredCallsWhenContentEmpty(t *testing.T) {
 	msg := openai.ChatCompletionMessage{
 		ToolCalls: []openai.ToolCall{
 			{
+				ID: "call_1",
 				Function: openai.FunctionCall{
 					Name:      "write_file",
 					Arguments: `{"path":"workspace/test.txt","content":"hello"}`,
@@ -18,14 +25,17 @@ func TestNormalizeOpenAIMessage_PrefersStructuredToolCallsWhenContentEmpty(t *te
 		},
 	}
 
-	got := normalizeOpenAIMessage(msg)
-	want := `{"tool_call":{"arguments":{"content":"hello","path":"workspace/test.txt"},"name":"write_file"}}`
-	if got != want {
-		t.Fatalf("normalizeOpenAIMessage() = %q, want %q", got, want)
+	if text := normalizeOpenAIMessage(msg); text != "" {
+		t.Fatalf("normalizeOpenAIMessage() = %q, want empty text", text)
+	}
+	got := openAIToolCalls(msg)
+	want := []ToolCall{{ID: "call_1", Name: "write_file", Arguments: map[string]any{"path": "workspace/test.txt", "content": "hello"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("openAIToolCalls() = %+v, want %+v", got, want)
 	}
 }
 
-func TestNormalizeOpenAIMessage_FallsBackToFunctionCall(t *testing.T) {
+func TestOpenAIToolCalls_FallsBackToFunctionCall(t *testing.T) {
 	msg := openai.ChatCompletionMessage{
 		FunctionCall: &openai.FunctionCall{
 			Name:      "delegate",
@@ -33,29 +43,163 @@ func TestNormalizeOpenAIMessage_FallsBackToFunctionCall(t *testing.T) {
 		},
 	}
 
-	got := normalizeOpenAIMessage(msg)
-	want := `{"tool_call":{"arguments":{"team_id":"admin-core"},"name":"delegate"}}`
-	if got != want {
-		t.Fatalf("normalizeOpenAIMessage() = %q, want %q", got, want)
+	got := openAIToolCalls(msg)
+	want := []ToolCall{{Name: "delegate", Arguments: map[string]any{"team_id": "admin-core"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("openAIToolCalls() = %+v, want %+v", got, want)
 	}
 }
 
-func TestNormalizeOpenAIMessage_PrefersStructuredToolCallsOverProse(t *testing.T) {
+func TestOpenAIToolCalls_KeepsProseSeparateFromCalls(t *testing.T) {
 	msg := openai.ChatCompletionMessage{
 		Content: "Here is the draft answer, but I also need to call a tool.",
 		ToolCalls: []openai.ToolCall{
 			{
 				Function: openai.FunctionCall{
 					Name:      "write_file",
-					Arguments: `{"path":"workspace/test.txt","content":"hello"}`,
+					Arguments: `not json`,
 				},
 			},
 		},
 	}
 
-	got := normalizeOpenAIMessage(msg)
-	want := `{"tool_call":{"arguments":{"content":"hello","path":"workspace/test.txt"},"name":"write_file"}}`
-	if got != want {
-		t.Fatalf("normalizeOpenAIMessage() = %q, want %q", got, want)
+	if text := normalizeOpenAIMessage(msg); text != msg.Content {
+		t.Fatalf("normalizeOpenAIMessage() = %q, want prose", text)
+	}
+	got := openAIToolCalls(msg)
+	if len(got) != 1 || got[0].Name != "write_file" || got[0].Arguments["raw_arguments"] != "not json" {
+		t.Fatalf("openAIToolCalls() = %+v", got)
+	}
+}
+
+func TestOpenAIAdapter_SendsNativeToolDefinitions(t *testing.T) {
+	t.Parallel()
+
+	var body struct {
+		Tools []struct {
+			Type     string `json:"type"`
+			Function struct {
+				Name       string         `json:"name"`
+				Parameters map[string]any `json:"parameters"`
+			} `json:"function"`
+		} `json:"tools"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":"c1","object":"chat.completion","choices":[{"index":0,"message":{"role":"assistant","content":"","tool_calls":[{"id":"call_9","type":"function","function":{"name":"recall","arguments":"{\"query\":\"launch\"}"}}]},"finish_reason":"tool_calls"}]}`)
+	}))
+	defer server.Close()
+
+	adapter, err := NewOpenAIAdapter(ProviderConfig{Type: "openai_compatible", Endpoint: server.URL + "/v1", ModelID: "qwen-test"})
+	if err != nil {
+		t.Fatalf("NewOpenAIAdapter() error = %v", err)
+	}
+
+	resp, err := adapter.Infer(context.Background(), "", InferOptions{
+		Messages: []ChatMessage{{Role: "user", Content: "what did we decide?"}},
+		Tools:    []ToolDefinition{{Name: "recall", Description: "Recall memory"}},
+	})
+	if err != nil {
+		t.Fatalf("Infer() error = %v", err)
+	}
+	if len(body.Tools) != 1 || body.Tools[0].Type != "function" || body.Tools[0].Function.Name != "recall" {
+		t.Fatalf("unexpected tools payload %+v", body.Tools)
+	}
+	if body.Tools[0].Function.Parameters["type"] != "object" {
+		t.Fatalf("expected default object schema, got %+v", body.Tools[0].Function.Parameters)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].ID != "call_9" || resp.ToolCalls[0].Arguments["query"] != "launch" {
+		t.Fatalf("resp.ToolCalls = %+v", resp.ToolCalls)
+	}
+}
+
+func TestAnthropicAdapter_MapsMessagesAndToolUse(t *testing.T) {
+	t.Parallel()
+
+	var body anthropicRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":"m1","content":[{"type":"text","text":"Looking."},{"type":"tool_use","id":"tu_1","name":"recall","input":{"query":"launch"}}]}`)
+	}))
+	defer server.Close()
+
+	adapter, err := NewAnthropicAdapter(ProviderConfig{Type: "anthropic", Endpoint: server.URL, ModelID: "claude-test", AuthKey: "k"})
+	if err != nil {
+		t.Fatalf("NewAnthropicAdapter() error = %v", err)
+	}
+	resp, err := adapter.Infer(context.Background(), "", InferOptions{
+		Messages: []ChatMessage{{Role: "system", Content: "be terse"}, {Role: "user", Content: "recall launch"}},
+		Tools:    []ToolDefinition{{Name: "recall", Parameters: map[string]any{"type": "object"}}},
+	})
+	if err != nil {
+		t.Fatalf("Infer() error = %v", err)
+	}
+	if body.System != "be terse" || len(body.Messages) != 1 || body.Messages[0].Content != "recall launch" {
+		t.Fatalf("unexpected message mapping system=%q messages=%+v", body.System, body.Messages)
+	}
+	if len(body.Tools) != 1 || body.Tools[0].Name != "recall" {
+		t.Fatalf("unexpected tools payload %+v", body.Tools)
+	}
+	if resp.Text != "Looking." || len(resp.ToolCalls) != 1 || resp.ToolCalls[0].ID != "tu_1" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
+
+func TestGoogleAdapter_MapsFunctionCallParts(t *testing.T) {
+	t.Parallel()
+
+	var body googleRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"candidates":[{"content":{"parts":[{"functionCall":{"name":"recall","args":{"query":"launch"}}}]}}]}`)
+	}))
+	defer server.Close()
+
+	adapter, err := NewGoogleAdapter(ProviderConfig{Type: "google", Endpoint: server.URL, ModelID: "gemini-test", AuthKey: "k"})
+	if err != nil {
+		t.Fatalf("NewGoogleAdapter() error = %v", err)
+	}
+	resp, err := adapter.Infer(context.Background(), "", InferOptions{
+		Messages: []ChatMessage{{Role: "system", Content: "be terse"}, {Role: "assistant", Content: "hi"}, {Role: "user", Content: "recall"}},
+		Tools:    []ToolDefinition{{Name: "recall"}},
+	})
+	if err != nil {
+		t.Fatalf("Infer() error = %v", err)
+	}
+	if body.SystemInstruction == nil || body.SystemInstruction.Parts[0].Text != "be terse" {
+		t.Fatalf("expected systemInstruction, got %+v", body.SystemInstruction)
+	}
+	if len(body.Contents) != 2 || body.Contents[0].Role != "model" {
+		t.Fatalf("unexpected contents %+v", body.Contents)
+	}
+	if len(body.Tools) != 1 || body.Tools[0].FunctionDeclarations[0].Name != "recall" {
+		t.Fatalf("unexpected tools %+v", body.Tools)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].Arguments["query"] != "launch" {
+		t.Fatalf("resp.ToolCalls = %+v", resp.ToolCalls)
+	}
+}
+
+func TestInferWithContract_StripsToolsWhenNativeToolsDisabled(t *testing.T) {
+	disabled := false
+	adapter := &captureAdapter{}
+	r := &Router{
+		Config: &BrainConfig{
+			Providers: map[string]ProviderConfig{"local": {Type: "ollama", ModelID: "tiny", Enabled: true, NativeTools: &disabled}},
+			Profiles:  map[string]string{"chat": "local"},
+		},
+		Adapters: map[string]LLMProvider{"local": adapter},
+	}
+	if _, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi", Tools: []ToolDefinition{{Name: "recall"}}}); err != nil {
+		t.Fatalf("InferWithContract: %v", err)
+	}
+	if len(adapter.lastOpts.Tools) != 0 {
+		t.Fatalf("expected tools to be stripped, got %+v", adapter.lastOpts.Tools)
 	}
 }
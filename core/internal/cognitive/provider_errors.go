@@ -0,0 +1,66 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// ProviderHTTPError is a non-2xx response from a provider API.
+type ProviderHTTPError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+}
+
+func (e *ProviderHTTPError) Error() string {
+	return fmt.Sprintf("%s error %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// isTransientError reports whether err is worth retrying on the same provider.
+func isTransientError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if status := httpStatusOf(err); status != 0 {
+		return status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isProviderFailure reports whether err counts against the provider's health.
+// Caller cancellation and 4xx request errors (other than 408/429) do not.
+func isProviderFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	status := httpStatusOf(err)
+	return status == 0 || status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+}
+
+func httpStatusOf(err error) int {
+	var providerErr *ProviderHTTPError
+	if errors.As(err, &providerErr) {
+		return providerErr.StatusCode
+	}
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatusCode
+	}
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.HTTPStatusCode
+	}
+	return 0
+}
@@ -0,0 +1,255 @@
+package cognitive
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"time"
+)
+
+// Circuit breaker states.
+const (
+	CircuitClosed   = "closed"    // calls flow normally
+	CircuitOpen     = "open"      // calls skip the provider until the cooldown ends
+	CircuitHalfOpen = "half_open" // one trial call decides whether to close or re-open
+)
+
+// Resilience defaults, applied when BrainConfig.Resilience omits a field.
+const (
+	DefaultMaxRetries       = 2
+	DefaultInitialBackoffMS = 250
+	DefaultMaxBackoffMS     = 2000
+	DefaultFailureThreshold = 3
+	DefaultOpenSeconds      = 30
+	DefaultHealthHistory    = 20
+)
+
+// ResiliencePolicy tunes retries and the per-provider circuit breaker.
+// Zero fields use the defaults; set max_retries to -1 to disable retries.
+type ResiliencePolicy struct {
+	MaxRetries       int `yaml:"max_retries,omitempty" json:"max_retries,omitempty"`               // retries for transient errors, per call
+	InitialBackoffMS int `yaml:"initial_backoff_ms,omitempty" json:"initial_backoff_ms,omitempty"` // first retry delay; doubles per retry
+	MaxBackoffMS     int `yaml:"max_backoff_ms,omitempty" json:"max_backoff_ms,omitempty"`
+	FailureThreshold int `yaml:"failure_threshold,omitempty" json:"failure_threshold,omitempty"` // consecutive failed calls before opening
+	OpenSeconds      int `yaml:"open_seconds,omitempty" json:"open_seconds,omitempty"`           // cooldown before a half-open trial
+	HistorySize      int `yaml:"history_size,omitempty" json:"history_size,omitempty"`           // health events kept per provider
+}
+
+// withDefaults fills unset fields.
+func (p ResiliencePolicy) withDefaults() ResiliencePolicy {
+	if p.MaxRetries == 0 {
+		p.MaxRetries = DefaultMaxRetries
+	} else if p.MaxRetries < 0 {
+		p.MaxRetries = 0
+	}
+	if p.InitialBackoffMS <= 0 {
+		p.InitialBackoffMS = DefaultInitialBackoffMS
+	}
+	if p.MaxBackoffMS <= 0 {
+		p.MaxBackoffMS = DefaultMaxBackoffMS
+	}
+	if p.FailureThreshold <= 0 {
+		p.FailureThreshold = DefaultFailureThreshold
+	}
+	if p.OpenSeconds <= 0 {
+		p.OpenSeconds = DefaultOpenSeconds
+	}
+	if p.HistorySize <= 0 {
+		p.HistorySize = DefaultHealthHistory
+	}
+	return p
+}
+
+// backoff returns the delay before retry n (0-based): exponential, capped,
+// with up to 25% jitter so concurrent agents don't retry in lockstep.
+func (p ResiliencePolicy) backoff(n int) time.Duration {
+	d := time.Duration(p.InitialBackoffMS) * time.Millisecond << n
+	if limit := time.Duration(p.MaxBackoffMS) * time.Millisecond; d > limit || d <= 0 {
+		d = limit
+	}
+	return d + time.Duration(rand.Int64N(int64(d)/4+1))
+}
+
+// HealthEvent is one entry in a provider's health history.
+type HealthEvent struct {
+	At        time.Time `json:"at"`
+	OK        bool      `json:"ok"`
+	Attempts  int       `json:"attempts"`
+	LatencyMS int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	State     string    `json:"state"` // breaker state after this event
+}
+
+// ProviderHealth is the breaker state and recent history for one provider.
+type ProviderHealth struct {
+	ProviderID          string        `json:"provider_id"`
+	State               string        `json:"state"`
+	ConsecutiveFailures int           `json:"consecutive_failures"`
+	OpenedAt            *time.Time    `json:"opened_at,omitempty"`
+	RetryAt             *time.Time    `json:"retry_at,omitempty"` // when an open breaker allows a trial
+	History             []HealthEvent `json:"history"`
+}
+
+// providerBreaker tracks one provider. Guarded by Router.healthMu.
+type providerBreaker struct {
+	state    string
+	failures int
+	openedAt time.Time
+	trial    bool // a half-open trial call is in flight
+	history  []HealthEvent
+}
+
+func (r *Router) resiliencePolicy() ResiliencePolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.Config == nil || r.Config.Resilience == nil {
+		return ResiliencePolicy{}.withDefaults()
+	}
+	return r.Config.Resilience.withDefaults()
+}
+
+func (r *Router) breakerFor(providerID string) *providerBreaker {
+	if r.breakers == nil {
+		r.breakers = make(map[string]*providerBreaker)
+	}
+	b := r.breakers[providerID]
+	if b == nil {
+		b = &providerBreaker{state: CircuitClosed}
+		r.breakers[providerID] = b
+	}
+	return b
+}
+
+// breakerAllows reports whether a call may be sent to providerID now. An open
+// breaker whose cooldown has elapsed admits exactly one half-open trial.
+func (r *Router) breakerAllows(providerID string, policy ResiliencePolicy) bool {
+	r.healthMu.Lock()
+	defer r.healthMu.Unlock()
+	b := r.breakerFor(providerID)
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < time.Duration(policy.OpenSeconds)*time.Second {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.trial = true
+		return true
+	case CircuitHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+// recordOutcome feeds one completed call (after retries) into the breaker and
+// the bounded health history.
+func (r *Router) recordOutcome(providerID string, policy ResiliencePolicy, attempts int, latency time.Duration, err error) {
+	r.healthMu.Lock()
+	defer r.healthMu.Unlock()
+	b := r.breakerFor(providerID)
+	b.trial = false
+	event := HealthEvent{At: time.Now().UTC(), OK: err == nil, Attempts: attempts, LatencyMS: latency.Milliseconds()}
+	if err == nil {
+		b.failures = 0
+		b.state = CircuitClosed
+	} else {
+		event.Error = err.Error()
+		b.failures++
+		if b.state == CircuitHalfOpen || b.failures >= policy.FailureThreshold {
+			if b.state != CircuitOpen {
+				fmt.Printf("⚡ Circuit OPEN for '%s' after %d failure(s): %v\n", providerID, b.failures, err)
+			}
+			b.state = CircuitOpen
+			b.openedAt = time.Now()
+		}
+	}
+	event.State = b.state
+	b.history = append(b.history, event)
+	if over := len(b.history) - policy.HistorySize; over > 0 {
+		b.history = append([]HealthEvent(nil), b.history[over:]...)
+	}
+}
+
+// ProviderHealth returns the breaker state and health history of every
+// provider the router has routed to, sorted by provider ID.
+func (r *Router) ProviderHealth() []ProviderHealth {
+	if r == nil {
+		return nil
+	}
+	policy := r.resiliencePolicy()
+	r.healthMu.Lock()
+	defer r.healthMu.Unlock()
+
+	ids := make([]string, 0, len(r.breakers))
+	for id := range r.breakers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	out := make([]ProviderHealth, 0, len(ids))
+	for _, id := range ids {
+		b := r.breakers[id]
+		h := ProviderHealth{
+			ProviderID:          id,
+			State:               b.state,
+			ConsecutiveFailures: b.failures,
+			History:             append([]HealthEvent(nil), b.history...),
+		}
+		if b.state != CircuitClosed {
+			opened := b.openedAt.UTC()
+			retry := opened.Add(time.Duration(policy.OpenSeconds) * time.Second)
+			h.OpenedAt, h.RetryAt = &opened, &retry
+		}
+		out = append(out, h)
+	}
+	return out
+}
+
+// dispatch sends one call to a provider, retrying transient errors with
+// backoff, and records the outcome against the provider's breaker. A retried
+// stream starts over: every attempt, failed or not, ends with a Done chunk,
+// and the next attempt re-sends its deltas from the beginning.
+func (r *Router) dispatch(ctx context.Context, providerID string, adapter LLMProvider, req InferRequest, opts InferOptions, policy ResiliencePolicy) (*InferResponse, error) {
+	start := time.Now()
+	attempts := 0
+	var resp *InferResponse
+	var err error
+	for {
+		attempts++
+		resp, err = inferWithAdapter(ctx, adapter, req.Prompt, opts, req.OnStream)
+		if err == nil || attempts > policy.MaxRetries || !isTransientError(ctx, err) {
+			break
+		}
+		delay := policy.backoff(attempts - 1)
+		fmt.Printf("⏳ Transient error on '%s' (attempt %d): %v. Retrying in %s\n", providerID, attempts, err, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			err = ctx.Err()
+		case <-timer.C:
+			continue
+		}
+		break
+	}
+
+	if err == nil && resp != nil {
+		r.recordOutcome(providerID, policy, attempts, time.Since(start), nil)
+		r.recordUsage(ctx, req, opts, providerID, resp)
+		return resp, nil
+	}
+	if isProviderFailure(ctx, err) {
+		r.recordOutcome(providerID, policy, attempts, time.Since(start), err)
+	} else {
+		// Caller cancellations and request errors say nothing about provider
+		// health; just release a half-open trial slot.
+		r.healthMu.Lock()
+		r.breakerFor(providerID).trial = false
+		r.healthMu.Unlock()
+	}
+	return resp, err
+}
@@ -0,0 +1,254 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// scriptedAdapter returns errs in order, then succeeds.
+type scriptedAdapter struct {
+	errs  []error
+	calls int
+}
+
+func (a *scriptedAdapter) Infer(context.Context, string, InferOptions) (*InferResponse, error) {
+	a.calls++
+	if len(a.errs) > 0 {
+		err := a.errs[0]
+		a.errs = a.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &InferResponse{Text: "ok", ModelUsed: "m"}, nil
+}
+
+func (a *scriptedAdapter) Probe(context.Context) (bool, error) {
+	return true, nil
+}
+
+// localFallback is the local provider the resilience tests fail over to.
+var localFallback = ProviderConfig{Type: "ollama", ModelID: "qwen3:8b", Location: "local", Enabled: true}
+
+func withResilience(policy *ResiliencePolicy) routerOption {
+	return withConfig(func(c *BrainConfig) { c.Resilience = policy })
+}
+
+func healthOf(r *Router, providerID string) ProviderHealth {
+	for _, h := range r.ProviderHealth() {
+		if h.ProviderID == providerID {
+			return h
+		}
+	}
+	return ProviderHealth{}
+}
+
+func TestInferWithContract_RetriesTransientErrors(t *testing.T) {
+	primary := &scriptedAdapter{errs: []error{
+		&ProviderHTTPError{Provider: "anthropic", StatusCode: http.StatusServiceUnavailable, Body: "overloaded"},
+		&ProviderHTTPError{Provider: "anthropic", StatusCode: http.StatusTooManyRequests, Body: "slow down"},
+	}}
+	r := newTestRouter(primary, withResilience(&ResiliencePolicy{InitialBackoffMS: 1, MaxBackoffMS: 2}))
+
+	resp, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi"})
+	if err != nil || resp.Text != "ok" {
+		t.Fatalf("InferWithContract = %v, %v", resp, err)
+	}
+	if primary.calls != 3 {
+		t.Fatalf("adapter calls = %d, want 3 (1 + 2 retries)", primary.calls)
+	}
+	h := healthOf(r, "remote")
+	if h.State != CircuitClosed || len(h.History) != 1 || !h.History[0].OK || h.History[0].Attempts != 3 {
+		t.Fatalf("unexpected health %+v", h)
+	}
+}
+
+func TestInferWithContract_RequestErrorsAreNotRetriedOrCounted(t *testing.T) {
+	primary := &scriptedAdapter{errs: []error{&ProviderHTTPError{Provider: "google", StatusCode: http.StatusBadRequest, Body: "bad schema"}}}
+	fallback := &scriptedAdapter{}
+	r := newTestRouter(primary, withProvider("ollama", localFallback, fallback), withResilience(&ResiliencePolicy{InitialBackoffMS: 1}))
+
+	if _, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi"}); err == nil {
+		t.Fatal("expected request error to surface")
+	}
+	if primary.calls != 1 || fallback.calls != 0 {
+		t.Fatalf("calls primary=%d fallback=%d, want 1/0", primary.calls, fallback.calls)
+	}
+	if h := healthOf(r, "remote"); h.ConsecutiveFailures != 0 || len(h.History) != 0 {
+		t.Fatalf("request error must not count against provider health: %+v", h)
+	}
+}
+
+func TestInferWithContract_OpenCircuitSkipsFlappingProvider(t *testing.T) {
+	down := errors.New("stream reset")
+	primary := &scriptedAdapter{errs: []error{down, down, down, down}}
+	fallback := &scriptedAdapter{}
+	r := newTestRouter(primary, withProvider("ollama", localFallback, fallback), withResilience(&ResiliencePolicy{FailureThreshold: 2, OpenSeconds: 60}))
+
+	for i := 0; i < 2; i++ {
+		if _, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi"}); err != nil {
+			t.Fatalf("call %d: expected failover to succeed, got %v", i, err)
+		}
+	}
+	if h := healthOf(r, "remote"); h.State != CircuitOpen || h.RetryAt == nil {
+		t.Fatalf("expected open circuit after threshold, got %+v", h)
+	}
+
+	// Open: the provider is skipped without being called.
+	if _, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi"}); err != nil {
+		t.Fatalf("open-circuit call: %v", err)
+	}
+	if primary.calls != 2 || fallback.calls != 3 {
+		t.Fatalf("calls primary=%d fallback=%d, want 2/3", primary.calls, fallback.calls)
+	}
+
+	// Cooldown elapsed: one half-open trial; success closes the circuit.
+	primary.errs = nil
+	r.healthMu.Lock()
+	r.breakers["remote"].openedAt = time.Now().Add(-time.Hour)
+	r.healthMu.Unlock()
+	if _, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi"}); err != nil {
+		t.Fatalf("half-open trial: %v", err)
+	}
+	if primary.calls != 3 {
+		t.Fatalf("expected half-open trial on primary, calls = %d", primary.calls)
+	}
+	if h := healthOf(r, "remote"); h.State != CircuitClosed || h.ConsecutiveFailures != 0 {
+		t.Fatalf("expected closed circuit after successful trial, got %+v", h)
+	}
+}
+
+func TestInferWithContract_OpenCircuitWithoutFallbackFailsFast(t *testing.T) {
+	primary := &scriptedAdapter{errs: []error{errors.New("connection refused")}}
+	r := newTestRouter(primary, withResilience(&ResiliencePolicy{FailureThreshold: 1}))
+
+	if _, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi"}); err == nil {
+		t.Fatal("expected failure")
+	}
+	_, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi"})
+	if err == nil || primary.calls != 1 {
+		t.Fatalf("expected fast failure without calling the open provider, err=%v calls=%d", err, primary.calls)
+	}
+}
+
+func TestRecordOutcome_BoundsHistory(t *testing.T) {
+	r := &Router{}
+	policy := ResiliencePolicy{HistorySize: 3}.withDefaults()
+	for i := 0; i < 5; i++ {
+		r.recordOutcome("remote", policy, 1, time.Millisecond, fmt.Errorf("failure %d", i))
+	}
+	h := healthOf(r, "remote")
+	if len(h.History) != 3 || h.History[0].Error != "failure 2" || h.History[2].Error != "failure 4" {
+		t.Fatalf("unexpected bounded history %+v", h.History)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"503", &ProviderHTTPError{StatusCode: 503}, true},
+		{"429", &ProviderHTTPError{StatusCode: 429}, true},
+		{"401", &ProviderHTTPError{StatusCode: 401}, false},
+		{"openai 502", fmt.Errorf("openai inference failed: %w", &openai.APIError{HTTPStatusCode: 502}), true},
+		{"openai 400", fmt.Errorf("openai inference failed: %w", &openai.RequestError{HTTPStatusCode: 400}), false},
+		{"conn refused", fmt.Errorf("dial: %w", syscall.ECONNREFUSED), true},
+		{"plain", errors.New("no choices returned"), false},
+	}
+	for _, tc := range cases {
+		if got := isTransientError(ctx, tc.err); got != tc.want {
+			t.Errorf("%s: isTransientError = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if isTransientError(cancelled, &ProviderHTTPError{StatusCode: 503}) {
+		t.Error("a cancelled caller must not retry")
+	}
+}
+
+// budgetGateFunc adapts a function to BudgetGate.
+type budgetGateFunc func(BudgetCheck) error
+
+func (f budgetGateFunc) CheckBudget(_ context.Context, check BudgetCheck) error { return f(check) }
+
+func TestInferWithContract_FailoverRechecksBudgetForFallback(t *testing.T) {
+	primary := &scriptedAdapter{errs: []error{errors.New("connection refused")}}
+	fallback := &scriptedAdapter{}
+	local := localFallback
+	local.MaxOutputTokens = 40
+	r := newTestRouter(primary,
+		withProvider("remote", pricedRemote, primary),
+		withProvider("ollama", local, fallback),
+		withBudgets(&BudgetPolicy{Run: BudgetLimit{MaxTokens: 1000}}),
+		withResilience(&ResiliencePolicy{InitialBackoffMS: 1}))
+	stop := &BudgetExceededError{Scope: BudgetScopeRun, Key: "run-1"}
+	var checks []BudgetCheck
+	r.SetBudgetGate(budgetGateFunc(func(check BudgetCheck) error {
+		checks = append(checks, check)
+		if check.ProviderID == "ollama" {
+			return stop
+		}
+		return nil
+	}))
+
+	_, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi", Attribution: UsageAttribution{RunID: "run-1"}})
+	if !errors.Is(err, stop) {
+		t.Fatalf("err = %v, want the fallback's budget stop", err)
+	}
+	if fallback.calls != 0 {
+		t.Fatalf("fallback dispatched %d times despite its budget stop", fallback.calls)
+	}
+	if len(checks) != 2 || checks[1].ProjectedTokens != 41 || checks[1].ProjectedCost != 0 {
+		t.Fatalf("checks = %+v, want the fallback projected with its own max tokens and no remote pricing", checks)
+	}
+}
+
+// resetStream drops its first stream partway through, then streams in full.
+type resetStream struct{ calls int }
+
+func (s *resetStream) Infer(context.Context, string, InferOptions) (*InferResponse, error) {
+	return nil, errors.New("not used")
+}
+
+func (s *resetStream) InferStream(_ context.Context, _ string, _ InferOptions, onDelta func(string)) (*InferResponse, error) {
+	s.calls++
+	if s.calls == 1 {
+		onDelta("Hel")
+		return nil, fmt.Errorf("read stream: %w", syscall.ECONNRESET)
+	}
+	onDelta("Hello")
+	onDelta(" there")
+	return &InferResponse{Text: "Hello there", ModelUsed: "m"}, nil
+}
+
+func (s *resetStream) Probe(context.Context) (bool, error) { return true, nil }
+
+func TestInferWithContract_RetryRestartsTheStream(t *testing.T) {
+	adapter := &resetStream{}
+	r := newTestRouter(adapter, withResilience(&ResiliencePolicy{InitialBackoffMS: 1, MaxBackoffMS: 2}))
+
+	var chunks []StreamChunk
+	resp, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi", OnStream: collectDeltas(&chunks)})
+	if err != nil || resp.Text != "Hello there" || adapter.calls != 2 {
+		t.Fatalf("resp = %+v, err = %v, calls = %d", resp, err, adapter.calls)
+	}
+	// The dropped attempt is closed before the retry re-streams from the start.
+	if len(chunks) != 5 || chunks[0].Delta != "Hel" || !chunks[1].Done || !chunks[4].Done {
+		t.Fatalf("unexpected chunks: %+v", chunks)
+	}
+	if got := joinDeltas(chunks[2:]); got != resp.Text {
+		t.Fatalf("text after the last reset = %q, want %q", got, resp.Text)
+	}
+}
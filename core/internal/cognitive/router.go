@@ -28,6 +28,19 @@ type Router struct {
 	totalTokens  atomic.Int64 // cumulative tokens processed
 	windowStart  atomic.Int64 // unix nanoseconds of window start
 	windowTokens atomic.Int64 // tokens in current window
+
+	// usage receives a ledger record per successful inference (optional).
+	usage UsageRecorder
+	// budget is consulted before dispatch and may hard-stop a call (optional).
+	budget BudgetGate
+	// cache serves repeated requests without dispatch (optional).
+	cache ResponseCache
+	// orgSampling supplies organization AI-engine sampling overrides (optional).
+	orgSampling OrganizationSampling
+
+	// Per-provider circuit breakers and health history.
+	healthMu sync.Mutex
+	breakers map[string]*providerBreaker
 }
 
 // RecordTokens adds to the cumulative and windowed token counters.
@@ -107,6 +120,7 @@ func NewRouter(configPath string, db *sql.DB) (*Router, error) {
 	}
 
 	// 4. Initialize Adapters
+	var cassetteIDs []string
 	for id, pConfig := range config.Providers {
 		log.Printf("DEBUG: Initializing provider %s with endpoint %s", id, pConfig.Endpoint)
 		var adapter LLMProvider
@@ -127,11 +141,11 @@ func NewRouter(configPath string, db *sql.DB) (*Router, error) {
 		case "google":
 			adapter, err = NewGoogleAdapter(pConfig)
 		case "ollama":
-			// Ollama matches OpenAI Compatible in our adapter, but let's be explicit
-			// Check if we have a dedicated Ollama adapter or reuse OpenAI
-			// Reuse OpenAI for now as it supports /v1
-			pConfig.Type = "openai_compatible" // Force type for adapter logic
-			adapter, err = NewOpenAIAdapter(pConfig)
+			adapter, err = NewOllamaAdapter(pConfig)
+		case "cassette":
+			// Built after the loop so a recording cassette can wrap its upstream.
+			cassetteIDs = append(cassetteIDs, id)
+			continue
 		default:
 			err = fmt.Errorf("unknown provider type: %s", inputType)
 		}
@@ -144,6 +158,16 @@ func NewRouter(configPath string, db *sql.DB) (*Router, error) {
 		r.Adapters[id] = adapter
 	}
 
+	// 4b. Cassette adapters (record/replay fixtures for deterministic tests)
+	for _, id := range cassetteIDs {
+		adapter, err := r.buildAdapter(id, config.Providers[id])
+		if err != nil {
+			fmt.Printf("⚠️ Failed to init provider %s: %v\n", id, err)
+			continue
+		}
+		r.Adapters[id] = adapter
+	}
+
 	// 5. Degraded startup posture
 	// Fail closed when no provider is configured instead of silently probing
 	// desktop-local loopback addresses that do not exist in deployed runtimes.
@@ -166,8 +190,20 @@ func NewRouter(configPath string, db *sql.DB) (*Router, error) {
 	return r, nil
 }
 
-// InferWithContract executes the request against the configured profile/provider
+// InferWithContract executes the request against the configured profile/provider.
+// When req.OnStream is set, completion text is streamed through it; a failed
+// attempt is closed with a Done chunk before self-recovery retries elsewhere.
+// Requests carrying a response schema are validated and repaired; see
+// inferStructured.
 func (r *Router) InferWithContract(ctx context.Context, req InferRequest) (*InferResponse, error) {
+	if schema := r.responseSchema(req); len(schema) > 0 {
+		return r.inferStructured(ctx, req, schema)
+	}
+	return r.infer(ctx, req)
+}
+
+// infer runs one routed inference: cache, budget, dispatch and failover.
+func (r *Router) infer(ctx context.Context, req InferRequest) (*InferResponse, error) {
 	resolution := r.resolveExecutionProvider(req.Profile, req.Provider)
 	if !resolution.Available {
 		return nil, fmt.Errorf("%s", resolution.Summary)
@@ -183,92 +219,58 @@ func (r *Router) InferWithContract(ctx context.Context, req InferRequest) (*Infe
 	// 3. Execute
 	// Defaults for options
 	providerCfg := NormalizeProviderTokenDefaults(r.Config.Providers[providerID])
-	opts := InferOptions{
-		Temperature: 0.7, // TODO: Load from Profile config
-		MaxTokens:   providerCfg.MaxOutputTokens,
-		Messages:    req.Messages,
+	opts := r.inferOptions(req, providerCfg)
+	cached, ticket := r.lookupCache(ctx, req, opts, providerID)
+	if cached != nil {
+		return cached, nil
 	}
-
-	resp, err := adapter.Infer(ctx, req.Prompt, opts)
-	if err == nil && resp != nil {
-		// Record tokens for telemetry. If the adapter didn't report token
-		// count, estimate at ~4 chars per token (conservative approximation).
-		tokens := resp.TokensUsed
-		if tokens == 0 && resp.Text != "" {
-			tokens = len(resp.Text) / 4
-			if tokens < 1 {
-				tokens = 1
-			}
-		}
-		r.RecordTokens(tokens)
-	}
-	if err != nil {
-		// --- Runtime Self-Recovery ---
-		// If inference fails, we should check if the provider is still healthy.
-		// If dead, we trigger AutoConfigure and retry ONCE.
-		fmt.Printf("⚠️ Inference failed on '%s': %v. Attempting Self-Recovery...\n", providerID, err)
-
-		// 1. Probe specific provider to confirm death (avoid jitter)
-		healthy, probeErr := adapter.Probe(ctx)
-		if !healthy {
-			fmt.Printf("❌ Provider '%s' confirmed DEAD (%v). Re-calibrating Matrix...\n", providerID, probeErr)
-
-			// 2. Trigger Auto-Config (Heal)
-			r.AutoConfigure(ctx)
-
-			// 3. Retry on NEW provider
-			recovered := r.resolveExecutionProvider(req.Profile, req.Provider)
-			if !recovered.Available {
-				return nil, fmt.Errorf("recovery failed: %s", recovered.Summary)
-			}
-			newProviderID := recovered.ProviderID
-
-			if newProviderID == providerID {
-				return nil, fmt.Errorf("recovery failed: no alternative provider found (stuck on %s, probe error: %v)", providerID, probeErr)
-			}
-
-			newAdapter, ok := r.Adapters[newProviderID]
-			if !ok {
-				return nil, fmt.Errorf("recovery failed: new provider %s not init", newProviderID)
-			}
-
-			fmt.Printf("✅ Optimized to '%s'. Retrying request...\n", newProviderID)
-			return newAdapter.Infer(ctx, req.Prompt, opts)
-		}
-		// If healthy (e.g. context timeout or API error), simple error return
+	if err := r.checkBudget(ctx, req, opts, providerID, providerCfg); err != nil {
 		return nil, err
 	}
 
-	return resp, nil
-}
-
-// Deprecated: Infer is alias for InferWithContract
-func (r *Router) Infer(req InferRequest) (*InferResponse, error) {
-	return r.InferWithContract(context.Background(), req)
-}
-
-// Embed generates a text embedding vector using the first available EmbedProvider.
-// Resolution order: "embed" profile → first Ollama-compatible adapter → first adapter.
-func (r *Router) Embed(ctx context.Context, text string, model string) ([]float64, error) {
-	if model == "" {
-		model = DefaultEmbedModel
+	policy := r.resiliencePolicy()
+	if !r.breakerAllows(providerID, policy) {
+		// Open circuit: skip the provider instead of stalling the turn on it.
+		return r.failover(ctx, req, providerID, policy, fmt.Errorf("provider '%s' circuit is open", providerID))
+	}
+	resp, err := r.dispatch(ctx, providerID, adapter, req, opts, policy)
+	if err != nil && isProviderFailure(ctx, err) {
+		fmt.Printf("⚠️ Inference failed on '%s': %v. Failing over...\n", providerID, err)
+		return r.failover(ctx, req, providerID, policy, err)
+	}
+	if err == nil {
+		// Failover answers are not cached: the scope names the routed provider.
+		r.storeCache(ctx, ticket, resp)
 	}
+	return resp, err
+}
 
-	// 1. Try "embed" profile if configured
-	if providerID, ok := r.Config.Profiles["embed"]; ok {
-		if adapter, ok := r.Adapters[providerID]; ok {
-			if ep, ok := adapter.(EmbedProvider); ok {
-				return ep.Embed(ctx, text, model)
-			}
+// failover retries the request once on the preferred alternative provider
+// whose breaker admits calls. No probing: breaker state is the health signal.
+// Options and the budget check are redone for the fallback, since its token
+// limits, pricing and budget scope differ from the failed provider's.
+func (r *Router) failover(ctx context.Context, req InferRequest, failedID string, policy ResiliencePolicy, cause error) (*InferResponse, error) {
+	var fallbackID string
+	for _, candidate := range r.fallbackProviderIDs() {
+		if _, ok := r.Adapters[candidate]; ok && candidate != failedID && r.breakerAllows(candidate, policy) {
+			fallbackID = candidate
+			break
 		}
 	}
+	if fallbackID == "" {
+		return nil, fmt.Errorf("recovery failed: no alternative provider available after '%s': %w", failedID, cause)
+	}
 
-	// 2. Try any adapter that implements EmbedProvider
-	for _, adapter := range r.Adapters {
-		if ep, ok := adapter.(EmbedProvider); ok {
-			return ep.Embed(ctx, text, model)
-		}
+	fallbackCfg := NormalizeProviderTokenDefaults(r.Config.Providers[fallbackID])
+	opts := r.inferOptions(req, fallbackCfg)
+	if err := r.checkBudget(ctx, req, opts, fallbackID, fallbackCfg); err != nil {
+		return nil, err
 	}
+	fmt.Printf("🔄 Routing request from '%s' to '%s'\n", failedID, fallbackID)
+	return r.dispatch(ctx, fallbackID, r.Adapters[fallbackID], req, opts, policy)
+}
 
-	return nil, fmt.Errorf("no embedding provider available (need OpenAI-compatible adapter)")
+// Deprecated: Infer is alias for InferWithContract
+func (r *Router) Infer(req InferRequest) (*InferResponse, error) {
+	return r.InferWithContract(context.Background(), req)
 }
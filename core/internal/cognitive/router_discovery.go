@@ -36,7 +36,10 @@ func (r *Router) autoConfigureWithProviders(ctx context.Context, providers map[s
 	fmt.Println("--- Cognitive Discovery Report ---")
 	for id, res := range discoveryResults {
 		modelID := r.Config.Providers[id].ModelID
-		tier := GradeModel(modelID)
+		tier := res.Tier
+		if tier == TierU {
+			tier = GradeModel(modelID)
+		}
 
 		status := "✅ Online"
 		if !res.Healthy {
@@ -0,0 +1,41 @@
+package cognitive
+
+import (
+	"context"
+	"fmt"
+)
+
+// EmbedModel returns the configured memory embedding model.
+func (r *Router) EmbedModel() string {
+	if r.Config != nil && r.Config.EmbedModel != "" {
+		return r.Config.EmbedModel
+	}
+	return DefaultEmbedModel
+}
+
+// Embed generates a text embedding vector using the first available EmbedProvider.
+// An empty model means EmbedModel().
+// Resolution order: "embed" profile → first Ollama-compatible adapter → first adapter.
+func (r *Router) Embed(ctx context.Context, text string, model string) ([]float64, error) {
+	if model == "" {
+		model = r.EmbedModel()
+	}
+
+	// 1. Try "embed" profile if configured
+	if providerID, ok := r.Config.Profiles["embed"]; ok {
+		if adapter, ok := r.Adapters[providerID]; ok {
+			if ep, ok := adapter.(EmbedProvider); ok {
+				return ep.Embed(ctx, text, model)
+			}
+		}
+	}
+
+	// 2. Try any adapter that implements EmbedProvider
+	for _, adapter := range r.Adapters {
+		if ep, ok := adapter.(EmbedProvider); ok {
+			return ep.Embed(ctx, text, model)
+		}
+	}
+
+	return nil, fmt.Errorf("no embedding provider available (need OpenAI-compatible adapter)")
+}
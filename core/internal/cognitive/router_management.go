@@ -23,8 +23,15 @@ func (r *Router) buildAdapter(id string, cfg ProviderConfig) (LLMProvider, error
 	case "google":
 		return NewGoogleAdapter(cfg)
 	case "ollama":
-		cfg.Type = "openai_compatible"
-		return NewOpenAIAdapter(cfg)
+		return NewOllamaAdapter(cfg)
+	case "cassette":
+		var upstream LLMProvider
+		if cfg.Cassette != nil && cfg.Cassette.Upstream != "" {
+			r.mu.RLock()
+			upstream = r.Adapters[cfg.Cassette.Upstream]
+			r.mu.RUnlock()
+		}
+		return NewCassetteAdapter(cfg, upstream)
 	default:
 		return nil, fmt.Errorf("unknown provider type %q for provider %q", inputType, id)
 	}
@@ -63,6 +70,12 @@ func (r *Router) UpdateProvider(id string, cfg ProviderConfig) error {
 	if cfg.AuthKeyEnv == "" {
 		cfg.AuthKeyEnv = existing.AuthKeyEnv
 	}
+	if cfg.Pricing == nil {
+		cfg.Pricing = existing.Pricing
+	}
+	if cfg.Cassette == nil {
+		cfg.Cassette = existing.Cassette
+	}
 	cfg = NormalizeProviderTokenDefaults(cfg)
 	adapter, err := r.buildAdapter(id, cfg)
 	if err != nil {
@@ -0,0 +1,165 @@
+package cognitive
+
+import (
+	"fmt"
+
+	"github.com/mycelis/core/pkg/protocol"
+	"gopkg.in/yaml.v3"
+)
+
+// SamplingParams tunes generation; see protocol.SamplingParams.
+type SamplingParams = protocol.SamplingParams
+
+// DefaultTemperature applies when no profile, organization or agent layer
+// sets a temperature.
+const DefaultTemperature = 0.7
+
+// OrganizationSampling supplies the sampling overrides of an organization's
+// AI-engine settings. Implemented by the admin server's organization store.
+type OrganizationSampling interface {
+	OrganizationSampling(organizationID string) (SamplingParams, bool)
+}
+
+// SetOrganizationSampling attaches the organization-level sampling source.
+func (r *Router) SetOrganizationSampling(src OrganizationSampling) {
+	r.orgSampling = src
+}
+
+// ProfileConfig is one entry under `profiles:` in cognitive.yaml. An entry
+// is either a bare provider ID or an object naming the provider plus its
+// sampling parameters:
+//
+//	profiles:
+//	  chat: ollama
+//	  coder: {provider: ollama, temperature: 0.2, seed: 7}
+type ProfileConfig struct {
+	Provider       string `yaml:"provider" json:"provider"`
+	SamplingParams `yaml:",inline"`
+}
+
+func (p *ProfileConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		p.Provider = node.Value
+		return nil
+	}
+	type plain ProfileConfig
+	return node.Decode((*plain)(p))
+}
+
+func (p ProfileConfig) MarshalYAML() (any, error) {
+	if p.SamplingParams.IsZero() {
+		return p.Provider, nil
+	}
+	type plain ProfileConfig
+	return plain(p), nil
+}
+
+// brainConfigYAML has BrainConfig's fields without its YAML methods.
+type brainConfigYAML BrainConfig
+
+// UnmarshalYAML accepts both profile forms, splitting them into the
+// Profiles routing table and ProfileParams.
+func (c *BrainConfig) UnmarshalYAML(node *yaml.Node) error {
+	var entries map[string]ProfileConfig
+	rest := *node
+	if node.Kind == yaml.MappingNode {
+		rest.Content = nil
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "profiles" {
+				if err := node.Content[i+1].Decode(&entries); err != nil {
+					return fmt.Errorf("profiles: %w", err)
+				}
+				continue
+			}
+			rest.Content = append(rest.Content, node.Content[i], node.Content[i+1])
+		}
+	}
+	var plain brainConfigYAML
+	if err := rest.Decode(&plain); err != nil {
+		return err
+	}
+	*c = BrainConfig(plain)
+	if entries != nil {
+		c.Profiles = make(map[string]string, len(entries))
+	}
+	for name, entry := range entries {
+		c.Profiles[name] = entry.Provider
+		if !entry.SamplingParams.IsZero() {
+			if c.ProfileParams == nil {
+				c.ProfileParams = make(map[string]SamplingParams)
+			}
+			c.ProfileParams[name] = entry.SamplingParams
+		}
+	}
+	return nil
+}
+
+// MarshalYAML writes profiles with sampling parameters in object form and
+// the rest as bare provider IDs.
+func (c BrainConfig) MarshalYAML() (any, error) {
+	var node yaml.Node
+	if err := node.Encode(brainConfigYAML(c)); err != nil {
+		return nil, err
+	}
+	if len(c.ProfileParams) == 0 {
+		return &node, nil
+	}
+	entries := make(map[string]ProfileConfig, len(c.Profiles))
+	for name, providerID := range c.Profiles {
+		entries[name] = ProfileConfig{Provider: providerID, SamplingParams: c.ProfileParams[name]}
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "profiles" {
+			if err := node.Content[i+1].Encode(entries); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &node, nil
+}
+
+// resolveSampling layers the profile, organization and request (agent
+// manifest) sampling parameters, most specific last.
+func (r *Router) resolveSampling(req InferRequest) SamplingParams {
+	params := r.Config.ProfileParams[req.Profile]
+	if r.orgSampling != nil && req.Attribution.OrganizationID != "" {
+		if org, ok := r.orgSampling.OrganizationSampling(req.Attribution.OrganizationID); ok {
+			params = params.Merge(org)
+		}
+	}
+	if req.Sampling != nil {
+		params = params.Merge(*req.Sampling)
+	}
+	return params
+}
+
+// inferOptions builds the adapter options for req on the given provider.
+func (r *Router) inferOptions(req InferRequest, providerCfg ProviderConfig) InferOptions {
+	params := r.resolveSampling(req)
+	opts := InferOptions{
+		Temperature:    DefaultTemperature,
+		MaxTokens:      providerCfg.MaxOutputTokens,
+		Stop:           params.Stop,
+		Seed:           params.Seed,
+		JSONMode:       params.JSONMode,
+		ResponseSchema: params.ResponseSchema,
+		Messages:       req.Messages,
+	}
+	if params.Temperature != nil {
+		opts.Temperature = *params.Temperature
+	}
+	if params.TopP != nil {
+		opts.TopP = *params.TopP
+	}
+	if params.MaxTokens > 0 {
+		opts.MaxTokens = params.MaxTokens
+	}
+	if len(req.ResponseSchema) > 0 {
+		opts.JSONMode = true
+		opts.ResponseSchema = req.ResponseSchema
+	}
+	if providerCfg.NativeToolsEnabled() {
+		opts.Tools = req.Tools
+	}
+	return opts
+}
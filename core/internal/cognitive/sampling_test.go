@@ -0,0 +1,159 @@
+package cognitive
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type stubOrgSampling map[string]SamplingParams
+
+func (s stubOrgSampling) OrganizationSampling(id string) (SamplingParams, bool) {
+	params, ok := s[id]
+	return params, ok
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestBrainConfig_YAMLAcceptsBothProfileForms(t *testing.T) {
+	src := `
+profiles:
+  chat: local
+  coder:
+    provider: local
+    temperature: 0.2
+    stop: ["<END>"]
+    seed: 7
+`
+	var cfg BrainConfig
+	if err := yaml.Unmarshal([]byte(src), &cfg); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if cfg.Profiles["chat"] != "local" || cfg.Profiles["coder"] != "local" {
+		t.Fatalf("Profiles = %+v", cfg.Profiles)
+	}
+	if _, ok := cfg.ProfileParams["chat"]; ok {
+		t.Fatal("bare profile should carry no sampling params")
+	}
+	coder := cfg.ProfileParams["coder"]
+	if coder.Temperature == nil || *coder.Temperature != 0.2 || coder.Seed == nil || *coder.Seed != 7 || coder.Stop[0] != "<END>" {
+		t.Fatalf("coder params = %+v", coder)
+	}
+
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var again BrainConfig
+	if err := yaml.Unmarshal(out, &again); err != nil {
+		t.Fatalf("re-Unmarshal: %v\n%s", err, out)
+	}
+	if again.Profiles["chat"] != "local" || *again.ProfileParams["coder"].Temperature != 0.2 {
+		t.Fatalf("round trip lost profiles:\n%s", out)
+	}
+}
+
+func TestInferWithContract_LayersProfileOrganizationAndAgentSampling(t *testing.T) {
+	adapter := &captureAdapter{}
+	r := &Router{
+		Config: &BrainConfig{
+			Providers: map[string]ProviderConfig{"local": {Type: "ollama", ModelID: "tiny", Enabled: true, MaxOutputTokens: 512}},
+			Profiles:  map[string]string{"chat": "local", "coder": "local"},
+			ProfileParams: map[string]SamplingParams{
+				"coder": {Temperature: floatPtr(0.2), TopP: floatPtr(0.9), Stop: []string{"<END>"}},
+			},
+		},
+		Adapters: map[string]LLMProvider{"local": adapter},
+	}
+	r.SetOrganizationSampling(stubOrgSampling{"org-1": {TopP: floatPtr(0.5), MaxTokens: 256}})
+
+	if _, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi"}); err != nil {
+		t.Fatalf("chat: %v", err)
+	}
+	if got := adapter.lastOpts; got.Temperature != DefaultTemperature || got.TopP != 0 || got.MaxTokens != 512 {
+		t.Fatalf("chat opts = %+v", got)
+	}
+
+	seed := 3
+	req := InferRequest{
+		Profile:     "coder",
+		Prompt:      "write it",
+		Attribution: UsageAttribution{OrganizationID: "org-1"},
+		Sampling:    &SamplingParams{Temperature: floatPtr(0.1), Seed: &seed, JSONMode: true},
+	}
+	if _, err := r.InferWithContract(context.Background(), req); err != nil {
+		t.Fatalf("coder: %v", err)
+	}
+	got := adapter.lastOpts
+	if got.Temperature != 0.1 || got.TopP != 0.5 || got.MaxTokens != 256 || got.Seed == nil || *got.Seed != 3 || !got.JSONMode {
+		t.Fatalf("coder opts = %+v", got)
+	}
+	if len(got.Stop) != 1 || got.Stop[0] != "<END>" {
+		t.Fatalf("expected profile stop sequences, got %+v", got.Stop)
+	}
+}
+
+func TestOpenAIAdapter_SendsSamplingAndResponseFormat(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":"c1","object":"chat.completion","choices":[{"index":0,"message":{"role":"assistant","content":"{}"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	adapter, err := NewOpenAIAdapter(ProviderConfig{Type: "openai_compatible", Endpoint: server.URL + "/v1", ModelID: "qwen-test"})
+	if err != nil {
+		t.Fatalf("NewOpenAIAdapter() error = %v", err)
+	}
+	seed := 42
+	_, err = adapter.Infer(context.Background(), "plan", InferOptions{
+		Temperature:    0.2,
+		TopP:           0.9,
+		Stop:           []string{"<END>"},
+		Seed:           &seed,
+		ResponseSchema: map[string]any{"type": "object"},
+	})
+	if err != nil {
+		t.Fatalf("Infer() error = %v", err)
+	}
+	if body["seed"] != float64(42) || body["stop"].([]any)[0] != "<END>" || body["top_p"] == nil {
+		t.Fatalf("unexpected sampling payload %+v", body)
+	}
+	format, _ := body["response_format"].(map[string]any)
+	if format["type"] != "json_schema" {
+		t.Fatalf("response_format = %+v", body["response_format"])
+	}
+}
+
+func TestAnthropicAdapter_ClampsTemperatureAndSendsStops(t *testing.T) {
+	t.Parallel()
+
+	var body anthropicRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":"m1","content":[{"type":"text","text":"ok"}]}`)
+	}))
+	defer server.Close()
+
+	adapter, err := NewAnthropicAdapter(ProviderConfig{Type: "anthropic", Endpoint: server.URL, ModelID: "claude-test", AuthKey: "k"})
+	if err != nil {
+		t.Fatalf("NewAnthropicAdapter() error = %v", err)
+	}
+	if _, err := adapter.Infer(context.Background(), "hi", InferOptions{Temperature: 1.5, Stop: []string{"###"}}); err != nil {
+		t.Fatalf("Infer() error = %v", err)
+	}
+	if body.Temperature == nil || *body.Temperature != 1 || len(body.StopSequences) != 1 || body.StopSequences[0] != "###" {
+		t.Fatalf("unexpected payload temperature=%v stops=%v", body.Temperature, body.StopSequences)
+	}
+}
@@ -0,0 +1,90 @@
+package cognitive
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+)
+
+// StreamChunk is one incremental event of a streaming completion.
+// Done marks a completion boundary: the router emits it after every adapter
+// attempt (including failed attempts before self-recovery), so consumers can
+// discard partial text when a new completion starts.
+type StreamChunk struct {
+	Delta string `json:"delta,omitempty"`
+	Done  bool   `json:"done,omitempty"`
+}
+
+// StreamFunc receives streaming chunks from InferWithContract.
+type StreamFunc func(chunk StreamChunk)
+
+// StreamingProvider is implemented by adapters that can emit completion text
+// incrementally. Not all LLMProviders support this — callers must type-assert.
+// InferStream returns the aggregated response once the stream ends.
+type StreamingProvider interface {
+	InferStream(ctx context.Context, prompt string, opts InferOptions, onDelta func(delta string)) (*InferResponse, error)
+}
+
+// inferWithAdapter dispatches one completion attempt. When onStream is set the
+// adapter streams if it can; otherwise the blocking result is emitted as a
+// single delta so callers see one consistent contract.
+func inferWithAdapter(ctx context.Context, adapter LLMProvider, prompt string, opts InferOptions, onStream StreamFunc) (*InferResponse, error) {
+	if onStream == nil {
+		return adapter.Infer(ctx, prompt, opts)
+	}
+	defer onStream(StreamChunk{Done: true})
+
+	if streamer, ok := adapter.(StreamingProvider); ok {
+		return streamer.InferStream(ctx, prompt, opts, func(delta string) {
+			if delta != "" {
+				onStream(StreamChunk{Delta: delta})
+			}
+		})
+	}
+
+	resp, err := adapter.Infer(ctx, prompt, opts)
+	if err == nil && resp != nil && resp.Text != "" {
+		onStream(StreamChunk{Delta: resp.Text})
+	}
+	return resp, err
+}
+
+// readSSEData parses a text/event-stream body and calls fn with the joined
+// data lines of every event. Comment lines and non-data fields are ignored.
+func readSSEData(body io.Reader, fn func(data []byte) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var data bytes.Buffer
+	flush := func() error {
+		if data.Len() == 0 {
+			return nil
+		}
+		payload := bytes.Clone(data.Bytes())
+		data.Reset()
+		return fn(payload)
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if err := flush(); err != nil {
+				return err
+			}
+			continue
+		}
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		if data.Len() > 0 {
+			data.WriteByte('\n')
+		}
+		data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
@@ -0,0 +1,283 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func collectDeltas(chunks *[]StreamChunk) StreamFunc {
+	return func(chunk StreamChunk) {
+		*chunks = append(*chunks, chunk)
+	}
+}
+
+func joinDeltas(chunks []StreamChunk) string {
+	var sb strings.Builder
+	for _, chunk := range chunks {
+		sb.WriteString(chunk.Delta)
+	}
+	return sb.String()
+}
+
+func TestOpenAIAdapter_InferStreamForwardsContentDeltas(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"c1\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"Hel\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"id\":\"c1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	adapter, err := NewOpenAIAdapter(ProviderConfig{Type: "openai_compatible", Endpoint: server.URL + "/v1", ModelID: "qwen-test"})
+	if err != nil {
+		t.Fatalf("NewOpenAIAdapter() error = %v", err)
+	}
+
+	var deltas []string
+	resp, err := adapter.InferStream(context.Background(), "hi", InferOptions{}, func(delta string) { deltas = append(deltas, delta) })
+	if err != nil {
+		t.Fatalf("InferStream() error = %v", err)
+	}
+	if resp.Text != "Hello" {
+		t.Fatalf("resp.Text = %q, want Hello", resp.Text)
+	}
+	if strings.Join(deltas, "|") != "Hel|lo" {
+		t.Fatalf("deltas = %v", deltas)
+	}
+}
+
+func TestOpenAIAdapter_InferStreamFoldsToolCallFragments(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"c1\",\"choices\":[{\"index\":0,\"delta\":{\"tool_calls\":[{\"index\":0,\"function\":{\"name\":\"read_file\",\"arguments\":\"{\\\"path\\\":\"}}]}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"id\":\"c1\",\"choices\":[{\"index\":0,\"delta\":{\"tool_calls\":[{\"index\":0,\"function\":{\"arguments\":\"\\\"a.txt\\\"}\"}}]}}]}\n\n")
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	adapter, err := NewOpenAIAdapter(ProviderConfig{Type: "openai_compatible", Endpoint: server.URL + "/v1", ModelID: "qwen-test"})
+	if err != nil {
+		t.Fatalf("NewOpenAIAdapter() error = %v", err)
+	}
+
+	deltaCount := 0
+	resp, err := adapter.InferStream(context.Background(), "hi", InferOptions{}, func(string) { deltaCount++ })
+	if err != nil {
+		t.Fatalf("InferStream() error = %v", err)
+	}
+	if deltaCount != 0 {
+		t.Fatalf("expected tool-call fragments not to stream as text, got %d deltas", deltaCount)
+	}
+	if resp.Text != "" {
+		t.Fatalf("resp.Text = %q, want empty", resp.Text)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].Name != "read_file" || resp.ToolCalls[0].Arguments["path"] != "a.txt" {
+		t.Fatalf("resp.ToolCalls = %+v", resp.ToolCalls)
+	}
+}
+
+func TestAnthropicAdapter_InferStreamParsesTextDeltas(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"stream":true`) {
+			t.Errorf("expected stream flag in request body, got %s", body)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "event: message_start\ndata: {\"type\":\"message_start\"}\n\n")
+		_, _ = io.WriteString(w, "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"Soma \"}}\n\n")
+		_, _ = io.WriteString(w, "event: ping\ndata: {\"type\":\"ping\"}\n\n")
+		_, _ = io.WriteString(w, "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"online\"}}\n\n")
+		_, _ = io.WriteString(w, "event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n")
+	}))
+	defer server.Close()
+
+	adapter, err := NewAnthropicAdapter(ProviderConfig{Type: "anthropic", Endpoint: server.URL, ModelID: "claude-test", AuthKey: "k"})
+	if err != nil {
+		t.Fatalf("NewAnthropicAdapter() error = %v", err)
+	}
+
+	var deltas []string
+	resp, err := adapter.InferStream(context.Background(), "status", InferOptions{}, func(delta string) { deltas = append(deltas, delta) })
+	if err != nil {
+		t.Fatalf("InferStream() error = %v", err)
+	}
+	if resp.Text != "Soma online" || len(deltas) != 2 {
+		t.Fatalf("resp.Text = %q deltas = %v", resp.Text, deltas)
+	}
+}
+
+func TestAnthropicAdapter_InferStreamSurfacesErrorEvent(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "event: error\ndata: {\"type\":\"error\",\"error\":{\"type\":\"overloaded_error\",\"message\":\"Overloaded\"}}\n\n")
+	}))
+	defer server.Close()
+
+	adapter, err := NewAnthropicAdapter(ProviderConfig{Type: "anthropic", Endpoint: server.URL, ModelID: "claude-test", AuthKey: "k"})
+	if err != nil {
+		t.Fatalf("NewAnthropicAdapter() error = %v", err)
+	}
+
+	_, err = adapter.InferStream(context.Background(), "status", InferOptions{}, func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "overloaded_error") {
+		t.Fatalf("expected overloaded_error, got %v", err)
+	}
+}
+
+func TestGoogleAdapter_InferStreamUsesSSEEndpoint(t *testing.T) {
+	t.Parallel()
+
+	var path, alt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		alt = r.URL.Query().Get("alt")
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"Gem\"}]}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"ini\"}]},\"finishReason\":\"STOP\"}]}\n\n")
+	}))
+	defer server.Close()
+
+	adapter, err := NewGoogleAdapter(ProviderConfig{Type: "google", Endpoint: server.URL, ModelID: "gemini-test", AuthKey: "k"})
+	if err != nil {
+		t.Fatalf("NewGoogleAdapter() error = %v", err)
+	}
+
+	var deltas []string
+	resp, err := adapter.InferStream(context.Background(), "hi", InferOptions{}, func(delta string) { deltas = append(deltas, delta) })
+	if err != nil {
+		t.Fatalf("InferStream() error = %v", err)
+	}
+	if path != "/gemini-test:streamGenerateContent" || alt != "sse" {
+		t.Fatalf("unexpected stream URL path=%q alt=%q", path, alt)
+	}
+	if resp.Text != "Gemini" || len(deltas) != 2 {
+		t.Fatalf("resp.Text = %q deltas = %v", resp.Text, deltas)
+	}
+}
+
+func TestInferWithContract_StreamsBlockingAdapterAsSingleDelta(t *testing.T) {
+	r := setupRouter()
+	r.Adapters["mock-provider"] = &MockProvider{OutputSequence: []string{"whole reply"}}
+
+	var chunks []StreamChunk
+	resp, err := r.InferWithContract(context.Background(), InferRequest{Profile: "test-retry", Prompt: "hi", OnStream: collectDeltas(&chunks)})
+	if err != nil {
+		t.Fatalf("InferWithContract: %v", err)
+	}
+	if resp.Text != "whole reply" {
+		t.Fatalf("resp.Text = %q", resp.Text)
+	}
+	if len(chunks) != 2 || chunks[0].Delta != "whole reply" || !chunks[1].Done {
+		t.Fatalf("unexpected chunks: %+v", chunks)
+	}
+}
+
+type deadStreamProvider struct{}
+
+func (deadStreamProvider) Infer(context.Context, string, InferOptions) (*InferResponse, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (deadStreamProvider) InferStream(_ context.Context, _ string, _ InferOptions, onDelta func(string)) (*InferResponse, error) {
+	onDelta("partial ")
+	return nil, errors.New("stream reset")
+}
+
+func (deadStreamProvider) Probe(context.Context) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func TestInferWithContract_StreamRecoveryClosesFailedAttempt(t *testing.T) {
+	r := &Router{
+		Config: &BrainConfig{
+			Providers: map[string]ProviderConfig{
+				"remote": {Type: "openai", ModelID: "gpt-test", Location: "remote", Enabled: true},
+				"ollama": {Type: "ollama", ModelID: "qwen3:8b", Location: "local", Enabled: true},
+			},
+			Profiles: map[string]string{"chat": "remote"},
+		},
+		Adapters: map[string]LLMProvider{
+			"remote": deadStreamProvider{},
+			"ollama": &MockProvider{OutputSequence: []string{"recovered"}},
+		},
+	}
+
+	var chunks []StreamChunk
+	resp, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi", OnStream: collectDeltas(&chunks)})
+	if err != nil {
+		t.Fatalf("InferWithContract: %v", err)
+	}
+	if resp.Text != "recovered" {
+		t.Fatalf("resp.Text = %q", resp.Text)
+	}
+	if len(chunks) != 4 || !chunks[1].Done || !chunks[3].Done {
+		t.Fatalf("expected two Done-terminated attempts, got %+v", chunks)
+	}
+	if joinDeltas(chunks) != "partial recovered" {
+		t.Fatalf("unexpected streamed text %q", joinDeltas(chunks))
+	}
+}
+
+func TestReadSSEData_JoinsMultilineData(t *testing.T) {
+	var events []string
+	err := readSSEData(strings.NewReader(": comment\nevent: x\ndata: a\ndata: b\n\ndata: c"), func(data []byte) error {
+		events = append(events, string(data))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("readSSEData: %v", err)
+	}
+	if len(events) != 2 || events[0] != "a\nb" || events[1] != "c" {
+		t.Fatalf("events = %q", events)
+	}
+}
+
+func TestOpenAIAdapter_InferStreamRequestsUsageOnlyWhereSupported(t *testing.T) {
+	t.Parallel()
+
+	on := true
+	cases := []struct {
+		name string
+		cfg  ProviderConfig
+		want bool
+	}{
+		{"openai_compatible", ProviderConfig{Type: "openai_compatible"}, false},
+		{"openai", ProviderConfig{Type: "openai", AuthKey: "k"}, true},
+		{"opted in", ProviderConfig{Type: "openai_compatible", StreamUsage: &on}, true},
+	}
+	for _, tc := range cases {
+		var body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw, _ := io.ReadAll(r.Body)
+			body = string(raw)
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, "data: {\"id\":\"c1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"ok\"}}]}\n\n")
+			_, _ = io.WriteString(w, "data: [DONE]\n\n")
+		}))
+		tc.cfg.Endpoint, tc.cfg.ModelID = server.URL+"/v1", "m"
+		adapter, err := NewOpenAIAdapter(tc.cfg)
+		if err != nil {
+			t.Fatalf("%s: NewOpenAIAdapter() error = %v", tc.name, err)
+		}
+		if _, err := adapter.InferStream(context.Background(), "hi", InferOptions{}, func(string) {}); err != nil {
+			t.Fatalf("%s: InferStream() error = %v", tc.name, err)
+		}
+		server.Close()
+		if got := strings.Contains(body, `"include_usage":true`); got != tc.want {
+			t.Errorf("%s: include_usage sent = %v, want %v (body %s)", tc.name, got, tc.want, body)
+		}
+	}
+}
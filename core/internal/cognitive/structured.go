@@ -0,0 +1,135 @@
+package cognitive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// DefaultMaxSchemaRepairs bounds the repair re-prompts sent after a reply
+// fails its response schema.
+const DefaultMaxSchemaRepairs = 2
+
+// ErrSchemaValidation is wrapped by every *SchemaError.
+var ErrSchemaValidation = errors.New("response does not match schema")
+
+// SchemaError reports a reply that still violated the response schema after
+// the last repair attempt.
+type SchemaError struct {
+	Attempts int
+	Problems []string
+	Text     string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%v after %d attempt(s): %s", ErrSchemaValidation, e.Attempts, strings.Join(e.Problems, "; "))
+}
+
+func (e *SchemaError) Unwrap() error { return ErrSchemaValidation }
+
+// responseSchema returns the schema req must satisfy: the request's own, else
+// one set through the profile, organization or agent sampling layers.
+func (r *Router) responseSchema(req InferRequest) map[string]any {
+	if len(req.ResponseSchema) > 0 {
+		return req.ResponseSchema
+	}
+	return r.resolveSampling(req).ResponseSchema
+}
+
+// inferStructured runs req and validates the reply against schema. Invalid
+// replies are sent back with the validation problems, at most
+// req.MaxRepairs times. A valid reply's Text holds only the JSON document.
+func (r *Router) inferStructured(ctx context.Context, req InferRequest, schema map[string]any) (*InferResponse, error) {
+	loader := gojsonschema.NewGoLoader(schema)
+	repairs := req.MaxRepairs
+	switch {
+	case repairs == 0:
+		repairs = DefaultMaxSchemaRepairs
+	case repairs < 0:
+		repairs = 0
+	}
+
+	attempt := req
+	for n := 1; ; n++ {
+		resp, err := r.infer(ctx, attempt)
+		if err != nil {
+			return nil, err
+		}
+		// Tool calls are answered by the caller before the final reply.
+		if len(resp.ToolCalls) > 0 {
+			return resp, nil
+		}
+		text := extractJSON(resp.Text)
+		if isTextToolCall(text) {
+			return resp, nil
+		}
+		problems, err := validateJSON(loader, text)
+		if err != nil {
+			return nil, fmt.Errorf("response schema: %w", err)
+		}
+		if len(problems) == 0 {
+			resp.Text = text
+			return resp, nil
+		}
+		if n > repairs {
+			return nil, &SchemaError{Attempts: n, Problems: problems, Text: resp.Text}
+		}
+		attempt = repairRequest(attempt, schema, resp.Text, problems)
+	}
+}
+
+// validateJSON lists the schema violations of text; an unparseable document
+// is reported as a violation so it can be repaired like any other.
+func validateJSON(schema gojsonschema.JSONLoader, text string) ([]string, error) {
+	if !json.Valid([]byte(text)) {
+		return []string{"reply is not a valid JSON document"}, nil
+	}
+	result, err := gojsonschema.Validate(schema, gojsonschema.NewStringLoader(text))
+	if err != nil {
+		return nil, err
+	}
+	problems := make([]string, 0, len(result.Errors()))
+	for _, e := range result.Errors() {
+		problems = append(problems, e.String())
+	}
+	return problems, nil
+}
+
+// repairRequest extends the transcript with the rejected reply and a
+// correction turn naming the schema problems.
+func repairRequest(req InferRequest, schema map[string]any, reply string, problems []string) InferRequest {
+	messages := append([]ChatMessage(nil), req.Messages...)
+	if len(messages) == 0 && req.Prompt != "" {
+		messages = append(messages, ChatMessage{Role: "user", Content: req.Prompt})
+	}
+	schemaJSON, _ := json.Marshal(schema)
+	var sb strings.Builder
+	sb.WriteString("Your reply did not match the required JSON schema:\n")
+	for _, p := range problems {
+		sb.WriteString("- " + p + "\n")
+	}
+	sb.WriteString("\nSchema:\n" + string(schemaJSON) + "\n")
+	sb.WriteString("\nReturn only the corrected JSON document. No markdown, no commentary.")
+	messages = append(messages,
+		ChatMessage{Role: "assistant", Content: reply},
+		ChatMessage{Role: "user", Content: sb.String()},
+	)
+	req.Prompt = ""
+	req.Messages = messages
+	return req
+}
+
+// isTextToolCall reports whether text is the {"tool_call": ...} fallback
+// contract agents use on providers without native tool calling.
+func isTextToolCall(text string) bool {
+	var probe map[string]json.RawMessage
+	if json.Unmarshal([]byte(text), &probe) != nil {
+		return false
+	}
+	_, ok := probe["tool_call"]
+	return ok
+}
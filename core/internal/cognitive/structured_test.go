@@ -0,0 +1,88 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// replyAdapter answers with replies in order, repeating the last one, and
+// keeps the options of every call.
+type replyAdapter struct {
+	replies []string
+	calls   []InferOptions
+}
+
+func (a *replyAdapter) Infer(_ context.Context, _ string, opts InferOptions) (*InferResponse, error) {
+	a.calls = append(a.calls, opts)
+	text := a.replies[min(len(a.calls), len(a.replies))-1]
+	return &InferResponse{Text: text, ModelUsed: "m"}, nil
+}
+
+func (a *replyAdapter) Probe(context.Context) (bool, error) {
+	return true, nil
+}
+
+var verdictSchema = map[string]any{
+	"type":       "object",
+	"required":   []any{"verdict"},
+	"properties": map[string]any{"verdict": map[string]any{"enum": []any{"pass", "fail"}}},
+}
+
+func TestInferWithContract_RepairsInvalidReply(t *testing.T) {
+	adapter := &replyAdapter{replies: []string{"Sure! ```json\n{\"verdict\": \"maybe\"}\n```", "```json\n{\"verdict\": \"pass\"}\n```"}}
+	r := newTestRouter(adapter)
+
+	resp, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "judge it", ResponseSchema: verdictSchema})
+	if err != nil {
+		t.Fatalf("InferWithContract: %v", err)
+	}
+	if resp.Text != `{"verdict": "pass"}` {
+		t.Fatalf("expected unwrapped JSON, got %q", resp.Text)
+	}
+	if len(adapter.calls) != 2 {
+		t.Fatalf("expected one repair re-prompt, got %d calls", len(adapter.calls))
+	}
+	if !adapter.calls[0].JSONMode || adapter.calls[0].ResponseSchema == nil {
+		t.Fatalf("expected schema passed to the provider, got %+v", adapter.calls[0])
+	}
+	repair := adapter.calls[1].Messages
+	if len(repair) != 3 || repair[0].Content != "judge it" || repair[1].Role != "assistant" || !strings.Contains(repair[2].Content, "verdict") {
+		t.Fatalf("unexpected repair transcript %+v", repair)
+	}
+}
+
+func TestInferWithContract_SchemaErrorAfterBoundedRepairs(t *testing.T) {
+	adapter := &replyAdapter{replies: []string{"not json"}}
+	r := newTestRouter(adapter)
+
+	_, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "judge it", ResponseSchema: verdictSchema})
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || !errors.Is(err, ErrSchemaValidation) {
+		t.Fatalf("expected SchemaError, got %v", err)
+	}
+	if schemaErr.Attempts != DefaultMaxSchemaRepairs+1 || len(adapter.calls) != DefaultMaxSchemaRepairs+1 || schemaErr.Text != "not json" {
+		t.Fatalf("unexpected attempts=%d calls=%d text=%q", schemaErr.Attempts, len(adapter.calls), schemaErr.Text)
+	}
+
+	adapter.calls = nil
+	_, err = r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "judge it", ResponseSchema: verdictSchema, MaxRepairs: -1})
+	if !errors.Is(err, ErrSchemaValidation) || len(adapter.calls) != 1 {
+		t.Fatalf("expected no repairs, got err=%v calls=%d", err, len(adapter.calls))
+	}
+}
+
+func TestInferWithContract_ProfileSchemaPassesToolCallsThrough(t *testing.T) {
+	adapter := &replyAdapter{replies: []string{`{"tool_call": {"name": "recall", "arguments": {}}}`}}
+	r := newTestRouter(adapter)
+	r.Config.ProfileParams = map[string]SamplingParams{"chat": {ResponseSchema: verdictSchema}}
+
+	resp, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "judge it"})
+	if err != nil {
+		t.Fatalf("InferWithContract: %v", err)
+	}
+	if len(adapter.calls) != 1 || !strings.Contains(resp.Text, "tool_call") {
+		t.Fatalf("expected tool call reply untouched, got %q after %d calls", resp.Text, len(adapter.calls))
+	}
+}
@@ -0,0 +1,126 @@
+package cognitive
+
+import (
+	"encoding/json"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// nativeToolCaller is implemented by adapters that send tool definitions,
+// tool calls and tool results in the provider's function-calling format.
+type nativeToolCaller interface {
+	nativeToolCalls() bool
+}
+
+func (a *OpenAIAdapter) nativeToolCalls() bool    { return true }
+func (a *AnthropicAdapter) nativeToolCalls() bool { return true }
+func (g *GoogleAdapter) nativeToolCalls() bool    { return true }
+
+// SupportsNativeTools reports whether a request for profile (or the explicit
+// provider) reaches an adapter that receives tool definitions natively, so
+// callers can skip describing the text tool_call protocol in the prompt.
+func (r *Router) SupportsNativeTools(profile, explicitProvider string) bool {
+	resolution := r.resolveExecutionProvider(profile, explicitProvider)
+	if !resolution.Available || !resolution.Provider.NativeToolsEnabled() {
+		return false
+	}
+	caller, ok := r.Adapters[resolution.ProviderID].(nativeToolCaller)
+	return ok && caller.nativeToolCalls()
+}
+
+// openAIMessage maps a chat turn, including assistant tool calls and tool
+// results, onto the Chat Completions message format.
+func openAIMessage(m ChatMessage) openai.ChatCompletionMessage {
+	msg := openai.ChatCompletionMessage{Role: m.Role, Content: m.Content, ToolCallID: m.ToolCallID}
+	for _, call := range m.ToolCalls {
+		msg.ToolCalls = append(msg.ToolCalls, openai.ToolCall{
+			ID:       call.ID,
+			Type:     openai.ToolTypeFunction,
+			Function: openai.FunctionCall{Name: call.Name, Arguments: encodeToolArguments(call.Arguments)},
+		})
+	}
+	return msg
+}
+
+func encodeToolArguments(args map[string]any) string {
+	if args == nil {
+		return "{}"
+	}
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return "{}"
+	}
+	return string(raw)
+}
+
+// anthropicInputBlock is a text, tool_use or tool_result block of a request
+// message.
+type anthropicInputBlock struct {
+	Type      string `json:"type"`
+	Text      string `json:"text,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Input     any    `json:"input,omitempty"`
+	ToolUseID string `json:"tool_use_id,omitempty"`
+	Content   string `json:"content,omitempty"`
+}
+
+func anthropicAssistantTurn(m ChatMessage) anthropicMessage {
+	if len(m.ToolCalls) == 0 {
+		return anthropicMessage{Role: "assistant", Content: m.Content}
+	}
+	blocks := make([]anthropicInputBlock, 0, len(m.ToolCalls)+1)
+	if m.Content != "" {
+		blocks = append(blocks, anthropicInputBlock{Type: "text", Text: m.Content})
+	}
+	for _, call := range m.ToolCalls {
+		input := call.Arguments
+		if input == nil {
+			input = map[string]any{}
+		}
+		blocks = append(blocks, anthropicInputBlock{Type: "tool_use", ID: call.ID, Name: call.Name, Input: input})
+	}
+	return anthropicMessage{Role: "assistant", Content: blocks}
+}
+
+// appendAnthropicToolResult adds m as a tool_result block. Results of calls
+// from the same assistant turn share one user turn, as the API requires.
+func appendAnthropicToolResult(out []anthropicMessage, m ChatMessage) []anthropicMessage {
+	block := anthropicInputBlock{Type: "tool_result", ToolUseID: m.ToolCallID, Content: m.Content}
+	if n := len(out); n > 0 && out[n-1].Role == "user" {
+		if blocks, ok := out[n-1].Content.([]anthropicInputBlock); ok {
+			out[n-1].Content = append(blocks, block)
+			return out
+		}
+	}
+	return append(out, anthropicMessage{Role: "user", Content: []anthropicInputBlock{block}})
+}
+
+// googleModelTurn maps an assistant turn onto a model content and records
+// the name behind each tool call ID, which functionResponse parts require.
+func googleModelTurn(m ChatMessage, callNames map[string]string) googleContent {
+	parts := make([]googlePart, 0, len(m.ToolCalls)+1)
+	if m.Content != "" || len(m.ToolCalls) == 0 {
+		parts = append(parts, googlePart{Text: m.Content})
+	}
+	for _, call := range m.ToolCalls {
+		callNames[call.ID] = call.Name
+		args := call.Arguments
+		if args == nil {
+			args = map[string]any{}
+		}
+		parts = append(parts, googlePart{FunctionCall: &googleFunctionCall{Name: call.Name, Args: args}})
+	}
+	return googleContent{Role: "model", Parts: parts}
+}
+
+// appendGoogleToolResult adds m as a functionResponse part, grouping the
+// results of one model turn into a single user content.
+func appendGoogleToolResult(contents []googleContent, m ChatMessage, name string) []googleContent {
+	part := googlePart{FunctionResponse: &googleFunctionResponse{Name: name, Response: map[string]any{"content": m.Content}}}
+	if n := len(contents); n > 0 && contents[n-1].Role == "user" && contents[n-1].Parts[0].FunctionResponse != nil {
+		contents[n-1].Parts = append(contents[n-1].Parts, part)
+		return contents
+	}
+	return append(contents, googleContent{Role: "user", Parts: []googlePart{part}})
+}
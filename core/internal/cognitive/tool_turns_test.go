@@ -0,0 +1,95 @@
+package cognitive
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func toolTurnTranscript() []ChatMessage {
+	return []ChatMessage{
+		{Role: "user", Content: "read both notes"},
+		{Role: "assistant", ToolCalls: []ToolCall{
+			{ID: "call_a", Name: "read_file", Arguments: map[string]any{"path": "a.md"}},
+			{ID: "call_b", Name: "read_file", Arguments: map[string]any{"path": "b.md"}},
+		}},
+		{Role: "tool", ToolCallID: "call_a", Content: "alpha"},
+		{Role: "tool", ToolCallID: "call_b", Content: "beta"},
+	}
+}
+
+func TestOpenAIAdapter_SendsToolTurnsKeyedByID(t *testing.T) {
+	adapter := &OpenAIAdapter{model: "gpt-test"}
+	req := adapter.buildChatRequest("", InferOptions{Messages: toolTurnTranscript()})
+
+	assistant := req.Messages[1]
+	if len(assistant.ToolCalls) != 2 || assistant.ToolCalls[1].ID != "call_b" || assistant.ToolCalls[1].Function.Arguments != `{"path":"b.md"}` {
+		t.Fatalf("assistant tool calls = %+v", assistant.ToolCalls)
+	}
+	if result := req.Messages[3]; result.Role != "tool" || result.ToolCallID != "call_b" || result.Content != "beta" {
+		t.Fatalf("tool turn = %+v", result)
+	}
+}
+
+func TestAnthropicMessages_GroupsToolResultsInOneUserTurn(t *testing.T) {
+	_, messages := anthropicMessages("", toolTurnTranscript())
+	if len(messages) != 3 {
+		t.Fatalf("messages = %+v, want user, assistant tool_use, user tool_result", messages)
+	}
+	raw, _ := json.Marshal(messages[1:])
+	var turns []struct {
+		Role    string                `json:"role"`
+		Content []anthropicInputBlock `json:"content"`
+	}
+	if err := json.Unmarshal(raw, &turns); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if uses := turns[0].Content; len(uses) != 2 || uses[0].Type != "tool_use" || uses[0].ID != "call_a" {
+		t.Fatalf("tool_use blocks = %+v", uses)
+	}
+	results := turns[1].Content
+	if turns[1].Role != "user" || len(results) != 2 || results[1].Type != "tool_result" || results[1].ToolUseID != "call_b" || results[1].Content != "beta" {
+		t.Fatalf("tool_result turn = %+v", turns[1])
+	}
+}
+
+func TestGoogleContents_NamesFunctionResponses(t *testing.T) {
+	_, contents := googleContents("", toolTurnTranscript())
+	if len(contents) != 3 {
+		t.Fatalf("contents = %+v", contents)
+	}
+	if model := contents[1]; model.Role != "model" || len(model.Parts) != 2 || model.Parts[0].FunctionCall == nil {
+		t.Fatalf("model turn = %+v", model)
+	}
+	responses := contents[2].Parts
+	if len(responses) != 2 || responses[1].FunctionResponse == nil || responses[1].FunctionResponse.Name != "read_file" || responses[1].FunctionResponse.Response["content"] != "beta" {
+		t.Fatalf("function responses = %+v", responses)
+	}
+}
+
+func TestRouterSupportsNativeTools(t *testing.T) {
+	disabled := false
+	router := &Router{
+		Config: &BrainConfig{
+			Profiles: map[string]string{"chat": "openai", "local": "mock"},
+			Providers: map[string]ProviderConfig{
+				"openai": {Type: "openai", Enabled: true, ModelID: "gpt-test"},
+				"plain":  {Type: "openai", Enabled: true, ModelID: "gpt-test", NativeTools: &disabled},
+				"mock":   {Type: "mock", Enabled: true, ModelID: "test-model"},
+			},
+		},
+		Adapters: map[string]LLMProvider{
+			"openai": &OpenAIAdapter{model: "gpt-test"},
+			"plain":  &OpenAIAdapter{model: "gpt-test"},
+			"mock":   &MockProvider{},
+		},
+	}
+	if !router.SupportsNativeTools("chat", "") {
+		t.Fatal("openai profile should support native tools")
+	}
+	if router.SupportsNativeTools("chat", "plain") {
+		t.Fatal("native_tools: false should fall back to the text protocol")
+	}
+	if router.SupportsNativeTools("local", "") {
+		t.Fatal("adapters without native tool turns should use the text protocol")
+	}
+}
@@ -0,0 +1,31 @@
+package cognitive
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// toolParametersSchema returns the JSON Schema for a tool definition. Tools
+// registered without a schema accept an open object so providers that
+// require a parameters block still accept the definition.
+func toolParametersSchema(params map[string]any) map[string]any {
+	if len(params) == 0 {
+		return map[string]any{"type": "object", "properties": map[string]any{}}
+	}
+	return params
+}
+
+// parseToolArguments decodes provider-encoded JSON arguments. Arguments that
+// are not a JSON object are preserved under raw_arguments so the agent can
+// still report what the model attempted.
+func parseToolArguments(raw string) map[string]any {
+	args := map[string]any{}
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return args
+	}
+	if err := json.Unmarshal([]byte(trimmed), &args); err != nil {
+		return map[string]any{"raw_arguments": trimmed}
+	}
+	return args
+}
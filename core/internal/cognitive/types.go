@@ -10,6 +10,18 @@ type BrainConfig struct {
 	Providers map[string]ProviderConfig `yaml:"providers" json:"providers"`
 	Profiles  map[string]string         `yaml:"profiles" json:"profiles"` // ProfileName -> ProviderID
 	Media     *MediaConfig              `yaml:"media,omitempty" json:"media,omitempty"`
+	Budgets   *BudgetPolicy             `yaml:"budgets,omitempty" json:"budgets,omitempty"`
+
+	// ProfileParams holds the sampling parameters of profiles written in
+	// object form; see ProfileConfig.
+	ProfileParams map[string]SamplingParams `yaml:"-" json:"profile_params,omitempty"`
+
+	Resilience *ResiliencePolicy `yaml:"resilience,omitempty" json:"resilience,omitempty"`
+	Cache      *CachePolicy      `yaml:"cache,omitempty" json:"cache,omitempty"`
+
+	// EmbedModel is the embedding model for durable memory; empty means
+	// DefaultEmbedModel. Changing it calls for an embedding migration.
+	EmbedModel string `yaml:"embed_model,omitempty" json:"embed_model,omitempty"`
 }
 
 type ExecutionAvailability struct {
@@ -50,7 +62,7 @@ type MediaConfig struct {
 }
 
 type ProviderConfig struct {
-	Type       string `yaml:"type" json:"type"`                   // openai, openai_compatible, anthropic, google
+	Type       string `yaml:"type" json:"type"`                   // openai, openai_compatible, ollama, anthropic, google, cassette
 	Driver     string `yaml:"-" json:"-"`                         // DB Driver type (mapped to Type)
 	Endpoint   string `yaml:"endpoint" json:"endpoint,omitempty"` // e.g. "http://localhost:11434/v1"
 	ModelID    string `yaml:"model_id" json:"model_id"`           // e.g. "qwen2.5-coder:7b"
@@ -65,6 +77,36 @@ type ProviderConfig struct {
 	MaxOutputTokens    int      `yaml:"max_output_tokens,omitempty" json:"max_output_tokens,omitempty"`       // bounded default output budget per provider
 	RolesAllowed       []string `yaml:"roles_allowed" json:"roles_allowed"`                                   // ["architect","coder"] or ["all"]
 	Enabled            bool     `yaml:"enabled" json:"enabled"`
+
+	// NativeTools controls provider-native function calling. Nil means enabled;
+	// set false for models that reject tool definitions so agents fall back to
+	// JSON-in-text tool_call parsing.
+	NativeTools *bool `yaml:"native_tools,omitempty" json:"native_tools,omitempty"`
+
+	// StreamUsage asks streamed completions for a closing usage chunk
+	// (stream_options.include_usage). Nil means on for type "openai" only;
+	// some OpenAI-compatible servers reject the field.
+	StreamUsage *bool `yaml:"stream_usage,omitempty" json:"stream_usage,omitempty"`
+
+	// Pricing converts recorded token usage into cost for the usage ledger.
+	Pricing *ProviderPricing `yaml:"pricing,omitempty" json:"pricing,omitempty"`
+
+	// Cassette configures a provider of type "cassette" that records and
+	// replays fixture responses for deterministic tests.
+	Cassette *CassetteConfig `yaml:"cassette,omitempty" json:"cassette,omitempty"`
+}
+
+// NativeToolsEnabled reports whether tool definitions should be sent natively.
+func (c ProviderConfig) NativeToolsEnabled() bool {
+	return c.NativeTools == nil || *c.NativeTools
+}
+
+// StreamUsageEnabled reports whether streamed requests ask for usage.
+func (c ProviderConfig) StreamUsageEnabled() bool {
+	if c.StreamUsage != nil {
+		return *c.StreamUsage
+	}
+	return c.Type == "openai"
 }
 
 const (
@@ -116,16 +158,44 @@ type LLMProvider interface {
 
 type InferOptions struct {
 	Temperature float64
+	TopP        float64 // 0 = provider default
 	MaxTokens   int
 	Stop        []string
-	Messages    []ChatMessage // Optional: Structured messages (overrides prompt if supported)
+	Seed        *int
+	Messages    []ChatMessage    // Optional: Structured messages (overrides prompt if supported)
+	Tools       []ToolDefinition // Optional: native function-calling definitions
+
+	// JSONMode asks for a JSON object reply; ResponseSchema constrains it to
+	// a JSON Schema on providers with a structured-output mode.
+	JSONMode       bool
+	ResponseSchema map[string]any
+}
+
+// ToolDefinition describes one callable tool for provider-native function calling.
+// Parameters is a JSON Schema object describing the tool arguments.
+type ToolDefinition struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+// ToolCall is a structured tool invocation returned by a provider.
+type ToolCall struct {
+	ID        string         `json:"id,omitempty"`
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
 }
 
 // --- Requests & Responses (Legacy/Compat) ---
 
+// ChatMessage is one conversation turn. An assistant turn that requested
+// native tool calls carries them in ToolCalls; each result comes back as a
+// "tool" turn whose ToolCallID names the call it answers.
 type ChatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
 }
 
 type InferRequest struct {
@@ -133,13 +203,45 @@ type InferRequest struct {
 	Provider string        `json:"provider,omitempty"` // Optional explicit provider override (bypasses profile routing)
 	Prompt   string        `json:"prompt"`             // Legacy
 	Messages []ChatMessage `json:"messages,omitempty"`
+
+	// Tools are offered to the provider's native function-calling API when the
+	// provider supports it; structured calls come back in InferResponse.ToolCalls.
+	Tools []ToolDefinition `json:"tools,omitempty"`
+
+	// Sampling overrides the profile and organization sampling parameters,
+	// typically from the calling agent's manifest.
+	Sampling *SamplingParams `json:"sampling,omitempty"`
+
+	// ResponseSchema is a JSON Schema the reply must satisfy. Providers with a
+	// structured-output mode receive it natively; every reply is validated and
+	// repaired by re-prompting at most MaxRepairs times (0 = default, <0 = none).
+	ResponseSchema map[string]any `json:"response_schema,omitempty"`
+	MaxRepairs     int            `json:"max_repairs,omitempty"`
+
+	// OnStream, when set, receives incremental completion text. Every
+	// re-inference that reuses the request streams through the same sink,
+	// and so does every retry, which restarts the completion: consumers drop
+	// the text received since the last Done chunk when the next one starts.
+	OnStream StreamFunc `json:"-"`
+
+	// Attribution identifies the organization, team, agent and run the
+	// inference is recorded against in the usage ledger.
+	Attribution UsageAttribution `json:"-"`
 }
 
 type InferResponse struct {
-	Text       string `json:"text"`
-	ModelUsed  string `json:"model_used"`
-	Provider   string `json:"provider"`
-	TokensUsed int    `json:"tokens_used,omitempty"`
+	Text             string     `json:"text"`
+	ModelUsed        string     `json:"model_used"`
+	Provider         string     `json:"provider"`
+	TokensUsed       int        `json:"tokens_used,omitempty"`
+	PromptTokens     int        `json:"prompt_tokens,omitempty"`
+	CompletionTokens int        `json:"completion_tokens,omitempty"`
+	ToolCalls        []ToolCall `json:"tool_calls,omitempty"`
+
+	// CacheHit is set when the response came from the response cache instead
+	// of a provider; CacheTier names the tier that matched.
+	CacheHit  bool   `json:"cache_hit,omitempty"`
+	CacheTier string `json:"cache_tier,omitempty"`
 }
 
 // --- Embedding Interface ---
@@ -150,7 +252,8 @@ type EmbedProvider interface {
 	Embed(ctx context.Context, text string, model string) ([]float64, error)
 }
 
-// DefaultEmbedModel is the standard embedding model (768 dims, matches context_vectors table).
+// DefaultEmbedModel is the embedding model used when BrainConfig.EmbedModel
+// is unset (768 dims).
 const DefaultEmbedModel = "nomic-embed-text"
 
 // --- Middleware / Contracts ---
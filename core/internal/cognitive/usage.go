@@ -0,0 +1,142 @@
+package cognitive
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultUsageCurrency is applied when a provider's pricing omits a currency.
+const DefaultUsageCurrency = "USD"
+
+// UsageAttribution identifies who an inference is billed to.
+type UsageAttribution struct {
+	OrganizationID string `json:"organization_id,omitempty"`
+	TeamID         string `json:"team_id,omitempty"`
+	AgentID        string `json:"agent_id,omitempty"`
+	RunID          string `json:"run_id,omitempty"`
+}
+
+// TokenPrice is the price per million prompt (input) and completion (output) tokens.
+type TokenPrice struct {
+	InputPerMillion  float64 `yaml:"input_per_million" json:"input_per_million"`
+	OutputPerMillion float64 `yaml:"output_per_million" json:"output_per_million"`
+}
+
+// ProviderPricing is the price table for one provider. The inline price is the
+// default; Models overrides it for specific model IDs served by the provider.
+type ProviderPricing struct {
+	Currency   string `yaml:"currency,omitempty" json:"currency,omitempty"`
+	TokenPrice `yaml:",inline"`
+	Models     map[string]TokenPrice `yaml:"models,omitempty" json:"models,omitempty"`
+}
+
+// Cost returns the cost of a call and its currency. A nil table is free.
+func (p *ProviderPricing) Cost(modelID string, promptTokens, completionTokens int) (float64, string) {
+	if p == nil {
+		return 0, DefaultUsageCurrency
+	}
+	currency := p.Currency
+	if currency == "" {
+		currency = DefaultUsageCurrency
+	}
+	price := p.TokenPrice
+	if override, ok := p.Models[modelID]; ok {
+		price = override
+	}
+	cost := float64(promptTokens)*price.InputPerMillion/1e6 + float64(completionTokens)*price.OutputPerMillion/1e6
+	return cost, currency
+}
+
+// UsageRecord is one ledger entry: the tokens and cost of a single inference.
+type UsageRecord struct {
+	ID               string           `json:"id,omitempty"`
+	Attribution      UsageAttribution `json:"attribution"`
+	Profile          string           `json:"profile,omitempty"`
+	ProviderID       string           `json:"provider_id"`
+	ModelID          string           `json:"model_id,omitempty"`
+	PromptTokens     int              `json:"prompt_tokens"`
+	CompletionTokens int              `json:"completion_tokens"`
+	TotalTokens      int              `json:"total_tokens"`
+	Estimated        bool             `json:"estimated,omitempty"` // adapter did not report counts
+	Cost             float64          `json:"cost"`
+	Currency         string           `json:"currency"`
+	CreatedAt        time.Time        `json:"created_at"`
+}
+
+// UsageRecorder persists usage records. Implemented by usage.Ledger.
+type UsageRecorder interface {
+	RecordUsage(ctx context.Context, rec UsageRecord) error
+}
+
+// SetUsageRecorder attaches the ledger that receives a record for every
+// successful inference. nil disables recording.
+func (r *Router) SetUsageRecorder(rec UsageRecorder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage = rec
+}
+
+func (r *Router) usageRecorder() UsageRecorder {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.usage
+}
+
+// recordUsage feeds the telemetry counters and the usage ledger for one
+// completed inference. Missing adapter counts are estimated at ~4 chars per
+// token (conservative approximation) and flagged as estimated.
+func (r *Router) recordUsage(ctx context.Context, req InferRequest, opts InferOptions, providerID string, resp *InferResponse) {
+	rec := UsageRecord{
+		Attribution:      req.Attribution,
+		Profile:          req.Profile,
+		ProviderID:       providerID,
+		ModelID:          resp.ModelUsed,
+		PromptTokens:     resp.PromptTokens,
+		CompletionTokens: resp.CompletionTokens,
+		TotalTokens:      resp.TokensUsed,
+		CreatedAt:        time.Now().UTC(),
+	}
+	if rec.PromptTokens == 0 && rec.CompletionTokens == 0 && rec.TotalTokens == 0 {
+		rec.Estimated = true
+		rec.PromptTokens = estimateTokens(req.Prompt)
+		for _, m := range opts.Messages {
+			rec.PromptTokens += estimateTokens(m.Content)
+		}
+		rec.CompletionTokens = estimateTokens(resp.Text)
+	}
+	if rec.TotalTokens == 0 {
+		rec.TotalTokens = rec.PromptTokens + rec.CompletionTokens
+	} else if rec.PromptTokens+rec.CompletionTokens == 0 {
+		// Adapter reported only a total; bill it as completion tokens.
+		rec.CompletionTokens = rec.TotalTokens
+	}
+	r.RecordTokens(rec.TotalTokens)
+
+	ledger := r.usageRecorder()
+	if ledger == nil {
+		return
+	}
+	var pricing *ProviderPricing
+	if r.Config != nil {
+		r.mu.RLock()
+		pricing = r.Config.Providers[providerID].Pricing
+		r.mu.RUnlock()
+	}
+	rec.Cost, rec.Currency = pricing.Cost(rec.ModelID, rec.PromptTokens, rec.CompletionTokens)
+	// The ledger write must land even if the caller's context ends with the reply.
+	if err := ledger.RecordUsage(context.WithoutCancel(ctx), rec); err != nil {
+		log.Printf("[cognitive] usage ledger write failed for %s: %v", providerID, err)
+	}
+}
+
+func estimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	tokens := len(text) / 4
+	if tokens < 1 {
+		tokens = 1
+	}
+	return tokens
+}
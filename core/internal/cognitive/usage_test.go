@@ -0,0 +1,168 @@
+package cognitive
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type recordingLedger struct {
+	records []UsageRecord
+}
+
+func (l *recordingLedger) RecordUsage(_ context.Context, rec UsageRecord) error {
+	l.records = append(l.records, rec)
+	return nil
+}
+
+type usageAdapter struct {
+	resp *InferResponse
+}
+
+func (a *usageAdapter) Infer(context.Context, string, InferOptions) (*InferResponse, error) {
+	copied := *a.resp
+	return &copied, nil
+}
+
+func (a *usageAdapter) Probe(context.Context) (bool, error) {
+	return true, nil
+}
+
+func TestProviderPricing_Cost(t *testing.T) {
+	pricing := &ProviderPricing{
+		TokenPrice: TokenPrice{InputPerMillion: 1, OutputPerMillion: 2},
+		Models:     map[string]TokenPrice{"big": {InputPerMillion: 10, OutputPerMillion: 30}},
+	}
+
+	cost, currency := pricing.Cost("small", 1_000_000, 500_000)
+	if cost != 2 || currency != DefaultUsageCurrency {
+		t.Fatalf("default price = %v %s, want 2 USD", cost, currency)
+	}
+	cost, _ = pricing.Cost("big", 1000, 1000)
+	if math.Abs(cost-0.04) > 1e-12 {
+		t.Fatalf("model override cost = %v, want 0.04", cost)
+	}
+
+	var free *ProviderPricing
+	if cost, currency := free.Cost("any", 1000, 1000); cost != 0 || currency != DefaultUsageCurrency {
+		t.Fatalf("nil pricing = %v %s, want 0 USD", cost, currency)
+	}
+}
+
+func TestInferWithContract_RecordsPricedUsage(t *testing.T) {
+	ledger := &recordingLedger{}
+	r := &Router{
+		Config: &BrainConfig{
+			Providers: map[string]ProviderConfig{"remote": {
+				Type: "openai", ModelID: "gpt-test", Enabled: true,
+				Pricing: &ProviderPricing{Currency: "EUR", TokenPrice: TokenPrice{InputPerMillion: 2, OutputPerMillion: 8}},
+			}},
+			Profiles: map[string]string{"chat": "remote"},
+		},
+		Adapters: map[string]LLMProvider{"remote": &usageAdapter{resp: &InferResponse{
+			Text: "ok", ModelUsed: "gpt-test", PromptTokens: 1000, CompletionTokens: 500, TokensUsed: 1500,
+		}}},
+	}
+	r.SetUsageRecorder(ledger)
+
+	attribution := UsageAttribution{OrganizationID: "org-1", TeamID: "council-core", AgentID: "architect", RunID: "run-1"}
+	if _, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "hi", Attribution: attribution}); err != nil {
+		t.Fatalf("InferWithContract: %v", err)
+	}
+
+	if len(ledger.records) != 1 {
+		t.Fatalf("recorded %d usage rows, want 1", len(ledger.records))
+	}
+	rec := ledger.records[0]
+	if rec.Attribution != attribution || rec.ProviderID != "remote" || rec.ModelID != "gpt-test" || rec.Profile != "chat" {
+		t.Fatalf("unexpected attribution %+v", rec)
+	}
+	if rec.Estimated || rec.TotalTokens != 1500 {
+		t.Fatalf("expected reported counts, got %+v", rec)
+	}
+	if math.Abs(rec.Cost-0.006) > 1e-12 || rec.Currency != "EUR" {
+		t.Fatalf("cost = %v %s, want 0.006 EUR", rec.Cost, rec.Currency)
+	}
+	if got := r.totalTokens.Load(); got != 1500 {
+		t.Fatalf("telemetry total = %d, want 1500", got)
+	}
+}
+
+func TestInferWithContract_EstimatesUsageWhenAdapterReportsNone(t *testing.T) {
+	ledger := &recordingLedger{}
+	r := &Router{
+		Config: &BrainConfig{
+			Providers: map[string]ProviderConfig{"local": {Type: "ollama", ModelID: "tiny", Enabled: true}},
+			Profiles:  map[string]string{"chat": "local"},
+		},
+		Adapters: map[string]LLMProvider{"local": &usageAdapter{resp: &InferResponse{Text: "twelve chars", ModelUsed: "tiny"}}},
+	}
+	r.SetUsageRecorder(ledger)
+
+	if _, err := r.InferWithContract(context.Background(), InferRequest{Profile: "chat", Prompt: "sixteen chars!!!"}); err != nil {
+		t.Fatalf("InferWithContract: %v", err)
+	}
+	rec := ledger.records[0]
+	if !rec.Estimated || rec.PromptTokens != 4 || rec.CompletionTokens != 3 || rec.TotalTokens != 7 {
+		t.Fatalf("unexpected estimate %+v", rec)
+	}
+	if rec.Cost != 0 || rec.Currency != DefaultUsageCurrency {
+		t.Fatalf("unpriced provider should be free, got %v %s", rec.Cost, rec.Currency)
+	}
+}
+
+func TestAdapters_ReportTokenUsage(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		body    string
+		adapter func(endpoint string) (LLMProvider, error)
+	}{
+		{
+			name: "openai",
+			body: `{"id":"c1","object":"chat.completion","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}],"usage":{"prompt_tokens":11,"completion_tokens":7,"total_tokens":18}}`,
+			adapter: func(endpoint string) (LLMProvider, error) {
+				return NewOpenAIAdapter(ProviderConfig{Type: "openai_compatible", Endpoint: endpoint + "/v1", ModelID: "m"})
+			},
+		},
+		{
+			name: "anthropic",
+			body: `{"id":"m1","content":[{"type":"text","text":"ok"}],"usage":{"input_tokens":11,"output_tokens":7}}`,
+			adapter: func(endpoint string) (LLMProvider, error) {
+				return NewAnthropicAdapter(ProviderConfig{Type: "anthropic", Endpoint: endpoint, ModelID: "m", AuthKey: "k"})
+			},
+		},
+		{
+			name: "google",
+			body: `{"candidates":[{"content":{"parts":[{"text":"ok"}]}}],"usageMetadata":{"promptTokenCount":11,"candidatesTokenCount":7,"totalTokenCount":18}}`,
+			adapter: func(endpoint string) (LLMProvider, error) {
+				return NewGoogleAdapter(ProviderConfig{Type: "google", Endpoint: endpoint, ModelID: "m", AuthKey: "k"})
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, tc.body)
+			}))
+			defer server.Close()
+
+			adapter, err := tc.adapter(server.URL)
+			if err != nil {
+				t.Fatalf("adapter: %v", err)
+			}
+			resp, err := adapter.Infer(context.Background(), "hi", InferOptions{})
+			if err != nil {
+				t.Fatalf("Infer() error = %v", err)
+			}
+			if resp.PromptTokens != 11 || resp.CompletionTokens != 7 || resp.TokensUsed != 18 {
+				t.Fatalf("usage = prompt %d completion %d total %d, want 11/7/18", resp.PromptTokens, resp.CompletionTokens, resp.TokensUsed)
+			}
+		})
+	}
+}
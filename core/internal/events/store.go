@@ -124,6 +124,34 @@ func (s *Store) GetRunTimeline(ctx context.Context, runID string) ([]protocol.Mi
 	}
 	defer rows.Close()
 
+	return scanEvents(rows), nil
+}
+
+// ListSince returns up to limit events emitted at or after since, newest
+// first. Used to replay mission history against candidate governance
+// policies.
+func (s *Store) ListSince(ctx context.Context, since time.Time, limit int) ([]protocol.MissionEventEnvelope, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("events: database not available")
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, run_id, tenant_id, event_type, severity,
+		       COALESCE(source_agent, ''), COALESCE(source_team, ''),
+		       COALESCE(payload, '{}'), COALESCE(audit_event_id::text, ''), emitted_at
+		FROM mission_events
+		WHERE emitted_at >= $1 AND tenant_id = 'default'
+		ORDER BY emitted_at DESC
+		LIMIT $2
+	`, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("events: query failed: %w", err)
+	}
+	defer rows.Close()
+	return scanEvents(rows), nil
+}
+
+func scanEvents(rows *sql.Rows) []protocol.MissionEventEnvelope {
 	var events []protocol.MissionEventEnvelope
 	for rows.Next() {
 		var ev protocol.MissionEventEnvelope
@@ -149,7 +177,7 @@ func (s *Store) GetRunTimeline(ctx context.Context, runID string) ([]protocol.Mi
 	if events == nil {
 		events = []protocol.MissionEventEnvelope{}
 	}
-	return events, nil
+	return events
 }
 
 // GetEvent returns one persisted mission event by id for runtime consumers that
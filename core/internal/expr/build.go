@@ -0,0 +1,112 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Expr is an expression tree built in Go. Callers translating structured
+// conditions use it instead of rendering source, so their values are never
+// parsed as expression syntax. src is only the display form.
+type Expr struct {
+	root node
+	src  string
+}
+
+// Build wraps x as a program; its String is x's display form.
+func Build(x Expr) *Program {
+	return &Program{src: x.src, root: x.root}
+}
+
+// Path reads the dotted path made of segments.
+func Path(segments ...string) Expr {
+	return Expr{root: pathNode{segments: segments}, src: strings.Join(segments, ".")}
+}
+
+// Value is a literal: null, a boolean, a number, a string or a list of
+// those.
+func Value(v any) (Expr, error) {
+	switch x := normalize(v).(type) {
+	case nil:
+		return Expr{root: literalNode{v: nil}, src: "null"}, nil
+	case bool:
+		return Expr{root: literalNode{v: x}, src: strconv.FormatBool(x)}, nil
+	case float64:
+		return Expr{root: literalNode{v: x}, src: strconv.FormatFloat(x, 'f', -1, 64)}, nil
+	case string:
+		return Expr{root: literalNode{v: x}, src: strconv.Quote(x)}, nil
+	case []any:
+		list := listNode{items: make([]node, len(x))}
+		srcs := make([]string, len(x))
+		for i, item := range x {
+			lit, err := Value(item)
+			if err != nil {
+				return Expr{}, err
+			}
+			list.items[i], srcs[i] = lit.root, lit.src
+		}
+		return Expr{root: list, src: "[" + strings.Join(srcs, ", ") + "]"}, nil
+	}
+	return Expr{}, fmt.Errorf("%v is not null, a boolean, a number, a string or a list", v)
+}
+
+// Compare applies a comparison operator: ==, !=, <, <=, >, >=, in, not in,
+// contains or matches. A literal matches pattern is checked here, as Compile
+// does.
+func Compare(op string, left, right Expr) (Expr, error) {
+	cmp := compareNode{op: op, left: left.root, right: right.root}
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=", "in", "not in", "contains":
+	case "matches":
+		if lit, ok := right.root.(literalNode); ok {
+			pattern, isString := lit.v.(string)
+			if !isString {
+				return Expr{}, fmt.Errorf("matches needs a string pattern")
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return Expr{}, fmt.Errorf("invalid pattern: %v", err)
+			}
+			cmp.re = re
+		}
+	default:
+		return Expr{}, fmt.Errorf("unknown operator %q", op)
+	}
+	return Expr{root: cmp, src: left.src + " " + op + " " + right.src}, nil
+}
+
+// Exists is true when the path made of segments is present, even if null.
+func Exists(segments ...string) Expr {
+	path := Path(segments...)
+	return Expr{
+		root: callNode{name: "exists", fn: functions["exists"], args: []node{path.root}},
+		src:  "exists(" + path.src + ")",
+	}
+}
+
+// Not negates x.
+func Not(x Expr) Expr {
+	return Expr{root: notNode{x: x.root}, src: "!" + group(x)}
+}
+
+// And is true when every operand is; the first operand is bare in the
+// display form and the rest are parenthesized.
+func And(first Expr, rest ...Expr) Expr {
+	out := first
+	for _, x := range rest {
+		out = Expr{root: logicalNode{and: true, left: out.root, right: x.root}, src: out.src + " && " + group(x)}
+	}
+	return out
+}
+
+// group parenthesizes the display form of anything but a path, literal or
+// call.
+func group(x Expr) string {
+	switch x.root.(type) {
+	case pathNode, literalNode, listNode, callNode:
+		return x.src
+	}
+	return "(" + x.src + ")"
+}
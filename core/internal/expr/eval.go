@@ -0,0 +1,196 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Env is what an expression is evaluated against. Vars is addressed with
+// dotted paths (payload.customer.tier, items.0); a missing path is null.
+// Now drives the time functions and defaults to the current time.
+type Env struct {
+	Vars map[string]any
+	Now  time.Time
+}
+
+// Eval runs the program. Comparisons involving null or mismatched types are
+// false rather than errors, so a condition over an absent field simply does
+// not match.
+func (p *Program) Eval(env Env) (bool, error) {
+	if p == nil || p.root == nil {
+		return true, nil
+	}
+	if env.Now.IsZero() {
+		env.Now = time.Now()
+	}
+	v, err := p.root.eval(&env)
+	if err != nil {
+		return false, err
+	}
+	return truthy(v), nil
+}
+
+type node interface {
+	eval(env *Env) (any, error)
+}
+
+type literalNode struct{ v any }
+
+func (n literalNode) eval(*Env) (any, error) { return n.v, nil }
+
+type pathNode struct{ segments []string }
+
+func (n pathNode) eval(env *Env) (any, error) {
+	v, _ := lookup(env.Vars, n.segments)
+	return v, nil
+}
+
+type listNode struct{ items []node }
+
+func (n listNode) eval(env *Env) (any, error) {
+	out := make([]any, 0, len(n.items))
+	for _, item := range n.items {
+		v, err := item.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+type notNode struct{ x node }
+
+func (n notNode) eval(env *Env) (any, error) {
+	v, err := n.x.eval(env)
+	return !truthy(v), err
+}
+
+type logicalNode struct {
+	and         bool
+	left, right node
+}
+
+func (n logicalNode) eval(env *Env) (any, error) {
+	l, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if truthy(l) != n.and {
+		return truthy(l), nil // short-circuit
+	}
+	r, err := n.right.eval(env)
+	return truthy(r), err
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+	re          *regexp.Regexp // precompiled literal pattern for matches
+}
+
+func (n compareNode) eval(env *Env) (any, error) {
+	l, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return equal(l, r), nil
+	case "!=":
+		return !equal(l, r), nil
+	case "in":
+		return member(l, r), nil
+	case "not in":
+		return !member(l, r), nil
+	case "contains":
+		return member(r, l), nil
+	case "matches":
+		s, ok := l.(string)
+		if !ok {
+			return false, nil
+		}
+		re := n.re
+		if re == nil {
+			pattern, isString := r.(string)
+			if !isString {
+				return false, nil
+			}
+			if re, err = regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("matches: %w", err)
+			}
+		}
+		return re.MatchString(s), nil
+	}
+	c, ok := order(l, r)
+	if !ok {
+		return false, nil
+	}
+	switch n.op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default: // ">="
+		return c >= 0, nil
+	}
+}
+
+type callNode struct {
+	name string
+	fn   *function
+	args []node
+}
+
+func (n callNode) eval(env *Env) (any, error) {
+	args := make([]any, len(n.args))
+	for i, a := range n.args {
+		if path, ok := a.(pathNode); ok && n.fn.rawPaths {
+			_, found := lookup(env.Vars, path.segments)
+			args[i] = found
+			continue
+		}
+		v, err := a.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := n.fn.call(env, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", n.name, err)
+	}
+	return v, nil
+}
+
+// lookup walks maps and slices along a dotted path.
+func lookup(vars map[string]any, segments []string) (any, bool) {
+	var cur any = vars
+	for _, seg := range segments {
+		switch c := cur.(type) {
+		case map[string]any:
+			v, ok := c[seg]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case []any:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			cur = c[i]
+		default:
+			return nil, false
+		}
+	}
+	return normalize(cur), true
+}
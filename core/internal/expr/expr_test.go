@@ -0,0 +1,158 @@
+package expr
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]any{
+		"amount": 120,
+		"team":   "finance",
+		"role":   "oncall",
+		"payload": map[string]any{
+			"customer": map[string]any{"tier": "gold", "tags": []any{"vip", "eu"}},
+			"items":    []any{map[string]any{"sku": "A-1"}},
+			"note":     nil,
+		},
+	}
+	// Wednesday 23:30 UTC
+	now := time.Date(2026, 1, 7, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{"", true},
+		{"amount > 50", true},
+		{"amount <= 50", false},
+		{"amount == 120 && team == 'finance'", true},
+		{"amount > 500 || role == \"oncall\"", true},
+		{"not (team == 'finance')", false},
+		{"!(amount < 0) and team != 'ops'", true},
+		{"payload.customer.tier in ['gold', 'platinum']", true},
+		{"payload.customer.tier not in ['gold']", false},
+		{"payload.customer.tags contains 'vip'", true},
+		{"payload.items.0.sku matches '^A-[0-9]+$'", true},
+		{"team contains 'nan'", true},
+		{"payload.missing > 1", false},
+		{"payload.missing == null", true},
+		{"exists(payload.note) && !exists(payload.ticket)", true},
+		{"len(payload.customer.tags) == 2", true},
+		{"lower('FINANCE') == team", true},
+		{"time_between('22:00', '06:00')", true},
+		{"time_between('09:00', '17:00')", false},
+		{"time_between('08:00', '17:00', 'Asia/Tokyo')", true},
+		{"weekday() in ['sat', 'sun']", false},
+		{"hour() >= 23", true},
+		{"amount > -5 and '130' > amount", true},
+	}
+	for _, tt := range tests {
+		prog, err := Compile(tt.src)
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", tt.src, err)
+		}
+		got, err := prog.Eval(Env{Vars: vars, Now: now})
+		if err != nil {
+			t.Fatalf("Eval(%q): %v", tt.src, err)
+		}
+		if got != tt.want {
+			t.Errorf("Eval(%q) = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		src       string
+		line, col int
+	}{
+		{"amount >", 1, 9},
+		{"amount > 50 &&\n  team = 'x'", 2, 8},
+		{"payload.id matches '('", 1, 20},
+		{"time_between('9am', '17:00')", 1, 1},
+		{"hour('Mars/Olympus')", 1, 1},
+		{"nope(1)", 1, 1},
+		{"exists('x')", 1, 1},
+		{"'unterminated", 1, 1},
+		{"42", 1, 1},
+		{"[1, 2", 1, 6},
+	}
+	for _, tt := range tests {
+		_, err := Compile(tt.src)
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf("Compile(%q): expected syntax error, got %v", tt.src, err)
+		}
+		if se.Line != tt.line || se.Column != tt.col {
+			t.Errorf("Compile(%q) error at %d:%d (%s), want %d:%d", tt.src, se.Line, se.Column, se.Msg, tt.line, tt.col)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	vars := map[string]any{"note": `a" || true || "b`, "tags": []any{"vip"}}
+	note := Path("note")
+	literal := func(v any) Expr {
+		x, err := Value(v)
+		if err != nil {
+			t.Fatalf("Value(%v): %v", v, err)
+		}
+		return x
+	}
+	eq, err := Compare("==", note, literal(`a" || true || "b`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ne, err := Compare("==", note, literal(`x" || true || "`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	in, err := Compare("contains", Path("tags"), literal("vip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		x    Expr
+		want bool
+		src  string
+	}{
+		{And(Exists("note"), eq), true, `exists(note) && (note == "a\" || true || \"b")`},
+		{ne, false, `note == "x\" || true || \""`},
+		{Not(in), false, `!(tags contains "vip")`},
+		{Not(Exists("missing")), true, `!exists(missing)`},
+	}
+	for _, tt := range tests {
+		prog := Build(tt.x)
+		if got, err := prog.Eval(Env{Vars: vars}); err != nil || got != tt.want {
+			t.Errorf("Eval(%s) = %v, %v; want %v", prog, got, err, tt.want)
+		}
+		if prog.String() != tt.src {
+			t.Errorf("String() = %s, want %s", prog, tt.src)
+		}
+	}
+
+	if _, err := Compare("matches", note, literal("(")); err == nil {
+		t.Error("expected an invalid pattern to be rejected")
+	}
+	if _, err := Compare("~=", note, literal(1)); err == nil {
+		t.Error("expected an unknown operator to be rejected")
+	}
+	if _, err := Value(map[string]any{}); err == nil {
+		t.Error("expected an object literal to be rejected")
+	}
+}
+
+func TestReferences(t *testing.T) {
+	prog, err := Compile("payload.amount > 1 && (exists(run.outputs) || 'x' in [run.status])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !prog.References("run") || !prog.References("payload") || prog.References("team") {
+		t.Fatal("References did not report the paths the program reads")
+	}
+	if (&Program{}).References("run") {
+		t.Fatal("empty program references nothing")
+	}
+}
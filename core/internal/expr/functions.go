@@ -0,0 +1,137 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type function struct {
+	minArgs, maxArgs int
+	// rawPaths passes path arguments as "was the path present" booleans.
+	rawPaths bool
+	check    func(args []node) error
+	call     func(env *Env, args []any) (any, error)
+}
+
+func (f *function) arity() string {
+	switch {
+	case f.minArgs == f.maxArgs && f.minArgs == 0:
+		return "no arguments"
+	case f.minArgs == f.maxArgs:
+		return fmt.Sprintf("%d argument(s)", f.minArgs)
+	}
+	return fmt.Sprintf("%d to %d arguments", f.minArgs, f.maxArgs)
+}
+
+// functions are the built-ins available to expressions.
+var functions = map[string]*function{
+	// exists(payload.ticket) is true when the path is present, even if null.
+	"exists": {minArgs: 1, maxArgs: 1, rawPaths: true, check: checkPathArg,
+		call: func(_ *Env, args []any) (any, error) { return args[0], nil }},
+	"len": {minArgs: 1, maxArgs: 1, call: func(_ *Env, args []any) (any, error) {
+		switch x := normalize(args[0]).(type) {
+		case string:
+			return float64(len(x)), nil
+		case []any:
+			return float64(len(x)), nil
+		case map[string]any:
+			return float64(len(x)), nil
+		}
+		return float64(0), nil
+	}},
+	"lower": {minArgs: 1, maxArgs: 1, call: func(_ *Env, args []any) (any, error) {
+		s, _ := args[0].(string)
+		return strings.ToLower(s), nil
+	}},
+	"upper": {minArgs: 1, maxArgs: 1, call: func(_ *Env, args []any) (any, error) {
+		s, _ := args[0].(string)
+		return strings.ToUpper(s), nil
+	}},
+	// hour([tz]) is the current hour, 0-23.
+	"hour": {minArgs: 0, maxArgs: 1, check: checkZoneArg(0), call: func(env *Env, args []any) (any, error) {
+		now, err := localNow(env, args, 0)
+		return float64(now.Hour()), err
+	}},
+	// weekday([tz]) is the current day as "mon".."sun".
+	"weekday": {minArgs: 0, maxArgs: 1, check: checkZoneArg(0), call: func(env *Env, args []any) (any, error) {
+		now, err := localNow(env, args, 0)
+		return strings.ToLower(now.Weekday().String()[:3]), err
+	}},
+	// time_between("22:00", "06:00"[, tz]) is true inside the window; windows
+	// that end before they start wrap past midnight.
+	"time_between": {minArgs: 2, maxArgs: 3, check: checkWindowArgs, call: func(env *Env, args []any) (any, error) {
+		now, err := localNow(env, args, 2)
+		if err != nil {
+			return nil, err
+		}
+		start, err1 := clockMinutes(args[0])
+		end, err2 := clockMinutes(args[1])
+		if err1 != nil || err2 != nil {
+			return false, nil
+		}
+		m := now.Hour()*60 + now.Minute()
+		if start <= end {
+			return m >= start && m < end, nil
+		}
+		return m >= start || m < end, nil
+	}},
+}
+
+func checkPathArg(args []node) error {
+	if _, ok := args[0].(pathNode); !ok {
+		return fmt.Errorf("argument must be a field path")
+	}
+	return nil
+}
+
+func checkZoneArg(i int) func(args []node) error {
+	return func(args []node) error {
+		if i >= len(args) {
+			return nil
+		}
+		if lit, ok := args[i].(literalNode); ok {
+			name, isString := lit.v.(string)
+			if !isString {
+				return fmt.Errorf("time zone must be a string")
+			}
+			if _, err := time.LoadLocation(name); err != nil {
+				return fmt.Errorf("unknown time zone %q", name)
+			}
+		}
+		return nil
+	}
+}
+
+func checkWindowArgs(args []node) error {
+	for _, a := range args[:2] {
+		if lit, ok := a.(literalNode); ok {
+			if _, err := clockMinutes(lit.v); err != nil {
+				return err
+			}
+		}
+	}
+	return checkZoneArg(2)(args)
+}
+
+// clockMinutes parses "HH:MM" into minutes after midnight.
+func clockMinutes(v any) (int, error) {
+	s, _ := v.(string)
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("time %q must be HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func localNow(env *Env, args []any, zoneArg int) (time.Time, error) {
+	if zoneArg >= len(args) {
+		return env.Now.UTC(), nil
+	}
+	name, _ := args[zoneArg].(string)
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unknown time zone %q", name)
+	}
+	return env.Now.In(loc), nil
+}
@@ -0,0 +1,138 @@
+// Package expr is the small boolean expression language used by governance
+// policy conditions and trigger rule conditions:
+//
+//	amount > 50 && payload.customer.tier in ["gold", "platinum"]
+//	not time_between("09:00", "17:00", "Europe/Berlin") || role == "oncall"
+//
+// Expressions are compiled once with Compile and evaluated many times against
+// an Env. Compile reports the line and column of the first problem.
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string // operator or identifier text; unquoted value for strings
+	pos  int
+}
+
+// keywords are identifiers with operator meaning.
+var keywords = map[string]bool{
+	"and": true, "or": true, "not": true, "in": true,
+	"contains": true, "matches": true,
+	"true": true, "false": true, "null": true,
+}
+
+// SyntaxError reports an expression that failed to compile.
+type SyntaxError struct {
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Msg    string `json:"message"`
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+func syntaxError(src string, pos int, format string, args ...any) *SyntaxError {
+	if pos > len(src) {
+		pos = len(src)
+	}
+	line := 1 + strings.Count(src[:pos], "\n")
+	col := pos - strings.LastIndex(src[:pos], "\n")
+	return &SyntaxError{Line: line, Column: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '_' || unicode.IsLetter(c):
+			start := i
+			for i < len(src) && (src[i] == '_' || unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[start:i], pos: start})
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(src) && (unicode.IsDigit(rune(src[i])) || src[i] == '.') {
+				// "items.0.name": a dot followed by a letter ends the number.
+				if src[i] == '.' && (i+1 >= len(src) || !unicode.IsDigit(rune(src[i+1]))) {
+					break
+				}
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[start:i], pos: start})
+		case c == '"' || c == '\'':
+			text, next, err := lexString(src, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokString, text: text, pos: i})
+			i = next
+		default:
+			op := lexOperator(src[i:])
+			if op == "" {
+				return nil, syntaxError(src, i, "unexpected character %q", c)
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+func lexString(src string, start int) (string, int, error) {
+	quote := src[start]
+	var sb strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case quote:
+			return sb.String(), i + 1, nil
+		case '\\':
+			if i+1 < len(src) {
+				i++
+				switch src[i] {
+				case 'n':
+					sb.WriteByte('\n')
+				case 't':
+					sb.WriteByte('\t')
+				default:
+					sb.WriteByte(src[i])
+				}
+			}
+		default:
+			sb.WriteByte(src[i])
+		}
+	}
+	return "", 0, syntaxError(src, start, "unterminated string")
+}
+
+func lexOperator(s string) string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "&&", "||"} {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	if strings.ContainsRune("<>!()[],.-", rune(s[0])) {
+		return s[:1]
+	}
+	return ""
+}
@@ -0,0 +1,284 @@
+package expr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Program is a compiled expression. The zero value and nil both evaluate to
+// true, matching an empty condition.
+type Program struct {
+	src  string
+	root node
+}
+
+// Compile parses and validates src. Regular expressions, time literals and
+// time zones are checked here so evaluation never fails on them.
+func Compile(src string) (*Program, error) {
+	if strings.TrimSpace(src) == "" {
+		return &Program{src: src}, nil
+	}
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorAt(tok, "unexpected %s", describe(tok))
+	}
+	if lit, ok := root.(literalNode); ok {
+		if _, isBool := lit.v.(bool); !isBool {
+			return nil, syntaxError(src, 0, "condition must be a boolean expression")
+		}
+	}
+	if _, ok := root.(listNode); ok {
+		return nil, syntaxError(src, 0, "condition must be a boolean expression")
+	}
+	return &Program{src: src, root: root}, nil
+}
+
+// String returns the source the program was compiled from.
+func (p *Program) String() string {
+	if p == nil {
+		return ""
+	}
+	return p.src
+}
+
+type parser struct {
+	src    string
+	tokens []token
+	i      int
+}
+
+func (p *parser) peek() token { return p.tokens[p.i] }
+
+func (p *parser) next() token {
+	tok := p.tokens[p.i]
+	if tok.kind != tokEOF {
+		p.i++
+	}
+	return tok
+}
+
+func (p *parser) is(tok token, texts ...string) bool {
+	if tok.kind != tokOp && tok.kind != tokIdent {
+		return false
+	}
+	for _, t := range texts {
+		if tok.text == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) errorAt(tok token, format string, args ...any) error {
+	return syntaxError(p.src, tok.pos, format, args...)
+}
+
+func (p *parser) expect(text string) error {
+	if tok := p.next(); !p.is(tok, text) {
+		return p.errorAt(tok, "expected %q, found %s", text, describe(tok))
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.is(p.peek(), "||", "or") {
+		p.next()
+		var right node
+		if right, err = p.parseAnd(); err == nil {
+			left = logicalNode{and: false, left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	for err == nil && p.is(p.peek(), "&&", "and") {
+		p.next()
+		var right node
+		if right, err = p.parseNot(); err == nil {
+			left = logicalNode{and: true, left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.is(p.peek(), "!", "not") {
+		p.next()
+		x, err := p.parseNot()
+		return notNode{x: x}, err
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	op := tok.text
+	switch {
+	case p.is(tok, "==", "!=", "<", "<=", ">", ">=", "in", "contains", "matches"):
+		p.next()
+	case p.is(tok, "not") && p.is(p.tokens[p.i+1], "in"):
+		p.i += 2
+		op = "not in"
+	default:
+		return left, nil
+	}
+	rhsTok := p.peek()
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	cmp := compareNode{op: op, left: left, right: right}
+	if op == "matches" {
+		if lit, ok := right.(literalNode); ok {
+			pattern, isString := lit.v.(string)
+			if !isString {
+				return nil, p.errorAt(rhsTok, "matches needs a string pattern")
+			}
+			if cmp.re, err = regexp.Compile(pattern); err != nil {
+				return nil, p.errorAt(rhsTok, "invalid pattern: %v", err)
+			}
+		}
+	}
+	return cmp, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch {
+	case tok.kind == tokNumber:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorAt(tok, "invalid number %q", tok.text)
+		}
+		return literalNode{v: v}, nil
+	case tok.kind == tokString:
+		return literalNode{v: tok.text}, nil
+	case p.is(tok, "-") && p.peek().kind == tokNumber:
+		n, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return literalNode{v: -n.(literalNode).v.(float64)}, nil
+	case p.is(tok, "("):
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return x, p.expect(")")
+	case p.is(tok, "["):
+		return p.parseList()
+	case tok.kind == tokIdent:
+		return p.parseIdent(tok)
+	}
+	return nil, p.errorAt(tok, "unexpected %s", describe(tok))
+}
+
+func (p *parser) parseList() (node, error) {
+	list := listNode{}
+	if p.is(p.peek(), "]") {
+		p.next()
+		return list, nil
+	}
+	for {
+		item, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		list.items = append(list.items, item)
+		tok := p.next()
+		if p.is(tok, "]") {
+			return list, nil
+		}
+		if !p.is(tok, ",") {
+			return nil, p.errorAt(tok, "expected \",\" or \"]\", found %s", describe(tok))
+		}
+	}
+}
+
+func (p *parser) parseIdent(tok token) (node, error) {
+	switch tok.text {
+	case "true":
+		return literalNode{v: true}, nil
+	case "false":
+		return literalNode{v: false}, nil
+	case "null":
+		return literalNode{v: nil}, nil
+	}
+	if keywords[tok.text] {
+		return nil, p.errorAt(tok, "unexpected %s", describe(tok))
+	}
+	if p.is(p.peek(), "(") {
+		return p.parseCall(tok)
+	}
+	path := pathNode{segments: []string{tok.text}}
+	for p.is(p.peek(), ".") {
+		p.next()
+		seg := p.next()
+		switch seg.kind {
+		case tokIdent:
+			path.segments = append(path.segments, seg.text)
+		case tokNumber:
+			path.segments = append(path.segments, strings.Split(seg.text, ".")...)
+		default:
+			return nil, p.errorAt(seg, "expected field name after \".\", found %s", describe(seg))
+		}
+	}
+	return path, nil
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, p.errorAt(name, "unknown function %q", name.text)
+	}
+	p.next() // "("
+	call := callNode{name: name.text, fn: fn}
+	for !p.is(p.peek(), ")") {
+		if len(call.args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+	}
+	p.next() // ")"
+	if len(call.args) < fn.minArgs || len(call.args) > fn.maxArgs {
+		return nil, p.errorAt(name, "%s takes %s", name.text, fn.arity())
+	}
+	if fn.check != nil {
+		if err := fn.check(call.args); err != nil {
+			return nil, p.errorAt(name, "%s: %v", name.text, err)
+		}
+	}
+	return call, nil
+}
+
+func describe(tok token) string {
+	switch tok.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(tok.text)
+	}
+	return "\"" + tok.text + "\""
+}
@@ -0,0 +1,36 @@
+package expr
+
+// References reports whether the program reads root or any path below it,
+// so callers can skip loading data no expression needs.
+func (p *Program) References(root string) bool {
+	if p == nil || p.root == nil {
+		return false
+	}
+	return references(p.root, root)
+}
+
+func references(n node, root string) bool {
+	switch x := n.(type) {
+	case pathNode:
+		return x.segments[0] == root
+	case listNode:
+		for _, item := range x.items {
+			if references(item, root) {
+				return true
+			}
+		}
+	case notNode:
+		return references(x.x, root)
+	case logicalNode:
+		return references(x.left, root) || references(x.right, root)
+	case compareNode:
+		return references(x.left, root) || references(x.right, root)
+	case callNode:
+		for _, arg := range x.args {
+			if references(arg, root) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package expr
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// normalize maps Go numeric and slice types onto float64 and []any.
+func normalize(v any) any {
+	switch x := v.(type) {
+	case nil, bool, string, float64, []any, map[string]any:
+		return v
+	case int:
+		return float64(x)
+	case int32:
+		return float64(x)
+	case int64:
+		return float64(x)
+	case float32:
+		return float64(x)
+	case []string:
+		out := make([]any, len(x))
+		for i, s := range x {
+			out[i] = s
+		}
+		return out
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Uint64 {
+		f, _ := strconv.ParseFloat(fmt.Sprint(v), 64)
+		return f
+	}
+	return v
+}
+
+func truthy(v any) bool {
+	switch x := normalize(v).(type) {
+	case nil:
+		return false
+	case bool:
+		return x
+	case float64:
+		return x != 0
+	case string:
+		return x != ""
+	case []any:
+		return len(x) > 0
+	case map[string]any:
+		return len(x) > 0
+	}
+	return true
+}
+
+// number reads v as a number, accepting numeric strings.
+func number(v any) (float64, bool) {
+	switch x := normalize(v).(type) {
+	case float64:
+		return x, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(x), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func equal(l, r any) bool {
+	l, r = normalize(l), normalize(r)
+	if l == nil || r == nil {
+		return l == nil && r == nil
+	}
+	_, lNum := l.(float64)
+	_, rNum := r.(float64)
+	if lNum || rNum {
+		a, okA := number(l)
+		b, okB := number(r)
+		return okA && okB && a == b
+	}
+	return reflect.DeepEqual(l, r)
+}
+
+// order compares two numbers or two strings.
+func order(l, r any) (int, bool) {
+	l, r = normalize(l), normalize(r)
+	if ls, ok := l.(string); ok {
+		if rs, ok := r.(string); ok {
+			return strings.Compare(ls, rs), true
+		}
+	}
+	a, okA := number(l)
+	b, okB := number(r)
+	if !okA || !okB {
+		return 0, false
+	}
+	switch {
+	case a < b:
+		return -1, true
+	case a > b:
+		return 1, true
+	}
+	return 0, true
+}
+
+// member reports whether needle is an element of a list, a substring of a
+// string, or a key of a map.
+func member(needle, haystack any) bool {
+	switch h := normalize(haystack).(type) {
+	case []any:
+		for _, item := range h {
+			if equal(needle, item) {
+				return true
+			}
+		}
+	case string:
+		s, ok := needle.(string)
+		return ok && strings.Contains(h, s)
+	case map[string]any:
+		s, ok := needle.(string)
+		if ok {
+			_, found := h[s]
+			return found
+		}
+	}
+	return false
+}
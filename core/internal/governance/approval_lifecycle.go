@@ -0,0 +1,216 @@
+package governance
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/mycelis/core/internal/identity"
+	pb "github.com/mycelis/core/pkg/pb/swarm"
+)
+
+// ErrApproverNotEligible is returned when a vote does not count toward a
+// request: wrong role, or the approver already voted.
+var ErrApproverNotEligible = errors.New("approver not eligible")
+
+// approvalWriteTimeout bounds store and audit writes made from Guard calls,
+// which have no caller context.
+const approvalWriteTimeout = 5 * time.Second
+
+// ApprovalStore persists approval state so pending requests survive a
+// restart. Implemented by ApprovalRepository.
+type ApprovalStore interface {
+	SaveApproval(ctx context.Context, a Approval) error
+	ListPendingApprovals(ctx context.Context) ([]Approval, error)
+	LoadApproval(ctx context.Context, id string) (Approval, error)
+}
+
+// AuditLog records approval lifecycle events. Implemented by identity.Store.
+type AuditLog interface {
+	RecordAuditEvent(ctx context.Context, event identity.AuditEvent) (*identity.AuditEvent, error)
+}
+
+// SetApprovalStore attaches durable storage and reloads the requests that
+// were pending when the core last stopped.
+func (g *Guard) SetApprovalStore(ctx context.Context, store ApprovalStore) error {
+	g.mu.Lock()
+	g.store = store
+	g.mu.Unlock()
+	if store == nil {
+		return nil
+	}
+
+	pending, err := store.ListPendingApprovals(ctx)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	if g.approvals == nil {
+		g.approvals = make(map[string]*Approval)
+	}
+	if g.PendingBuffer == nil {
+		g.PendingBuffer = make(map[string]*pb.ApprovalRequest)
+	}
+	// Core-originated requests come back without their callbacks; their
+	// subsystems reattach them through ReclaimApproval, and any left
+	// unclaimed expire on their deadline.
+	for i := range pending {
+		a := pending[i]
+		g.approvals[a.ID] = &a
+		g.PendingBuffer[a.ID] = a.request()
+	}
+	g.mu.Unlock()
+
+	log.Printf("Governance: restored %d pending approval(s)", len(pending))
+	return nil
+}
+
+// ReclaimApproval reattaches onResolve to a request raised through
+// RequestApproval before a restart. It returns ApprovalPending when the
+// callback was attached, the stored outcome when the request already
+// settled, or "" when it is unknown.
+func (g *Guard) ReclaimApproval(ctx context.Context, reqID string, onResolve func(approved bool)) string {
+	g.mu.Lock()
+	if g.approvalLocked(reqID) != nil {
+		if g.onResolve == nil {
+			g.onResolve = make(map[string]func(bool))
+		}
+		g.onResolve[reqID] = onResolve
+		g.mu.Unlock()
+		return ApprovalPending
+	}
+	store := g.store
+	g.mu.Unlock()
+	if store == nil {
+		return ""
+	}
+	a, err := store.LoadApproval(ctx, reqID)
+	if err != nil {
+		log.Printf("WARN: governance approval %s not reclaimed: %v", reqID, err)
+		return ""
+	}
+	return a.Status
+}
+
+// SetAuditLog attaches the identity audit log.
+func (g *Guard) SetAuditLog(audit AuditLog) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.auditLog = audit
+}
+
+// SweepExpired settles every request whose deadline has passed by now:
+// escalating rules hand the request to EscalateTo once, everything else
+// expires as a denial. Returns the number of requests touched.
+func (g *Guard) SweepExpired(now time.Time) int {
+	type expiry struct {
+		snapshot  Approval
+		callback  func(bool)
+		escalated bool
+	}
+	var due []expiry
+	g.mu.Lock()
+	for id := range g.PendingBuffer {
+		a := g.approvalLocked(id)
+		if a.ExpiresAt.IsZero() || a.ExpiresAt.After(now) {
+			continue
+		}
+		if a.Rule.OnTimeout == OnTimeoutEscalate && a.Rule.EscalateTo != "" && !a.Escalated {
+			a.Escalated = true
+			a.RequiredRole = a.Rule.EscalateTo
+			a.Quorum = a.Rule.EscalationQuorum
+			a.Decisions = nil
+			a.ExpiresAt = now.Add(a.Rule.timeout())
+			g.PendingBuffer[id] = a.request()
+			due = append(due, expiry{snapshot: a.clone(), escalated: true})
+			continue
+		}
+		a.Status = ApprovalExpired
+		_, callback, snapshot := g.settleLocked(a, now)
+		due = append(due, expiry{snapshot: snapshot, callback: callback})
+	}
+	g.mu.Unlock()
+
+	for _, e := range due {
+		g.persist(e.snapshot)
+		if e.escalated {
+			g.audit(e.snapshot, "governance.approval.escalated", "", map[string]any{"escalate_to": e.snapshot.RequiredRole})
+			log.Printf("ESCALATED: Request %s to role %s", e.snapshot.ID, e.snapshot.RequiredRole)
+			continue
+		}
+		g.audit(e.snapshot, "governance.approval.expired", "", nil)
+		log.Printf("EXPIRED: Request %s", e.snapshot.ID)
+		if e.callback != nil {
+			e.callback(false)
+		}
+	}
+	return len(due)
+}
+
+// StartExpirySweeper runs SweepExpired every interval until ctx is done.
+func (g *Guard) StartExpirySweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				g.SweepExpired(now.UTC())
+			}
+		}
+	}()
+}
+
+func (g *Guard) persist(a Approval) {
+	g.mu.RLock()
+	store := g.store
+	g.mu.RUnlock()
+	if store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), approvalWriteTimeout)
+	defer cancel()
+	if err := store.SaveApproval(ctx, a); err != nil {
+		log.Printf("WARN: governance approval %s not persisted: %v", a.ID, err)
+	}
+}
+
+func (g *Guard) audit(a Approval, eventType, actor string, extra map[string]any) {
+	g.mu.RLock()
+	auditLog := g.auditLog
+	g.mu.RUnlock()
+	if auditLog == nil {
+		return
+	}
+	payload := map[string]any{
+		"status":    a.Status,
+		"reason":    a.Reason,
+		"quorum":    a.Quorum,
+		"approvals": a.Approvals(),
+		"role":      a.RequiredRole,
+		"actor":     actor, // operators need not be identity users, so not actor_user_id
+	}
+	if a.Message != nil {
+		payload["team_id"] = a.Message.TeamId
+		payload["source_agent_id"] = a.Message.SourceAgentId
+	}
+	for k, v := range extra {
+		payload[k] = v
+	}
+	event := identity.AuditEvent{
+		EventType:     eventType,
+		TargetKind:    "governance_approval",
+		TargetID:      a.ID,
+		SourceKind:    "governance",
+		SourceChannel: "governance.guard",
+		Payload:       payload,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), approvalWriteTimeout)
+	defer cancel()
+	if _, err := auditLog.RecordAuditEvent(ctx, event); err != nil {
+		log.Printf("WARN: governance audit %s for %s not recorded: %v", eventType, a.ID, err)
+	}
+}
@@ -0,0 +1,122 @@
+package governance
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/mycelis/core/pkg/pb/swarm"
+	"google.golang.org/protobuf/proto"
+)
+
+// ApprovalRepository persists approvals in governance_approvals (migration
+// 053). Implements ApprovalStore; each save writes the whole approval, so
+// pending approvals survive a restart with their decisions so far.
+type ApprovalRepository struct {
+	db *sql.DB
+}
+
+// NewApprovalRepository creates a repository backed by the shared DB. db may
+// be nil (degraded mode).
+func NewApprovalRepository(db *sql.DB) *ApprovalRepository {
+	return &ApprovalRepository{db: db}
+}
+
+// SaveApproval upserts the full state of one approval.
+func (r *ApprovalRepository) SaveApproval(ctx context.Context, a Approval) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("governance: database not available")
+	}
+	var message []byte
+	if a.Message != nil {
+		var err error
+		if message, err = proto.Marshal(a.Message); err != nil {
+			return fmt.Errorf("governance: encode message: %w", err)
+		}
+	}
+	rule, _ := json.Marshal(a.Rule)
+	decisions, _ := json.Marshal(a.Decisions)
+	if a.Decisions == nil {
+		decisions = []byte("[]")
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO governance_approvals
+			(id, reason, original_message, status, rule, required_role, quorum,
+			 escalated, decisions, core_origin, created_at, expires_at, resolved_at)
+		VALUES ($1, $2, $3, $4, $5::jsonb, $6, $7, $8, $9::jsonb, $10, $11, $12, $13)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			required_role = EXCLUDED.required_role,
+			quorum = EXCLUDED.quorum,
+			escalated = EXCLUDED.escalated,
+			decisions = EXCLUDED.decisions,
+			expires_at = EXCLUDED.expires_at,
+			resolved_at = EXCLUDED.resolved_at
+	`, a.ID, a.Reason, message, a.Status, string(rule), a.RequiredRole, a.Quorum,
+		a.Escalated, string(decisions), a.CoreOrigin, a.CreatedAt, a.ExpiresAt, a.ResolvedAt)
+	if err != nil {
+		return fmt.Errorf("governance: persist approval failed: %w", err)
+	}
+	return nil
+}
+
+// ListPendingApprovals returns every approval still awaiting a decision.
+func (r *ApprovalRepository) ListPendingApprovals(ctx context.Context) ([]Approval, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("governance: database not available")
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+approvalColumns+`
+		FROM governance_approvals
+		WHERE status = 'pending'
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("governance: list approvals failed: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Approval
+	for rows.Next() {
+		a, err := scanApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// LoadApproval returns one approval in any status.
+func (r *ApprovalRepository) LoadApproval(ctx context.Context, id string) (Approval, error) {
+	if r == nil || r.db == nil {
+		return Approval{}, fmt.Errorf("governance: database not available")
+	}
+	row := r.db.QueryRowContext(ctx, `SELECT `+approvalColumns+` FROM governance_approvals WHERE id = $1`, id)
+	return scanApproval(row)
+}
+
+const approvalColumns = `id, reason, original_message, status, rule::text, required_role, quorum,
+		       escalated, decisions::text, core_origin, created_at, expires_at`
+
+func scanApproval(row interface{ Scan(...any) error }) (Approval, error) {
+	var a Approval
+	var message []byte
+	var rule, decisions string
+	if err := row.Scan(&a.ID, &a.Reason, &message, &a.Status, &rule, &a.RequiredRole, &a.Quorum,
+		&a.Escalated, &decisions, &a.CoreOrigin, &a.CreatedAt, &a.ExpiresAt); err != nil {
+		return Approval{}, fmt.Errorf("governance: scan approval: %w", err)
+	}
+	if len(message) > 0 {
+		a.Message = &pb.MsgEnvelope{}
+		if err := proto.Unmarshal(message, a.Message); err != nil {
+			return Approval{}, fmt.Errorf("governance: decode message for %s: %w", a.ID, err)
+		}
+	}
+	_ = json.Unmarshal([]byte(rule), &a.Rule)
+	_ = json.Unmarshal([]byte(decisions), &a.Decisions)
+	a.Rule = a.Rule.withDefaults()
+	return a, nil
+}
@@ -0,0 +1,70 @@
+package governance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/mycelis/core/pkg/pb/swarm"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestApprovalRepository_RoundTrip(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	repo := NewApprovalRepository(db)
+
+	now := time.Now().UTC()
+	msg := &pb.MsgEnvelope{Id: "msg-1", TeamId: "finance"}
+	a := Approval{
+		ID: "req-1", Reason: "Policy Triggered", Message: msg, Status: ApprovalPending,
+		Rule: ApprovalRule{Role: "finance", Quorum: 2}.withDefaults(), RequiredRole: "finance", Quorum: 2,
+		CreatedAt: now, ExpiresAt: now.Add(time.Hour),
+	}
+	encoded, _ := proto.Marshal(msg)
+
+	mock.ExpectExec("INSERT INTO governance_approvals").
+		WithArgs("req-1", "Policy Triggered", encoded, ApprovalPending, sqlmock.AnyArg(), "finance", 2,
+			false, "[]", false, now, now.Add(time.Hour), nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := repo.SaveApproval(context.Background(), a); err != nil {
+		t.Fatalf("SaveApproval: %v", err)
+	}
+
+	mock.ExpectQuery("FROM governance_approvals").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "reason", "original_message", "status", "rule",
+			"required_role", "quorum", "escalated", "decisions", "core_origin", "created_at", "expires_at"}).
+			AddRow("req-1", "Policy Triggered", encoded, ApprovalPending, `{"role":"finance","quorum":2}`,
+				"finance", 2, false, `[{"approver":"alice","approved":true,"at":"2026-01-01T00:00:00Z"}]`,
+				false, now, now.Add(time.Hour)))
+	pending, err := repo.ListPendingApprovals(context.Background())
+	if err != nil {
+		t.Fatalf("ListPendingApprovals: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Message.GetTeamId() != "finance" || pending[0].Approvals() != 1 ||
+		pending[0].Rule.TimeoutSeconds != int(DefaultApprovalTimeout/time.Second) {
+		t.Fatalf("unexpected restored approvals: %+v", pending)
+	}
+
+	mock.ExpectQuery(`FROM governance_approvals WHERE id = \$1`).WithArgs("req-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "reason", "original_message", "status", "rule",
+			"required_role", "quorum", "escalated", "decisions", "core_origin", "created_at", "expires_at"}).
+			AddRow("req-1", "Budget exceeded", nil, ApprovalDenied, `{}`, "", 1, false, `[]`, true, now, now.Add(time.Hour)))
+	loaded, err := repo.LoadApproval(context.Background(), "req-1")
+	if err != nil || loaded.Status != ApprovalDenied || !loaded.CoreOrigin || loaded.Message != nil {
+		t.Fatalf("LoadApproval = %+v, %v", loaded, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestApprovalRepository_NilDB(t *testing.T) {
+	if err := NewApprovalRepository(nil).SaveApproval(context.Background(), Approval{}); err == nil {
+		t.Fatal("expected error without a database")
+	}
+}
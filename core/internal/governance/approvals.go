@@ -0,0 +1,263 @@
+package governance
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/mycelis/core/pkg/pb/swarm"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Approval statuses.
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalDenied   = "denied"
+	ApprovalExpired  = "expired"
+)
+
+// Timeout behaviours for ApprovalRule.OnTimeout.
+const (
+	OnTimeoutExpire   = "expire"
+	OnTimeoutEscalate = "escalate"
+)
+
+// DefaultApprovalTimeout is how long an approval waits for its quorum.
+const DefaultApprovalTimeout = time.Hour
+
+// ApprovalRule decides who settles a REQUIRE_APPROVAL request and what happens
+// when nobody does in time:
+//
+//	approval:
+//	  role: finance-lead       # approvers must hold this role ("" = any)
+//	  quorum: 2                # distinct approvals required (default 1)
+//	  timeout_seconds: 1800    # default 3600
+//	  on_timeout: escalate     # or "expire" (default): deny on timeout
+//	  escalate_to: cfo         # role that takes over after one timeout
+//	  escalation_quorum: 1
+type ApprovalRule struct {
+	Role             string `yaml:"role,omitempty" json:"role,omitempty"`
+	Quorum           int    `yaml:"quorum,omitempty" json:"quorum,omitempty"`
+	TimeoutSeconds   int    `yaml:"timeout_seconds,omitempty" json:"timeout_seconds,omitempty"`
+	OnTimeout        string `yaml:"on_timeout,omitempty" json:"on_timeout,omitempty"`
+	EscalateTo       string `yaml:"escalate_to,omitempty" json:"escalate_to,omitempty"`
+	EscalationQuorum int    `yaml:"escalation_quorum,omitempty" json:"escalation_quorum,omitempty"`
+}
+
+func (r ApprovalRule) withDefaults() ApprovalRule {
+	if r.Quorum <= 0 {
+		r.Quorum = 1
+	}
+	if r.TimeoutSeconds <= 0 {
+		r.TimeoutSeconds = int(DefaultApprovalTimeout / time.Second)
+	}
+	if r.OnTimeout == "" {
+		r.OnTimeout = OnTimeoutExpire
+	}
+	if r.EscalationQuorum <= 0 {
+		r.EscalationQuorum = 1
+	}
+	return r
+}
+
+func (r ApprovalRule) timeout() time.Duration {
+	return time.Duration(r.TimeoutSeconds) * time.Second
+}
+
+// ApprovalDecision is one approver's vote on a request.
+type ApprovalDecision struct {
+	Approver string    `json:"approver"`
+	Role     string    `json:"role,omitempty"`
+	Approved bool      `json:"approved"`
+	At       time.Time `json:"at"`
+}
+
+// Approval is the durable state of one approval request.
+type Approval struct {
+	ID           string             `json:"id"`
+	Reason       string             `json:"reason"`
+	Message      *pb.MsgEnvelope    `json:"-"`
+	Status       string             `json:"status"`
+	Rule         ApprovalRule       `json:"rule"`
+	RequiredRole string             `json:"required_role,omitempty"`
+	Quorum       int                `json:"quorum"`
+	Escalated    bool               `json:"escalated,omitempty"`
+	Decisions    []ApprovalDecision `json:"decisions,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+	ExpiresAt    time.Time          `json:"expires_at"`
+	ResolvedAt   *time.Time         `json:"resolved_at,omitempty"`
+
+	// CoreOrigin marks requests raised by Core subsystems through
+	// RequestApproval. They are never re-published to the bus; after a
+	// restart the subsystem reattaches its callback with ReclaimApproval.
+	CoreOrigin bool `json:"core_origin,omitempty"`
+}
+
+// Approvals counts distinct approving votes.
+func (a *Approval) Approvals() int {
+	n := 0
+	for _, d := range a.Decisions {
+		if d.Approved {
+			n++
+		}
+	}
+	return n
+}
+
+func (a *Approval) clone() Approval {
+	c := *a
+	c.Decisions = append([]ApprovalDecision(nil), a.Decisions...)
+	if a.Message != nil {
+		c.Message = proto.Clone(a.Message).(*pb.MsgEnvelope)
+	}
+	return c
+}
+
+func (a *Approval) request() *pb.ApprovalRequest {
+	return &pb.ApprovalRequest{
+		RequestId:       a.ID,
+		OriginalMessage: a.Message,
+		Reason:          a.Reason,
+		ExpiresAt:       timestamppb.New(a.ExpiresAt),
+	}
+}
+
+// ApprovalOutcome reports the effect of a decision. Message is set when the
+// request was approved and its intercepted message should be re-published.
+type ApprovalOutcome struct {
+	Status    string          `json:"status"`
+	Approvals int             `json:"approvals"`
+	Quorum    int             `json:"quorum"`
+	Message   *pb.MsgEnvelope `json:"-"`
+}
+
+// Decide records an approver's vote. A denial settles the request at once;
+// approvals settle it once Quorum distinct approvers holding RequiredRole
+// have voted.
+func (g *Guard) Decide(reqID, approver, role string, approved bool) (ApprovalOutcome, error) {
+	now := time.Now().UTC()
+	g.mu.Lock()
+	a := g.approvalLocked(reqID)
+	if a == nil {
+		g.mu.Unlock()
+		return ApprovalOutcome{}, fmt.Errorf("request %s not found", reqID)
+	}
+	if a.RequiredRole != "" && role != a.RequiredRole {
+		g.mu.Unlock()
+		return ApprovalOutcome{}, fmt.Errorf("%w: request %s requires role %q", ErrApproverNotEligible, reqID, a.RequiredRole)
+	}
+	for _, d := range a.Decisions {
+		if d.Approver == approver {
+			g.mu.Unlock()
+			return ApprovalOutcome{}, fmt.Errorf("%w: %s already voted on %s", ErrApproverNotEligible, approver, reqID)
+		}
+	}
+	a.Decisions = append(a.Decisions, ApprovalDecision{Approver: approver, Role: role, Approved: approved, At: now})
+	switch {
+	case !approved:
+		a.Status = ApprovalDenied
+	case a.Approvals() >= a.Quorum:
+		a.Status = ApprovalApproved
+	}
+	outcome, callback, snapshot := g.settleLocked(a, now)
+	g.mu.Unlock()
+
+	g.persist(snapshot)
+	g.audit(snapshot, "governance.approval.decided", approver, map[string]any{"approved": approved, "role": role})
+	if snapshot.Status != ApprovalPending {
+		g.audit(snapshot, "governance.approval."+snapshot.Status, approver, nil)
+		log.Printf("RESOLVED: Request %s %s by %s (%d/%d approvals)", reqID, snapshot.Status, approver, outcome.Approvals, outcome.Quorum)
+	}
+	if callback != nil {
+		callback(snapshot.Status == ApprovalApproved)
+		outcome.Message = nil
+	}
+	return outcome, nil
+}
+
+// Resolve settles a request with a single administrative decision, bypassing
+// its role and quorum rules. Operator-facing paths use Decide.
+func (g *Guard) Resolve(reqID string, approved bool, user string) (*pb.MsgEnvelope, error) {
+	now := time.Now().UTC()
+	g.mu.Lock()
+	a := g.approvalLocked(reqID)
+	if a == nil {
+		g.mu.Unlock()
+		return nil, fmt.Errorf("request %s not found", reqID)
+	}
+	a.Decisions = append(a.Decisions, ApprovalDecision{Approver: user, Approved: approved, At: now})
+	a.Status = ApprovalDenied
+	if approved {
+		a.Status = ApprovalApproved
+	}
+	outcome, callback, snapshot := g.settleLocked(a, now)
+	g.mu.Unlock()
+
+	g.persist(snapshot)
+	g.audit(snapshot, "governance.approval."+snapshot.Status, user, map[string]any{"override": true})
+	if callback != nil {
+		log.Printf("RESOLVED: Request %s approved=%t by %s", reqID, approved, user)
+		callback(approved)
+		return nil, nil
+	}
+	if approved {
+		log.Printf("APPROVED: Request %s MANUALLY APPROVED by %s", reqID, user)
+		return outcome.Message, nil
+	}
+	log.Printf("DENIED: Request %s MANUALLY DENIED by %s", reqID, user)
+	return nil, nil // Nil message means nothing to forward
+}
+
+// GetApproval returns a snapshot of a pending request's approval state.
+func (g *Guard) GetApproval(reqID string) (Approval, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	a := g.approvalLocked(reqID)
+	if a == nil {
+		return Approval{}, false
+	}
+	return a.clone(), true
+}
+
+// approvalLocked returns the state of a pending request, synthesizing a
+// default single-approver state for requests placed directly in
+// PendingBuffer.
+func (g *Guard) approvalLocked(reqID string) *Approval {
+	req, ok := g.PendingBuffer[reqID]
+	if !ok {
+		return nil
+	}
+	if a, ok := g.approvals[reqID]; ok {
+		return a
+	}
+	rule := ApprovalRule{}.withDefaults()
+	a := &Approval{ID: reqID, Reason: req.Reason, Message: req.OriginalMessage, Status: ApprovalPending, Rule: rule, Quorum: rule.Quorum, CreatedAt: time.Now().UTC()}
+	if req.ExpiresAt != nil {
+		a.ExpiresAt = req.ExpiresAt.AsTime()
+	}
+	if g.approvals == nil {
+		g.approvals = make(map[string]*Approval)
+	}
+	g.approvals[reqID] = a
+	return a
+}
+
+// settleLocked drops a request that is no longer pending from the buffer and
+// returns its outcome, callback and a snapshot for persistence.
+func (g *Guard) settleLocked(a *Approval, now time.Time) (ApprovalOutcome, func(bool), Approval) {
+	outcome := ApprovalOutcome{Status: a.Status, Approvals: a.Approvals(), Quorum: a.Quorum}
+	var callback func(bool)
+	if a.Status != ApprovalPending {
+		a.ResolvedAt = &now
+		delete(g.PendingBuffer, a.ID)
+		delete(g.approvals, a.ID)
+		callback = g.onResolve[a.ID]
+		delete(g.onResolve, a.ID)
+		if a.Status == ApprovalApproved && !a.CoreOrigin {
+			outcome.Message = a.Message
+		}
+	}
+	return outcome, callback, a.clone()
+}
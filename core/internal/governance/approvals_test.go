@@ -0,0 +1,221 @@
+package governance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mycelis/core/internal/identity"
+	pb "github.com/mycelis/core/pkg/pb/swarm"
+)
+
+type memoryApprovalStore struct {
+	mu    sync.Mutex
+	saved map[string]Approval
+}
+
+func (s *memoryApprovalStore) SaveApproval(_ context.Context, a Approval) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.saved == nil {
+		s.saved = make(map[string]Approval)
+	}
+	s.saved[a.ID] = a
+	return nil
+}
+
+func (s *memoryApprovalStore) ListPendingApprovals(context.Context) ([]Approval, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Approval
+	for _, a := range s.saved {
+		if a.Status == ApprovalPending {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (s *memoryApprovalStore) LoadApproval(_ context.Context, id string) (Approval, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.saved[id]
+	if !ok {
+		return Approval{}, errors.New("not found")
+	}
+	return a, nil
+}
+
+type recordingAuditLog struct {
+	mu     sync.Mutex
+	events []identity.AuditEvent
+}
+
+func (l *recordingAuditLog) RecordAuditEvent(_ context.Context, e identity.AuditEvent) (*identity.AuditEvent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+	return &e, nil
+}
+
+func (l *recordingAuditLog) types() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]string, 0, len(l.events))
+	for _, e := range l.events {
+		out = append(out, e.EventType)
+	}
+	return out
+}
+
+func approvalGuard(rule *ApprovalRule) (*Guard, *memoryApprovalStore, *recordingAuditLog) {
+	g := &Guard{Engine: &Engine{Config: &PolicyConfig{
+		Groups: []PolicyGroup{{Targets: []string{"*"}, Rules: []PolicyRule{
+			{Intent: "payment", Action: ActionRequireApproval, Approval: rule},
+		}}},
+		Defaults: DefaultConfig{DefaultAction: ActionAllow},
+	}}}
+	store, audit := &memoryApprovalStore{}, &recordingAuditLog{}
+	_ = g.SetApprovalStore(context.Background(), store)
+	g.SetAuditLog(audit)
+	return g, store, audit
+}
+
+func holdPayment(t *testing.T, g *Guard) string {
+	t.Helper()
+	_, action, reqID := g.Intercept(&pb.MsgEnvelope{
+		TeamId:  "finance",
+		Payload: &pb.MsgEnvelope_Event{Event: &pb.EventPayload{EventType: "payment"}},
+	})
+	if action != ActionRequireApproval || reqID == "" {
+		t.Fatalf("expected payment held for approval, got %s", action)
+	}
+	return reqID
+}
+
+func TestDecide_QuorumOfRole(t *testing.T) {
+	g, store, audit := approvalGuard(&ApprovalRule{Role: "finance", Quorum: 2})
+	reqID := holdPayment(t, g)
+
+	if _, err := g.Decide(reqID, "eve", "viewer", true); !errors.Is(err, ErrApproverNotEligible) {
+		t.Fatalf("expected wrong role rejected, got %v", err)
+	}
+	out, err := g.Decide(reqID, "alice", "finance", true)
+	if err != nil || out.Status != ApprovalPending || out.Message != nil {
+		t.Fatalf("expected 1/2 pending, got %+v err=%v", out, err)
+	}
+	if _, err := g.Decide(reqID, "alice", "finance", true); !errors.Is(err, ErrApproverNotEligible) {
+		t.Fatalf("expected duplicate vote rejected, got %v", err)
+	}
+	out, err = g.Decide(reqID, "bob", "finance", true)
+	if err != nil || out.Status != ApprovalApproved || out.Message == nil {
+		t.Fatalf("expected approval with message, got %+v err=%v", out, err)
+	}
+
+	if saved := store.saved[reqID]; saved.Status != ApprovalApproved || len(saved.Decisions) != 2 || saved.ResolvedAt == nil {
+		t.Fatalf("unexpected persisted state: %+v", saved)
+	}
+	want := []string{"governance.approval.requested", "governance.approval.decided",
+		"governance.approval.decided", "governance.approval.approved"}
+	if got := audit.types(); len(got) != len(want) || got[3] != want[3] {
+		t.Fatalf("audit events = %v, want %v", got, want)
+	}
+}
+
+func TestDecide_DenySettlesImmediately(t *testing.T) {
+	g, _, _ := approvalGuard(&ApprovalRule{Quorum: 3})
+	reqID := holdPayment(t, g)
+
+	out, err := g.Decide(reqID, "alice", "", false)
+	if err != nil || out.Status != ApprovalDenied || out.Message != nil {
+		t.Fatalf("expected denial, got %+v err=%v", out, err)
+	}
+	if len(g.ListPending()) != 0 {
+		t.Fatal("denied request should leave the pending buffer")
+	}
+}
+
+func TestSweepExpired_ExpiresAndRunsCallback(t *testing.T) {
+	g, store, audit := approvalGuard(nil)
+	var decisions []bool
+	reqID := g.RequestApproval(nil, "Budget exceeded", func(approved bool) {
+		decisions = append(decisions, approved)
+	})
+
+	if n := g.SweepExpired(time.Now()); n != 0 {
+		t.Fatalf("nothing should expire yet, swept %d", n)
+	}
+	if n := g.SweepExpired(time.Now().Add(2 * DefaultApprovalTimeout)); n != 1 {
+		t.Fatalf("expected 1 expiry, swept %d", n)
+	}
+	if len(decisions) != 1 || decisions[0] {
+		t.Fatalf("expected one denied callback, got %v", decisions)
+	}
+	if store.saved[reqID].Status != ApprovalExpired {
+		t.Fatalf("expected expired status persisted, got %q", store.saved[reqID].Status)
+	}
+	if got := audit.types(); got[len(got)-1] != "governance.approval.expired" {
+		t.Fatalf("expected expiry audited, got %v", got)
+	}
+}
+
+func TestSweepExpired_EscalatesOnce(t *testing.T) {
+	g, _, audit := approvalGuard(&ApprovalRule{
+		Role: "finance", Quorum: 2, TimeoutSeconds: 60,
+		OnTimeout: OnTimeoutEscalate, EscalateTo: "cfo",
+	})
+	reqID := holdPayment(t, g)
+	if _, err := g.Decide(reqID, "alice", "finance", true); err != nil {
+		t.Fatalf("Decide: %v", err)
+	}
+
+	now := time.Now().Add(2 * time.Minute)
+	g.SweepExpired(now)
+	a, ok := g.GetApproval(reqID)
+	if !ok || !a.Escalated || a.RequiredRole != "cfo" || a.Quorum != 1 || len(a.Decisions) != 0 {
+		t.Fatalf("expected escalation to cfo, got %+v", a)
+	}
+	if got := audit.types(); got[len(got)-1] != "governance.approval.escalated" {
+		t.Fatalf("expected escalation audited, got %v", got)
+	}
+
+	g.SweepExpired(now.Add(2 * time.Minute))
+	if _, ok := g.GetApproval(reqID); ok {
+		t.Fatal("escalated request should expire on its second timeout")
+	}
+}
+
+func TestSetApprovalStore_RestoresPending(t *testing.T) {
+	g, store, _ := approvalGuard(&ApprovalRule{Quorum: 2})
+	reqID := holdPayment(t, g)
+	coreID := g.RequestApproval(nil, "Budget exceeded", func(bool) {})
+
+	restarted, _, _ := approvalGuard(nil)
+	if err := restarted.SetApprovalStore(context.Background(), store); err != nil {
+		t.Fatalf("SetApprovalStore: %v", err)
+	}
+	a, ok := restarted.GetApproval(reqID)
+	if !ok || a.Quorum != 2 || a.Message.GetEvent().GetEventType() != "payment" {
+		t.Fatalf("expected bus request restored, got %+v", a)
+	}
+	if _, ok := restarted.GetApproval(coreID); !ok {
+		t.Fatal("core-originated request should stay pending for its subsystem to reclaim")
+	}
+
+	var resolved []bool
+	if status := restarted.ReclaimApproval(context.Background(), coreID, func(approved bool) { resolved = append(resolved, approved) }); status != ApprovalPending {
+		t.Fatalf("ReclaimApproval = %q, want pending", status)
+	}
+	msg, err := restarted.Resolve(coreID, true, "operator")
+	if err != nil || msg != nil || len(resolved) != 1 || !resolved[0] {
+		t.Fatalf("reclaimed request: msg=%v err=%v callbacks=%v", msg, err, resolved)
+	}
+	if status := restarted.ReclaimApproval(context.Background(), coreID, func(bool) {}); status != ApprovalApproved {
+		t.Fatalf("ReclaimApproval after settling = %q, want the stored outcome", status)
+	}
+	if status := restarted.ReclaimApproval(context.Background(), "req-unknown", func(bool) {}); status != "" {
+		t.Fatalf("ReclaimApproval of an unknown request = %q", status)
+	}
+}
@@ -0,0 +1,120 @@
+package governance
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// ErrNoDecisionLog is returned by DecisionHistory when no store is attached.
+var ErrNoDecisionLog = errors.New("governance: decision log not available")
+
+const (
+	decisionBufferSize    = 1024
+	decisionBatchSize     = 100
+	decisionFlushInterval = time.Second
+	decisionPruneInterval = time.Hour
+
+	// DecisionRetention is how long intercept decisions are kept for replay.
+	DecisionRetention = 90 * 24 * time.Hour
+)
+
+// Decision is one Intercept outcome with the message fields its conditions
+// were evaluated against.
+type Decision struct {
+	TeamID       string         `json:"team_id"`
+	AgentID      string         `json:"agent_id"`
+	Intent       string         `json:"intent"`
+	Payload      map[string]any `json:"payload"`
+	SwarmContext map[string]any `json:"context"`
+	Action       string         `json:"action"`
+	RequestID    string         `json:"request_id,omitempty"`
+	DecidedAt    time.Time      `json:"decided_at"`
+}
+
+// DecisionStore persists intercept decisions. Implemented by
+// DecisionRepository.
+type DecisionStore interface {
+	RecordDecisions(ctx context.Context, decisions []Decision) error
+	ListDecisions(ctx context.Context, since time.Time, limit int) ([]Decision, error)
+	PruneDecisions(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SetDecisionLog starts writing intercept decisions to store in batches
+// until ctx is done. Decisions are dropped, not queued, when the writer
+// falls behind so Intercept never blocks on the database.
+func (g *Guard) SetDecisionLog(ctx context.Context, store DecisionStore) {
+	ch := make(chan Decision, decisionBufferSize)
+	g.mu.Lock()
+	g.decisions, g.decisionCh = store, ch
+	g.mu.Unlock()
+	go g.writeDecisions(ctx, store, ch)
+}
+
+// DecisionHistory returns the logged decisions since the given time, newest
+// first.
+func (g *Guard) DecisionHistory(ctx context.Context, since time.Time, limit int) ([]Decision, error) {
+	g.mu.RLock()
+	store := g.decisions
+	g.mu.RUnlock()
+	if store == nil {
+		return nil, ErrNoDecisionLog
+	}
+	return store.ListDecisions(ctx, since, limit)
+}
+
+func (g *Guard) recordDecision(d Decision) {
+	g.mu.RLock()
+	ch := g.decisionCh
+	g.mu.RUnlock()
+	if ch == nil {
+		return
+	}
+	select {
+	case ch <- d:
+	default:
+		if n := g.droppedDecisions.Add(1); n%1000 == 1 {
+			log.Printf("WARN: governance decision log behind; %d decision(s) dropped", n)
+		}
+	}
+}
+
+func (g *Guard) writeDecisions(ctx context.Context, store DecisionStore, ch <-chan Decision) {
+	flushTicker := time.NewTicker(decisionFlushInterval)
+	pruneTicker := time.NewTicker(decisionPruneInterval)
+	defer flushTicker.Stop()
+	defer pruneTicker.Stop()
+
+	batch := make([]Decision, 0, decisionBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		wctx, cancel := context.WithTimeout(context.Background(), approvalWriteTimeout)
+		defer cancel()
+		if err := store.RecordDecisions(wctx, batch); err != nil {
+			log.Printf("WARN: %d governance decision(s) not persisted: %v", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			flush()
+			return
+		case d := <-ch:
+			if batch = append(batch, d); len(batch) >= decisionBatchSize {
+				flush()
+			}
+		case <-flushTicker.C:
+			flush()
+		case now := <-pruneTicker.C:
+			pctx, cancel := context.WithTimeout(ctx, approvalWriteTimeout)
+			if _, err := store.PruneDecisions(pctx, now.Add(-DecisionRetention)); err != nil {
+				log.Printf("WARN: governance decision prune failed: %v", err)
+			}
+			cancel()
+		}
+	}
+}
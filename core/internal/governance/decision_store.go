@@ -0,0 +1,105 @@
+package governance
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DecisionRepository persists intercept decisions in governance_decisions
+// (migration 054). Implements DecisionStore.
+type DecisionRepository struct {
+	db *sql.DB
+}
+
+// NewDecisionRepository creates a repository backed by the shared DB. db may
+// be nil (degraded mode).
+func NewDecisionRepository(db *sql.DB) *DecisionRepository {
+	return &DecisionRepository{db: db}
+}
+
+// RecordDecisions inserts a batch of decisions in one statement.
+func (r *DecisionRepository) RecordDecisions(ctx context.Context, decisions []Decision) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("governance: database not available")
+	}
+	if len(decisions) == 0 {
+		return nil
+	}
+	const cols = 8
+	values := make([]string, 0, len(decisions))
+	args := make([]any, 0, len(decisions)*cols)
+	for i, d := range decisions {
+		payload, _ := json.Marshal(orEmpty(d.Payload))
+		swarmContext, _ := json.Marshal(orEmpty(d.SwarmContext))
+		n := i * cols
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d::jsonb, $%d::jsonb, $%d, $%d, $%d)",
+			n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8))
+		args = append(args, d.TeamID, d.AgentID, d.Intent, string(payload), string(swarmContext),
+			d.Action, d.RequestID, d.DecidedAt)
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO governance_decisions
+			(team_id, agent_id, intent, payload, swarm_context, action, request_id, decided_at)
+		VALUES `+strings.Join(values, ", "), args...)
+	if err != nil {
+		return fmt.Errorf("governance: record decisions failed: %w", err)
+	}
+	return nil
+}
+
+// ListDecisions returns up to limit decisions made at or after since,
+// newest first.
+func (r *DecisionRepository) ListDecisions(ctx context.Context, since time.Time, limit int) ([]Decision, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("governance: database not available")
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT team_id, agent_id, intent, payload::text, swarm_context::text,
+		       action, request_id, decided_at
+		FROM governance_decisions
+		WHERE decided_at >= $1
+		ORDER BY decided_at DESC
+		LIMIT $2
+	`, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("governance: list decisions failed: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Decision
+	for rows.Next() {
+		var d Decision
+		var payload, swarmContext string
+		if err := rows.Scan(&d.TeamID, &d.AgentID, &d.Intent, &payload, &swarmContext,
+			&d.Action, &d.RequestID, &d.DecidedAt); err != nil {
+			return nil, fmt.Errorf("governance: scan decision: %w", err)
+		}
+		_ = json.Unmarshal([]byte(payload), &d.Payload)
+		_ = json.Unmarshal([]byte(swarmContext), &d.SwarmContext)
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// PruneDecisions deletes decisions older than before.
+func (r *DecisionRepository) PruneDecisions(ctx context.Context, before time.Time) (int64, error) {
+	if r == nil || r.db == nil {
+		return 0, fmt.Errorf("governance: database not available")
+	}
+	res, err := r.db.ExecContext(ctx, `DELETE FROM governance_decisions WHERE decided_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("governance: prune decisions failed: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func orEmpty(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
@@ -5,11 +5,10 @@ import (
 	"log"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	pb "github.com/mycelis/core/pkg/pb/swarm"
-	"google.golang.org/protobuf/types/known/structpb"
-	"google.golang.org/protobuf/types/known/timestamppb"
 	"gopkg.in/yaml.v3"
 )
 
@@ -18,6 +17,21 @@ type Guard struct {
 	Engine        *Engine
 	PendingBuffer map[string]*pb.ApprovalRequest
 	mu            sync.RWMutex
+
+	// onResolve holds callbacks for approvals raised by Core subsystems
+	// (e.g. budget enforcement) rather than intercepted bus messages.
+	onResolve map[string]func(approved bool)
+
+	// approvals holds the quorum and expiry state behind PendingBuffer;
+	// store and auditLog make it durable and audited (both optional).
+	approvals map[string]*Approval
+	store     ApprovalStore
+	auditLog  AuditLog
+
+	// decisions logs every Intercept outcome for policy simulation.
+	decisions        DecisionStore
+	decisionCh       chan Decision
+	droppedDecisions atomic.Int64
 }
 
 func NewGuard(policyPath string) (*Guard, error) {
@@ -29,78 +43,138 @@ func NewGuard(policyPath string) (*Guard, error) {
 	return &Guard{
 		Engine:        engine,
 		PendingBuffer: make(map[string]*pb.ApprovalRequest),
+		approvals:     make(map[string]*Approval),
 	}, nil
 }
 
 // Intercept evaluates a message and returns (proceed bool, action string, requestID string)
 func (g *Guard) Intercept(msg *pb.MsgEnvelope) (bool, string, string) {
-	// Extract Context
-	ctx := make(map[string]interface{})
-	if msg.SwarmContext != nil {
-		for k, v := range msg.SwarmContext.Fields {
-			// Basic type mapping for the naive parser
-			if _, ok := v.Kind.(*structpb.Value_NumberValue); ok {
-				ctx[k] = v.GetNumberValue()
-			} else if _, ok := v.Kind.(*structpb.Value_StringValue); ok {
-				ctx[k] = v.GetStringValue()
-			} else if _, ok := v.Kind.(*structpb.Value_BoolValue); ok {
-				ctx[k] = v.GetBoolValue()
-			}
-		}
-	}
-
-	// Also mix in Event Data for context?
-	// For "amount > 50", usually amount is in the event data, not the header context.
-	// The policy engine might want both.
-	// For this loop, let's also pull data from EventPayload if present.
-	if msg.GetEvent() != nil && msg.GetEvent().Data != nil {
-		for k, v := range msg.GetEvent().Data.Fields {
-			if _, ok := v.Kind.(*structpb.Value_NumberValue); ok {
-				ctx[k] = v.GetNumberValue()
-			}
-		}
-	}
-
-	// Extract intent
 	intent := ""
 	if msg.GetEvent() != nil {
 		intent = msg.GetEvent().EventType
 	}
 
-	action := g.Engine.Evaluate(msg.TeamId, msg.SourceAgentId, intent, ctx)
+	ctx := interceptContext(msg)
+	rule, matched := g.Engine.Match(msg.TeamId, msg.SourceAgentId, intent, ctx)
+	action := g.Engine.Config.Defaults.DefaultAction
+	if matched {
+		action = rule.Action
+	}
+	decision := Decision{
+		TeamID: msg.TeamId, AgentID: msg.SourceAgentId, Intent: intent, Action: action,
+		Payload: ctx["payload"].(map[string]interface{}), SwarmContext: ctx["context"].(map[string]interface{}),
+		DecidedAt: time.Now().UTC(),
+	}
 
 	if action == ActionAllow {
+		if intent != "agent.heartbeat" {
+			g.recordDecision(decision)
+		}
 		return true, action, ""
 	}
 
 	if action == ActionDeny {
 		log.Printf("DENY: Guard blocked: %s from %s", intent, msg.SourceAgentId)
+		g.recordDecision(decision)
 		return false, action, ""
 	}
 
 	if action == ActionRequireApproval {
-		reqID := g.createApprovalRequest(msg, "Policy Triggered")
+		reqID := g.createApprovalRequest(msg, "Policy Triggered", g.Engine.approvalRule(rule), nil)
 		log.Printf("HALT: Guard paused: %s. Request ID: %s", intent, reqID)
+		decision.RequestID = reqID
+		g.recordDecision(decision)
 		return false, action, reqID
 	}
 
 	return true, ActionAllow, ""
 }
 
-func (g *Guard) createApprovalRequest(msg *pb.MsgEnvelope, reason string) string {
-	g.mu.Lock()
-	defer g.mu.Unlock()
+// interceptContext is the condition environment of an intercepted message.
+func interceptContext(msg *pb.MsgEnvelope) map[string]interface{} {
+	payload := map[string]interface{}{}
+	if data := msg.GetEvent().GetData(); data != nil {
+		payload = data.AsMap()
+	}
+	swarmContext := map[string]interface{}{}
+	if msg.SwarmContext != nil {
+		swarmContext = msg.SwarmContext.AsMap()
+	}
+	return conditionContext(payload, swarmContext)
+}
+
+// conditionContext lays out a condition environment: event data and swarm
+// context fields at the top level (as the original "amount > 50" conditions
+// expect), the full structures under payload and context, and the sender's
+// role when the swarm context carries one.
+func conditionContext(payload, swarmContext map[string]interface{}) map[string]interface{} {
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+	if swarmContext == nil {
+		swarmContext = map[string]interface{}{}
+	}
+	ctx := make(map[string]interface{}, len(payload)+len(swarmContext)+3)
+	for k, v := range swarmContext {
+		ctx[k] = v
+	}
+	for k, v := range payload {
+		ctx[k] = v
+	}
+	ctx["payload"], ctx["context"] = payload, swarmContext
+	role, _ := swarmContext["role"].(string)
+	if role == "" {
+		role, _ = swarmContext["agent_role"].(string)
+	}
+	ctx["role"] = role
+	return ctx
+}
 
-	reqID := fmt.Sprintf("req-%d", time.Now().UnixNano())
+func (g *Guard) createApprovalRequest(msg *pb.MsgEnvelope, reason string, rule ApprovalRule, onResolve func(bool)) string {
+	now := time.Now().UTC()
+	reqID := fmt.Sprintf("req-%d", now.UnixNano())
+	a := &Approval{
+		ID:           reqID,
+		Reason:       reason,
+		Message:      msg,
+		Status:       ApprovalPending,
+		Rule:         rule,
+		RequiredRole: rule.Role,
+		Quorum:       rule.Quorum,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(rule.timeout()),
+		CoreOrigin:   onResolve != nil,
+	}
 
-	req := &pb.ApprovalRequest{
-		RequestId:       reqID,
-		OriginalMessage: msg,
-		Reason:          reason,
-		ExpiresAt:       timestamppb.New(time.Now().Add(1 * time.Hour)),
+	g.mu.Lock()
+	if g.PendingBuffer == nil {
+		g.PendingBuffer = make(map[string]*pb.ApprovalRequest)
 	}
+	if g.approvals == nil {
+		g.approvals = make(map[string]*Approval)
+	}
+	g.PendingBuffer[reqID] = a.request()
+	g.approvals[reqID] = a
+	if onResolve != nil {
+		if g.onResolve == nil {
+			g.onResolve = make(map[string]func(bool))
+		}
+		g.onResolve[reqID] = onResolve
+	}
+	snapshot := a.clone()
+	g.mu.Unlock()
+
+	g.persist(snapshot)
+	g.audit(snapshot, "governance.approval.requested", msg.GetSourceAgentId(), nil)
+	return reqID
+}
 
-	g.PendingBuffer[reqID] = req
+// RequestApproval parks a Core-originated action behind the same approval
+// queue as intercepted messages. onResolve runs once the request is approved
+// or denied; such requests are never re-published to the bus.
+func (g *Guard) RequestApproval(msg *pb.MsgEnvelope, reason string, onResolve func(approved bool)) string {
+	reqID := g.createApprovalRequest(msg, reason, g.Engine.approvalRule(PolicyRule{}), onResolve)
+	log.Printf("HALT: Guard paused: %s. Request ID: %s", reason, reqID)
 	return reqID
 }
 
@@ -116,27 +190,6 @@ func (g *Guard) ListPending() []*pb.ApprovalRequest {
 	return list
 }
 
-// Resolve manually approves or denies a request
-func (g *Guard) Resolve(reqID string, approved bool, user string) (*pb.MsgEnvelope, error) {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	req, exists := g.PendingBuffer[reqID]
-	if !exists {
-		return nil, fmt.Errorf("request %s not found", reqID)
-	}
-
-	delete(g.PendingBuffer, reqID)
-
-	if approved {
-		log.Printf("APPROVED: Request %s MANUALLY APPROVED by %s", reqID, user)
-		return req.OriginalMessage, nil
-	}
-
-	log.Printf("DENIED: Request %s MANUALLY DENIED by %s", reqID, user)
-	return nil, nil // Nil message means nothing to forward
-}
-
 // ValidateIngress checks raw NATS messages before they enter the Soma processing loop.
 // It enforces size limits and subject allowlists.
 func (g *Guard) ValidateIngress(subject string, data []byte) error {
@@ -49,3 +49,29 @@ func TestValidateIngress(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestApproval_ResolveRunsCallback(t *testing.T) {
+	g := &Guard{}
+
+	var decisions []bool
+	reqID := g.RequestApproval(nil, "Budget exceeded: run run-1", func(approved bool) {
+		decisions = append(decisions, approved)
+	})
+	if len(g.ListPending()) != 1 {
+		t.Fatalf("expected 1 pending request, got %d", len(g.ListPending()))
+	}
+
+	msg, err := g.Resolve(reqID, true, "tester")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if msg != nil {
+		t.Errorf("callback-owned approvals must not be re-published, got %+v", msg)
+	}
+	if len(decisions) != 1 || !decisions[0] {
+		t.Errorf("expected one approved callback, got %v", decisions)
+	}
+	if _, err := g.Resolve(reqID, false, "tester"); err == nil {
+		t.Error("expected resolved request to be gone")
+	}
+}
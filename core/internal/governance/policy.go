@@ -2,10 +2,11 @@ package governance
 
 import (
 	"fmt"
+	"log"
 	"os"
-	"regexp"
-	"strings"
+	"sync"
 
+	"github.com/mycelis/core/internal/expr"
 	"gopkg.in/yaml.v3"
 )
 
@@ -31,20 +32,32 @@ type PolicyGroup struct {
 
 type PolicyRule struct {
 	Intent    string `yaml:"intent" json:"intent"`
-	Condition string `yaml:"condition" json:"condition"` // Simple "key > value" string
+	Condition string `yaml:"condition" json:"condition"` // expr language, e.g. "amount > 50 && role != 'cfo'"
 	Action    string `yaml:"action" json:"action"`
+
+	// Approval tunes how a REQUIRE_APPROVAL match is decided (optional).
+	Approval *ApprovalRule `yaml:"approval,omitempty" json:"approval,omitempty"`
 }
 
 type DefaultConfig struct {
 	DefaultAction string `yaml:"default_action" json:"default_action"`
+
+	// Approval applies to REQUIRE_APPROVAL rules without their own block.
+	Approval *ApprovalRule `yaml:"approval,omitempty" json:"approval,omitempty"`
 }
 
 // Engine handles policy evaluation
 type Engine struct {
 	Config *PolicyConfig
+
+	// compiled caches the regexes and condition programs of Config; it is
+	// rebuilt whenever Config is replaced.
+	mu       sync.Mutex
+	compiled *compiledPolicy
 }
 
-// NewEngine loads the policy from a file
+// NewEngine loads the policy from a file, rejecting rules that do not
+// compile with errors that point at their line in the file.
 func NewEngine(path string) (*Engine, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -55,47 +68,64 @@ func NewEngine(path string) (*Engine, error) {
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse policy yaml: %w", err)
 	}
+	if errs := ValidatePolicy(&config, data); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, errs)
+	}
 
 	return &Engine{Config: &config}, nil
 }
 
 // Evaluate determines the action for a given request
 // simple evaluation: check if target matches group, then check intent, then condition
-// Evaluate determines the action for a given request
 func (e *Engine) Evaluate(teamID, agentID, intent string, context map[string]interface{}) string {
-	// 1. Find matching groups
-	for _, group := range e.Config.Groups {
-		if e.matchesTarget(group.Targets, teamID, agentID) {
-			// 2. Check Rules
-			for _, rule := range group.Rules {
-				// Regex Intent Matching
-				// Cache patterns? For now, re-compile is easier but slow.
-				// Optimization: Compile regex on Load.
-				// Fast path: Exact match or Wildcard
-				matched := false
-				if rule.Intent == "*" || rule.Intent == intent {
-					matched = true
-				} else {
-					// Try RegEx (if it looks like one?)
-					// For safety, treat all as regex if not exact match?
-					// Or assume config provides valid regex.
-					match, err := regexp.MatchString(rule.Intent, intent)
-					if err == nil && match {
-						matched = true
-					}
-				}
-
-				if matched {
-					// 3. Check Condition (Simplified)
-					if e.checkCondition(rule.Condition, context) {
-						return rule.Action
-					}
-				}
+	if rule, ok := e.Match(teamID, agentID, intent, context); ok {
+		return rule.Action
+	}
+	return e.Config.Defaults.DefaultAction
+}
+
+// Match returns the first rule that applies to the request, if any. context
+// is the condition environment; team, agent and intent are bound on top of
+// it.
+func (e *Engine) Match(teamID, agentID, intent string, context map[string]interface{}) (PolicyRule, bool) {
+	vars := make(map[string]any, len(context)+3)
+	for k, v := range context {
+		vars[k] = v
+	}
+	vars["team"], vars["agent"], vars["intent"] = teamID, agentID, intent
+	env := expr.Env{Vars: vars}
+
+	for _, group := range e.policy().groups {
+		if !e.matchesTarget(group.targets, teamID, agentID) {
+			continue
+		}
+		for _, rule := range group.rules {
+			if !rule.matchesIntent(intent) {
+				continue
+			}
+			ok, err := rule.condition.Eval(env)
+			if err != nil {
+				log.Printf("WARN: governance condition %q failed: %v", rule.Condition, err)
+				continue
+			}
+			if ok {
+				return rule.PolicyRule, true
 			}
 		}
 	}
 
-	return e.Config.Defaults.DefaultAction
+	return PolicyRule{}, false
+}
+
+// approvalRule returns the approval settings for a matched rule.
+func (e *Engine) approvalRule(rule PolicyRule) ApprovalRule {
+	if rule.Approval != nil {
+		return rule.Approval.withDefaults()
+	}
+	if e != nil && e.Config != nil && e.Config.Defaults.Approval != nil {
+		return e.Config.Defaults.Approval.withDefaults()
+	}
+	return ApprovalRule{}.withDefaults()
 }
 
 func (e *Engine) matchesTarget(targets []string, teamID, agentID string) bool {
@@ -112,63 +142,3 @@ func (e *Engine) matchesTarget(targets []string, teamID, agentID string) bool {
 	}
 	return false
 }
-
-// checkCondition implements a very basic parser for "key > value"
-// Supports: >, <, ==
-func (e *Engine) checkCondition(condition string, context map[string]interface{}) bool {
-	if condition == "" {
-		return true
-	}
-
-	// Example: "amount > 50"
-	parts := strings.Split(condition, " ")
-	if len(parts) != 3 {
-		return false
-	}
-
-	key := parts[0]
-	op := parts[1]
-	valStr := parts[2]
-
-	// Get context value
-	ctxVal, exists := context[key]
-	if !exists {
-		// If the key is missing from context, we cannot evaluate, assume mismatch (FALSE)
-		return false
-	}
-
-	// Naive float implementation for demo
-	// Convert ctxVal to float64
-	var v1 float64
-	switch v := ctxVal.(type) {
-	case float64:
-		v1 = v
-	case int:
-		v1 = float64(v)
-	case int64:
-		v1 = float64(v)
-	default:
-		return false // Not a number
-	}
-
-	// Parse target value
-	var v2 float64
-	if _, err := fmt.Sscanf(valStr, "%f", &v2); err != nil {
-		return false
-	}
-
-	switch op {
-	case ">":
-		return v1 > v2
-	case "<":
-		return v1 < v2
-	case "==":
-		return v1 == v2
-	case ">=":
-		return v1 >= v2
-	case "<=":
-		return v1 <= v2
-	}
-
-	return false
-}
@@ -0,0 +1,236 @@
+package governance
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/mycelis/core/internal/expr"
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyError is one rule that failed validation. Line and Column point into
+// the policy source when it was available (YAML file or JSON request body).
+type PolicyError struct {
+	Path    string `json:"path"` // e.g. groups[1].rules[0].condition
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+
+	// position of the problem inside a condition expression
+	exprLine, exprColumn int
+}
+
+func (e PolicyError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// PolicyErrors lists every problem found in a policy.
+type PolicyErrors []PolicyError
+
+func (errs PolicyErrors) Error() string {
+	parts := make([]string, len(errs))
+	for i, e := range errs {
+		parts[i] = e.Error()
+	}
+	return strings.Join(parts, "; ")
+}
+
+type compiledPolicy struct {
+	source *PolicyConfig
+	groups []compiledGroup
+}
+
+type compiledGroup struct {
+	targets []string
+	rules   []compiledRule
+}
+
+type compiledRule struct {
+	PolicyRule
+	intent    *regexp.Regexp // nil for "*" and for exact-only intents
+	condition *expr.Program
+}
+
+func (r compiledRule) matchesIntent(intent string) bool {
+	return r.Intent == "*" || r.Intent == intent || (r.intent != nil && r.intent.MatchString(intent))
+}
+
+// policy returns the compiled form of the current Config. Configs assigned
+// without validation are compiled here; their invalid rules never match.
+func (e *Engine) policy() *compiledPolicy {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.compiled == nil || e.compiled.source != e.Config {
+		compiled, errs := compilePolicy(e.Config)
+		for _, err := range errs {
+			log.Printf("WARN: governance rule skipped: %v", err)
+		}
+		e.compiled = compiled
+	}
+	return e.compiled
+}
+
+// ValidatePolicy compiles every rule of cfg. When src (the YAML or JSON the
+// config was decoded from) is given, errors carry line and column numbers.
+func ValidatePolicy(cfg *PolicyConfig, src []byte) PolicyErrors {
+	_, errs := compilePolicy(cfg)
+	if len(errs) > 0 && len(src) > 0 {
+		locatePolicyErrors(src, errs)
+	}
+	return errs
+}
+
+func compilePolicy(cfg *PolicyConfig) (*compiledPolicy, PolicyErrors) {
+	compiled := &compiledPolicy{source: cfg}
+	if cfg == nil {
+		return compiled, nil
+	}
+	var errs PolicyErrors
+	if !validAction(cfg.Defaults.DefaultAction) {
+		errs = append(errs, PolicyError{Path: "defaults.default_action", Message: fmt.Sprintf("unknown action %q", cfg.Defaults.DefaultAction)})
+	}
+	if cfg.Defaults.Approval != nil {
+		errs = append(errs, approvalErrors("defaults.approval", *cfg.Defaults.Approval)...)
+	}
+	for gi, group := range cfg.Groups {
+		cg := compiledGroup{targets: group.Targets}
+		for ri, rule := range group.Rules {
+			path := fmt.Sprintf("groups[%d].rules[%d]", gi, ri)
+			cr, ruleErrs := compileRule(path, rule)
+			if len(ruleErrs) > 0 {
+				errs = append(errs, ruleErrs...)
+				continue
+			}
+			cg.rules = append(cg.rules, cr)
+		}
+		compiled.groups = append(compiled.groups, cg)
+	}
+	return compiled, errs
+}
+
+func compileRule(path string, rule PolicyRule) (compiledRule, PolicyErrors) {
+	cr := compiledRule{PolicyRule: rule}
+	var errs PolicyErrors
+	switch {
+	case rule.Intent == "":
+		errs = append(errs, PolicyError{Path: path + ".intent", Message: "intent is required"})
+	case rule.Intent != "*":
+		// Intents match exactly or as an unanchored regular expression.
+		re, err := regexp.Compile(rule.Intent)
+		if err != nil {
+			errs = append(errs, PolicyError{Path: path + ".intent", Message: fmt.Sprintf("invalid pattern: %v", err)})
+		}
+		cr.intent = re
+	}
+	if !validAction(rule.Action) {
+		errs = append(errs, PolicyError{Path: path + ".action", Message: fmt.Sprintf("unknown action %q", rule.Action)})
+	}
+	program, err := expr.Compile(rule.Condition)
+	if err != nil {
+		pe := PolicyError{Path: path + ".condition", Message: err.Error()}
+		var se *expr.SyntaxError
+		if errors.As(err, &se) {
+			pe.Message, pe.exprLine, pe.exprColumn = se.Msg, se.Line, se.Column
+		}
+		errs = append(errs, pe)
+	}
+	cr.condition = program
+	if rule.Approval != nil {
+		errs = append(errs, approvalErrors(path+".approval", *rule.Approval)...)
+	}
+	return cr, errs
+}
+
+func validAction(action string) bool {
+	return action == ActionAllow || action == ActionDeny || action == ActionRequireApproval
+}
+
+func approvalErrors(path string, rule ApprovalRule) PolicyErrors {
+	var errs PolicyErrors
+	switch rule.OnTimeout {
+	case "", OnTimeoutExpire:
+	case OnTimeoutEscalate:
+		if rule.EscalateTo == "" {
+			errs = append(errs, PolicyError{Path: path + ".escalate_to", Message: "required when on_timeout is escalate"})
+		}
+	default:
+		errs = append(errs, PolicyError{Path: path + ".on_timeout", Message: fmt.Sprintf("unknown value %q", rule.OnTimeout)})
+	}
+	if rule.Quorum < 0 || rule.EscalationQuorum < 0 || rule.TimeoutSeconds < 0 {
+		errs = append(errs, PolicyError{Path: path, Message: "quorum and timeouts cannot be negative"})
+	}
+	return errs
+}
+
+// locatePolicyErrors resolves each error's path to a line and column in src.
+func locatePolicyErrors(src []byte, errs PolicyErrors) {
+	var doc yaml.Node
+	if yaml.Unmarshal(src, &doc) != nil || len(doc.Content) == 0 {
+		return
+	}
+	for i := range errs {
+		n := findPolicyNode(doc.Content[0], errs[i].Path)
+		for path := errs[i].Path; n == nil && strings.Contains(path, "."); {
+			// Missing fields are reported at their rule.
+			path = path[:strings.LastIndexByte(path, '.')]
+			n = findPolicyNode(doc.Content[0], path)
+			errs[i].exprLine = 0
+		}
+		if n == nil {
+			continue
+		}
+		errs[i].Line, errs[i].Column = n.Line, n.Column
+		if errs[i].exprLine == 0 {
+			continue
+		}
+		errs[i].Line += errs[i].exprLine - 1
+		if errs[i].exprLine > 1 {
+			errs[i].Column = errs[i].exprColumn
+			continue
+		}
+		errs[i].Column += errs[i].exprColumn - 1
+		if n.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+			errs[i].Column++ // skip the opening quote
+		}
+	}
+}
+
+// findPolicyNode walks a path like groups[1].rules[0].condition.
+func findPolicyNode(n *yaml.Node, path string) *yaml.Node {
+	for _, part := range strings.Split(path, ".") {
+		key, index := part, -1
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			key = part[:open]
+			fmt.Sscanf(part[open:], "[%d]", &index)
+		}
+		n = mappingValue(n, key)
+		if n != nil && index >= 0 {
+			if n.Kind != yaml.SequenceNode || index >= len(n.Content) {
+				return nil
+			}
+			n = n.Content[index]
+		}
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package governance
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/mycelis/core/pkg/pb/swarm"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestIntercept_ExpressionConditions(t *testing.T) {
+	g := &Guard{Engine: &Engine{Config: &PolicyConfig{
+		Groups: []PolicyGroup{{Targets: []string{"*"}, Rules: []PolicyRule{
+			{Intent: "payment\\..*", Condition: `payload.customer.tier in ["gold", "platinum"] && amount <= 10000`, Action: ActionAllow},
+			{Intent: "payment\\..*", Condition: `amount > 50 and role != "cfo"`, Action: ActionRequireApproval},
+			{Intent: "deploy", Condition: `team == "ops" && not time_between("00:00", "23:59")`, Action: ActionDeny},
+		}}},
+		Defaults: DefaultConfig{DefaultAction: ActionAllow},
+	}}}
+
+	payment := func(data map[string]any, context map[string]any) *pb.MsgEnvelope {
+		d, _ := structpb.NewStruct(data)
+		c, _ := structpb.NewStruct(context)
+		return &pb.MsgEnvelope{
+			TeamId:       "finance",
+			SwarmContext: c,
+			Payload:      &pb.MsgEnvelope_Event{Event: &pb.EventPayload{EventType: "payment.create", Data: d}},
+		}
+	}
+
+	tests := []struct {
+		name string
+		msg  *pb.MsgEnvelope
+		want string
+	}{
+		{"gold customer", payment(map[string]any{"amount": 500, "customer": map[string]any{"tier": "gold"}}, nil), ActionAllow},
+		{"large payment", payment(map[string]any{"amount": 500, "customer": map[string]any{"tier": "basic"}}, nil), ActionRequireApproval},
+		{"cfo payment", payment(map[string]any{"amount": 500}, map[string]any{"role": "cfo"}), ActionAllow},
+		{"small payment", payment(map[string]any{"amount": 5}, nil), ActionAllow},
+	}
+	for _, tt := range tests {
+		if _, action, _ := g.Intercept(tt.msg); action != tt.want {
+			t.Errorf("%s: action = %s, want %s", tt.name, action, tt.want)
+		}
+	}
+}
+
+func TestNewEngine_RejectsInvalidRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	content := `groups:
+  - name: finance
+    targets: ["*"]
+    rules:
+      - intent: payment
+        condition: "amount > 50 &&"
+        action: REQUIRE_APPROVAL
+      - intent: refund
+        condition: amount in [1, 2
+        action: DENY
+defaults:
+  default_action: ALLOW
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewEngine(path)
+	var errs PolicyErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected 2 policy errors, got %v", err)
+	}
+	if errs[0].Line != 6 || errs[0].Column != 35 {
+		t.Errorf("quoted condition error at %d:%d, want 6:35 (%v)", errs[0].Line, errs[0].Column, errs[0])
+	}
+	if errs[1].Line != 9 || !strings.Contains(errs[1].Message, "expected") {
+		t.Errorf("unexpected second error: %v", errs[1])
+	}
+}
+
+func TestEngine_UnvalidatedConfigSkipsInvalidRules(t *testing.T) {
+	e := &Engine{Config: &PolicyConfig{
+		Groups: []PolicyGroup{{Targets: []string{"*"}, Rules: []PolicyRule{
+			{Intent: "payment", Condition: "amount >>> 5", Action: ActionDeny},
+			{Intent: "payment", Condition: "amount > 5", Action: ActionRequireApproval},
+		}}},
+		Defaults: DefaultConfig{DefaultAction: ActionAllow},
+	}}
+	if got := e.Evaluate("t", "a", "payment", map[string]any{"amount": 10}); got != ActionRequireApproval {
+		t.Fatalf("Evaluate = %s, want %s", got, ActionRequireApproval)
+	}
+}
@@ -0,0 +1,140 @@
+package governance
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Simulation sources.
+const (
+	SourceIntercept    = "intercept"
+	SourceMissionEvent = "mission_event"
+)
+
+// maxSimulationExamples bounds the sample changes kept per group.
+const maxSimulationExamples = 5
+
+// SimulationInput is one historical request replayed against a candidate
+// policy. Action is the decision actually made; empty means the request was
+// never intercepted and is evaluated against the current policy instead.
+type SimulationInput struct {
+	Source  string
+	TeamID  string
+	AgentID string
+	Intent  string
+	Context map[string]any
+	Action  string
+	At      time.Time
+}
+
+// Input converts a logged decision for replay.
+func (d Decision) Input() SimulationInput {
+	return SimulationInput{
+		Source: SourceIntercept, TeamID: d.TeamID, AgentID: d.AgentID, Intent: d.Intent,
+		Context: conditionContext(d.Payload, d.SwarmContext), Action: d.Action, At: d.DecidedAt,
+	}
+}
+
+// MissionEventInput converts a persisted mission event for replay.
+func MissionEventInput(team, agent, eventType string, payload map[string]any, at time.Time) SimulationInput {
+	return SimulationInput{
+		Source: SourceMissionEvent, TeamID: team, AgentID: agent, Intent: eventType,
+		Context: conditionContext(payload, nil), At: at,
+	}
+}
+
+// SimulationChange is one request whose decision would change.
+type SimulationChange struct {
+	Source  string    `json:"source"`
+	AgentID string    `json:"agent_id"`
+	At      time.Time `json:"at"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+}
+
+// SimulationGroup aggregates changed decisions for one team and intent.
+type SimulationGroup struct {
+	TeamID      string             `json:"team_id"`
+	Intent      string             `json:"intent"`
+	Evaluated   int                `json:"evaluated"`
+	Changed     int                `json:"changed"`
+	Transitions map[string]int     `json:"transitions"` // "ALLOW->DENY": n
+	Examples    []SimulationChange `json:"examples"`
+}
+
+// SimulationReport summarizes how a candidate policy would have decided.
+type SimulationReport struct {
+	Since       time.Time         `json:"since"`
+	Evaluated   int               `json:"evaluated"`
+	Changed     int               `json:"changed"`
+	Sources     map[string]int    `json:"sources"`
+	Transitions map[string]int    `json:"transitions"`
+	Groups      []SimulationGroup `json:"groups"` // only groups with changes, most changed first
+}
+
+// Simulate replays inputs against candidate and reports every decision that
+// differs from the recorded one (or, for inputs without one, from current).
+// An invalid candidate returns its PolicyErrors.
+func Simulate(current, candidate *PolicyConfig, inputs []SimulationInput) (SimulationReport, error) {
+	if candidate == nil {
+		return SimulationReport{}, fmt.Errorf("governance: candidate policy is required")
+	}
+	if errs := ValidatePolicy(candidate, nil); len(errs) > 0 {
+		return SimulationReport{}, errs
+	}
+	currentEngine := &Engine{Config: current}
+	candidateEngine := &Engine{Config: candidate}
+
+	report := SimulationReport{Sources: map[string]int{}, Transitions: map[string]int{}}
+	groups := map[[2]string]*SimulationGroup{}
+	for _, in := range inputs {
+		from := in.Action
+		if from == "" {
+			if current == nil {
+				continue
+			}
+			from = currentEngine.Evaluate(in.TeamID, in.AgentID, in.Intent, in.Context)
+		}
+		to := candidateEngine.Evaluate(in.TeamID, in.AgentID, in.Intent, in.Context)
+
+		key := [2]string{in.TeamID, in.Intent}
+		g := groups[key]
+		if g == nil {
+			g = &SimulationGroup{TeamID: in.TeamID, Intent: in.Intent, Transitions: map[string]int{}}
+			groups[key] = g
+		}
+		report.Evaluated++
+		report.Sources[in.Source]++
+		g.Evaluated++
+		if from == to {
+			continue
+		}
+		transition := from + "->" + to
+		report.Changed++
+		report.Transitions[transition]++
+		g.Changed++
+		g.Transitions[transition]++
+		if len(g.Examples) < maxSimulationExamples {
+			g.Examples = append(g.Examples, SimulationChange{Source: in.Source, AgentID: in.AgentID, At: in.At, From: from, To: to})
+		}
+	}
+
+	report.Groups = make([]SimulationGroup, 0, len(groups))
+	for _, g := range groups {
+		if g.Changed > 0 {
+			report.Groups = append(report.Groups, *g)
+		}
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if a.Changed != b.Changed {
+			return a.Changed > b.Changed
+		}
+		if a.TeamID != b.TeamID {
+			return a.TeamID < b.TeamID
+		}
+		return a.Intent < b.Intent
+	})
+	return report, nil
+}
@@ -0,0 +1,117 @@
+package governance
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/mycelis/core/pkg/pb/swarm"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type memoryDecisionStore struct {
+	mu        sync.Mutex
+	decisions []Decision
+	recorded  chan struct{}
+}
+
+func (s *memoryDecisionStore) RecordDecisions(_ context.Context, ds []Decision) error {
+	s.mu.Lock()
+	s.decisions = append(s.decisions, ds...)
+	s.mu.Unlock()
+	s.recorded <- struct{}{}
+	return nil
+}
+
+func (s *memoryDecisionStore) ListDecisions(_ context.Context, since time.Time, limit int) ([]Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Decision
+	for _, d := range s.decisions {
+		if !d.DecidedAt.Before(since) && len(out) < limit {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (s *memoryDecisionStore) PruneDecisions(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestIntercept_LogsDecisions(t *testing.T) {
+	g := &Guard{Engine: &Engine{Config: &PolicyConfig{
+		Groups: []PolicyGroup{{Targets: []string{"*"}, Rules: []PolicyRule{
+			{Intent: "payment", Condition: "amount > 50", Action: ActionRequireApproval},
+		}}},
+		Defaults: DefaultConfig{DefaultAction: ActionAllow},
+	}}}
+	store := &memoryDecisionStore{recorded: make(chan struct{}, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	g.SetDecisionLog(ctx, store)
+
+	data, _ := structpb.NewStruct(map[string]any{"amount": 80})
+	_, _, reqID := g.Intercept(&pb.MsgEnvelope{
+		TeamId:  "finance",
+		Payload: &pb.MsgEnvelope_Event{Event: &pb.EventPayload{EventType: "payment", Data: data}},
+	})
+
+	select {
+	case <-store.recorded:
+	case <-time.After(3 * time.Second):
+		t.Fatal("decision was not flushed")
+	}
+	history, err := g.DecisionHistory(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("expected 1 logged decision, got %d (%v)", len(history), err)
+	}
+	d := history[0]
+	if d.Action != ActionRequireApproval || d.RequestID != reqID || d.Payload["amount"] != float64(80) {
+		t.Fatalf("unexpected decision: %+v", d)
+	}
+}
+
+func TestSimulate_ReportsChangedDecisions(t *testing.T) {
+	current := &PolicyConfig{Defaults: DefaultConfig{DefaultAction: ActionAllow}}
+	candidate := &PolicyConfig{
+		Groups: []PolicyGroup{{Targets: []string{"team:finance"}, Rules: []PolicyRule{
+			{Intent: "payment", Condition: "amount > 100", Action: ActionRequireApproval},
+			{Intent: "refund", Action: ActionDeny},
+		}}},
+		Defaults: DefaultConfig{DefaultAction: ActionAllow},
+	}
+	now := time.Now()
+	payment := func(amount float64) SimulationInput {
+		return Decision{TeamID: "finance", Intent: "payment", Action: ActionAllow,
+			Payload: map[string]any{"amount": amount}, DecidedAt: now}.Input()
+	}
+	inputs := []SimulationInput{
+		payment(20), payment(150), payment(500),
+		MissionEventInput("finance", "bot", "refund", map[string]any{}, now),
+		MissionEventInput("ops", "bot", "refund", map[string]any{}, now),
+	}
+
+	report, err := Simulate(current, candidate, inputs)
+	if err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+	if report.Evaluated != 5 || report.Changed != 3 {
+		t.Fatalf("evaluated/changed = %d/%d, want 5/3", report.Evaluated, report.Changed)
+	}
+	if report.Transitions["ALLOW->REQUIRE_APPROVAL"] != 2 || report.Transitions["ALLOW->DENY"] != 1 {
+		t.Fatalf("unexpected transitions: %v", report.Transitions)
+	}
+	if report.Sources[SourceIntercept] != 3 || report.Sources[SourceMissionEvent] != 2 {
+		t.Fatalf("unexpected sources: %v", report.Sources)
+	}
+	if len(report.Groups) != 2 || report.Groups[0].Intent != "payment" || report.Groups[0].Evaluated != 3 || len(report.Groups[0].Examples) != 2 {
+		t.Fatalf("unexpected groups: %+v", report.Groups)
+	}
+
+	bad := &PolicyConfig{Defaults: DefaultConfig{DefaultAction: "MAYBE"}}
+	if _, err := Simulate(current, bad, inputs); err == nil {
+		t.Fatal("expected invalid candidate to be rejected")
+	}
+}
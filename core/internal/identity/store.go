@@ -133,6 +133,9 @@ func (s *Store) GetUserContext(ctx context.Context, accountID, userID string) (*
 	}, nil
 }
 
+// RecordAuditEvent appends an audit event. Events without an AccountID, such
+// as governance decisions made outside a user session, land on the default
+// account.
 func (s *Store) RecordAuditEvent(ctx context.Context, event AuditEvent) (*AuditEvent, error) {
 	if s.db == nil {
 		return nil, fmt.Errorf("identity store: database not available")
@@ -146,7 +149,8 @@ func (s *Store) RecordAuditEvent(ctx context.Context, event AuditEvent) (*AuditE
 		INSERT INTO identity_audit_events
 			(id, account_id, actor_user_id, actor_session_id, event_type,
 			 target_kind, target_id, source_kind, source_channel, payload)
-		VALUES ($1, $2, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5,
+		VALUES ($1, COALESCE(NULLIF($2, '')::uuid, (SELECT id FROM accounts WHERE slug = 'default')),
+			NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5,
 			$6, $7, $8, $9, $10::jsonb)
 		RETURNING id, account_id, COALESCE(actor_user_id::text, ''),
 			COALESCE(actor_session_id::text, ''), event_type, target_kind,
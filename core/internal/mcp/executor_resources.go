@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	mcplib "github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxResourceText bounds the resource text handed back to an agent.
+const maxResourceText = 64 << 10
+
+// ListResources returns the cached resources of every connected server.
+func (a *ToolExecutorAdapter) ListResources(ctx context.Context) ([]ResourceDef, error) {
+	return a.Service.ListAllResources(ctx)
+}
+
+// ListPrompts returns the cached prompt templates of every connected server.
+func (a *ToolExecutorAdapter) ListPrompts(ctx context.Context) ([]PromptDef, error) {
+	return a.Service.ListAllPrompts(ctx)
+}
+
+// ReadResource reads uri from the named server and returns its text.
+func (a *ToolExecutorAdapter) ReadResource(ctx context.Context, serverName, uri string) (string, error) {
+	serverID, err := a.serverID(ctx, serverName)
+	if err != nil {
+		return "", err
+	}
+	result, err := a.Pool.ReadResource(ctx, serverID, uri)
+	if err != nil {
+		return "", err
+	}
+	return formatReadResourceResult(result), nil
+}
+
+// SubscribeResource asks the named server for update notifications on uri.
+func (a *ToolExecutorAdapter) SubscribeResource(ctx context.Context, serverName, uri string) error {
+	serverID, err := a.serverID(ctx, serverName)
+	if err != nil {
+		return err
+	}
+	return a.Pool.SubscribeResource(ctx, serverID, uri)
+}
+
+// GetPrompt renders a prompt template on the named server as text.
+func (a *ToolExecutorAdapter) GetPrompt(ctx context.Context, serverName, name string, args map[string]string) (string, error) {
+	serverID, err := a.serverID(ctx, serverName)
+	if err != nil {
+		return "", err
+	}
+	result, err := a.Pool.GetPrompt(ctx, serverID, name, args)
+	if err != nil {
+		return "", err
+	}
+	return formatGetPromptResult(result), nil
+}
+
+func (a *ToolExecutorAdapter) serverID(ctx context.Context, serverName string) (uuid.UUID, error) {
+	srv, err := a.Service.FindServerByName(ctx, serverName)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("find mcp server %q: %w", serverName, err)
+	}
+	return srv.ID, nil
+}
+
+// formatReadResourceResult joins text contents and summarizes binary ones.
+func formatReadResourceResult(result *mcplib.ReadResourceResult) string {
+	if result == nil {
+		return ""
+	}
+	var parts []string
+	for _, c := range result.Contents {
+		switch rc := c.(type) {
+		case mcplib.TextResourceContents:
+			parts = append(parts, rc.Text)
+		case mcplib.BlobResourceContents:
+			size := base64.StdEncoding.DecodedLen(len(rc.Blob))
+			parts = append(parts, fmt.Sprintf("[binary resource %s, %s, about %d bytes]", rc.URI, rc.MIMEType, size))
+		}
+	}
+	text := strings.Join(parts, "\n")
+	if len(text) > maxResourceText {
+		text = text[:maxResourceText] + "\n[truncated]"
+	}
+	if text == "" {
+		return "(empty resource)"
+	}
+	return text
+}
+
+// formatGetPromptResult renders prompt messages as "role: text" lines.
+func formatGetPromptResult(result *mcplib.GetPromptResult) string {
+	if result == nil {
+		return ""
+	}
+	var lines []string
+	if result.Description != "" {
+		lines = append(lines, result.Description)
+	}
+	for _, m := range result.Messages {
+		switch c := m.Content.(type) {
+		case mcplib.TextContent:
+			lines = append(lines, string(m.Role)+": "+c.Text)
+		case mcplib.EmbeddedResource:
+			if text, ok := c.Resource.(mcplib.TextResourceContents); ok {
+				lines = append(lines, string(m.Role)+": "+text.Text)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -86,13 +86,13 @@ func TestExecutorAdapter_FindToolByName(t *testing.T) {
 
 	findToolColumns := []string{
 		"id", "server_id", "server_name", "name", "description", "input_schema",
-		"srv_id", "srv_name", "transport", "command", "args", "env", "url", "headers", "status", "error_message", "created_at", "updated_at",
+		"srv_id", "srv_name", "transport", "command", "args", "env", "url", "headers", "auth", "sandbox", "status", "error_message", "created_at", "updated_at",
 	}
 	mock.ExpectQuery("SELECT .+ FROM mcp_tools .+ JOIN mcp_servers").
 		WithArgs("read_file").
 		WillReturnRows(sqlmock.NewRows(findToolColumns).
 			AddRow(toolID, serverID, "filesystem", "read_file", "Read", []byte(`{}`),
-				serverID, "filesystem", "stdio", "npx", `[]`, `{}`, "", `{}`, "connected", nil, time.Now(), time.Now()))
+				serverID, "filesystem", "stdio", "npx", `[]`, `{}`, "", `{}`, nil, nil, "connected", nil, time.Now(), time.Now()))
 
 	gotID, gotName, err := adapter.FindToolByName(context.Background(), "read_file")
 	if err != nil {
@@ -113,7 +113,7 @@ func TestExecutorAdapter_FindToolByName_NotFound(t *testing.T) {
 
 	findToolColumns := []string{
 		"id", "server_id", "server_name", "name", "description", "input_schema",
-		"srv_id", "srv_name", "transport", "command", "args", "env", "url", "headers", "status", "error_message", "created_at", "updated_at",
+		"srv_id", "srv_name", "transport", "command", "args", "env", "url", "headers", "auth", "sandbox", "status", "error_message", "created_at", "updated_at",
 	}
 	mock.ExpectQuery("SELECT .+ FROM mcp_tools .+ JOIN mcp_servers").
 		WithArgs("nonexistent").
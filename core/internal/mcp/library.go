@@ -42,6 +42,9 @@ type LibraryEntry struct {
 	Env                  map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
 	EnvironmentVariables []LibraryEnvVar   `json:"environment_variables,omitempty" yaml:"environment_variables,omitempty"`
 	URL                  string            `json:"url,omitempty" yaml:"url,omitempty"`
+	Headers              map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"` // values use ${secret:NAME} / ${env:NAME}
+	Auth                 *AuthConfig       `json:"auth,omitempty" yaml:"auth,omitempty"`
+	Sandbox              *SandboxProfile   `json:"sandbox,omitempty" yaml:"sandbox,omitempty"`
 	Packages             []LibraryPackage  `json:"packages,omitempty" yaml:"packages,omitempty"`
 	Repository           string            `json:"repository,omitempty" yaml:"repository,omitempty"`
 	Homepage             string            `json:"homepage,omitempty" yaml:"homepage,omitempty"`
@@ -90,6 +93,7 @@ func (lib *Library) FindByName(name string) *LibraryEntry {
 
 // ToServerConfig converts a LibraryEntry into a ServerConfig suitable for Install().
 // envOverrides allows the caller to fill in required environment variables.
+// Headers, Auth and Sandbox are copied as declared; callers layer their own on top.
 func (entry *LibraryEntry) ToServerConfig(envOverrides map[string]string) ServerConfig {
 	env := make(map[string]string, len(entry.Env)+len(entry.EnvironmentVariables))
 	for k, v := range entry.Env {
@@ -110,6 +114,26 @@ func (entry *LibraryEntry) ToServerConfig(envOverrides map[string]string) Server
 		env[k] = v
 	}
 
+	var headers map[string]string
+	if len(entry.Headers) > 0 {
+		headers = make(map[string]string, len(entry.Headers))
+		for k, v := range entry.Headers {
+			headers[k] = v
+		}
+	}
+	var auth *AuthConfig
+	if entry.Auth != nil {
+		copied := *entry.Auth
+		copied.Scopes = append([]string(nil), entry.Auth.Scopes...)
+		auth = &copied
+	}
+	var sandbox *SandboxProfile
+	if entry.Sandbox != nil {
+		copied := *entry.Sandbox
+		copied.PassEnv = append([]string(nil), entry.Sandbox.PassEnv...)
+		sandbox = &copied
+	}
+
 	return ServerConfig{
 		Name:      entry.Name,
 		Transport: entry.Transport,
@@ -117,6 +141,9 @@ func (entry *LibraryEntry) ToServerConfig(envOverrides map[string]string) Server
 		Args:      append([]string(nil), entry.Args...),
 		Env:       env,
 		URL:       entry.URL,
+		Headers:   headers,
+		Auth:      auth,
+		Sandbox:   sandbox,
 	}
 }
 
@@ -0,0 +1,194 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mycelis/core/internal/secrets"
+)
+
+// tokenRefreshLeeway renews access tokens this long before they expire.
+const tokenRefreshLeeway = 30 * time.Second
+
+// oauthTokenSecretName is where the OAuth tokens of a server are kept.
+func oauthTokenSecretName(serverName string) string {
+	return "mcp-oauth/" + serverName
+}
+
+// secretTokenStore keeps OAuth tokens for one server in the secret store,
+// so refresh tokens survive restarts without touching the database in
+// plaintext. Implements transport.TokenStore.
+type secretTokenStore struct {
+	store *secrets.Store
+	name  string
+}
+
+func (s *secretTokenStore) GetToken(ctx context.Context) (*transport.Token, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	raw, err := s.store.Get(ctx, s.name)
+	if errors.Is(err, secrets.ErrNotFound) {
+		return nil, transport.ErrNoToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	var token transport.Token
+	if err := json.Unmarshal([]byte(raw), &token); err != nil {
+		return nil, fmt.Errorf("decode oauth token %s: %w", s.name, err)
+	}
+	return &token, nil
+}
+
+func (s *secretTokenStore) SaveToken(ctx context.Context, token *transport.Token) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	raw, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("encode oauth token %s: %w", s.name, err)
+	}
+	return s.store.Put(ctx, s.name, string(raw))
+}
+
+// clientCredentialsSource issues bearer tokens with the OAuth
+// client_credentials grant, reusing the stored token until it is about to
+// expire and preferring a refresh token when the server handed one out.
+type clientCredentialsSource struct {
+	cfg          AuthConfig
+	clientSecret string
+	tokens       transport.TokenStore
+	httpClient   *http.Client
+
+	mu      sync.Mutex
+	current *transport.Token // last token seen, saves a store read per request
+}
+
+// token returns a valid access token, fetching a new one when needed.
+func (s *clientCredentialsSource) token(ctx context.Context) (*transport.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current == nil {
+		stored, err := s.tokens.GetToken(ctx)
+		if err != nil && !errors.Is(err, transport.ErrNoToken) {
+			return nil, err
+		}
+		s.current = stored
+	}
+	if s.current != nil && s.current.AccessToken != "" && !tokenExpiring(s.current) {
+		return s.current, nil
+	}
+	form := url.Values{}
+	if s.current != nil && s.current.RefreshToken != "" {
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", s.current.RefreshToken)
+		if fresh, err := s.request(ctx, form); err == nil {
+			s.current = fresh
+			return fresh, nil
+		}
+		form = url.Values{}
+	}
+	form.Set("grant_type", "client_credentials")
+	if len(s.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.cfg.Scopes, " "))
+	}
+	fresh, err := s.request(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	s.current = fresh
+	return fresh, nil
+}
+
+// headers is the transport.HTTPHeaderFunc for a client-credentials server.
+func (s *clientCredentialsSource) headers(ctx context.Context) map[string]string {
+	token, err := s.token(ctx)
+	if err != nil {
+		log.Printf("mcp oauth: client credentials for %s: %v", s.cfg.ClientID, err)
+		return nil
+	}
+	return map[string]string{"Authorization": "Bearer " + token.AccessToken}
+}
+
+func (s *clientCredentialsSource) request(ctx context.Context, form url.Values) (*transport.Token, error) {
+	tokenURL, err := s.tokenEndpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form.Set("client_id", s.cfg.ClientID)
+	if s.clientSecret != "" {
+		form.Set("client_secret", s.clientSecret)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read token response: %w", err)
+	}
+	var oauthErr transport.OAuthError
+	if json.Unmarshal(body, &oauthErr) == nil && oauthErr.ErrorCode != "" {
+		return nil, fmt.Errorf("%s grant: %w", form.Get("grant_type"), oauthErr)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s grant: token endpoint returned %d", form.Get("grant_type"), resp.StatusCode)
+	}
+	var token transport.Token
+	if err := json.Unmarshal(body, &token); err != nil || token.AccessToken == "" {
+		return nil, fmt.Errorf("%s grant: token endpoint returned no access token", form.Get("grant_type"))
+	}
+	if token.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	if err := s.tokens.SaveToken(ctx, &token); err != nil {
+		return nil, fmt.Errorf("save token: %w", err)
+	}
+	return &token, nil
+}
+
+// tokenEndpoint returns the configured token URL, falling back to the
+// token_endpoint advertised by the authorization server metadata.
+func (s *clientCredentialsSource) tokenEndpoint(ctx context.Context) (string, error) {
+	if s.cfg.TokenURL != "" {
+		return s.cfg.TokenURL, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.MetadataURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("metadata request: %w", err)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("metadata request: %w", err)
+	}
+	defer resp.Body.Close()
+	var metadata transport.AuthServerMetadata
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&metadata); err != nil || metadata.TokenEndpoint == "" {
+		return "", fmt.Errorf("metadata %s advertises no token_endpoint", s.cfg.MetadataURL)
+	}
+	s.cfg.TokenURL = metadata.TokenEndpoint
+	return s.cfg.TokenURL, nil
+}
+
+func tokenExpiring(token *transport.Token) bool {
+	return !token.ExpiresAt.IsZero() && time.Now().Add(tokenRefreshLeeway).After(token.ExpiresAt)
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/client"
-	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -18,14 +17,20 @@ type ManagedClient struct {
 	Config    ServerConfig
 	Client    *client.Client
 	Tools     []mcp.Tool
+	Resources []mcp.Resource
+	Prompts   []mcp.Prompt
 	Connected bool
 }
 
 // ClientPool manages live MCP client connections with thread-safe access.
 type ClientPool struct {
-	mu      sync.RWMutex
-	clients map[uuid.UUID]*ManagedClient
-	service *Service // reference to the DB service for status updates + tool caching
+	mu         sync.RWMutex
+	clients    map[uuid.UUID]*ManagedClient
+	service    *Service // reference to the DB service for status updates + tool caching
+	resources  resourceState
+	supervisor supervisorState
+	auth       authState
+	sandboxes  sandboxState
 }
 
 // NewClientPool creates a new pool that uses the given service for persistence.
@@ -39,38 +44,18 @@ func NewClientPool(svc *Service) *ClientPool {
 // Connect establishes a live MCP connection for the given server config.
 // It creates the transport, starts the client, initializes the MCP session,
 // discovers tools, caches them in the database, and stores the managed client.
+// The server stays under supervision until Disconnect.
 func (p *ClientPool) Connect(ctx context.Context, cfg ServerConfig) error {
-	_ = p.Disconnect(cfg.ID)
+	p.dropClient(cfg.ID)
+	p.track(cfg)
 
-	var t transport.Interface
-	var err error
-
-	switch cfg.Transport {
-	case "stdio":
-		// Convert env map to []string{"KEY=VALUE", ...} for stdio transport.
-		envSlice := make([]string, 0, len(cfg.Env))
-		for k, v := range cfg.Env {
-			envSlice = append(envSlice, k+"="+v)
-		}
-		t = transport.NewStdio(cfg.Command, envSlice, cfg.Args...)
-
-	case "sse":
-		t, err = transport.NewStreamableHTTP(cfg.URL)
-		if err != nil {
-			statusErr := p.service.UpdateStatus(ctx, cfg.ID, "error", fmt.Sprintf("transport init: %v", err))
-			if statusErr != nil {
-				log.Printf("mcp pool: failed to update status for %s: %v", cfg.ID, statusErr)
-			}
-			return fmt.Errorf("create streamable HTTP transport for %s: %w", cfg.Name, err)
-		}
-
-	default:
-		errMsg := fmt.Sprintf("unsupported transport type: %s", cfg.Transport)
-		statusErr := p.service.UpdateStatus(ctx, cfg.ID, "error", errMsg)
+	t, err := p.newTransport(ctx, cfg)
+	if err != nil {
+		statusErr := p.service.UpdateStatus(ctx, cfg.ID, "error", fmt.Sprintf("transport init: %v", err))
 		if statusErr != nil {
 			log.Printf("mcp pool: failed to update status for %s: %v", cfg.ID, statusErr)
 		}
-		return fmt.Errorf("%s", errMsg)
+		return fmt.Errorf("create %s transport for %s: %w", cfg.Transport, cfg.Name, err)
 	}
 
 	// Create the MCP client with the transport.
@@ -84,6 +69,7 @@ func (p *ClientPool) Connect(ctx context.Context, cfg ServerConfig) error {
 		}
 		return fmt.Errorf("start mcp client for %s: %w", cfg.Name, err)
 	}
+	p.watchSandboxStderr(cfg, t)
 
 	// Initialize the MCP session.
 	initReq := mcp.InitializeRequest{
@@ -97,7 +83,11 @@ func (p *ClientPool) Connect(ctx context.Context, cfg ServerConfig) error {
 	_, err = c.Initialize(ctx, initReq)
 	if err != nil {
 		_ = c.Close()
-		statusErr := p.service.UpdateStatus(ctx, cfg.ID, "error", fmt.Sprintf("initialize: %v", err))
+		detail := fmt.Sprintf("initialize: %v", err)
+		if client.IsOAuthAuthorizationRequiredError(err) {
+			detail = "oauth authorization required: start it with POST /api/v1/mcp/servers/{id}/oauth/authorize"
+		}
+		statusErr := p.service.UpdateStatus(ctx, cfg.ID, "error", detail)
 		if statusErr != nil {
 			log.Printf("mcp pool: failed to update status for %s: %v", cfg.ID, statusErr)
 		}
@@ -137,6 +127,8 @@ func (p *ClientPool) Connect(ctx context.Context, cfg ServerConfig) error {
 		return fmt.Errorf("cache tools for %s: %w", cfg.Name, err)
 	}
 
+	resources, prompts := p.discoverResources(ctx, cfg.ID, c)
+
 	// Update the server status to connected.
 	if err := p.service.UpdateStatus(ctx, cfg.ID, "connected", ""); err != nil {
 		_ = c.Close()
@@ -150,9 +142,13 @@ func (p *ClientPool) Connect(ctx context.Context, cfg ServerConfig) error {
 		Config:    cfg,
 		Client:    c,
 		Tools:     tools,
+		Resources: resources,
+		Prompts:   prompts,
 		Connected: true,
 	}
 	p.mu.Unlock()
+	p.watchResources(cfg.ID, c)
+	p.recordHealthy(cfg)
 
 	log.Printf("mcp pool: connected to %s (%s), discovered %d tools", cfg.Name, cfg.ID, len(tools))
 	return nil
@@ -160,10 +156,12 @@ func (p *ClientPool) Connect(ctx context.Context, cfg ServerConfig) error {
 
 // Disconnect closes the MCP client for the given server and removes it from the pool.
 func (p *ClientPool) Disconnect(serverID uuid.UUID) error {
+	p.untrack(serverID)
 	p.mu.Lock()
 	mc, ok := p.clients[serverID]
 	if !ok {
 		p.mu.Unlock()
+		p.releaseSandbox(serverID)
 		return fmt.Errorf("mcp client %s not found in pool", serverID)
 	}
 	delete(p.clients, serverID)
@@ -172,6 +170,7 @@ func (p *ClientPool) Disconnect(serverID uuid.UUID) error {
 	if err := mc.Client.Close(); err != nil {
 		log.Printf("mcp pool: error closing client %s: %v", serverID, err)
 	}
+	p.releaseSandbox(serverID)
 
 	// Update status to stopped (best-effort; use background context since
 	// the caller may not care about DB persistence failures here).
@@ -186,15 +185,9 @@ func (p *ClientPool) Disconnect(serverID uuid.UUID) error {
 
 // CallTool invokes a tool on the specified MCP server and returns the result.
 func (p *ClientPool) CallTool(ctx context.Context, serverID uuid.UUID, toolName string, args map[string]any) (*mcp.CallToolResult, error) {
-	p.mu.RLock()
-	mc, ok := p.clients[serverID]
-	p.mu.RUnlock()
-
-	if !ok {
-		return nil, fmt.Errorf("mcp client %s not found in pool", serverID)
-	}
-	if !mc.Connected {
-		return nil, fmt.Errorf("mcp client %s is not connected", serverID)
+	mc, err := p.connectedClient(serverID)
+	if err != nil {
+		return nil, err
 	}
 
 	req := mcp.CallToolRequest{}
@@ -212,15 +205,9 @@ func (p *ClientPool) CallTool(ctx context.Context, serverID uuid.UUID, toolName
 // DiscoverTools re-discovers tools from the specified MCP server,
 // updates the database cache, and returns the discovered tools.
 func (p *ClientPool) DiscoverTools(ctx context.Context, serverID uuid.UUID) ([]mcp.Tool, error) {
-	p.mu.RLock()
-	mc, ok := p.clients[serverID]
-	p.mu.RUnlock()
-
-	if !ok {
-		return nil, fmt.Errorf("mcp client %s not found in pool", serverID)
-	}
-	if !mc.Connected {
-		return nil, fmt.Errorf("mcp client %s is not connected", serverID)
+	mc, err := p.connectedClient(serverID)
+	if err != nil {
+		return nil, err
 	}
 
 	toolsResult, err := mc.Client.ListTools(ctx, mcp.ListToolsRequest{})
@@ -276,6 +263,7 @@ func (p *ClientPool) ReconnectAll(ctx context.Context, configs []ServerConfig) {
 
 // ShutdownAll closes all active MCP client connections and clears the pool.
 func (p *ClientPool) ShutdownAll() {
+	p.untrackAll()
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -289,5 +277,6 @@ func (p *ClientPool) ShutdownAll() {
 
 	// Clear the map.
 	p.clients = make(map[uuid.UUID]*ManagedClient)
+	p.releaseAllSandboxes()
 	log.Printf("mcp pool: all clients shut down")
 }
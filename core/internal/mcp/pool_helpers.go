@@ -34,3 +34,17 @@ func convertTools(serverID uuid.UUID, tools []mcp.Tool) ([]ToolDef, error) {
 	}
 	return defs, nil
 }
+
+// connectedClient returns the live client for serverID.
+func (p *ClientPool) connectedClient(serverID uuid.UUID) (*ManagedClient, error) {
+	p.mu.RLock()
+	mc, ok := p.clients[serverID]
+	p.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("mcp client %s not found in pool", serverID)
+	}
+	if !mc.Connected {
+		return nil, fmt.Errorf("mcp client %s is not connected", serverID)
+	}
+	return mc, nil
+}
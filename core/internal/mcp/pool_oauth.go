@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// authorizationTTL bounds how long a PKCE authorization waits for its callback.
+const authorizationTTL = 10 * time.Minute
+
+// BeginAuthorization starts the authorization code + PKCE flow for an
+// oauth_pkce server and returns the URL the operator must open. The
+// authorization server redirects back with a state that CompleteAuthorization
+// matches to this call.
+func (p *ClientPool) BeginAuthorization(ctx context.Context, cfg ServerConfig) (string, error) {
+	if cfg.Auth == nil || cfg.Auth.Type != AuthOAuthPKCE {
+		return "", fmt.Errorf("%s is not configured for %s", cfg.Name, AuthOAuthPKCE)
+	}
+	if err := ValidateRemoteConfig(cfg); err != nil {
+		return "", err
+	}
+	_, oauthCfg, err := p.authOptions(ctx, cfg)
+	if err != nil {
+		return "", err
+	}
+	handler := transport.NewOAuthHandler(*oauthCfg)
+	if u, err := url.Parse(cfg.URL); err == nil {
+		handler.SetBaseURL(u.Scheme + "://" + u.Host)
+	}
+	verifier, err := transport.GenerateCodeVerifier()
+	if err != nil {
+		return "", fmt.Errorf("generate code verifier: %w", err)
+	}
+	state, err := transport.GenerateState()
+	if err != nil {
+		return "", fmt.Errorf("generate state: %w", err)
+	}
+	authURL, err := handler.GetAuthorizationURL(ctx, state, transport.GenerateCodeChallenge(verifier))
+	if err != nil {
+		return "", fmt.Errorf("authorization url for %s: %w", cfg.Name, err)
+	}
+
+	now := time.Now()
+	p.auth.mu.Lock()
+	if p.auth.pending == nil {
+		p.auth.pending = make(map[string]pendingAuthorization)
+	}
+	for s, pending := range p.auth.pending {
+		if now.After(pending.expires) {
+			delete(p.auth.pending, s)
+		}
+	}
+	p.auth.pending[state] = pendingAuthorization{serverID: cfg.ID, verifier: verifier, handler: handler, expires: now.Add(authorizationTTL)}
+	p.auth.mu.Unlock()
+	return authURL, nil
+}
+
+// CompleteAuthorization exchanges the callback code for tokens, stores them
+// in the secret store and returns the server the authorization was for.
+func (p *ClientPool) CompleteAuthorization(ctx context.Context, state, code string) (uuid.UUID, error) {
+	p.auth.mu.Lock()
+	pending, ok := p.auth.pending[state]
+	delete(p.auth.pending, state)
+	p.auth.mu.Unlock()
+	if !ok || time.Now().After(pending.expires) {
+		return uuid.Nil, fmt.Errorf("unknown or expired authorization state")
+	}
+	if err := pending.handler.ProcessAuthorizationResponse(ctx, code, state, pending.verifier); err != nil {
+		return uuid.Nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+	return pending.serverID, nil
+}
@@ -0,0 +1,204 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// resourceState tracks resource subscriptions across reconnects and the
+// callback for update notifications.
+type resourceState struct {
+	mu            sync.Mutex
+	subscriptions map[uuid.UUID]map[string]bool
+	onUpdated     func(serverID uuid.UUID, uri string)
+}
+
+// OnResourceUpdated registers fn for resource update notifications from
+// subscribed resources.
+func (p *ClientPool) OnResourceUpdated(fn func(serverID uuid.UUID, uri string)) {
+	p.resources.mu.Lock()
+	p.resources.onUpdated = fn
+	p.resources.mu.Unlock()
+}
+
+// discoverResources lists and caches the resources and prompts of a server
+// that declares them. Both are optional MCP features, so failures are
+// logged and do not fail the connection.
+func (p *ClientPool) discoverResources(ctx context.Context, serverID uuid.UUID, c *client.Client) ([]mcp.Resource, []mcp.Prompt) {
+	caps := c.GetServerCapabilities()
+	var resources []mcp.Resource
+	var prompts []mcp.Prompt
+	if caps.Resources != nil {
+		if result, err := c.ListResources(ctx, mcp.ListResourcesRequest{}); err != nil {
+			log.Printf("mcp pool: list resources for %s: %v", serverID, err)
+		} else {
+			resources = result.Resources
+			if err := p.service.CacheResources(ctx, serverID, convertResources(serverID, resources)); err != nil {
+				log.Printf("mcp pool: cache resources for %s: %v", serverID, err)
+			}
+		}
+	}
+	if caps.Prompts != nil {
+		if result, err := c.ListPrompts(ctx, mcp.ListPromptsRequest{}); err != nil {
+			log.Printf("mcp pool: list prompts for %s: %v", serverID, err)
+		} else {
+			prompts = result.Prompts
+			if err := p.service.CachePrompts(ctx, serverID, convertPrompts(serverID, prompts)); err != nil {
+				log.Printf("mcp pool: cache prompts for %s: %v", serverID, err)
+			}
+		}
+	}
+	return resources, prompts
+}
+
+// watchResources handles the server's tool, resource and prompt
+// notifications and restores the subscriptions the previous connection held.
+func (p *ClientPool) watchResources(serverID uuid.UUID, c *client.Client) {
+	c.OnNotification(func(n mcp.JSONRPCNotification) { p.handleNotification(serverID, n) })
+
+	p.resources.mu.Lock()
+	uris := make([]string, 0, len(p.resources.subscriptions[serverID]))
+	for uri := range p.resources.subscriptions[serverID] {
+		uris = append(uris, uri)
+	}
+	p.resources.mu.Unlock()
+	for _, uri := range uris {
+		err := withMCPConnectTimeout(context.Background(), func(ctx context.Context) error {
+			return c.Subscribe(ctx, mcp.SubscribeRequest{Params: mcp.SubscribeParams{URI: uri}})
+		})
+		if err != nil {
+			log.Printf("mcp pool: resubscribe %s on %s: %v", uri, serverID, err)
+		}
+	}
+}
+
+func (p *ClientPool) handleNotification(serverID uuid.UUID, n mcp.JSONRPCNotification) {
+	switch n.Method {
+	case mcp.MethodNotificationResourceUpdated:
+		uri, _ := n.Params.AdditionalFields["uri"].(string)
+		p.resources.mu.Lock()
+		fn := p.resources.onUpdated
+		p.resources.mu.Unlock()
+		if fn != nil && uri != "" {
+			fn(serverID, uri)
+		}
+	case mcp.MethodNotificationToolsListChanged:
+		go func() {
+			if _, err := p.DiscoverTools(context.Background(), serverID); err != nil {
+				log.Printf("mcp pool: refresh tools for %s: %v", serverID, err)
+			}
+		}()
+	case mcp.MethodNotificationResourcesListChanged, mcp.MethodNotificationPromptsListChanged:
+		go func() {
+			if _, _, err := p.DiscoverResources(context.Background(), serverID); err != nil {
+				log.Printf("mcp pool: refresh resources for %s: %v", serverID, err)
+			}
+		}()
+	}
+}
+
+// DiscoverResources re-lists the resources and prompts of a server and
+// updates the database cache.
+func (p *ClientPool) DiscoverResources(ctx context.Context, serverID uuid.UUID) ([]mcp.Resource, []mcp.Prompt, error) {
+	mc, err := p.connectedClient(serverID)
+	if err != nil {
+		return nil, nil, err
+	}
+	resources, prompts := p.discoverResources(ctx, serverID, mc.Client)
+	p.mu.Lock()
+	mc.Resources, mc.Prompts = resources, prompts
+	p.mu.Unlock()
+	return resources, prompts, nil
+}
+
+// ReadResource reads one resource from the specified MCP server.
+func (p *ClientPool) ReadResource(ctx context.Context, serverID uuid.UUID, uri string) (*mcp.ReadResourceResult, error) {
+	mc, err := p.connectedClient(serverID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := mc.Client.ReadResource(ctx, mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: uri}})
+	if err != nil {
+		return nil, fmt.Errorf("read resource %q on %s: %w", uri, serverID, err)
+	}
+	return result, nil
+}
+
+// SubscribeResource asks the server for update notifications on uri. The
+// subscription is restored when the server reconnects.
+func (p *ClientPool) SubscribeResource(ctx context.Context, serverID uuid.UUID, uri string) error {
+	mc, err := p.connectedClient(serverID)
+	if err != nil {
+		return err
+	}
+	if caps := mc.Client.GetServerCapabilities(); caps.Resources == nil || !caps.Resources.Subscribe {
+		return fmt.Errorf("mcp server %s does not support resource subscriptions", serverID)
+	}
+	if err := mc.Client.Subscribe(ctx, mcp.SubscribeRequest{Params: mcp.SubscribeParams{URI: uri}}); err != nil {
+		return fmt.Errorf("subscribe %q on %s: %w", uri, serverID, err)
+	}
+	p.resources.mu.Lock()
+	defer p.resources.mu.Unlock()
+	if p.resources.subscriptions == nil {
+		p.resources.subscriptions = make(map[uuid.UUID]map[string]bool)
+	}
+	if p.resources.subscriptions[serverID] == nil {
+		p.resources.subscriptions[serverID] = make(map[string]bool)
+	}
+	p.resources.subscriptions[serverID][uri] = true
+	return nil
+}
+
+// UnsubscribeResource stops update notifications for uri.
+func (p *ClientPool) UnsubscribeResource(ctx context.Context, serverID uuid.UUID, uri string) error {
+	p.resources.mu.Lock()
+	delete(p.resources.subscriptions[serverID], uri)
+	p.resources.mu.Unlock()
+	mc, err := p.connectedClient(serverID)
+	if err != nil {
+		return err
+	}
+	if err := mc.Client.Unsubscribe(ctx, mcp.UnsubscribeRequest{Params: mcp.UnsubscribeParams{URI: uri}}); err != nil {
+		return fmt.Errorf("unsubscribe %q on %s: %w", uri, serverID, err)
+	}
+	return nil
+}
+
+// GetPrompt renders a prompt template on the specified MCP server.
+func (p *ClientPool) GetPrompt(ctx context.Context, serverID uuid.UUID, name string, args map[string]string) (*mcp.GetPromptResult, error) {
+	mc, err := p.connectedClient(serverID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := mc.Client.GetPrompt(ctx, mcp.GetPromptRequest{Params: mcp.GetPromptParams{Name: name, Arguments: args}})
+	if err != nil {
+		return nil, fmt.Errorf("get prompt %q on %s: %w", name, serverID, err)
+	}
+	return result, nil
+}
+
+func convertResources(serverID uuid.UUID, resources []mcp.Resource) []ResourceDef {
+	defs := make([]ResourceDef, 0, len(resources))
+	for _, r := range resources {
+		defs = append(defs, ResourceDef{ServerID: serverID, URI: r.URI, Name: r.Name, Description: r.Description, MIMEType: r.MIMEType})
+	}
+	return defs
+}
+
+func convertPrompts(serverID uuid.UUID, prompts []mcp.Prompt) []PromptDef {
+	defs := make([]PromptDef, 0, len(prompts))
+	for _, p := range prompts {
+		args := make([]PromptArgument, 0, len(p.Arguments))
+		for _, a := range p.Arguments {
+			args = append(args, PromptArgument{Name: a.Name, Description: a.Description, Required: a.Required})
+		}
+		defs = append(defs, PromptDef{ServerID: serverID, Name: p.Name, Description: p.Description, Arguments: args})
+	}
+	return defs
+}
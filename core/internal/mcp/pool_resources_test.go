@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newResourceTestClient starts an in-process MCP server publishing one
+// resource and one prompt template.
+func newResourceTestClient(t *testing.T) *client.Client {
+	t.Helper()
+	srv := server.NewMCPServer("resources-test", "1.0.0",
+		server.WithResourceCapabilities(false, true),
+		server.WithPromptCapabilities(true),
+	)
+	srv.AddResource(
+		mcp.NewResource("db://main/schema", "main schema", mcp.WithResourceDescription("Tables of the main database"), mcp.WithMIMEType("text/plain")),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: req.Params.URI, MIMEType: "text/plain", Text: "CREATE TABLE users (id int);"}}, nil
+		},
+	)
+	srv.AddPrompt(
+		mcp.NewPrompt("review", mcp.WithPromptDescription("Review a change"), mcp.WithArgument("topic", mcp.RequiredArgument())),
+		func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return mcp.NewGetPromptResult("Review", []mcp.PromptMessage{
+				mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("Review "+req.Params.Arguments["topic"])),
+			}), nil
+		},
+	)
+	c, err := client.NewInProcessClient(srv)
+	if err != nil {
+		t.Fatalf("in-process client: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if _, err := c.Initialize(ctx, mcp.InitializeRequest{}); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	return c
+}
+
+func TestClientPool_DiscoversAndCachesResourcesAndPrompts(t *testing.T) {
+	svc, mock := newTestService(t)
+	c := newResourceTestClient(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM mcp_resources").WithArgs(testServerID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO mcp_resources").
+		WithArgs(testServerID, "db://main/schema", "main schema", "Tables of the main database", "text/plain").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM mcp_prompts").WithArgs(testServerID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO mcp_prompts").
+		WithArgs(testServerID, "review", "Review a change", []byte(`[{"name":"topic","required":true}]`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	pool := NewClientPool(svc)
+	resources, prompts := pool.discoverResources(context.Background(), testServerID, c)
+	if len(resources) != 1 || len(prompts) != 1 {
+		t.Fatalf("discovered %d resources, %d prompts; want 1 and 1", len(resources), len(prompts))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("cache expectations: %v", err)
+	}
+
+	pool.clients[testServerID] = &ManagedClient{ServerID: testServerID, Client: c, Connected: true}
+	read, err := pool.ReadResource(context.Background(), testServerID, "db://main/schema")
+	if err != nil {
+		t.Fatalf("ReadResource: %v", err)
+	}
+	if got := formatReadResourceResult(read); got != "CREATE TABLE users (id int);" {
+		t.Fatalf("resource text = %q", got)
+	}
+	prompt, err := pool.GetPrompt(context.Background(), testServerID, "review", map[string]string{"topic": "auth"})
+	if err != nil {
+		t.Fatalf("GetPrompt: %v", err)
+	}
+	if got := formatGetPromptResult(prompt); !strings.Contains(got, "user: Review auth") {
+		t.Fatalf("prompt text = %q", got)
+	}
+	if err := pool.SubscribeResource(context.Background(), testServerID, "db://main/schema"); err == nil {
+		t.Fatal("subscribe succeeded on a server without subscription support")
+	}
+}
+
+func TestClientPool_ForwardsResourceUpdates(t *testing.T) {
+	pool := NewClientPool(nil)
+	var gotServer uuid.UUID
+	var gotURI string
+	pool.OnResourceUpdated(func(serverID uuid.UUID, uri string) { gotServer, gotURI = serverID, uri })
+
+	n := mcp.JSONRPCNotification{Notification: mcp.Notification{
+		Method: mcp.MethodNotificationResourceUpdated,
+		Params: mcp.NotificationParams{AdditionalFields: map[string]any{"uri": "file:///notes.md"}},
+	}}
+	pool.handleNotification(testServerID, n)
+	if gotServer != testServerID || gotURI != "file:///notes.md" {
+		t.Fatalf("update = %s %q", gotServer, gotURI)
+	}
+}
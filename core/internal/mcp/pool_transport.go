@@ -0,0 +1,222 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mycelis/core/internal/secrets"
+)
+
+// oauthHTTPTimeout bounds token and metadata requests.
+const oauthHTTPTimeout = 30 * time.Second
+
+// authState holds what HTTP transports need to authenticate: the secret
+// store for header references and tokens, and in-flight PKCE authorizations.
+type authState struct {
+	mu         sync.Mutex
+	secrets    *secrets.Store
+	httpClient *http.Client
+	pending    map[string]pendingAuthorization
+}
+
+// SetSecretStore makes "secret:NAME" references resolvable and lets OAuth
+// tokens persist across restarts. Without it only "env:NAME" references
+// work and OAuth servers cannot connect.
+func (p *ClientPool) SetSecretStore(store *secrets.Store) {
+	p.auth.mu.Lock()
+	p.auth.secrets = store
+	p.auth.mu.Unlock()
+}
+
+func (p *ClientPool) secretStore() *secrets.Store {
+	p.auth.mu.Lock()
+	defer p.auth.mu.Unlock()
+	return p.auth.secrets
+}
+
+func (p *ClientPool) oauthHTTPClient() *http.Client {
+	p.auth.mu.Lock()
+	defer p.auth.mu.Unlock()
+	if p.auth.httpClient != nil {
+		return p.auth.httpClient
+	}
+	return &http.Client{Timeout: oauthHTTPTimeout}
+}
+
+// newTransport builds the client transport for cfg. Sandboxed stdio servers
+// launch under bubblewrap; HTTP transports get their header references
+// resolved and, when cfg.Auth is set, an OAuth token source.
+func (p *ClientPool) newTransport(ctx context.Context, cfg ServerConfig) (transport.Interface, error) {
+	switch cfg.Transport {
+	case TransportStdio:
+		if cfg.Sandbox != nil {
+			return p.sandboxTransport(cfg)
+		}
+		// Convert env map to []string{"KEY=VALUE", ...} for stdio transport.
+		envSlice := make([]string, 0, len(cfg.Env))
+		for k, v := range cfg.Env {
+			envSlice = append(envSlice, k+"="+v)
+		}
+		return transport.NewStdio(cfg.Command, envSlice, cfg.Args...), nil
+	case TransportStreamableHTTP, TransportSSE:
+	default:
+		return nil, fmt.Errorf("unsupported transport type: %s", cfg.Transport)
+	}
+	if err := ValidateRemoteConfig(cfg); err != nil {
+		return nil, err
+	}
+	headers, err := p.resolveHeaders(ctx, cfg.Headers)
+	if err != nil {
+		return nil, err
+	}
+	headerFunc, oauth, err := p.authOptions(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Transport == TransportSSE {
+		opts := []transport.ClientOption{transport.WithHeaders(headers)}
+		if headerFunc != nil {
+			opts = append(opts, transport.WithHeaderFunc(headerFunc))
+		}
+		if oauth != nil {
+			opts = append(opts, transport.WithOAuth(*oauth))
+		}
+		return transport.NewSSE(cfg.URL, opts...)
+	}
+	opts := []transport.StreamableHTTPCOption{transport.WithHTTPHeaders(headers)}
+	if headerFunc != nil {
+		opts = append(opts, transport.WithHTTPHeaderFunc(headerFunc))
+	}
+	if oauth != nil {
+		opts = append(opts, transport.WithHTTPOAuth(*oauth))
+	}
+	return transport.NewStreamableHTTP(cfg.URL, opts...)
+}
+
+// resolveHeaders expands ${env:NAME} and ${secret:NAME} placeholders.
+func (p *ClientPool) resolveHeaders(ctx context.Context, headers map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(headers))
+	for name, value := range headers {
+		expanded, err := secrets.Expand(ctx, p.secretStore(), value)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", name, err)
+		}
+		resolved[name] = expanded
+	}
+	return resolved, nil
+}
+
+// authOptions returns either a bearer header func (client credentials) or
+// an mcp-go OAuth config (authorization code + PKCE) for cfg.Auth.
+func (p *ClientPool) authOptions(ctx context.Context, cfg ServerConfig) (transport.HTTPHeaderFunc, *transport.OAuthConfig, error) {
+	if cfg.Auth == nil {
+		return nil, nil, nil
+	}
+	store := p.secretStore()
+	if store == nil {
+		return nil, nil, fmt.Errorf("oauth for %s needs the secret store (set %s)", cfg.Name, secrets.KeyEnv)
+	}
+	var clientSecret string
+	if cfg.Auth.ClientSecretRef != "" {
+		var err error
+		if clientSecret, err = secrets.Resolve(ctx, store, cfg.Auth.ClientSecretRef); err != nil {
+			return nil, nil, fmt.Errorf("oauth client secret: %w", err)
+		}
+	}
+	tokens := &secretTokenStore{store: store, name: oauthTokenSecretName(cfg.Name)}
+
+	if cfg.Auth.Type == AuthOAuthClientCredentials {
+		source := &clientCredentialsSource{cfg: *cfg.Auth, clientSecret: clientSecret, tokens: tokens, httpClient: p.oauthHTTPClient()}
+		// Fetch up front so a bad client secret fails the connect with a
+		// useful error instead of an anonymous 401.
+		if _, err := source.token(ctx); err != nil {
+			return nil, nil, fmt.Errorf("oauth client credentials: %w", err)
+		}
+		return source.headers, nil, nil
+	}
+	return nil, &transport.OAuthConfig{
+		ClientID:              cfg.Auth.ClientID,
+		ClientSecret:          clientSecret,
+		RedirectURI:           cfg.Auth.RedirectURI,
+		Scopes:                cfg.Auth.Scopes,
+		TokenStore:            tokens,
+		AuthServerMetadataURL: cfg.Auth.MetadataURL,
+		PKCEEnabled:           true,
+		HTTPClient:            p.oauthHTTPClient(),
+	}, nil
+}
+
+// ValidateRemoteConfig checks the HTTP-only parts of cfg: a URL, headers
+// that keep credentials out of plaintext, and a complete auth block.
+func ValidateRemoteConfig(cfg ServerConfig) error {
+	if IsHTTPTransport(cfg.Transport) && strings.TrimSpace(cfg.URL) == "" {
+		return fmt.Errorf("%s transport requires a url", cfg.Transport)
+	}
+	for name, value := range cfg.Headers {
+		if sensitiveHeader(name) && value != "" && !secrets.HasPlaceholder(value) {
+			return fmt.Errorf("header %s must reference a secret (${secret:NAME} or ${env:NAME}), not a plaintext value", name)
+		}
+		if err := secrets.CheckEnvRefs(value); err != nil {
+			return fmt.Errorf("header %s: %w", name, err)
+		}
+	}
+	if cfg.Auth == nil {
+		return nil
+	}
+	if !IsHTTPTransport(cfg.Transport) {
+		return fmt.Errorf("auth is only supported on HTTP transports")
+	}
+	auth := cfg.Auth
+	if auth.ClientID == "" {
+		return fmt.Errorf("auth.client_id is required")
+	}
+	if auth.ClientSecretRef != "" && !secrets.IsRef(auth.ClientSecretRef) {
+		return fmt.Errorf("auth.client_secret_ref must be an env: or secret: reference")
+	}
+	if err := secrets.CheckEnvRefs(auth.ClientSecretRef); err != nil {
+		return fmt.Errorf("auth.client_secret_ref: %w", err)
+	}
+	switch auth.Type {
+	case AuthOAuthClientCredentials:
+		if auth.TokenURL == "" && auth.MetadataURL == "" {
+			return fmt.Errorf("auth.token_url or auth.auth_server_metadata_url is required for %s", auth.Type)
+		}
+	case AuthOAuthPKCE:
+		if auth.RedirectURI == "" {
+			return fmt.Errorf("auth.redirect_uri is required for %s", auth.Type)
+		}
+	default:
+		return fmt.Errorf("unsupported auth type %s", auth.Type)
+	}
+	return nil
+}
+
+// sensitiveHeader reports whether a header typically carries credentials.
+func sensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	switch lower {
+	case "authorization", "proxy-authorization", "cookie":
+		return true
+	}
+	for _, marker := range []string{"token", "secret", "key", "auth", "password"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingAuthorization is a PKCE authorization waiting for its callback.
+type pendingAuthorization struct {
+	serverID uuid.UUID
+	verifier string
+	handler  *transport.OAuthHandler
+	expires  time.Time
+}
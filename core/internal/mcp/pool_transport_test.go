@@ -0,0 +1,177 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mycelis/core/internal/secrets"
+)
+
+// headerLog records the request headers a stand-in MCP server received.
+type headerLog struct {
+	mu   sync.Mutex
+	seen map[string]string
+}
+
+func (h *headerLog) capture(ctx context.Context, r *http.Request) context.Context {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, name := range []string{"Authorization", "X-Api-Key", "X-Team"} {
+		if v := r.Header.Get(name); v != "" {
+			h.seen[name] = v
+		}
+	}
+	return ctx
+}
+
+func (h *headerLog) get(name string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.seen[name]
+}
+
+// newStandInServer starts a local MCP server with one tool over transport
+// and returns its URL.
+func newStandInServer(t *testing.T, transportType string) (string, *headerLog) {
+	t.Helper()
+	srv := server.NewMCPServer("remote", "1.0.0", server.WithToolCapabilities(true))
+	srv.AddTool(mcp.NewTool("lookup"), func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	log := &headerLog{seen: map[string]string{}}
+	var ts *httptest.Server
+	var path string
+	if transportType == TransportSSE {
+		ts, path = server.NewTestServer(srv, server.WithSSEContextFunc(log.capture)), "/sse"
+	} else {
+		ts, path = server.NewTestStreamableHTTPServer(srv, server.WithHTTPContextFunc(log.capture)), "/mcp"
+	}
+	t.Cleanup(ts.Close)
+	return ts.URL + path, log
+}
+
+func expectConnectWrites(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM mcp_tools").WithArgs(testServerID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO mcp_tools").WithArgs(testServerID, "lookup", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("UPDATE mcp_servers").WithArgs("connected", sqlmock.AnyArg(), testServerID).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestClientPool_ConnectsHTTPTransportsWithResolvedHeaders(t *testing.T) {
+	t.Setenv("MYCELIS_MCP_TEST_KEY", "key-from-env")
+	for _, transportType := range []string{TransportStreamableHTTP, TransportSSE} {
+		t.Run(transportType, func(t *testing.T) {
+			url, headers := newStandInServer(t, transportType)
+			svc, mock := newTestService(t)
+			pool := NewClientPool(svc)
+			t.Cleanup(pool.ShutdownAll)
+			expectConnectWrites(mock)
+
+			cfg := ServerConfig{ID: testServerID, Name: "remote", Transport: transportType, URL: url,
+				Headers: map[string]string{"X-Api-Key": "${env:MYCELIS_MCP_TEST_KEY}", "X-Team": "alpha"}}
+			if err := pool.Connect(context.Background(), cfg); err != nil {
+				t.Fatalf("Connect: %v", err)
+			}
+			if got := headers.get("X-Api-Key"); got != "key-from-env" {
+				t.Errorf("X-Api-Key = %q, want the resolved env value", got)
+			}
+			if got := headers.get("X-Team"); got != "alpha" {
+				t.Errorf("X-Team = %q", got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestClientPool_ClientCredentialsTokenReachesServer(t *testing.T) {
+	t.Setenv("MYCELIS_MCP_TEST_CLIENT_SECRET", "s3cret")
+	grants := 0
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_id") != "mycelis" ||
+			r.Form.Get("client_secret") != "s3cret" || r.Form.Get("scope") != "tools:read" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+		grants++
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "tok-1", "token_type": "bearer", "expires_in": 3600})
+	}))
+	t.Cleanup(tokenSrv.Close)
+
+	url, headers := newStandInServer(t, TransportStreamableHTTP)
+	secretDB, secretMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { secretDB.Close() })
+	secretMock.ExpectExec("INSERT INTO secrets_kdf").WillReturnResult(sqlmock.NewResult(0, 1))
+	secretMock.ExpectQuery("SELECT salt FROM secrets_kdf").WillReturnRows(sqlmock.NewRows([]string{"salt"}).AddRow([]byte("test-salt")))
+	store, err := secrets.NewStore(context.Background(), secretDB, "test-key")
+	if err != nil {
+		t.Fatalf("secret store: %v", err)
+	}
+	secretMock.ExpectQuery("SELECT ciphertext FROM secrets").WithArgs("mcp-oauth/remote").
+		WillReturnRows(sqlmock.NewRows([]string{"ciphertext"}))
+	secretMock.ExpectExec("INSERT INTO secrets").WithArgs("mcp-oauth/remote", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	svc, mock := newTestService(t)
+	pool := NewClientPool(svc)
+	pool.SetSecretStore(store)
+	t.Cleanup(pool.ShutdownAll)
+	expectConnectWrites(mock)
+
+	cfg := ServerConfig{ID: testServerID, Name: "remote", Transport: TransportStreamableHTTP, URL: url,
+		Auth: &AuthConfig{Type: AuthOAuthClientCredentials, ClientID: "mycelis", ClientSecretRef: "env:MYCELIS_MCP_TEST_CLIENT_SECRET",
+			TokenURL: tokenSrv.URL, Scopes: []string{"tools:read"}}}
+	if err := pool.Connect(context.Background(), cfg); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if got := headers.get("Authorization"); got != "Bearer tok-1" {
+		t.Errorf("Authorization = %q, want the issued bearer token", got)
+	}
+	if grants != 1 {
+		t.Errorf("token endpoint hit %d times, want the token reused", grants)
+	}
+	if err := secretMock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("token not persisted in the secret store: %v", err)
+	}
+}
+
+func TestValidateRemoteConfig(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  ServerConfig
+		want string
+	}{
+		{"plaintext authorization", ServerConfig{Transport: TransportStreamableHTTP, URL: "https://mcp.example.com/mcp", Headers: map[string]string{"Authorization": "Bearer ghp_live"}}, "must reference a secret"},
+		{"referenced authorization", ServerConfig{Transport: TransportStreamableHTTP, URL: "https://mcp.example.com/mcp", Headers: map[string]string{"Authorization": "Bearer ${secret:github-token}"}}, ""},
+		{"missing url", ServerConfig{Transport: TransportSSE}, "requires a url"},
+		{"auth on stdio", ServerConfig{Transport: TransportStdio, Auth: &AuthConfig{Type: AuthOAuthPKCE, ClientID: "c"}}, "only supported on HTTP"},
+		{"pkce without redirect", ServerConfig{Transport: TransportSSE, URL: "https://mcp.example.com/sse", Auth: &AuthConfig{Type: AuthOAuthPKCE, ClientID: "c"}}, "redirect_uri"},
+		{"plaintext client secret", ServerConfig{Transport: TransportSSE, URL: "https://mcp.example.com/sse", Auth: &AuthConfig{Type: AuthOAuthClientCredentials, ClientID: "c", ClientSecretRef: "hunter2", TokenURL: "https://auth.example.com/token"}}, "client_secret_ref"},
+		{"store passphrase as client secret", ServerConfig{Transport: TransportSSE, URL: "https://mcp.example.com/sse", Auth: &AuthConfig{Type: AuthOAuthClientCredentials, ClientID: "c", ClientSecretRef: "env:" + secrets.KeyEnv, TokenURL: "https://auth.example.com/token"}}, "not allowed"},
+		{"header reads unrelated env", ServerConfig{Transport: TransportStreamableHTTP, URL: "https://mcp.example.com/mcp", Headers: map[string]string{"X-Api-Key": "${env:DATABASE_URL}"}}, "not allowed"},
+	}
+	for _, tc := range cases {
+		err := ValidateRemoteConfig(tc.cfg)
+		switch {
+		case tc.want == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+			t.Errorf("%s: error = %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
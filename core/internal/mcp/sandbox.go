@@ -0,0 +1,196 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// Environment variables that locate the sandbox tooling on the host.
+const (
+	SandboxBwrapEnv         = "MYCELIS_MCP_BWRAP"          // bubblewrap binary, default "bwrap" on PATH
+	SandboxWorkspaceRootEnv = "MYCELIS_MCP_WORKSPACE_ROOT" // parent of per-server workspaces
+	SandboxCgroupRootEnv    = "MYCELIS_MCP_CGROUP_ROOT"    // cgroup v2 directory for limits
+)
+
+// SandboxProfile isolates a stdio MCP server with bubblewrap: only the
+// system directories and a few /etc files are mounted, read-only, /tmp is
+// private, namespaces are unshared and the process starts with a clean
+// environment. The command must live in those directories or the workspace. Limits are enforced by a
+// per-server cgroup v2; zero values leave a limit unset.
+type SandboxProfile struct {
+	Network    bool     `json:"network,omitempty" yaml:"network,omitempty"`         // keep the host network namespace
+	Workspace  string   `json:"workspace,omitempty" yaml:"workspace,omitempty"`     // writable directory, relative to the workspace root
+	MemoryMB   int      `json:"memory_mb,omitempty" yaml:"memory_mb,omitempty"`     // memory.max; swap is disabled
+	CPUPercent int      `json:"cpu_percent,omitempty" yaml:"cpu_percent,omitempty"` // cpu.max, 100 = one core
+	MaxPIDs    int      `json:"max_pids,omitempty" yaml:"max_pids,omitempty"`       // pids.max
+	PassEnv    []string `json:"pass_env,omitempty" yaml:"pass_env,omitempty"`       // host variables copied into the clean environment
+}
+
+// hasLimits reports whether the profile needs a cgroup.
+func (sp SandboxProfile) hasLimits() bool {
+	return sp.MemoryMB > 0 || sp.CPUPercent > 0 || sp.MaxPIDs > 0
+}
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateSandbox checks cfg.Sandbox: only stdio servers can be sandboxed,
+// the workspace must stay inside the workspace root and limits must be
+// non-negative.
+func ValidateSandbox(cfg ServerConfig) error {
+	sp := cfg.Sandbox
+	if sp == nil {
+		return nil
+	}
+	if cfg.Transport != TransportStdio {
+		return fmt.Errorf("sandbox is only supported on the stdio transport")
+	}
+	if sp.Workspace != "" {
+		clean := filepath.Clean(sp.Workspace)
+		if filepath.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("sandbox workspace %s must be a relative path inside the workspace root", sp.Workspace)
+		}
+	}
+	if sp.MemoryMB < 0 || sp.CPUPercent < 0 || sp.MaxPIDs < 0 {
+		return fmt.Errorf("sandbox limits must not be negative")
+	}
+	for _, name := range sp.PassEnv {
+		if !envNamePattern.MatchString(name) {
+			return fmt.Errorf("sandbox pass_env %s is not a valid variable name", name)
+		}
+	}
+	return nil
+}
+
+// sandboxWorkspaceRoot is where per-server workspaces are created.
+func sandboxWorkspaceRoot() string {
+	if root := strings.TrimSpace(os.Getenv(SandboxWorkspaceRootEnv)); root != "" {
+		return root
+	}
+	return filepath.Join(os.TempDir(), "mycelis-mcp-workspaces")
+}
+
+// prepareWorkspace creates the profile's workspace and returns its host path,
+// or "" when the profile has none.
+func prepareWorkspace(sp SandboxProfile) (string, error) {
+	if sp.Workspace == "" {
+		return "", nil
+	}
+	dir := filepath.Join(sandboxWorkspaceRoot(), filepath.Clean(sp.Workspace))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("create sandbox workspace: %w", err)
+	}
+	return dir, nil
+}
+
+// sandboxSystemPaths are the host paths a sandboxed server sees, read-only:
+// binaries and libraries, and the /etc files name resolution, TLS and user
+// lookup need. Paths missing on the host are skipped.
+var sandboxSystemPaths = []string{
+	"/usr", "/bin", "/sbin", "/lib", "/lib32", "/lib64",
+	"/etc/resolv.conf", "/etc/hosts", "/etc/nsswitch.conf",
+	"/etc/passwd", "/etc/group", "/etc/ld.so.cache", "/etc/alternatives",
+	"/etc/ssl", "/etc/ca-certificates", "/etc/pki",
+}
+
+// bwrapArgs returns the bubblewrap arguments that run command with args
+// under sp. workspace is the host path bound read-write, if any.
+func bwrapArgs(sp SandboxProfile, workspace, command string, args []string) []string {
+	argv := []string{
+		"--die-with-parent",
+		"--new-session",
+		"--unshare-all",
+	}
+	if sp.Network {
+		argv = append(argv, "--share-net")
+	}
+	argv = append(argv, "--cap-drop", "ALL")
+	for _, path := range sandboxSystemPaths {
+		argv = append(argv, "--ro-bind-try", path, path)
+	}
+	argv = append(argv,
+		"--dev", "/dev",
+		"--proc", "/proc",
+		"--tmpfs", "/tmp",
+	)
+	if workspace != "" {
+		argv = append(argv, "--bind", workspace, workspace, "--chdir", workspace)
+	}
+	argv = append(argv, "--", command)
+	return append(argv, args...)
+}
+
+// sandboxEnv builds the clean environment for a sandboxed server: a minimal
+// base, the host variables named in PassEnv, then the server's own env.
+func sandboxEnv(sp SandboxProfile, workspace string, env map[string]string) []string {
+	home := "/tmp"
+	if workspace != "" {
+		home = workspace
+	}
+	vars := map[string]string{
+		"PATH":   os.Getenv("PATH"),
+		"HOME":   home,
+		"TMPDIR": "/tmp",
+		"LANG":   "C.UTF-8",
+	}
+	for _, name := range sp.PassEnv {
+		if v, ok := os.LookupEnv(name); ok {
+			vars[name] = v
+		}
+	}
+	for k, v := range env {
+		vars[k] = v
+	}
+	out := make([]string, 0, len(vars))
+	for k, v := range vars {
+		out = append(out, k+"="+v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// sandboxTransport returns a stdio transport that launches cfg under
+// bubblewrap. It fails closed: a missing bwrap binary or an unavailable
+// cgroup for requested limits stops the server from starting.
+func (p *ClientPool) sandboxTransport(cfg ServerConfig) (transport.Interface, error) {
+	if err := ValidateSandbox(cfg); err != nil {
+		return nil, err
+	}
+	bwrap := strings.TrimSpace(os.Getenv(SandboxBwrapEnv))
+	if bwrap == "" {
+		bwrap = "bwrap"
+	}
+	bwrapPath, err := exec.LookPath(bwrap)
+	if err != nil {
+		return nil, fmt.Errorf("sandbox requires bubblewrap (set %s): %w", SandboxBwrapEnv, err)
+	}
+	sp := *cfg.Sandbox
+	workspace, err := prepareWorkspace(sp)
+	if err != nil {
+		return nil, err
+	}
+	spawn := func(_ context.Context, command string, _ []string, args []string) (*exec.Cmd, error) {
+		// Not CommandContext: the connect context ends once the session is
+		// up, and the server must outlive it. Close stops the process.
+		cmd := exec.Command(bwrapPath, bwrapArgs(sp, workspace, command, args)...)
+		cmd.Env = sandboxEnv(sp, workspace, cfg.Env)
+		if !sp.hasLimits() {
+			return cmd, nil
+		}
+		cg, err := newSandboxCgroup(cfg.ID.String(), sp)
+		if err != nil {
+			return nil, err
+		}
+		cg.attach(cmd)
+		p.setSandboxCgroup(cfg.ID, cg)
+		return cmd, nil
+	}
+	return transport.NewStdioWithOptions(cfg.Command, nil, cfg.Args, transport.WithCommandFunc(spawn)), nil
+}
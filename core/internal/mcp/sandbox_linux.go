@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const defaultSandboxCgroupRoot = "/sys/fs/cgroup/mycelis-mcp"
+
+// sandboxCgroup is the cgroup v2 directory holding one sandboxed server.
+type sandboxCgroup struct {
+	path string
+	dir  *os.File // passed to clone via CgroupFD
+	seen map[string]int64
+}
+
+// newSandboxCgroup creates a cgroup for the server named by key and writes
+// the profile's limits into it.
+func newSandboxCgroup(key string, sp SandboxProfile) (*sandboxCgroup, error) {
+	root := strings.TrimSpace(os.Getenv(SandboxCgroupRootEnv))
+	if root == "" {
+		root = defaultSandboxCgroupRoot
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(root), "cgroup.controllers")); err != nil {
+		return nil, fmt.Errorf("sandbox limits require cgroup v2 at %s (set %s): %w", filepath.Dir(root), SandboxCgroupRootEnv, err)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create sandbox cgroup root: %w", err)
+	}
+	// Delegate the controllers down to the per-server groups. These writes
+	// fail harmlessly when the controllers are already enabled.
+	for _, dir := range []string{filepath.Dir(root), root} {
+		_ = os.WriteFile(filepath.Join(dir, "cgroup.subtree_control"), []byte("+memory +cpu +pids"), 0o644)
+	}
+
+	path := filepath.Join(root, key+"-"+uuid.NewString()[:8])
+	if err := os.Mkdir(path, 0o755); err != nil {
+		return nil, fmt.Errorf("create sandbox cgroup: %w", err)
+	}
+	cg := &sandboxCgroup{path: path, seen: map[string]int64{}}
+	limits := map[string]string{}
+	if sp.MemoryMB > 0 {
+		limits["memory.max"] = strconv.FormatInt(int64(sp.MemoryMB)<<20, 10)
+		limits["memory.swap.max"] = "0"
+	}
+	if sp.CPUPercent > 0 {
+		limits["cpu.max"] = fmt.Sprintf("%d 100000", sp.CPUPercent*1000)
+	}
+	if sp.MaxPIDs > 0 {
+		limits["pids.max"] = strconv.Itoa(sp.MaxPIDs)
+	}
+	for file, value := range limits {
+		if err := os.WriteFile(filepath.Join(path, file), []byte(value), 0o644); err != nil && file != "memory.swap.max" {
+			cg.release()
+			return nil, fmt.Errorf("set sandbox %s: %w", file, err)
+		}
+	}
+	dir, err := os.Open(path)
+	if err != nil {
+		cg.release()
+		return nil, fmt.Errorf("open sandbox cgroup: %w", err)
+	}
+	cg.dir = dir
+	return cg, nil
+}
+
+// attach makes cmd start directly inside the cgroup, so the limits apply
+// from the first instruction.
+func (cg *sandboxCgroup) attach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{UseCgroupFD: true, CgroupFD: int(cg.dir.Fd())}
+}
+
+// events returns limit hits since the previous call, keyed by violation kind.
+func (cg *sandboxCgroup) events() map[string]int64 {
+	hits := map[string]int64{}
+	for file, counters := range map[string]map[string]string{
+		"memory.events": {"oom_kill": SandboxViolationMemory},
+		"pids.events":   {"max": SandboxViolationPIDs},
+	} {
+		raw, err := os.ReadFile(filepath.Join(cg.path, file))
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(raw), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 2 {
+				continue
+			}
+			kind, ok := counters[fields[0]]
+			if !ok {
+				continue
+			}
+			n, _ := strconv.ParseInt(fields[1], 10, 64)
+			if n > cg.seen[kind] {
+				hits[kind] = n - cg.seen[kind]
+			}
+			cg.seen[kind] = n
+		}
+	}
+	return hits
+}
+
+// release kills anything left in the cgroup and removes it.
+func (cg *sandboxCgroup) release() {
+	if cg.dir != nil {
+		_ = cg.dir.Close()
+	}
+	_ = os.WriteFile(filepath.Join(cg.path, "cgroup.kill"), []byte("1"), 0o644)
+	for i := 0; i < 10; i++ {
+		if err := os.Remove(cg.path); err == nil || os.IsNotExist(err) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
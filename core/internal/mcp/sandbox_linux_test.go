@@ -0,0 +1,30 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSandboxCgroup_EventsReportNewHitsOnly(t *testing.T) {
+	dir := t.TempDir()
+	write := func(file, body string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cg := &sandboxCgroup{path: dir, seen: map[string]int64{}}
+	write("memory.events", "low 0\nhigh 0\nmax 4\noom 1\noom_kill 1\n")
+	write("pids.events", "max 0\n")
+	if hits := cg.events(); len(hits) != 1 || hits[SandboxViolationMemory] != 1 {
+		t.Fatalf("first read = %v", hits)
+	}
+	if hits := cg.events(); len(hits) != 0 {
+		t.Fatalf("unchanged counters reported again: %v", hits)
+	}
+	write("pids.events", "max 3\n")
+	if hits := cg.events(); len(hits) != 1 || hits[SandboxViolationPIDs] != 3 {
+		t.Fatalf("pids read = %v", hits)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build !linux
+
+package mcp
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// sandboxCgroup is unavailable off Linux; newSandboxCgroup always fails so
+// profiles with limits fail closed.
+type sandboxCgroup struct{}
+
+func newSandboxCgroup(string, SandboxProfile) (*sandboxCgroup, error) {
+	return nil, fmt.Errorf("sandbox limits require Linux cgroup v2")
+}
+
+func (cg *sandboxCgroup) attach(*exec.Cmd) {}
+
+func (cg *sandboxCgroup) events() map[string]int64 { return nil }
+
+func (cg *sandboxCgroup) release() {}
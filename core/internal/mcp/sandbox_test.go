@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestBwrapArgs(t *testing.T) {
+	argv := bwrapArgs(SandboxProfile{}, "", "npx", []string{"-y", "server-fs"})
+	if !slices.Contains(argv, "--unshare-all") || slices.Contains(argv, "--share-net") {
+		t.Fatalf("network not isolated: %v", argv)
+	}
+	if got := strings.Join(argv, " "); !strings.Contains(got, "--ro-bind-try /usr /usr") || !strings.HasSuffix(got, "-- npx -y server-fs") {
+		t.Fatalf("argv = %s", got)
+	}
+	if slices.Contains(argv, "--bind") {
+		t.Fatalf("writable bind without a workspace: %v", argv)
+	}
+
+	argv = bwrapArgs(SandboxProfile{Network: true}, "/srv/ws/fs", "npx", nil)
+	got := strings.Join(argv, " ")
+	if !strings.Contains(got, "--share-net") || !strings.Contains(got, "--bind /srv/ws/fs /srv/ws/fs --chdir /srv/ws/fs") {
+		t.Fatalf("argv = %s", got)
+	}
+}
+
+func TestBwrapArgs_HidesTheHostFilesystem(t *testing.T) {
+	configDir, err := filepath.Abs("../../config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	argv := bwrapArgs(SandboxProfile{Workspace: "fs"}, "/srv/ws/fs", "npx", nil)
+	for i, arg := range argv {
+		if arg != "--ro-bind" && arg != "--ro-bind-try" && arg != "--bind" {
+			continue
+		}
+		source := argv[i+1]
+		if rel, err := filepath.Rel(source, configDir); err == nil && !strings.HasPrefix(rel, "..") {
+			t.Fatalf("%s %s exposes the core config dir %s", arg, source, configDir)
+		}
+		for _, hidden := range []string{"/home", "/root", "/etc/shadow", "/var", "/proc/1/root"} {
+			if rel, err := filepath.Rel(source, hidden); err == nil && !strings.HasPrefix(rel, "..") {
+				t.Fatalf("%s %s exposes %s", arg, source, hidden)
+			}
+		}
+	}
+}
+
+func TestSandboxEnv_StartsClean(t *testing.T) {
+	t.Setenv("MYCELIS_TEST_HOST_SECRET", "leak")
+	t.Setenv("MYCELIS_TEST_PASSED", "kept")
+	env := sandboxEnv(SandboxProfile{PassEnv: []string{"MYCELIS_TEST_PASSED"}}, "/srv/ws/fs", map[string]string{"API_URL": "http://x"})
+	joined := strings.Join(env, "\n")
+	if strings.Contains(joined, "MYCELIS_TEST_HOST_SECRET") {
+		t.Fatal("host environment leaked into the sandbox")
+	}
+	for _, want := range []string{"MYCELIS_TEST_PASSED=kept", "API_URL=http://x", "HOME=/srv/ws/fs", "TMPDIR=/tmp"} {
+		if !slices.Contains(env, want) {
+			t.Errorf("env missing %s: %v", want, env)
+		}
+	}
+}
+
+func TestValidateSandbox(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  ServerConfig
+		want string
+	}{
+		{"plain", ServerConfig{Transport: TransportStdio, Sandbox: &SandboxProfile{Workspace: "fs", MemoryMB: 256}}, ""},
+		{"http", ServerConfig{Transport: TransportStreamableHTTP, Sandbox: &SandboxProfile{}}, "stdio"},
+		{"absolute workspace", ServerConfig{Transport: TransportStdio, Sandbox: &SandboxProfile{Workspace: "/etc"}}, "relative"},
+		{"escaping workspace", ServerConfig{Transport: TransportStdio, Sandbox: &SandboxProfile{Workspace: "a/../../b"}}, "relative"},
+		{"negative limit", ServerConfig{Transport: TransportStdio, Sandbox: &SandboxProfile{MaxPIDs: -1}}, "negative"},
+		{"bad pass_env", ServerConfig{Transport: TransportStdio, Sandbox: &SandboxProfile{PassEnv: []string{"A=B"}}}, "pass_env"},
+	}
+	for _, tc := range cases {
+		err := ValidateSandbox(tc.cfg)
+		switch {
+		case tc.want == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+			t.Errorf("%s: error = %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestClassifySandboxStderr(t *testing.T) {
+	offline := SandboxProfile{}
+	cases := map[string]string{
+		"Error: EROFS: read-only file system, open '/etc/passwd'": SandboxViolationFilesystem,
+		"getaddrinfo EAI_AGAIN registry.npmjs.org":                SandboxViolationNetwork,
+		"bwrap: Creating new namespace failed":                    SandboxViolationSetup,
+		"ptrace: Operation not permitted":                         SandboxViolationPermission,
+		"Server listening on stdio":                               "",
+	}
+	for line, want := range cases {
+		if got := classifySandboxStderr(offline, line); got != want {
+			t.Errorf("%q classified %q, want %q", line, got, want)
+		}
+	}
+	if got := classifySandboxStderr(SandboxProfile{Network: true}, "getaddrinfo EAI_AGAIN example.com"); got != "" {
+		t.Errorf("network error with network on classified %q", got)
+	}
+}
+
+func TestClientPool_SandboxViolationsAreThrottled(t *testing.T) {
+	pool := NewClientPool(nil)
+	var got []SandboxViolation
+	pool.OnSandboxViolation(func(v SandboxViolation) { got = append(got, v) })
+	cfg := ServerConfig{ID: testServerID, Name: "fs"}
+	pool.reportSandboxViolation(cfg, SandboxViolationFilesystem, "first")
+	pool.reportSandboxViolation(cfg, SandboxViolationFilesystem, "repeat")
+	pool.reportSandboxViolation(cfg, SandboxViolationNetwork, "other kind")
+	if len(got) != 2 || got[0].Detail != "first" || got[1].Kind != SandboxViolationNetwork {
+		t.Fatalf("violations = %+v", got)
+	}
+}
+
+func TestClientPool_SandboxFailsClosedWithoutBubblewrap(t *testing.T) {
+	t.Setenv(SandboxBwrapEnv, filepath.Join(t.TempDir(), "missing-bwrap"))
+	svc, mock := newTestService(t)
+	pool := NewClientPool(svc)
+	mock.ExpectExec("UPDATE mcp_servers").WithArgs("error", sqlmock.AnyArg(), testServerID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	cfg := ServerConfig{ID: testServerID, Name: "fs", Transport: TransportStdio, Command: "true", Sandbox: &SandboxProfile{}}
+	err := pool.Connect(context.Background(), cfg)
+	if err == nil || !strings.Contains(err.Error(), "bubblewrap") {
+		t.Fatalf("Connect error = %v, want a bubblewrap error", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,166 @@
+package mcp
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// Kinds of SandboxViolation.
+const (
+	SandboxViolationFilesystem = "filesystem" // write outside the workspace
+	SandboxViolationNetwork    = "network"    // network use with network off
+	SandboxViolationPermission = "permission" // syscall refused by the sandbox
+	SandboxViolationMemory     = "memory"     // OOM kill at memory.max
+	SandboxViolationPIDs       = "pids"       // fork refused at pids.max
+	SandboxViolationSetup      = "setup"      // bubblewrap failed to build the sandbox
+)
+
+// sandboxReportInterval suppresses repeats of the same violation kind.
+const sandboxReportInterval = time.Minute
+
+// SandboxViolation reports a sandboxed server hitting one of its limits.
+type SandboxViolation struct {
+	ServerID   uuid.UUID `json:"server_id"`
+	ServerName string    `json:"server_name"`
+	Kind       string    `json:"kind"`
+	Detail     string    `json:"detail"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// sandboxState tracks the cgroups of running sandboxed servers and when
+// each violation kind was last reported.
+type sandboxState struct {
+	mu       sync.Mutex
+	cgroups  map[uuid.UUID]*sandboxCgroup
+	reported map[string]time.Time
+	onReport func(SandboxViolation)
+}
+
+// OnSandboxViolation registers fn for violations by sandboxed servers.
+func (p *ClientPool) OnSandboxViolation(fn func(SandboxViolation)) {
+	p.sandboxes.mu.Lock()
+	p.sandboxes.onReport = fn
+	p.sandboxes.mu.Unlock()
+}
+
+func (p *ClientPool) setSandboxCgroup(serverID uuid.UUID, cg *sandboxCgroup) {
+	p.sandboxes.mu.Lock()
+	if p.sandboxes.cgroups == nil {
+		p.sandboxes.cgroups = make(map[uuid.UUID]*sandboxCgroup)
+	}
+	previous := p.sandboxes.cgroups[serverID]
+	p.sandboxes.cgroups[serverID] = cg
+	p.sandboxes.mu.Unlock()
+	if previous != nil {
+		previous.release()
+	}
+}
+
+// releaseSandbox removes the server's cgroup; call it after the client is
+// closed so the process has already exited.
+func (p *ClientPool) releaseSandbox(serverID uuid.UUID) {
+	p.sandboxes.mu.Lock()
+	cg := p.sandboxes.cgroups[serverID]
+	delete(p.sandboxes.cgroups, serverID)
+	p.sandboxes.mu.Unlock()
+	if cg != nil {
+		cg.release()
+	}
+}
+
+func (p *ClientPool) releaseAllSandboxes() {
+	p.sandboxes.mu.Lock()
+	cgroups := p.sandboxes.cgroups
+	p.sandboxes.cgroups = nil
+	p.sandboxes.mu.Unlock()
+	for _, cg := range cgroups {
+		cg.release()
+	}
+}
+
+// watchSandboxStderr drains a sandboxed server's stderr, logging it and
+// reporting lines that show the sandbox refusing something.
+func (p *ClientPool) watchSandboxStderr(cfg ServerConfig, t transport.Interface) {
+	stdio, ok := t.(*transport.Stdio)
+	if cfg.Sandbox == nil || !ok || stdio.Stderr() == nil {
+		return
+	}
+	go func() {
+		scanner := bufio.NewScanner(stdio.Stderr())
+		for scanner.Scan() {
+			line := scanner.Text()
+			log.Printf("mcp sandbox: %s: %s", cfg.Name, line)
+			if kind := classifySandboxStderr(*cfg.Sandbox, line); kind != "" {
+				p.reportSandboxViolation(cfg, kind, line)
+			}
+		}
+	}()
+}
+
+// checkSandboxLimits reports cgroup limit hits since the last check.
+func (p *ClientPool) checkSandboxLimits(cfg ServerConfig) {
+	p.sandboxes.mu.Lock()
+	cg := p.sandboxes.cgroups[cfg.ID]
+	var hits map[string]int64
+	if cg != nil {
+		hits = cg.events()
+	}
+	p.sandboxes.mu.Unlock()
+	for kind, n := range hits {
+		p.reportSandboxViolation(cfg, kind, fmt.Sprintf("%s limit hit %d time(s)", kind, n))
+	}
+}
+
+func (p *ClientPool) reportSandboxViolation(cfg ServerConfig, kind, detail string) {
+	now := time.Now()
+	key := cfg.ID.String() + "/" + kind
+	p.sandboxes.mu.Lock()
+	if last, ok := p.sandboxes.reported[key]; ok && now.Sub(last) < sandboxReportInterval {
+		p.sandboxes.mu.Unlock()
+		return
+	}
+	if p.sandboxes.reported == nil {
+		p.sandboxes.reported = make(map[string]time.Time)
+	}
+	p.sandboxes.reported[key] = now
+	fn := p.sandboxes.onReport
+	p.sandboxes.mu.Unlock()
+
+	log.Printf("mcp sandbox: %s (%s) violation %s: %s", cfg.Name, cfg.ID, kind, detail)
+	if fn != nil {
+		fn(SandboxViolation{ServerID: cfg.ID, ServerName: cfg.Name, Kind: kind, Detail: detail, Timestamp: now})
+	}
+}
+
+// classifySandboxStderr maps a stderr line to a violation kind, or "".
+func classifySandboxStderr(sp SandboxProfile, line string) string {
+	lower := strings.ToLower(line)
+	switch {
+	case strings.HasPrefix(lower, "bwrap:"):
+		return SandboxViolationSetup
+	case strings.Contains(lower, "read-only file system") || strings.Contains(lower, "erofs"):
+		return SandboxViolationFilesystem
+	case !sp.Network && containsAny(lower, "network is unreachable", "enetunreach", "eai_again",
+		"temporary failure in name resolution", "name or service not known"):
+		return SandboxViolationNetwork
+	case strings.Contains(lower, "operation not permitted") || strings.Contains(lower, "eperm"):
+		return SandboxViolationPermission
+	}
+	return ""
+}
+
+func containsAny(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
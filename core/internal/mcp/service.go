@@ -34,14 +34,26 @@ func (s *Service) Install(ctx context.Context, cfg ServerConfig) (*ServerConfig,
 	if err != nil {
 		return nil, fmt.Errorf("marshal headers: %w", err)
 	}
+	var authJSON []byte
+	if cfg.Auth != nil {
+		if authJSON, err = json.Marshal(cfg.Auth); err != nil {
+			return nil, fmt.Errorf("marshal auth: %w", err)
+		}
+	}
+	var sandboxJSON []byte
+	if cfg.Sandbox != nil {
+		if sandboxJSON, err = json.Marshal(cfg.Sandbox); err != nil {
+			return nil, fmt.Errorf("marshal sandbox: %w", err)
+		}
+	}
 
 	var result ServerConfig
-	var argsOut, envOut, headersOut []byte
+	var argsOut, envOut, headersOut, authOut, sandboxOut []byte
 	var errMsg sql.NullString
 
 	err = s.DB.QueryRowContext(ctx, `
-		INSERT INTO mcp_servers (name, transport, command, args, env, url, headers)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO mcp_servers (name, transport, command, args, env, url, headers, auth, sandbox)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		ON CONFLICT (name) DO UPDATE
 		SET transport = EXCLUDED.transport,
 		    command = EXCLUDED.command,
@@ -49,30 +61,22 @@ func (s *Service) Install(ctx context.Context, cfg ServerConfig) (*ServerConfig,
 		    env = EXCLUDED.env,
 		    url = EXCLUDED.url,
 		    headers = EXCLUDED.headers,
+		    auth = EXCLUDED.auth,
+		    sandbox = EXCLUDED.sandbox,
 		    status = 'installed',
 		    error_message = NULL,
 		    updated_at = NOW()
-		RETURNING id, name, transport, command, args, env, url, headers, status, error_message, created_at, updated_at
-	`, cfg.Name, cfg.Transport, cfg.Command, argsJSON, envJSON, cfg.URL, headersJSON).Scan(
+		RETURNING id, name, transport, command, args, env, url, headers, auth, sandbox, status, error_message, created_at, updated_at
+	`, cfg.Name, cfg.Transport, cfg.Command, argsJSON, envJSON, cfg.URL, headersJSON, authJSON, sandboxJSON).Scan(
 		&result.ID, &result.Name, &result.Transport, &result.Command,
-		&argsOut, &envOut, &result.URL, &headersOut,
+		&argsOut, &envOut, &result.URL, &headersOut, &authOut, &sandboxOut,
 		&result.Status, &errMsg, &result.CreatedAt, &result.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("install mcp server: %w", err)
 	}
-
-	if errMsg.Valid {
-		result.Error = errMsg.String
-	}
-	if err := json.Unmarshal(argsOut, &result.Args); err != nil {
-		return nil, fmt.Errorf("unmarshal args: %w", err)
-	}
-	if err := json.Unmarshal(envOut, &result.Env); err != nil {
-		return nil, fmt.Errorf("unmarshal env: %w", err)
-	}
-	if err := json.Unmarshal(headersOut, &result.Headers); err != nil {
-		return nil, fmt.Errorf("unmarshal headers: %w", err)
+	if err := decodeServerJSON(&result, argsOut, envOut, headersOut, authOut, sandboxOut, errMsg); err != nil {
+		return nil, err
 	}
 
 	return &result, nil
@@ -81,7 +85,7 @@ func (s *Service) Install(ctx context.Context, cfg ServerConfig) (*ServerConfig,
 // List returns all registered MCP servers.
 func (s *Service) List(ctx context.Context) ([]ServerConfig, error) {
 	rows, err := s.DB.QueryContext(ctx, `
-		SELECT id, name, transport, command, args, env, url, headers, status, error_message, created_at, updated_at
+		SELECT id, name, transport, command, args, env, url, headers, auth, sandbox, status, error_message, created_at, updated_at
 		FROM mcp_servers
 		ORDER BY created_at ASC
 	`)
@@ -104,24 +108,24 @@ func (s *Service) List(ctx context.Context) ([]ServerConfig, error) {
 // Get retrieves a single MCP server by ID.
 func (s *Service) Get(ctx context.Context, id uuid.UUID) (*ServerConfig, error) {
 	row := s.DB.QueryRowContext(ctx, `
-		SELECT id, name, transport, command, args, env, url, headers, status, error_message, created_at, updated_at
+		SELECT id, name, transport, command, args, env, url, headers, auth, sandbox, status, error_message, created_at, updated_at
 		FROM mcp_servers
 		WHERE id = $1
 	`, id)
 
 	var srv ServerConfig
-	var argsJSON, envJSON, headersJSON []byte
+	var argsJSON, envJSON, headersJSON, authJSON, sandboxJSON []byte
 	var errMsg sql.NullString
 
 	err := row.Scan(
 		&srv.ID, &srv.Name, &srv.Transport, &srv.Command,
-		&argsJSON, &envJSON, &srv.URL, &headersJSON,
+		&argsJSON, &envJSON, &srv.URL, &headersJSON, &authJSON, &sandboxJSON,
 		&srv.Status, &errMsg, &srv.CreatedAt, &srv.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("get mcp server %s: %w", id, err)
 	}
-	if err := decodeServerJSON(&srv, argsJSON, envJSON, headersJSON, errMsg); err != nil {
+	if err := decodeServerJSON(&srv, argsJSON, envJSON, headersJSON, authJSON, sandboxJSON, errMsg); err != nil {
 		return nil, err
 	}
 
@@ -66,7 +66,7 @@ func (s *Service) FindToolByName(ctx context.Context, name string) (*ToolDef, *S
 	row := s.DB.QueryRowContext(ctx, `
 		SELECT
 			t.id, t.server_id, s.name, t.name, t.description, t.input_schema,
-			s.id, s.name, s.transport, s.command, s.args, s.env, s.url, s.headers, s.status, s.error_message, s.created_at, s.updated_at
+			s.id, s.name, s.transport, s.command, s.args, s.env, s.url, s.headers, s.auth, s.sandbox, s.status, s.error_message, s.created_at, s.updated_at
 		FROM mcp_tools t
 		JOIN mcp_servers s ON s.id = t.server_id
 		WHERE t.name = $1
@@ -76,13 +76,13 @@ func (s *Service) FindToolByName(ctx context.Context, name string) (*ToolDef, *S
 	var tool ToolDef
 	var srv ServerConfig
 	var toolDesc sql.NullString
-	var argsJSON, envJSON, headersJSON []byte
+	var argsJSON, envJSON, headersJSON, authJSON, sandboxJSON []byte
 	var srvErrMsg sql.NullString
 
 	err := row.Scan(
 		&tool.ID, &tool.ServerID, &tool.ServerName, &tool.Name, &toolDesc, &tool.InputSchema,
 		&srv.ID, &srv.Name, &srv.Transport, &srv.Command,
-		&argsJSON, &envJSON, &srv.URL, &headersJSON,
+		&argsJSON, &envJSON, &srv.URL, &headersJSON, &authJSON, &sandboxJSON,
 		&srv.Status, &srvErrMsg, &srv.CreatedAt, &srv.UpdatedAt,
 	)
 	if err != nil {
@@ -91,7 +91,7 @@ func (s *Service) FindToolByName(ctx context.Context, name string) (*ToolDef, *S
 	if toolDesc.Valid {
 		tool.Description = toolDesc.String
 	}
-	if err := decodeServerJSON(&srv, argsJSON, envJSON, headersJSON, srvErrMsg); err != nil {
+	if err := decodeServerJSON(&srv, argsJSON, envJSON, headersJSON, authJSON, sandboxJSON, srvErrMsg); err != nil {
 		return nil, nil, err
 	}
 	return &tool, &srv, nil
@@ -99,18 +99,18 @@ func (s *Service) FindToolByName(ctx context.Context, name string) (*ToolDef, *S
 
 func (s *Service) FindServerByName(ctx context.Context, name string) (*ServerConfig, error) {
 	row := s.DB.QueryRowContext(ctx, `
-		SELECT id, name, transport, command, args, env, url, headers, status, error_message, created_at, updated_at
+		SELECT id, name, transport, command, args, env, url, headers, auth, sandbox, status, error_message, created_at, updated_at
 		FROM mcp_servers
 		WHERE name = $1
 	`, name)
 
 	var srv ServerConfig
-	var argsJSON, envJSON, headersJSON []byte
+	var argsJSON, envJSON, headersJSON, authJSON, sandboxJSON []byte
 	var errMsg sql.NullString
 
 	err := row.Scan(
 		&srv.ID, &srv.Name, &srv.Transport, &srv.Command,
-		&argsJSON, &envJSON, &srv.URL, &headersJSON,
+		&argsJSON, &envJSON, &srv.URL, &headersJSON, &authJSON, &sandboxJSON,
 		&srv.Status, &errMsg, &srv.CreatedAt, &srv.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -119,7 +119,7 @@ func (s *Service) FindServerByName(ctx context.Context, name string) (*ServerCon
 	if err != nil {
 		return nil, fmt.Errorf("find server by name %q: %w", name, err)
 	}
-	if err := decodeServerJSON(&srv, argsJSON, envJSON, headersJSON, errMsg); err != nil {
+	if err := decodeServerJSON(&srv, argsJSON, envJSON, headersJSON, authJSON, sandboxJSON, errMsg); err != nil {
 		return nil, err
 	}
 	return &srv, nil
@@ -17,7 +17,7 @@ func TestService_FindToolByName(t *testing.T) {
 		WithArgs("read_file").
 		WillReturnRows(sqlmock.NewRows(findToolColumns()).
 			AddRow(testToolID, testServerID, "filesystem", "read_file", "Read a file", []byte(`{}`),
-				testServerID, "filesystem", "stdio", "npx", `[]`, `{}`, "", `{}`, "connected", nil, now, now))
+				testServerID, "filesystem", "stdio", "npx", `[]`, `{}`, "", `{}`, nil, nil, "connected", nil, now, now))
 
 	tool, srv, err := svc.FindToolByName(context.Background(), "read_file")
 	if err != nil {
@@ -51,7 +51,7 @@ func TestService_FindServerByName(t *testing.T) {
 	mock.ExpectQuery("SELECT .+ FROM mcp_servers WHERE name").
 		WithArgs("filesystem").
 		WillReturnRows(sqlmock.NewRows(serverColumns()).
-			AddRow(testServerID, "filesystem", "stdio", "npx", `[]`, `{}`, "", `{}`, "connected", nil, now, now))
+			AddRow(testServerID, "filesystem", "stdio", "npx", `[]`, `{}`, "", `{}`, nil, nil, "connected", nil, now, now))
 
 	srv, err := svc.FindServerByName(context.Background(), "filesystem")
 	if err != nil {
@@ -84,6 +84,6 @@ func TestService_FindServerByName_NotFound(t *testing.T) {
 func findToolColumns() []string {
 	return []string{
 		"id", "server_id", "server_name", "name", "description", "input_schema",
-		"srv_id", "srv_name", "transport", "command", "args", "env", "url", "headers", "status", "error_message", "created_at", "updated_at",
+		"srv_id", "srv_name", "transport", "command", "args", "env", "url", "headers", "auth", "sandbox", "status", "error_message", "created_at", "updated_at",
 	}
 }
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// CacheResources replaces the cached resource list for an MCP server.
+func (s *Service) CacheResources(ctx context.Context, serverID uuid.UUID, resources []ResourceDef) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mcp_resources WHERE server_id = $1`, serverID); err != nil {
+		return fmt.Errorf("delete existing resources: %w", err)
+	}
+	for _, r := range resources {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO mcp_resources (server_id, uri, name, description, mime_type)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (server_id, uri) DO UPDATE
+			SET name = EXCLUDED.name, description = EXCLUDED.description, mime_type = EXCLUDED.mime_type
+		`, serverID, r.URI, r.Name, r.Description, r.MIMEType)
+		if err != nil {
+			return fmt.Errorf("insert resource %q: %w", r.URI, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// CachePrompts replaces the cached prompt templates for an MCP server.
+func (s *Service) CachePrompts(ctx context.Context, serverID uuid.UUID, prompts []PromptDef) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mcp_prompts WHERE server_id = $1`, serverID); err != nil {
+		return fmt.Errorf("delete existing prompts: %w", err)
+	}
+	for _, p := range prompts {
+		argsJSON, err := json.Marshal(p.Arguments)
+		if err != nil {
+			return fmt.Errorf("marshal arguments for prompt %q: %w", p.Name, err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO mcp_prompts (server_id, name, description, arguments)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (server_id, name) DO UPDATE
+			SET description = EXCLUDED.description, arguments = EXCLUDED.arguments
+		`, serverID, p.Name, p.Description, argsJSON)
+		if err != nil {
+			return fmt.Errorf("insert prompt %q: %w", p.Name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ListAllResources returns the cached resources of every server.
+func (s *Service) ListAllResources(ctx context.Context) ([]ResourceDef, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT r.id, r.server_id, s.name, r.uri, r.name, r.description, r.mime_type
+		FROM mcp_resources r
+		JOIN mcp_servers s ON s.id = r.server_id
+		ORDER BY s.name ASC, r.uri ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list all resources: %w", err)
+	}
+	defer rows.Close()
+
+	var resources []ResourceDef
+	for rows.Next() {
+		var r ResourceDef
+		var desc, mimeType sql.NullString
+		if err := rows.Scan(&r.ID, &r.ServerID, &r.ServerName, &r.URI, &r.Name, &desc, &mimeType); err != nil {
+			return nil, fmt.Errorf("scan resource: %w", err)
+		}
+		r.Description, r.MIMEType = desc.String, mimeType.String
+		resources = append(resources, r)
+	}
+	return resources, rows.Err()
+}
+
+// ListAllPrompts returns the cached prompt templates of every server.
+func (s *Service) ListAllPrompts(ctx context.Context) ([]PromptDef, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT p.id, p.server_id, s.name, p.name, p.description, p.arguments
+		FROM mcp_prompts p
+		JOIN mcp_servers s ON s.id = p.server_id
+		ORDER BY s.name ASC, p.name ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list all prompts: %w", err)
+	}
+	defer rows.Close()
+
+	var prompts []PromptDef
+	for rows.Next() {
+		var p PromptDef
+		var desc sql.NullString
+		var argsJSON []byte
+		if err := rows.Scan(&p.ID, &p.ServerID, &p.ServerName, &p.Name, &desc, &argsJSON); err != nil {
+			return nil, fmt.Errorf("scan prompt: %w", err)
+		}
+		p.Description = desc.String
+		if len(argsJSON) > 0 {
+			if err := json.Unmarshal(argsJSON, &p.Arguments); err != nil {
+				return nil, fmt.Errorf("unmarshal arguments for prompt %q: %w", p.Name, err)
+			}
+		}
+		prompts = append(prompts, p)
+	}
+	return prompts, rows.Err()
+}
@@ -73,10 +73,10 @@ func TestEnsureRuntimeDefaults_PersistsFilesystemWorkspaceArg(t *testing.T) {
 	now := time.Now()
 
 	mock.ExpectQuery("INSERT INTO mcp_servers").
-		WithArgs("filesystem", "stdio", "npx", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
+		WithArgs("filesystem", "stdio", "npx", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows(serverColumns()).
 			AddRow(testServerID, "filesystem", "stdio", "npx",
-				`["-y","@modelcontextprotocol/server-filesystem","`+workspaceRoot+`"]`, `{}`, "", `{}`,
+				`["-y","@modelcontextprotocol/server-filesystem","`+workspaceRoot+`"]`, `{}`, "", `{}`, nil, nil,
 				"installed", nil, now, now))
 
 	got, err := svc.EnsureRuntimeDefaults(context.Background(), ServerConfig{
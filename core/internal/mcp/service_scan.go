@@ -8,24 +8,24 @@ import (
 
 func scanServerConfig(rows *sql.Rows) (*ServerConfig, error) {
 	var srv ServerConfig
-	var argsJSON, envJSON, headersJSON []byte
+	var argsJSON, envJSON, headersJSON, authJSON, sandboxJSON []byte
 	var errMsg sql.NullString
 
 	err := rows.Scan(
 		&srv.ID, &srv.Name, &srv.Transport, &srv.Command,
-		&argsJSON, &envJSON, &srv.URL, &headersJSON,
+		&argsJSON, &envJSON, &srv.URL, &headersJSON, &authJSON, &sandboxJSON,
 		&srv.Status, &errMsg, &srv.CreatedAt, &srv.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("scan server config: %w", err)
 	}
-	if err := decodeServerJSON(&srv, argsJSON, envJSON, headersJSON, errMsg); err != nil {
+	if err := decodeServerJSON(&srv, argsJSON, envJSON, headersJSON, authJSON, sandboxJSON, errMsg); err != nil {
 		return nil, err
 	}
 	return &srv, nil
 }
 
-func decodeServerJSON(srv *ServerConfig, argsJSON, envJSON, headersJSON []byte, errMsg sql.NullString) error {
+func decodeServerJSON(srv *ServerConfig, argsJSON, envJSON, headersJSON, authJSON, sandboxJSON []byte, errMsg sql.NullString) error {
 	if errMsg.Valid {
 		srv.Error = errMsg.String
 	}
@@ -38,5 +38,15 @@ func decodeServerJSON(srv *ServerConfig, argsJSON, envJSON, headersJSON []byte,
 	if err := json.Unmarshal(headersJSON, &srv.Headers); err != nil {
 		return fmt.Errorf("unmarshal headers: %w", err)
 	}
+	if len(authJSON) > 0 && string(authJSON) != "null" {
+		if err := json.Unmarshal(authJSON, &srv.Auth); err != nil {
+			return fmt.Errorf("unmarshal auth: %w", err)
+		}
+	}
+	if len(sandboxJSON) > 0 && string(sandboxJSON) != "null" {
+		if err := json.Unmarshal(sandboxJSON, &srv.Sandbox); err != nil {
+			return fmt.Errorf("unmarshal sandbox: %w", err)
+		}
+	}
 	return nil
 }
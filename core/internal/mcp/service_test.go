@@ -16,10 +16,10 @@ func TestService_Install_HappyPath(t *testing.T) {
 	now := time.Now()
 
 	mock.ExpectQuery("INSERT INTO mcp_servers").
-		WithArgs("filesystem", "stdio", "npx", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
+		WithArgs("filesystem", "stdio", "npx", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows(serverColumns()).
 			AddRow(testServerID, "filesystem", "stdio", "npx",
-				`["-y","@mcp/server-fs"]`, `{}`, "", `{}`,
+				`["-y","@mcp/server-fs"]`, `{}`, "", `{}`, nil, nil,
 				"installed", nil, now, now))
 
 	cfg := ServerConfig{
@@ -48,10 +48,10 @@ func TestService_Install_DuplicateNameUpsertsExistingServer(t *testing.T) {
 	now := time.Now()
 
 	mock.ExpectQuery("INSERT INTO mcp_servers").
-		WithArgs("filesystem", "stdio", "npx", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
+		WithArgs("filesystem", "stdio", "npx", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows(serverColumns()).
 			AddRow(testServerID, "filesystem", "stdio", "npx",
-				`["-y","@modelcontextprotocol/server-filesystem","/data/workspace"]`, `{}`, "", `{}`,
+				`["-y","@modelcontextprotocol/server-filesystem","/data/workspace"]`, `{}`, "", `{}`, nil, nil,
 				"installed", nil, now, now))
 
 	result, err := svc.Install(context.Background(), ServerConfig{
@@ -79,7 +79,7 @@ func TestService_List_HappyPath(t *testing.T) {
 
 	rows := sqlmock.NewRows(serverColumns()).
 		AddRow(testServerID, "filesystem", "stdio", "npx",
-			`["-y","@mcp/server-fs"]`, `{}`, "", `{}`,
+			`["-y","@mcp/server-fs"]`, `{}`, "", `{}`, nil, nil,
 			"connected", nil, now, now)
 	mock.ExpectQuery("SELECT .+ FROM mcp_servers").WillReturnRows(rows)
 
@@ -124,7 +124,7 @@ func TestService_Get_Found(t *testing.T) {
 		WithArgs(testServerID).
 		WillReturnRows(sqlmock.NewRows(serverColumns()).
 			AddRow(testServerID, "filesystem", "stdio", "npx",
-				`[]`, `{"FOO":"bar"}`, "", `{}`,
+				`[]`, `{"FOO":"bar"}`, "", `{}`, nil, nil,
 				"connected", nil, now, now))
 
 	srv, err := svc.Get(context.Background(), testServerID)
@@ -18,7 +18,7 @@ func newTestService(t *testing.T) (*Service, sqlmock.Sqlmock) {
 }
 
 func serverColumns() []string {
-	return []string{"id", "name", "transport", "command", "args", "env", "url", "headers", "status", "error_message", "created_at", "updated_at"}
+	return []string{"id", "name", "transport", "command", "args", "env", "url", "headers", "auth", "sandbox", "status", "error_message", "created_at", "updated_at"}
 }
 
 func toolColumns() []string {
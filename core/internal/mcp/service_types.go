@@ -17,12 +17,44 @@ type ServerConfig struct {
 	Env       map[string]string `json:"env,omitempty"`
 	URL       string            `json:"url,omitempty"`
 	Headers   map[string]string `json:"headers,omitempty"`
+	Auth      *AuthConfig       `json:"auth,omitempty"`
+	Sandbox   *SandboxProfile   `json:"sandbox,omitempty"` // stdio only; nil runs unsandboxed
 	Status    string            `json:"status"`
 	Error     string            `json:"error,omitempty"`
 	CreatedAt time.Time         `json:"created_at"`
 	UpdatedAt time.Time         `json:"updated_at"`
 }
 
+// Transport types accepted in ServerConfig.Transport.
+const (
+	TransportStdio          = "stdio"
+	TransportStreamableHTTP = "streamable_http"
+	TransportSSE            = "sse" // legacy HTTP+SSE transport
+)
+
+// IsHTTPTransport reports whether transport dials a remote URL.
+func IsHTTPTransport(transport string) bool {
+	return transport == TransportStreamableHTTP || transport == TransportSSE
+}
+
+// OAuth grant types accepted in AuthConfig.Type.
+const (
+	AuthOAuthClientCredentials = "oauth_client_credentials"
+	AuthOAuthPKCE              = "oauth_pkce"
+)
+
+// AuthConfig configures OAuth for an HTTP MCP server. It only carries
+// references; the client secret and issued tokens live in the secret store.
+type AuthConfig struct {
+	Type            string   `json:"type" yaml:"type"`
+	ClientID        string   `json:"client_id" yaml:"client_id"`
+	ClientSecretRef string   `json:"client_secret_ref,omitempty" yaml:"client_secret_ref,omitempty"`
+	TokenURL        string   `json:"token_url,omitempty" yaml:"token_url,omitempty"`
+	MetadataURL     string   `json:"auth_server_metadata_url,omitempty" yaml:"auth_server_metadata_url,omitempty"`
+	RedirectURI     string   `json:"redirect_uri,omitempty" yaml:"redirect_uri,omitempty"`
+	Scopes          []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+}
+
 // ToolDef represents a tool exposed by an MCP server.
 type ToolDef struct {
 	ID          uuid.UUID       `json:"id"`
@@ -32,3 +64,31 @@ type ToolDef struct {
 	Description string          `json:"description"`
 	InputSchema json.RawMessage `json:"input_schema"`
 }
+
+// ResourceDef represents a resource published by an MCP server.
+type ResourceDef struct {
+	ID          uuid.UUID `json:"id"`
+	ServerID    uuid.UUID `json:"server_id"`
+	ServerName  string    `json:"server_name,omitempty"`
+	URI         string    `json:"uri"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	MIMEType    string    `json:"mime_type,omitempty"`
+}
+
+// PromptDef represents a prompt template published by an MCP server.
+type PromptDef struct {
+	ID          uuid.UUID        `json:"id"`
+	ServerID    uuid.UUID        `json:"server_id"`
+	ServerName  string           `json:"server_name,omitempty"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Arguments   []PromptArgument `json:"arguments"`
+}
+
+// PromptArgument is one named argument of a prompt template.
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
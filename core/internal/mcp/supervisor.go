@@ -0,0 +1,241 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SupervisorConfig tunes MCP server health checks and reconnect backoff.
+type SupervisorConfig struct {
+	Interval    time.Duration // time between health checks
+	PingTimeout time.Duration // per-server ping deadline
+	MinBackoff  time.Duration // first reconnect delay
+	MaxBackoff  time.Duration // reconnect delay ceiling
+}
+
+// DefaultSupervisorConfig returns the production health check cadence.
+func DefaultSupervisorConfig() SupervisorConfig {
+	return SupervisorConfig{
+		Interval:    30 * time.Second,
+		PingTimeout: 5 * time.Second,
+		MinBackoff:  5 * time.Second,
+		MaxBackoff:  5 * time.Minute,
+	}
+}
+
+// StatusChange reports a supervised server moving between states.
+type StatusChange struct {
+	ServerID   uuid.UUID `json:"server_id"`
+	ServerName string    `json:"server_name"`
+	Previous   string    `json:"previous"`
+	Status     string    `json:"status"`
+	Detail     string    `json:"detail,omitempty"`
+	Attempts   int       `json:"attempts,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// supervisedServer is the supervisor's view of one server the operator
+// wants running.
+type supervisedServer struct {
+	cfg         ServerConfig
+	status      string
+	failures    int
+	nextAttempt time.Time
+}
+
+// supervisorState holds the servers under supervision. Connect adds a
+// server, Disconnect removes it.
+type supervisorState struct {
+	mu       sync.Mutex
+	servers  map[uuid.UUID]*supervisedServer
+	onChange func(StatusChange)
+	now      func() time.Time
+}
+
+// OnStatusChange registers fn for supervised server state transitions.
+func (p *ClientPool) OnStatusChange(fn func(StatusChange)) {
+	p.supervisor.mu.Lock()
+	p.supervisor.onChange = fn
+	p.supervisor.mu.Unlock()
+}
+
+// Supervise health-checks supervised servers every cfg.Interval until ctx
+// is done, reconnecting failed servers with exponential backoff.
+func (p *ClientPool) Supervise(ctx context.Context, cfg SupervisorConfig) {
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.CheckServers(ctx, cfg)
+		}
+	}
+}
+
+// CheckServers runs one supervision pass: sandbox limit hits are reported,
+// connected servers are pinged and servers that are down are reconnected
+// once their backoff has elapsed.
+func (p *ClientPool) CheckServers(ctx context.Context, cfg SupervisorConfig) {
+	p.supervisor.mu.Lock()
+	servers := make([]supervisedServer, 0, len(p.supervisor.servers))
+	for _, s := range p.supervisor.servers {
+		servers = append(servers, *s)
+	}
+	p.supervisor.mu.Unlock()
+
+	for _, s := range servers {
+		if ctx.Err() != nil {
+			return
+		}
+		p.checkSandboxLimits(s.cfg)
+		mc, err := p.connectedClient(s.cfg.ID)
+		if err == nil {
+			pingCtx, cancel := context.WithTimeout(ctx, cfg.PingTimeout)
+			err = mc.Client.Ping(pingCtx)
+			cancel()
+			if err == nil {
+				continue
+			}
+			p.markUnhealthy(ctx, s.cfg.ID, fmt.Sprintf("health check: %v", err))
+			p.recordFailure(s.cfg, err.Error(), cfg)
+			continue
+		}
+		if p.supervisorNow().Before(s.nextAttempt) {
+			continue
+		}
+		if err := p.reconnect(ctx, s.cfg); err != nil {
+			p.recordFailure(s.cfg, err.Error(), cfg)
+		}
+	}
+}
+
+// reconnect replaces the server's client; Connect reports the recovery.
+func (p *ClientPool) reconnect(ctx context.Context, cfg ServerConfig) error {
+	log.Printf("mcp supervisor: reconnecting %s (%s)", cfg.Name, cfg.ID)
+	return withMCPConnectTimeout(ctx, func(connectCtx context.Context) error {
+		return p.Connect(connectCtx, cfg)
+	})
+}
+
+// markUnhealthy flags a live client as down and records the error.
+func (p *ClientPool) markUnhealthy(ctx context.Context, serverID uuid.UUID, reason string) {
+	p.mu.Lock()
+	if mc, ok := p.clients[serverID]; ok {
+		mc.Connected = false
+	}
+	p.mu.Unlock()
+	if p.service == nil {
+		return
+	}
+	if err := p.service.UpdateStatus(ctx, serverID, "error", reason); err != nil {
+		log.Printf("mcp supervisor: failed to update status for %s: %v", serverID, err)
+	}
+}
+
+// dropClient closes the server's client without marking it stopped, so the
+// server stays under supervision.
+func (p *ClientPool) dropClient(serverID uuid.UUID) {
+	p.mu.Lock()
+	mc := p.clients[serverID]
+	delete(p.clients, serverID)
+	p.mu.Unlock()
+	if mc != nil {
+		_ = mc.Client.Close()
+	}
+	p.releaseSandbox(serverID)
+}
+
+// track puts cfg under supervision, keeping any failure history.
+func (p *ClientPool) track(cfg ServerConfig) {
+	p.supervisor.mu.Lock()
+	defer p.supervisor.mu.Unlock()
+	if p.supervisor.servers == nil {
+		p.supervisor.servers = make(map[uuid.UUID]*supervisedServer)
+	}
+	if s, ok := p.supervisor.servers[cfg.ID]; ok {
+		s.cfg = cfg
+		return
+	}
+	p.supervisor.servers[cfg.ID] = &supervisedServer{cfg: cfg, status: "connecting"}
+}
+
+func (p *ClientPool) untrack(serverID uuid.UUID) {
+	p.supervisor.mu.Lock()
+	delete(p.supervisor.servers, serverID)
+	p.supervisor.mu.Unlock()
+}
+
+func (p *ClientPool) untrackAll() {
+	p.supervisor.mu.Lock()
+	p.supervisor.servers = nil
+	p.supervisor.mu.Unlock()
+}
+
+// recordHealthy marks a server connected and clears its backoff.
+func (p *ClientPool) recordHealthy(cfg ServerConfig) {
+	p.transition(cfg, func(s *supervisedServer) (string, string) {
+		attempts := s.failures
+		s.failures, s.nextAttempt = 0, time.Time{}
+		if attempts > 0 {
+			return "connected", fmt.Sprintf("recovered after %d failed attempt(s)", attempts)
+		}
+		return "connected", ""
+	})
+}
+
+func (p *ClientPool) recordFailure(cfg ServerConfig, reason string, sc SupervisorConfig) {
+	p.transition(cfg, func(s *supervisedServer) (string, string) {
+		s.failures++
+		s.nextAttempt = p.supervisorNow().Add(reconnectBackoff(s.failures, sc))
+		return "error", reason
+	})
+}
+
+// transition applies update and reports the change when the status moved.
+func (p *ClientPool) transition(cfg ServerConfig, update func(*supervisedServer) (string, string)) {
+	p.supervisor.mu.Lock()
+	s, ok := p.supervisor.servers[cfg.ID]
+	if !ok {
+		p.supervisor.mu.Unlock()
+		return
+	}
+	previous := s.status
+	status, detail := update(s)
+	s.status = status
+	change := StatusChange{ServerID: cfg.ID, ServerName: cfg.Name, Previous: previous, Status: status, Detail: detail, Attempts: s.failures, Timestamp: p.supervisorNow()}
+	fn := p.supervisor.onChange
+	p.supervisor.mu.Unlock()
+	if previous == status {
+		return
+	}
+	log.Printf("mcp supervisor: %s (%s) %s -> %s %s", cfg.Name, cfg.ID, previous, status, detail)
+	if fn != nil {
+		fn(change)
+	}
+}
+
+func (p *ClientPool) supervisorNow() time.Time {
+	if p.supervisor.now != nil {
+		return p.supervisor.now()
+	}
+	return time.Now()
+}
+
+// reconnectBackoff doubles the delay per consecutive failure up to the cap.
+func reconnectBackoff(failures int, cfg SupervisorConfig) time.Duration {
+	delay := cfg.MinBackoff
+	for i := 1; i < failures && delay < cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > cfg.MaxBackoff {
+		delay = cfg.MaxBackoff
+	}
+	return delay
+}
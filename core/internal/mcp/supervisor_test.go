@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestReconnectBackoff(t *testing.T) {
+	cfg := SupervisorConfig{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, w := range want {
+		if got := reconnectBackoff(i+1, cfg); got != w {
+			t.Errorf("backoff after %d failures = %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestClientPool_SupervisorDetectsDeadServerAndBacksOff(t *testing.T) {
+	svc, mock := newTestService(t)
+	pool := NewClientPool(svc)
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	pool.supervisor.now = func() time.Time { return now }
+	cfg := ServerConfig{ID: testServerID, Name: "docs", Transport: "unsupported"}
+	httpSrv := server.NewTestStreamableHTTPServer(server.NewMCPServer("docs", "1.0.0"))
+	c, err := client.NewStreamableHttpClient(httpSrv.URL + "/mcp")
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if _, err := c.Initialize(context.Background(), mcp.InitializeRequest{}); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	pool.clients[testServerID] = &ManagedClient{ServerID: testServerID, Config: cfg, Client: c, Connected: true}
+	pool.track(cfg)
+	pool.recordHealthy(cfg)
+	var changes []StatusChange
+	pool.OnStatusChange(func(c StatusChange) { changes = append(changes, c) })
+	sc := SupervisorConfig{PingTimeout: time.Second, MinBackoff: time.Minute, MaxBackoff: time.Hour}
+
+	pool.CheckServers(context.Background(), sc)
+	if len(changes) != 0 {
+		t.Fatalf("healthy server reported changes: %+v", changes)
+	}
+
+	httpSrv.Close()
+	mock.ExpectExec("UPDATE mcp_servers").WithArgs("error", sqlmock.AnyArg(), testServerID).WillReturnResult(sqlmock.NewResult(0, 1))
+	pool.CheckServers(context.Background(), sc)
+	if len(changes) != 1 || changes[0].Previous != "connected" || changes[0].Status != "error" {
+		t.Fatalf("changes after failed ping = %+v", changes)
+	}
+	if _, err := pool.connectedClient(testServerID); err == nil {
+		t.Fatal("dead client still reported as connected")
+	}
+
+	// Within the backoff window nothing is retried.
+	pool.CheckServers(context.Background(), sc)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unexpected reconnect inside backoff: %v", err)
+	}
+
+	// After the backoff the supervisor reconnects; this one fails again
+	// and the next attempt waits twice as long.
+	now = now.Add(time.Minute)
+	mock.ExpectExec("UPDATE mcp_servers").WithArgs("error", sqlmock.AnyArg(), testServerID).WillReturnResult(sqlmock.NewResult(0, 1))
+	pool.CheckServers(context.Background(), sc)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("reconnect attempt: %v", err)
+	}
+	pool.supervisor.mu.Lock()
+	s := *pool.supervisor.servers[testServerID]
+	pool.supervisor.mu.Unlock()
+	if s.failures != 2 || !s.nextAttempt.Equal(now.Add(2*time.Minute)) {
+		t.Fatalf("supervised state = %+v, want 2 failures and a 2m backoff", s)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("repeated failure reported as a change: %+v", changes)
+	}
+
+	mock.ExpectExec("UPDATE mcp_servers").WithArgs("stopped", sqlmock.AnyArg(), testServerID).WillReturnResult(sqlmock.NewResult(0, 1))
+	_ = pool.Disconnect(testServerID)
+	pool.supervisor.mu.Lock()
+	_, tracked := pool.supervisor.servers[testServerID]
+	pool.supervisor.mu.Unlock()
+	if tracked {
+		t.Fatal("disconnected server still supervised")
+	}
+}
+
+func TestClientPool_RediscoversToolsOnListChanged(t *testing.T) {
+	svc, mock := newTestService(t)
+	pool := NewClientPool(svc)
+	srv := server.NewMCPServer("tools", "1.0.0", server.WithToolCapabilities(true))
+	srv.AddTool(mcp.NewTool("lookup"), func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	c, err := client.NewInProcessClient(srv)
+	if err != nil {
+		t.Fatalf("in-process client: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if _, err := c.Initialize(context.Background(), mcp.InitializeRequest{}); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	pool.clients[testServerID] = &ManagedClient{ServerID: testServerID, Client: c, Connected: true}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM mcp_tools").WithArgs(testServerID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO mcp_tools").WithArgs(testServerID, "lookup", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	pool.handleNotification(testServerID, mcp.JSONRPCNotification{Notification: mcp.Notification{Method: mcp.MethodNotificationToolsListChanged}})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for mock.ExpectationsWereMet() != nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("tools were not re-discovered: %v", mock.ExpectationsWereMet())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -71,18 +72,9 @@ FORMAT (JSON):
 	`, teamID, start.Format(time.RFC3339), end.Format(time.RFC3339), logSummary)
 
 	req := cognitive.InferRequest{
-		Profile: "architect", // Use the Architect profile (likely stronger model)
-		Prompt:  prompt,
-	}
-
-	// Make Schema Enforcement Explicit?
-	// For now we trust the Architect to follow instructions.
-
-	resp, err := a.Cog.InferWithContract(ctx, req)
-	if err != nil {
-		// Log error but treat as "brain fog"
-		log.Printf("Archivist: Brain Fog (Inference Failed): %v", err)
-		return err
+		Profile:        "architect", // Use the Architect profile (likely stronger model)
+		Prompt:         prompt,
+		ResponseSchema: sitrepSchema,
 	}
 
 	// 4. Parse Response
@@ -92,14 +84,23 @@ FORMAT (JSON):
 		Strategies string   `json:"strategies"`
 	}
 
-	// Try to unmarshal JSON from text (it might be wrapped in Markdown ```json ... ```)
-	// Simple cleanup: find { and }
-	cleanText := resp.Text // TODO: robust JSON extractor
-
-	if err := json.Unmarshal([]byte(cleanText), &sitrep); err != nil {
-		log.Printf("Archivist: Failed to parse SitRep JSON: %v. Raw: %s", err, resp.Text)
+	resp, err := a.Cog.InferWithContract(ctx, req)
+	var schemaErr *cognitive.SchemaError
+	switch {
+	case errors.As(err, &schemaErr):
+		log.Printf("Archivist: SitRep failed its schema: %v. Raw: %s", err, schemaErr.Text)
 		// Fallback: Store raw text as summary
-		sitrep.Summary = resp.Text
+		sitrep.Summary = schemaErr.Text
+	case err != nil:
+		// Log error but treat as "brain fog"
+		log.Printf("Archivist: Brain Fog (Inference Failed): %v", err)
+		return err
+	default:
+		// The router has already validated and unwrapped the JSON reply.
+		if err := json.Unmarshal([]byte(resp.Text), &sitrep); err != nil {
+			log.Printf("Archivist: Failed to parse SitRep JSON: %v. Raw: %s", err, resp.Text)
+			sitrep.Summary = resp.Text
+		}
 	}
 
 	// 5. Save to DB
@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -200,14 +201,9 @@ FORMAT (JSON):
 	)
 
 	req := cognitive.InferRequest{
-		Profile: "architect",
-		Prompt:  prompt,
-	}
-
-	resp, err := a.Cog.InferWithContract(ctx, req)
-	if err != nil {
-		log.Printf("Archivist Daemon: Compression failed for %s: %v", teamID, err)
-		return
+		Profile:        "architect",
+		Prompt:         prompt,
+		ResponseSchema: sitrepSchema,
 	}
 
 	var sitrep struct {
@@ -216,10 +212,20 @@ FORMAT (JSON):
 		Strategies string   `json:"strategies"`
 	}
 
-	cleanText := extractJSON(resp.Text)
-	if err := json.Unmarshal([]byte(cleanText), &sitrep); err != nil {
-		log.Printf("Archivist Daemon: JSON parse failed: %v. Storing raw.", err)
-		sitrep.Summary = resp.Text
+	resp, err := a.Cog.InferWithContract(ctx, req)
+	var schemaErr *cognitive.SchemaError
+	switch {
+	case errors.As(err, &schemaErr):
+		log.Printf("Archivist Daemon: SitRep for %s failed its schema: %v. Storing raw.", teamID, err)
+		sitrep.Summary = schemaErr.Text
+	case err != nil:
+		log.Printf("Archivist Daemon: Compression failed for %s: %v", teamID, err)
+		return
+	default:
+		if err := json.Unmarshal([]byte(extractJSON(resp.Text)), &sitrep); err != nil {
+			log.Printf("Archivist Daemon: JSON parse failed: %v. Storing raw.", err)
+			sitrep.Summary = resp.Text
+		}
 	}
 
 	eventsJSON, _ := json.Marshal(sitrep.KeyEvents)
@@ -240,6 +246,17 @@ FORMAT (JSON):
 	a.embedSitRep(ctx, teamID, sitrep.Summary, windowStart, windowEnd)
 }
 
+// sitrepSchema is the JSON Schema of a compressed SitRep reply.
+var sitrepSchema = map[string]any{
+	"type":     "object",
+	"required": []any{"summary"},
+	"properties": map[string]any{
+		"summary":    map[string]any{"type": "string", "minLength": 1},
+		"key_events": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		"strategies": map[string]any{"type": "string"},
+	},
+}
+
 // extractJSON strips markdown code fences from LLM output to extract raw JSON.
 func extractJSON(text string) string {
 	// Strip ```json ... ``` wrapping
@@ -0,0 +1,206 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Embedding migration statuses.
+const (
+	MigrationRunning   = "running"
+	MigrationCancelled = "cancelled"
+	MigrationFailed    = "failed"
+	MigrationCompleted = "completed"
+)
+
+// ErrMigrationNotFound is returned for an unknown migration id.
+var ErrMigrationNotFound = errors.New("embedding migration not found")
+
+// EmbeddingMigration is a background job re-embedding context vectors into
+// one target space. CursorID is the last row visited, so an interrupted job
+// resumes where it stopped.
+type EmbeddingMigration struct {
+	ID          string     `json:"id"`
+	SourceModel string     `json:"source_model"` // "" re-embeds every other space
+	TargetModel string     `json:"target_model"`
+	TargetDim   int        `json:"target_dim"`
+	Status      string     `json:"status"`
+	Total       int        `json:"total"`
+	Processed   int        `json:"processed"`
+	Failed      int        `json:"failed"`
+	CursorID    string     `json:"cursor_id,omitempty"`
+	BatchSize   int        `json:"batch_size"`
+	PerMinute   int        `json:"per_minute"`
+	Error       string     `json:"error,omitempty"`
+	CreatedBy   string     `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+const migrationColumns = `id, source_model, target_model, target_dim, status, total, processed, failed,
+	COALESCE(cursor_id::text, ''), batch_size, per_minute, COALESCE(error_message, ''), created_by,
+	created_at, updated_at, completed_at`
+
+func scanMigration(row interface{ Scan(...any) error }) (EmbeddingMigration, error) {
+	var m EmbeddingMigration
+	var completed sql.NullTime
+	err := row.Scan(&m.ID, &m.SourceModel, &m.TargetModel, &m.TargetDim, &m.Status, &m.Total, &m.Processed, &m.Failed,
+		&m.CursorID, &m.BatchSize, &m.PerMinute, &m.Error, &m.CreatedBy, &m.CreatedAt, &m.UpdatedAt, &completed)
+	if completed.Valid {
+		m.CompletedAt = &completed.Time
+	}
+	return m, err
+}
+
+// GetEmbeddingMigration loads one migration.
+func (s *Service) GetEmbeddingMigration(ctx context.Context, id string) (*EmbeddingMigration, error) {
+	m, err := scanMigration(s.db.QueryRowContext(ctx, `SELECT `+migrationColumns+` FROM embedding_migrations WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrMigrationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get embedding migration: %w", err)
+	}
+	return &m, nil
+}
+
+// ListEmbeddingMigrations returns migrations newest first, optionally
+// filtered by status.
+func (s *Service) ListEmbeddingMigrations(ctx context.Context, status string, limit int) ([]EmbeddingMigration, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+migrationColumns+`
+		FROM embedding_migrations
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list embedding migrations: %w", err)
+	}
+	defer rows.Close()
+	out := []EmbeddingMigration{}
+	for rows.Next() {
+		m, err := scanMigration(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+func (s *Service) insertEmbeddingMigration(ctx context.Context, m *EmbeddingMigration) error {
+	return s.db.QueryRowContext(ctx, `
+		INSERT INTO embedding_migrations (source_model, target_model, target_dim, total, batch_size, per_minute, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, status, created_at, updated_at
+	`, m.SourceModel, m.TargetModel, m.TargetDim, m.Total, m.BatchSize, m.PerMinute, m.CreatedBy).
+		Scan(&m.ID, &m.Status, &m.CreatedAt, &m.UpdatedAt)
+}
+
+// saveMigrationProgress records counters and the resume cursor and returns
+// the migration's persisted status, which another replica may have changed;
+// "" means the migration no longer exists.
+func (s *Service) saveMigrationProgress(ctx context.Context, m *EmbeddingMigration) (string, error) {
+	var status string
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE embedding_migrations
+		SET processed = $2, failed = $3, cursor_id = NULLIF($4, '')::uuid, updated_at = NOW()
+		WHERE id = $1
+		RETURNING status
+	`, m.ID, m.Processed, m.Failed, m.CursorID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return status, err
+}
+
+// setMigrationStatus moves a migration to status; completed also stamps
+// completed_at. Only a running migration can move to anything but running.
+func (s *Service) setMigrationStatus(ctx context.Context, id, status, errMsg string) (bool, error) {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE embedding_migrations
+		SET status = $2, error_message = NULLIF($3, ''), updated_at = NOW(),
+		    completed_at = CASE WHEN $2 = 'completed' THEN NOW() ELSE completed_at END
+		WHERE id = $1 AND (status = 'running') <> ($2 = 'running')
+	`, id, status, errMsg)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// pendingFilter selects the rows a migration still has to re-embed.
+func pendingFilter(m *EmbeddingMigration) (string, []any) {
+	clauses := []string{
+		"embedding IS NOT NULL",
+		"(embedding_model IS DISTINCT FROM $1 OR embedding_dim IS DISTINCT FROM $2)",
+	}
+	args := []any{m.TargetModel, m.TargetDim}
+	if m.SourceModel != "" {
+		clauses = append(clauses, "embedding_model = $3")
+		args = append(args, m.SourceModel)
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+func (s *Service) countPendingVectors(ctx context.Context, m *EmbeddingMigration) (int, error) {
+	where, args := pendingFilter(m)
+	var n int
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM context_vectors WHERE `+where, args...).Scan(&n)
+	return n, err
+}
+
+type pendingVector struct {
+	ID      string
+	Content string
+}
+
+// nextPendingBatch returns up to m.BatchSize rows after the cursor, in id
+// order, that are not yet in the target space.
+func (s *Service) nextPendingBatch(ctx context.Context, m *EmbeddingMigration) ([]pendingVector, error) {
+	where, args := pendingFilter(m)
+	next := len(args) + 1
+	if m.CursorID != "" {
+		where += fmt.Sprintf(" AND id > $%d", next)
+		args = append(args, m.CursorID)
+		next++
+	}
+	args = append(args, m.BatchSize)
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, content FROM context_vectors
+		WHERE `+where+`
+		ORDER BY id
+		LIMIT $`+fmt.Sprintf("%d", next), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	batch := []pendingVector{}
+	for rows.Next() {
+		var v pendingVector
+		if err := rows.Scan(&v.ID, &v.Content); err != nil {
+			return nil, err
+		}
+		batch = append(batch, v)
+	}
+	return batch, rows.Err()
+}
+
+func (s *Service) replaceEmbedding(ctx context.Context, id string, vec []float64, model string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE context_vectors
+		SET embedding = $2::vector, embedding_model = $3, embedding_dim = $4
+		WHERE id = $1
+	`, id, formatVector(vec), model, len(vec))
+	return err
+}
@@ -0,0 +1,283 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Re-embedding defaults and bounds.
+const (
+	defaultMigrationBatch     = 50
+	maxMigrationBatch         = 500
+	defaultMigrationPerMinute = 600
+)
+
+var (
+	// ErrMigrationActive is returned when a migration is already running.
+	ErrMigrationActive = errors.New("an embedding migration is already running")
+	// ErrNoEmbedder is returned when the memory service has no embedder.
+	ErrNoEmbedder = errors.New("no embedder configured")
+
+	errMigrationCancelled = errors.New("cancelled by operator")
+)
+
+// EmbeddingMigrationRequest starts a migration. TargetModel defaults to the
+// embedder's active model; zero BatchSize and PerMinute take the defaults.
+type EmbeddingMigrationRequest struct {
+	SourceModel string `json:"source_model,omitempty"`
+	TargetModel string `json:"target_model,omitempty"`
+	BatchSize   int    `json:"batch_size,omitempty"`
+	PerMinute   int    `json:"per_minute,omitempty"`
+}
+
+// EmbeddingMigrator re-embeds stored vectors in the background, one batch
+// at a time and throttled to PerMinute embeddings. Progress is persisted
+// after every batch; a restart resumes running migrations from their cursor.
+// Rows that fail to embed are skipped and counted; a fresh migration
+// retries them.
+type EmbeddingMigrator struct {
+	mem  *Service
+	wait func(ctx context.Context, d time.Duration) error // throttle; swapped in tests
+
+	mu      sync.Mutex
+	base    context.Context
+	running map[string]context.CancelCauseFunc
+}
+
+// NewEmbeddingMigrator returns a migrator using mem's database and embedder.
+func NewEmbeddingMigrator(mem *Service) *EmbeddingMigrator {
+	return &EmbeddingMigrator{
+		mem:     mem,
+		wait:    sleepCtx,
+		base:    context.Background(),
+		running: map[string]context.CancelCauseFunc{},
+	}
+}
+
+// Start binds migrations to ctx and resumes those a previous process left
+// running. Cancelling ctx pauses them without changing their status.
+func (m *EmbeddingMigrator) Start(ctx context.Context) {
+	m.mu.Lock()
+	m.base = ctx
+	m.mu.Unlock()
+	interrupted, err := m.mem.ListEmbeddingMigrations(ctx, MigrationRunning, 100)
+	if err != nil {
+		log.Printf("Memory: cannot resume embedding migrations: %v", err)
+		return
+	}
+	for _, mig := range interrupted {
+		log.Printf("Memory: resuming embedding migration %s at %d/%d", mig.ID, mig.Processed+mig.Failed, mig.Total)
+		m.launch(mig)
+	}
+}
+
+// StartMigration validates req, builds the target space's index and begins
+// re-embedding every vector not yet in that space.
+func (m *EmbeddingMigrator) StartMigration(ctx context.Context, req EmbeddingMigrationRequest, createdBy string) (*EmbeddingMigration, error) {
+	if m.mem.embedder == nil {
+		return nil, ErrNoEmbedder
+	}
+	mig := &EmbeddingMigration{
+		SourceModel: strings.TrimSpace(req.SourceModel),
+		TargetModel: strings.TrimSpace(req.TargetModel),
+		BatchSize:   req.BatchSize,
+		PerMinute:   req.PerMinute,
+		CreatedBy:   createdBy,
+	}
+	if mig.TargetModel == "" {
+		mig.TargetModel = m.mem.embedModel()
+	}
+	if !ValidEmbedModel(mig.TargetModel) || (mig.SourceModel != "" && !ValidEmbedModel(mig.SourceModel)) {
+		return nil, fmt.Errorf("invalid embedding model name")
+	}
+	if mig.BatchSize <= 0 {
+		mig.BatchSize = defaultMigrationBatch
+	}
+	mig.BatchSize = min(mig.BatchSize, maxMigrationBatch)
+	if mig.PerMinute <= 0 {
+		mig.PerMinute = defaultMigrationPerMinute
+	}
+	if active, err := m.mem.ListEmbeddingMigrations(ctx, MigrationRunning, 1); err != nil {
+		return nil, err
+	} else if len(active) > 0 {
+		return nil, ErrMigrationActive
+	}
+
+	probe, err := m.mem.embedder.Embed(ctx, "embedding dimension probe", mig.TargetModel)
+	if err != nil {
+		return nil, fmt.Errorf("embed with %s: %w", mig.TargetModel, err)
+	}
+	if mig.TargetDim = len(probe); mig.TargetDim == 0 {
+		return nil, fmt.Errorf("embed with %s returned an empty vector", mig.TargetModel)
+	}
+	if mig.Total, err = m.mem.countPendingVectors(ctx, mig); err != nil {
+		return nil, fmt.Errorf("count vectors to migrate: %w", err)
+	}
+	if err := m.mem.insertEmbeddingMigration(ctx, mig); err != nil {
+		return nil, fmt.Errorf("record embedding migration: %w", err)
+	}
+	m.launch(*mig)
+	return mig, nil
+}
+
+// CancelMigration stops a running migration. Its cursor is kept, so it can
+// be resumed later. A migration running on another replica is cancelled in
+// the database; that replica stops after its current batch.
+func (m *EmbeddingMigrator) CancelMigration(ctx context.Context, id string) (*EmbeddingMigration, error) {
+	m.mu.Lock()
+	cancel, local := m.running[id]
+	m.mu.Unlock()
+	if local {
+		cancel(errMigrationCancelled)
+	} else if ok, err := m.mem.setMigrationStatus(ctx, id, MigrationCancelled, errMigrationCancelled.Error()); err != nil {
+		return nil, err
+	} else if !ok {
+		if _, err := m.mem.GetEmbeddingMigration(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("embedding migration %s is not running", id)
+	}
+	return m.mem.GetEmbeddingMigration(ctx, id)
+}
+
+// ResumeMigration restarts a cancelled or failed migration from its cursor.
+func (m *EmbeddingMigrator) ResumeMigration(ctx context.Context, id string) (*EmbeddingMigration, error) {
+	mig, err := m.mem.GetEmbeddingMigration(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if mig.Status != MigrationCancelled && mig.Status != MigrationFailed {
+		return nil, fmt.Errorf("embedding migration %s is %s; only cancelled or failed migrations resume", id, mig.Status)
+	}
+	if active, err := m.mem.ListEmbeddingMigrations(ctx, MigrationRunning, 1); err != nil {
+		return nil, err
+	} else if len(active) > 0 {
+		return nil, ErrMigrationActive
+	}
+	if ok, err := m.mem.setMigrationStatus(ctx, id, MigrationRunning, ""); err != nil {
+		return nil, fmt.Errorf("resume embedding migration %s: %w", id, err)
+	} else if !ok {
+		return nil, fmt.Errorf("embedding migration %s changed state; try again", id)
+	}
+	mig.Status, mig.Error = MigrationRunning, ""
+	m.launch(*mig)
+	return mig, nil
+}
+
+func (m *EmbeddingMigrator) launch(mig EmbeddingMigration) {
+	m.mu.Lock()
+	ctx, cancel := context.WithCancelCause(m.base)
+	m.running[mig.ID] = cancel
+	m.mu.Unlock()
+	go func() {
+		defer func() {
+			m.mu.Lock()
+			delete(m.running, mig.ID)
+			m.mu.Unlock()
+			cancel(nil)
+		}()
+		m.run(ctx, &mig)
+	}()
+}
+
+// run re-embeds batches until none are left, the migration is cancelled
+// or the embedder fails a whole batch.
+func (m *EmbeddingMigrator) run(ctx context.Context, mig *EmbeddingMigration) {
+	store := context.WithoutCancel(ctx)
+	if err := m.mem.EnsureEmbeddingIndex(ctx, mig.TargetModel, mig.TargetDim); err != nil {
+		log.Printf("Memory: embedding migration %s continues without an index: %v", mig.ID, err)
+	}
+	interval := time.Minute / time.Duration(mig.PerMinute)
+	for {
+		batch, err := m.mem.nextPendingBatch(ctx, mig)
+		if err != nil {
+			m.stop(store, ctx, mig, fmt.Errorf("read batch: %w", err))
+			return
+		}
+		if len(batch) == 0 {
+			m.finish(store, mig, MigrationCompleted, "")
+			return
+		}
+		done, failed, cursor, lastErr := 0, 0, mig.CursorID, error(nil)
+		for _, row := range batch {
+			if err := m.wait(ctx, interval); err != nil {
+				break
+			}
+			vec, err := m.mem.embedder.Embed(ctx, row.Content, mig.TargetModel)
+			if err == nil && len(vec) != mig.TargetDim {
+				err = fmt.Errorf("got %d dimensions, want %d", len(vec), mig.TargetDim)
+			}
+			if err == nil {
+				err = m.mem.replaceEmbedding(ctx, row.ID, vec, mig.TargetModel)
+			}
+			if ctx.Err() != nil {
+				break
+			}
+			if err != nil {
+				failed, lastErr = failed+1, err
+			} else {
+				done++
+			}
+			cursor = row.ID
+		}
+		if done == 0 && failed > 0 && failed == len(batch) {
+			// Likely the embedder is down: stop before the cursor so a
+			// resume retries this batch rather than skipping it.
+			m.finish(store, mig, MigrationFailed, lastErr.Error())
+			return
+		}
+		mig.Processed, mig.Failed, mig.CursorID = mig.Processed+done, mig.Failed+failed, cursor
+		status, err := m.mem.saveMigrationProgress(store, mig)
+		if err != nil {
+			log.Printf("Memory: embedding migration %s progress not saved: %v", mig.ID, err)
+		}
+		if ctx.Err() != nil {
+			m.stop(store, ctx, mig, ctx.Err())
+			return
+		}
+		if err == nil && status != MigrationRunning {
+			// Cancelled through another replica, which only records the
+			// status; the cursor saved above lets it resume.
+			m.mem.invalidateSpaces()
+			log.Printf("Memory: embedding migration %s stopped at %d/%d: status is now %q", mig.ID, mig.Processed+mig.Failed, mig.Total, status)
+			return
+		}
+	}
+}
+
+// stop ends a run that was interrupted. Operator cancellation and errors
+// are recorded; a shutdown leaves the migration running for the next start.
+func (m *EmbeddingMigrator) stop(store, ctx context.Context, mig *EmbeddingMigration, err error) {
+	switch {
+	case errors.Is(context.Cause(ctx), errMigrationCancelled):
+		m.finish(store, mig, MigrationCancelled, errMigrationCancelled.Error())
+	case ctx.Err() != nil:
+		log.Printf("Memory: embedding migration %s paused at %d/%d", mig.ID, mig.Processed+mig.Failed, mig.Total)
+	default:
+		m.finish(store, mig, MigrationFailed, err.Error())
+	}
+}
+
+func (m *EmbeddingMigrator) finish(ctx context.Context, mig *EmbeddingMigration, status, errMsg string) {
+	if _, err := m.mem.setMigrationStatus(ctx, mig.ID, status, errMsg); err != nil {
+		log.Printf("Memory: embedding migration %s status not saved: %v", mig.ID, err)
+	}
+	m.mem.invalidateSpaces()
+	log.Printf("Memory: embedding migration %s %s (%d re-embedded, %d failed)", mig.ID, status, mig.Processed, mig.Failed)
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
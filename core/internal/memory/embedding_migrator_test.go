@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func newTestMigrator(t *testing.T, embedder Embedder) (*EmbeddingMigrator, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	svc := NewServiceWithDB(db)
+	svc.SetEmbedder(embedder)
+	m := NewEmbeddingMigrator(svc)
+	m.wait = func(ctx context.Context, _ time.Duration) error { return ctx.Err() }
+	return m, mock
+}
+
+func testMigration() *EmbeddingMigration {
+	return &EmbeddingMigration{
+		ID:          "mig-1",
+		SourceModel: "nomic-embed-text",
+		TargetModel: "mxbai-embed-large",
+		TargetDim:   3,
+		Status:      MigrationRunning,
+		Total:       2,
+		BatchSize:   2,
+		PerMinute:   600,
+	}
+}
+
+func TestEmbeddingMigrator_ReembedsBatchesAndCompletes(t *testing.T) {
+	m, mock := newTestMigrator(t, &fakeEmbedder{model: "mxbai-embed-large", dims: map[string]int{"mxbai-embed-large": 3}})
+	batchColumns := []string{"id", "content"}
+
+	mock.ExpectExec("CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_context_vectors_emb_mxbai_embed_large_3").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT id, content FROM context_vectors").
+		WithArgs("mxbai-embed-large", 3, "nomic-embed-text", 2).
+		WillReturnRows(sqlmock.NewRows(batchColumns).AddRow("v1", "alpha").AddRow("v2", "beta"))
+	mock.ExpectExec("UPDATE context_vectors").WithArgs("v1", "[1,1,1]", "mxbai-embed-large", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE context_vectors").WithArgs("v2", "[1,1,1]", "mxbai-embed-large", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE embedding_migrations\\s+SET processed").WithArgs("mig-1", 2, 0, "v2").
+		WillReturnRows(statusRow(MigrationRunning))
+	mock.ExpectQuery("SELECT id, content FROM context_vectors").
+		WithArgs("mxbai-embed-large", 3, "nomic-embed-text", "v2", 2).
+		WillReturnRows(sqlmock.NewRows(batchColumns))
+	mock.ExpectExec("UPDATE embedding_migrations\\s+SET status").WithArgs("mig-1", MigrationCompleted, "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mig := testMigration()
+	m.run(context.Background(), mig)
+	if mig.Processed != 2 || mig.Failed != 0 || mig.CursorID != "v2" {
+		t.Fatalf("progress = %d processed, %d failed, cursor %q", mig.Processed, mig.Failed, mig.CursorID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEmbeddingMigrator_FailsWithoutSkippingWhenEmbedderIsDown(t *testing.T) {
+	m, mock := newTestMigrator(t, &fakeEmbedder{model: "mxbai-embed-large"})
+
+	mock.ExpectExec("CREATE INDEX CONCURRENTLY").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT id, content FROM context_vectors").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "content"}).AddRow("v1", "alpha"))
+	mock.ExpectExec("UPDATE embedding_migrations\\s+SET status").WithArgs("mig-1", MigrationFailed, "unknown model").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mig := testMigration()
+	m.run(context.Background(), mig)
+	if mig.CursorID != "" || mig.Failed != 0 {
+		t.Fatalf("cursor %q, failed %d; the batch should be retried on resume", mig.CursorID, mig.Failed)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEmbeddingMigrator_CancelKeepsCursor(t *testing.T) {
+	m, mock := newTestMigrator(t, &fakeEmbedder{model: "mxbai-embed-large", dims: map[string]int{"mxbai-embed-large": 3}})
+	ctx, cancel := context.WithCancelCause(context.Background())
+	m.wait = func(ctx context.Context, _ time.Duration) error {
+		cancel(errMigrationCancelled)
+		return ctx.Err()
+	}
+
+	mock.ExpectExec("CREATE INDEX CONCURRENTLY").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT id, content FROM context_vectors").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "content"}).AddRow("v9", "gamma"))
+	mock.ExpectQuery("UPDATE embedding_migrations\\s+SET processed").WithArgs("mig-1", 0, 0, "v8").
+		WillReturnRows(statusRow(MigrationRunning))
+	mock.ExpectExec("UPDATE embedding_migrations\\s+SET status").WithArgs("mig-1", MigrationCancelled, errMigrationCancelled.Error()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mig := testMigration()
+	mig.CursorID = "v8"
+	m.run(ctx, mig)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func statusRow(status string) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"status"}).AddRow(status)
+}
+
+func TestEmbeddingMigrator_StopsWhenCancelledOnAnotherReplica(t *testing.T) {
+	m, mock := newTestMigrator(t, &fakeEmbedder{model: "mxbai-embed-large", dims: map[string]int{"mxbai-embed-large": 3}})
+
+	mock.ExpectExec("CREATE INDEX CONCURRENTLY").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT id, content FROM context_vectors").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "content"}).AddRow("v1", "alpha"))
+	mock.ExpectExec("UPDATE context_vectors").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE embedding_migrations\\s+SET processed").WithArgs("mig-1", 1, 0, "v1").
+		WillReturnRows(statusRow(MigrationCancelled))
+
+	// No further batch is read and the status written elsewhere is kept.
+	mig := testMigration()
+	m.run(context.Background(), mig)
+	if mig.CursorID != "v1" || mig.Processed != 1 {
+		t.Fatalf("cursor %q, processed %d", mig.CursorID, mig.Processed)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStartMigration_RejectsConcurrentRun(t *testing.T) {
+	m, mock := newTestMigrator(t, &fakeEmbedder{model: "mxbai-embed-large", dims: map[string]int{"mxbai-embed-large": 3}})
+	now := time.Now()
+	mock.ExpectQuery("FROM embedding_migrations").WithArgs(MigrationRunning, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "source_model", "target_model", "target_dim", "status", "total", "processed", "failed",
+			"cursor_id", "batch_size", "per_minute", "error_message", "created_by", "created_at", "updated_at", "completed_at"}).
+			AddRow("mig-0", "", "mxbai-embed-large", 3, MigrationRunning, 10, 4, 0, "", 50, 600, "", "admin", now, now, nil))
+
+	if _, err := m.StartMigration(context.Background(), EmbeddingMigrationRequest{}, "admin"); !errors.Is(err, ErrMigrationActive) {
+		t.Fatalf("err = %v, want ErrMigrationActive", err)
+	}
+}
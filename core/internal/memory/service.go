@@ -24,8 +24,12 @@ type LogEntry struct {
 
 // Service manages the projection of stream events to state.
 type Service struct {
-	db     *sql.DB
-	events chan *LogEntry // Buffered channel to prevent blocking
+	db       *sql.DB
+	events   chan *LogEntry // Buffered channel to prevent blocking
+	reranker Reranker       // optional: reorders hybrid search results
+
+	embedder   Embedder // optional: records vector models, enables dual-read
+	spaceCache spaceState
 }
 
 // NewServiceWithDB creates a memory service from an existing *sql.DB.
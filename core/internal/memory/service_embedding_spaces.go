@@ -0,0 +1,189 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// spaceCacheTTL bounds how stale the list of stored embedding spaces may be.
+const spaceCacheTTL = time.Minute
+
+// maxLegacySpaces caps how many older embedding spaces a search also reads.
+const maxLegacySpaces = 2
+
+// maxHNSWDims is the widest vector pgvector can index with hnsw.
+const maxHNSWDims = 2000
+
+var (
+	embedModelPattern = regexp.MustCompile(`^[A-Za-z0-9._:/-]+$`)
+	indexNameUnsafe   = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// ValidEmbedModel reports whether model is a plausible embedding model name.
+func ValidEmbedModel(model string) bool {
+	return len(model) <= 200 && embedModelPattern.MatchString(model)
+}
+
+// Embedder produces query and re-embedding vectors. *cognitive.Router
+// implements it.
+type Embedder interface {
+	Embed(ctx context.Context, text string, model string) ([]float64, error)
+	EmbedModel() string
+}
+
+// EmbeddingSpace is the model and dimension a vector was produced with.
+// Vectors are only comparable within one space.
+type EmbeddingSpace struct {
+	Model string `json:"model"`
+	Dim   int    `json:"dim"`
+	Count int    `json:"count,omitempty"`
+}
+
+// spaceState caches the embedding spaces present in context_vectors.
+type spaceState struct {
+	mu        sync.Mutex
+	spaces    []EmbeddingSpace
+	fetchedAt time.Time
+}
+
+// SetEmbedder records which model new vectors come from and lets searches
+// that carry QueryText also read vectors still stored under older models.
+// Call it during startup, before vectors are stored.
+func (s *Service) SetEmbedder(e Embedder) {
+	s.embedder = e
+}
+
+// embedModel is the model StoreVector records, or "" when unknown.
+func (s *Service) embedModel() string {
+	if s.embedder == nil {
+		return ""
+	}
+	return s.embedder.EmbedModel()
+}
+
+// EmbeddingSpaces lists the embedding spaces stored in context_vectors with
+// their row counts.
+func (s *Service) EmbeddingSpaces(ctx context.Context) ([]EmbeddingSpace, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT COALESCE(embedding_model, ''), COALESCE(embedding_dim, 0), COUNT(*)
+		FROM context_vectors
+		WHERE embedding IS NOT NULL
+		GROUP BY 1, 2
+		ORDER BY 3 DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list embedding spaces: %w", err)
+	}
+	defer rows.Close()
+	spaces := []EmbeddingSpace{}
+	for rows.Next() {
+		var sp EmbeddingSpace
+		if err := rows.Scan(&sp.Model, &sp.Dim, &sp.Count); err != nil {
+			return nil, err
+		}
+		spaces = append(spaces, sp)
+	}
+	return spaces, rows.Err()
+}
+
+// legacySpaces returns stored spaces other than active, largest first.
+func (s *Service) legacySpaces(ctx context.Context, active EmbeddingSpace) []EmbeddingSpace {
+	s.spaceCache.mu.Lock()
+	defer s.spaceCache.mu.Unlock()
+	if time.Since(s.spaceCache.fetchedAt) > spaceCacheTTL {
+		spaces, err := s.EmbeddingSpaces(ctx)
+		if err != nil {
+			log.Printf("Memory: cannot list embedding spaces, reading the active model only: %v", err)
+			return nil
+		}
+		s.spaceCache.spaces, s.spaceCache.fetchedAt = spaces, time.Now()
+	}
+	out := []EmbeddingSpace{}
+	for _, sp := range s.spaceCache.spaces {
+		if sp.Model == "" || sp.Model == active.Model && sp.Dim == active.Dim {
+			continue
+		}
+		if out = append(out, sp); len(out) == maxLegacySpaces {
+			break
+		}
+	}
+	return out
+}
+
+// invalidateSpaces forces the next search to re-list embedding spaces.
+func (s *Service) invalidateSpaces() {
+	s.spaceCache.mu.Lock()
+	s.spaceCache.fetchedAt = time.Time{}
+	s.spaceCache.mu.Unlock()
+}
+
+// dualRead searches the older embedding spaces still holding vectors, so
+// recall keeps working while a model change is being migrated.
+func (s *Service) dualRead(ctx context.Context, active EmbeddingSpace, opts SemanticSearchOptions) []VectorResult {
+	var results []VectorResult
+	for _, sp := range s.legacySpaces(ctx, active) {
+		vec, err := s.embedder.Embed(ctx, opts.QueryText, sp.Model)
+		if err != nil || len(vec) != sp.Dim {
+			log.Printf("Memory: dual-read skipped %s/%d: embedding unavailable (%v)", sp.Model, sp.Dim, err)
+			continue
+		}
+		more, err := s.searchSpace(ctx, sp, vec, opts)
+		if err != nil {
+			log.Printf("Memory: dual-read of %s/%d failed: %v", sp.Model, sp.Dim, err)
+			continue
+		}
+		results = append(results, more...)
+	}
+	return results
+}
+
+// EnsureEmbeddingIndex builds the partial hnsw index serving one embedding
+// space, without locking writes. Spaces wider than hnsw supports are left
+// to sequential scans.
+func (s *Service) EnsureEmbeddingIndex(ctx context.Context, model string, dim int) error {
+	if !ValidEmbedModel(model) || dim <= 0 {
+		return fmt.Errorf("invalid embedding space %q/%d", model, dim)
+	}
+	if dim > maxHNSWDims {
+		log.Printf("Memory: %s/%d is too wide for an hnsw index; searches will scan", model, dim)
+		return nil
+	}
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+		CREATE INDEX CONCURRENTLY IF NOT EXISTS %s
+		ON context_vectors USING hnsw ((embedding::vector(%d)) vector_cosine_ops)
+		WHERE embedding_model = %s AND embedding_dim = %d
+	`, embeddingIndexName(model, dim), dim, quoteLiteral(model), dim))
+	if err != nil {
+		return fmt.Errorf("create embedding index for %s/%d: %w", model, dim, err)
+	}
+	return nil
+}
+
+// embeddingIndexName derives the index name for a space, matching the name
+// migration 060 gave the nomic-embed-text index. Names that lose characters
+// or exceed the identifier limit get a hash suffix to stay distinct.
+func embeddingIndexName(model string, dim int) string {
+	lower := strings.ToLower(model)
+	safe := strings.Trim(indexNameUnsafe.ReplaceAllString(lower, "_"), "_")
+	name := fmt.Sprintf("idx_context_vectors_emb_%s_%d", safe, dim)
+	if safe == strings.ReplaceAll(lower, "-", "_") && lower == model && len(name) <= 63 {
+		return name
+	}
+	h := fnv.New32a()
+	h.Write([]byte(model))
+	if len(safe) > 24 {
+		safe = safe[:24]
+	}
+	return fmt.Sprintf("idx_context_vectors_emb_%s_%d_%08x", safe, dim, h.Sum32())
+}
+
+// quoteLiteral quotes s as a SQL string literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
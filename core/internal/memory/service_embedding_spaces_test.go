@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// fakeEmbedder returns a vector of dims[model] ones, or fails for models it
+// does not know.
+type fakeEmbedder struct {
+	model string
+	dims  map[string]int
+	calls []string
+}
+
+func (f *fakeEmbedder) EmbedModel() string { return f.model }
+
+func (f *fakeEmbedder) Embed(_ context.Context, _ string, model string) ([]float64, error) {
+	f.calls = append(f.calls, model)
+	n, ok := f.dims[model]
+	if !ok {
+		return nil, errors.New("unknown model")
+	}
+	vec := make([]float64, n)
+	for i := range vec {
+		vec[i] = 1
+	}
+	return vec, nil
+}
+
+func TestStoreVector_RecordsEmbeddingSpace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	mock.ExpectExec("INSERT INTO context_vectors \\(content, embedding, metadata, embedding_model, embedding_dim\\)").
+		WithArgs("note", "[0.1,0.2,0.3]", sqlmock.AnyArg(), "mxbai-embed-large", 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	svc := NewServiceWithDB(db)
+	svc.SetEmbedder(&fakeEmbedder{model: "mxbai-embed-large"})
+	if err := svc.StoreVector(context.Background(), "note", []float64{0.1, 0.2, 0.3}, nil); err != nil {
+		t.Fatalf("StoreVector: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSemanticSearch_DualReadsOlderEmbeddingSpaces(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	now := time.Now()
+
+	mock.ExpectQuery("embedding_dim = 3 AND embedding_model = 'mxbai-embed-large'.*ORDER BY embedding::vector\\(3\\)").
+		WithArgs(sqlmock.AnyArg(), "default", 2).
+		WillReturnRows(sqlmock.NewRows(hybridColumns).AddRow("new-1", "migrated", `{}`, 0.7, now))
+	mock.ExpectQuery("SELECT COALESCE\\(embedding_model, ''\\), COALESCE\\(embedding_dim, 0\\), COUNT").
+		WillReturnRows(sqlmock.NewRows([]string{"model", "dim", "count"}).
+			AddRow("nomic-embed-text", 2, 40).
+			AddRow("mxbai-embed-large", 3, 10).
+			AddRow("", 0, 1))
+	mock.ExpectQuery("embedding_dim = 2 AND embedding_model = 'nomic-embed-text'.*ORDER BY embedding::vector\\(2\\)").
+		WithArgs(sqlmock.AnyArg(), "default", 2).
+		WillReturnRows(sqlmock.NewRows(hybridColumns).
+			AddRow("old-1", "not yet migrated", `{}`, 0.9, now).
+			AddRow("old-2", "weak match", `{}`, 0.1, now))
+
+	embedder := &fakeEmbedder{model: "mxbai-embed-large", dims: map[string]int{"nomic-embed-text": 2}}
+	svc := NewServiceWithDB(db)
+	svc.SetEmbedder(embedder)
+	results, err := svc.SemanticSearchWithOptions(context.Background(), []float64{0.1, 0.2, 0.3}, SemanticSearchOptions{
+		Limit:               2,
+		TenantID:            "default",
+		AllowLegacyUnscoped: true,
+		QueryText:           "deploy runbook",
+	})
+	if err != nil {
+		t.Fatalf("SemanticSearchWithOptions: %v", err)
+	}
+	ids := []string{}
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	if !slices.Equal(ids, []string{"old-1", "new-1"}) {
+		t.Fatalf("ids = %v, want both spaces merged by score", ids)
+	}
+	if !slices.Equal(embedder.calls, []string{"nomic-embed-text"}) {
+		t.Fatalf("embed calls = %v, want one for the older space", embedder.calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEmbeddingIndexName(t *testing.T) {
+	if got := embeddingIndexName("nomic-embed-text", 768); got != "idx_context_vectors_emb_nomic_embed_text_768" {
+		t.Fatalf("nomic index = %s, want the name migration 060 created", got)
+	}
+	a, b := embeddingIndexName("bge:m3", 1024), embeddingIndexName("bge/m3", 1024)
+	if a == b {
+		t.Fatalf("models differing only in punctuation share index %s", a)
+	}
+	if long := embeddingIndexName("sentence-transformers/all-mpnet-base-v2-extra-long-name", 768); len(long) > 63 {
+		t.Fatalf("index name %s exceeds the identifier limit", long)
+	}
+}
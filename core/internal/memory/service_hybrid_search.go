@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// rrfK dampens the weight of top ranks in reciprocal rank fusion; 60 is the
+// constant from the original RRF paper and works well without tuning.
+const rrfK = 60
+
+// minHybridCandidates is the smallest per-branch candidate pool fused.
+const minHybridCandidates = 20
+
+// FullTextSearchWithOptions ranks context_vectors with Postgres full-text
+// search over the content_tsv column. Query terms are OR-ed so partial
+// matches still surface, with ts_rank_cd favouring rows that match more of
+// them. Scope options behave as in SemanticSearchWithOptions.
+func (s *Service) FullTextSearchWithOptions(ctx context.Context, query string, opts SemanticSearchOptions) ([]VectorResult, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 5
+	}
+	terms := textSearchTerms(query)
+	if len(terms) == 0 {
+		return []VectorResult{}, nil
+	}
+	clauses, args, nextArg := textSearchScopeClauses(opts)
+	clauses = append(clauses, "content_tsv @@ q")
+	args = append(args, strings.Join(terms, " | "), limit)
+
+	sqlQuery := `
+		SELECT id, content, metadata, ts_rank_cd(content_tsv, q) AS score, created_at
+		FROM context_vectors, to_tsquery('english', $` + fmt.Sprintf("%d", nextArg) + `) AS q
+		WHERE ` + strings.Join(clauses, " AND ") + `
+		ORDER BY score DESC, created_at DESC
+		LIMIT $` + fmt.Sprintf("%d", nextArg+1)
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("full-text search failed: %w", err)
+	}
+	defer rows.Close()
+
+	results := []VectorResult{}
+	for rows.Next() {
+		var r VectorResult
+		var metaJSON []byte
+		if err := rows.Scan(&r.ID, &r.Content, &metaJSON, &r.Score, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		if len(metaJSON) > 0 {
+			_ = json.Unmarshal(metaJSON, &r.Metadata)
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// HybridSearch runs full-text and vector recall under the same scope and
+// fuses both rankings with reciprocal rank fusion. A nil queryVec (no
+// embedding available) searches lexically only; if one branch fails the
+// other is still used. When a reranker is set, the fused head is reordered
+// by it before the limit is applied. Result scores are fused RRF scores.
+func (s *Service) HybridSearch(ctx context.Context, query string, queryVec []float64, opts SemanticSearchOptions) ([]VectorResult, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 5
+	}
+	branch := opts
+	branch.Limit = max(limit*4, minHybridCandidates)
+	branch.QueryText = query
+
+	var lists [][]VectorResult
+	var labels []string
+	var vecErr error
+	if len(queryVec) > 0 {
+		var vecResults []VectorResult
+		if vecResults, vecErr = s.SemanticSearchWithOptions(ctx, queryVec, branch); vecErr == nil {
+			lists, labels = append(lists, vecResults), append(labels, "vector")
+		}
+	}
+	lexResults, lexErr := s.FullTextSearchWithOptions(ctx, query, branch)
+	if lexErr == nil {
+		lists, labels = append(lists, lexResults), append(labels, "lexical")
+	}
+	switch {
+	case len(lists) == 0 && vecErr != nil:
+		return nil, fmt.Errorf("hybrid search failed: %w; %w", vecErr, lexErr)
+	case len(lists) == 0:
+		return nil, fmt.Errorf("hybrid search failed: %w", lexErr)
+	case vecErr != nil:
+		log.Printf("Memory: hybrid search vector branch failed, using lexical only: %v", vecErr)
+	case lexErr != nil:
+		log.Printf("Memory: hybrid search lexical branch failed, using vector only: %v", lexErr)
+	}
+
+	fused := FuseRRF(labels, lists...)
+	if s.reranker != nil && len(fused) > 1 {
+		fused = s.rerank(ctx, query, fused, limit*2)
+	}
+	if len(fused) > limit {
+		fused = fused[:limit]
+	}
+	return fused, nil
+}
+
+// FuseRRF merges ranked lists by reciprocal rank fusion: each result scores
+// the sum of 1/(rrfK+rank) over the lists it appears in. labels name the
+// lists and are recorded in MatchedBy.
+func FuseRRF(labels []string, lists ...[]VectorResult) []VectorResult {
+	byID := map[string]*VectorResult{}
+	order := []string{}
+	for i, list := range lists {
+		for rank, hit := range list {
+			fused, ok := byID[hit.ID]
+			if !ok {
+				copied := hit
+				copied.Score = 0
+				copied.MatchedBy = nil
+				fused = &copied
+				byID[hit.ID] = fused
+				order = append(order, hit.ID)
+			}
+			fused.Score += 1.0 / float64(rrfK+rank+1)
+			if i < len(labels) {
+				fused.MatchedBy = append(fused.MatchedBy, labels[i])
+			}
+		}
+	}
+	out := make([]VectorResult, 0, len(order))
+	for _, id := range order {
+		out = append(out, *byID[id])
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	return out
+}
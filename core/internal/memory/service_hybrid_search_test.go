@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var hybridColumns = []string{"id", "content", "metadata", "score", "created_at"}
+
+func TestFuseRRF_RewardsAgreementAcrossLists(t *testing.T) {
+	now := time.Now()
+	vector := []VectorResult{{ID: "a", Score: 0.9, CreatedAt: now}, {ID: "b", Score: 0.8, CreatedAt: now}, {ID: "c", Score: 0.7, CreatedAt: now}}
+	lexical := []VectorResult{{ID: "c", Score: 0.5, CreatedAt: now}, {ID: "d", Score: 0.4, CreatedAt: now}}
+
+	fused := FuseRRF([]string{"vector", "lexical"}, vector, lexical)
+	ids := make([]string, 0, len(fused))
+	for _, r := range fused {
+		ids = append(ids, r.ID)
+	}
+	if !slices.Equal(ids, []string{"c", "a", "b", "d"}) {
+		t.Fatalf("fused order = %v, want c (in both lists) first", ids)
+	}
+	if !slices.Equal(fused[0].MatchedBy, []string{"vector", "lexical"}) {
+		t.Fatalf("MatchedBy = %v", fused[0].MatchedBy)
+	}
+	if want := 1.0/63 + 1.0/61; fused[0].Score != want {
+		t.Fatalf("Score = %v, want %v", fused[0].Score, want)
+	}
+}
+
+func TestHybridSearch_SharesScopeAcrossBranches(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	now := time.Now()
+
+	mock.ExpectQuery("1 - \\(embedding <=> \\$1::vector\\) AS score").
+		WithArgs(sqlmock.AnyArg(), "default", "agent_memory", "alpha", 20).
+		WillReturnRows(sqlmock.NewRows(hybridColumns).
+			AddRow("vec-1", "deploy runbook", `{"team_id":"alpha"}`, 0.91, now).
+			AddRow("vec-2", "incident summary", `{"team_id":"alpha"}`, 0.85, now))
+	mock.ExpectQuery("ts_rank_cd\\(content_tsv, q\\) AS score").
+		WithArgs("default", "agent_memory", "alpha", "incident | runbook", 20).
+		WillReturnRows(sqlmock.NewRows(hybridColumns).
+			AddRow("vec-2", "incident summary", `{"team_id":"alpha"}`, 0.6, now))
+
+	svc := NewServiceWithDB(db)
+	results, err := svc.HybridSearch(context.Background(), "incident runbook", []float64{0.1, 0.2}, SemanticSearchOptions{
+		Limit:       1,
+		TenantID:    "default",
+		TeamID:      "alpha",
+		Types:       []string{"agent_memory"},
+		AllowGlobal: true,
+	})
+	if err != nil {
+		t.Fatalf("HybridSearch: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "vec-2" {
+		t.Fatalf("results = %+v, want the row both branches found", results)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHybridSearch_FallsBackToFullTextWhenVectorBranchFails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	mock.ExpectQuery("embedding <=>").WillReturnError(errors.New("different vector dimensions"))
+	mock.ExpectQuery("ts_rank_cd").
+		WillReturnRows(sqlmock.NewRows(hybridColumns).AddRow("vec-3", "release notes", `{}`, 0.2, time.Now()))
+
+	svc := NewServiceWithDB(db)
+	results, err := svc.HybridSearch(context.Background(), "release", []float64{0.1}, SemanticSearchOptions{})
+	if err != nil {
+		t.Fatalf("HybridSearch: %v", err)
+	}
+	if len(results) != 1 || !slices.Equal(results[0].MatchedBy, []string{"lexical"}) {
+		t.Fatalf("results = %+v", results)
+	}
+}
+
+type reverseReranker struct{ calls int }
+
+func (r *reverseReranker) Rerank(_ context.Context, _ string, passages []string) ([]int, error) {
+	r.calls++
+	ranking := make([]int, 0, len(passages))
+	for i := len(passages) - 1; i >= 0; i-- {
+		ranking = append(ranking, i)
+	}
+	return ranking, nil
+}
+
+func TestHybridSearch_AppliesReranker(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	now := time.Now()
+
+	mock.ExpectQuery("ts_rank_cd").
+		WillReturnRows(sqlmock.NewRows(hybridColumns).
+			AddRow("first", "one", `{}`, 0.9, now).
+			AddRow("second", "two", `{}`, 0.5, now))
+
+	svc := NewServiceWithDB(db)
+	reranker := &reverseReranker{}
+	svc.SetReranker(reranker)
+	results, err := svc.HybridSearch(context.Background(), "one two", nil, SemanticSearchOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("HybridSearch: %v", err)
+	}
+	if reranker.calls != 1 || len(results) != 1 || results[0].ID != "second" {
+		t.Fatalf("results = %+v after %d rerank call(s)", results, reranker.calls)
+	}
+}
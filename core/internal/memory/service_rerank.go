@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/mycelis/core/internal/cognitive"
+)
+
+// rerankPassageChars bounds each passage sent to the reranker.
+const rerankPassageChars = 600
+
+// Reranker reorders retrieved passages by relevance to query. It returns
+// passage indexes, most relevant first; passages it leaves out keep their
+// fused order after the ranked ones.
+type Reranker interface {
+	Rerank(ctx context.Context, query string, passages []string) ([]int, error)
+}
+
+// SetReranker enables reranking of hybrid search results. Call it during
+// startup, before searches run; nil disables reranking.
+func (s *Service) SetReranker(r Reranker) {
+	s.reranker = r
+}
+
+// rerank reorders the first window results with the reranker, keeping the
+// fused order when reranking fails.
+func (s *Service) rerank(ctx context.Context, query string, results []VectorResult, window int) []VectorResult {
+	if window > len(results) {
+		window = len(results)
+	}
+	passages := make([]string, window)
+	for i := range passages {
+		passages[i] = truncateRunes(results[i].Content, rerankPassageChars)
+	}
+	ranking, err := s.reranker.Rerank(ctx, query, passages)
+	if err != nil {
+		log.Printf("Memory: rerank failed, keeping fused order: %v", err)
+		return results
+	}
+	out := make([]VectorResult, 0, len(results))
+	used := make([]bool, window)
+	for _, idx := range ranking {
+		if idx < 0 || idx >= window || used[idx] {
+			continue
+		}
+		used[idx] = true
+		out = append(out, results[idx])
+	}
+	for i := 0; i < window; i++ {
+		if !used[i] {
+			out = append(out, results[i])
+		}
+	}
+	return append(out, results[window:]...)
+}
+
+var rerankSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"ranking": map[string]any{"type": "array", "items": map[string]any{"type": "integer"}},
+	},
+	"required": []string{"ranking"},
+}
+
+// CognitiveReranker ranks passages with a model behind the cognitive router.
+type CognitiveReranker struct {
+	Cog     *cognitive.Router
+	Profile string // router profile to infer with, e.g. "chat"
+}
+
+// NewCognitiveReranker returns a Reranker that asks the given profile.
+func NewCognitiveReranker(cog *cognitive.Router, profile string) *CognitiveReranker {
+	return &CognitiveReranker{Cog: cog, Profile: profile}
+}
+
+// Rerank implements Reranker.
+func (c *CognitiveReranker) Rerank(ctx context.Context, query string, passages []string) ([]int, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Rank the passages by how well they answer the query. Reply with the passage numbers, most relevant first; leave out irrelevant passages.\n\nQUERY: %s\n\nPASSAGES:\n", query)
+	for i, p := range passages {
+		fmt.Fprintf(&b, "[%d] %s\n", i, strings.ReplaceAll(p, "\n", " "))
+	}
+	resp, err := c.Cog.InferWithContract(ctx, cognitive.InferRequest{
+		Profile:        c.Profile,
+		Prompt:         b.String(),
+		ResponseSchema: rerankSchema,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rerank inference: %w", err)
+	}
+	var parsed struct {
+		Ranking []int `json:"ranking"`
+	}
+	if err := json.Unmarshal([]byte(resp.Text), &parsed); err != nil {
+		return nil, fmt.Errorf("parse rerank reply: %w", err)
+	}
+	return parsed.Ranking, nil
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
@@ -14,8 +15,9 @@ type VectorResult struct {
 	ID        string         `json:"id"`
 	Content   string         `json:"content"`
 	Metadata  map[string]any `json:"metadata"`
-	Score     float64        `json:"score"` // cosine similarity (1.0 = identical)
+	Score     float64        `json:"score"` // cosine similarity (1.0 = identical); fused RRF score from HybridSearch
 	CreatedAt time.Time      `json:"created_at"`
+	MatchedBy []string       `json:"matched_by,omitempty"` // HybridSearch branches that found it: vector, lexical
 }
 
 // SemanticSearchOptions constrains pgvector recall so durable memory can be
@@ -30,17 +32,22 @@ type SemanticSearchOptions struct {
 	Types               []string
 	AllowGlobal         bool
 	AllowLegacyUnscoped bool
+
+	// QueryText is the text queryVec was embedded from. With an embedder
+	// set, it lets the search also read vectors stored under older models.
+	QueryText string
 }
 
-// StoreVector persists an embedding into context_vectors for future RAG retrieval.
+// StoreVector persists an embedding into context_vectors for future RAG
+// retrieval, recording the embedder's model and the vector's dimension.
 func (s *Service) StoreVector(ctx context.Context, content string, embedding []float64, metadata map[string]any) error {
 	metaJSON, _ := json.Marshal(metadata)
 	vecStr := formatVector(embedding)
 
 	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO context_vectors (content, embedding, metadata)
-		VALUES ($1, $2::vector, $3)
-	`, content, vecStr, metaJSON)
+		INSERT INTO context_vectors (content, embedding, metadata, embedding_model, embedding_dim)
+		VALUES ($1, $2::vector, $3, NULLIF($4, ''), $5)
+	`, content, vecStr, metaJSON, s.embedModel(), len(embedding))
 
 	if err != nil {
 		return fmt.Errorf("store vector failed: %w", err)
@@ -60,11 +67,35 @@ func (s *Service) SemanticSearch(ctx context.Context, queryVec []float64, limit
 
 // SemanticSearchWithOptions finds the top-K nearest vectors by cosine
 // similarity while respecting optional scope and visibility boundaries.
+// queryVec must come from the embedder's current model; only vectors of
+// that model and dimension are compared. With QueryText set, vectors still
+// stored under older models are searched too and merged by similarity.
 func (s *Service) SemanticSearchWithOptions(ctx context.Context, queryVec []float64, opts SemanticSearchOptions) ([]VectorResult, error) {
 	limit := opts.Limit
 	if limit <= 0 {
 		limit = 5
 	}
+	active := EmbeddingSpace{Model: s.embedModel(), Dim: len(queryVec)}
+	results, err := s.searchSpace(ctx, active, queryVec, opts)
+	if err != nil || opts.QueryText == "" || s.embedder == nil {
+		return results, err
+	}
+	if legacy := s.dualRead(ctx, active, opts); len(legacy) > 0 {
+		results = append(results, legacy...)
+		sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+		if len(results) > limit {
+			results = results[:limit]
+		}
+	}
+	return results, nil
+}
+
+// searchSpace runs the nearest-neighbour query within one embedding space.
+func (s *Service) searchSpace(ctx context.Context, space EmbeddingSpace, queryVec []float64, opts SemanticSearchOptions) ([]VectorResult, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 5
+	}
 
 	tenantID := strings.TrimSpace(opts.TenantID)
 	if tenantID == "" {
@@ -72,7 +103,12 @@ func (s *Service) SemanticSearchWithOptions(ctx context.Context, queryVec []floa
 	}
 
 	vecStr := formatVector(queryVec)
-	clauses := []string{"embedding IS NOT NULL"}
+	// The space is inlined rather than bound so the planner can match the
+	// per-model partial index under cached generic plans too.
+	clauses := []string{"embedding IS NOT NULL", fmt.Sprintf("embedding_dim = %d", space.Dim)}
+	if space.Model != "" {
+		clauses = append(clauses, "embedding_model = "+quoteLiteral(space.Model))
+	}
 	args := []any{vecStr}
 	nextArg := 2
 
@@ -139,7 +175,7 @@ func (s *Service) SemanticSearchWithOptions(ctx context.Context, queryVec []floa
 		SELECT id, content, metadata, 1 - (embedding <=> $1::vector) AS score, created_at
 		FROM context_vectors
 		WHERE ` + strings.Join(clauses, " AND ") + `
-		ORDER BY embedding <=> $1::vector
+		ORDER BY embedding::vector(` + fmt.Sprintf("%d", space.Dim) + `) <=> $1::vector
 		LIMIT $` + fmt.Sprintf("%d", nextArg)
 	args = append(args, limit)
 
@@ -0,0 +1,177 @@
+// Package responsecache provides the durable response cache in front of the
+// cognitive router. Exact matches use the request key; the semantic tier
+// uses pgvector cosine similarity over the request embedding. Entries are
+// scoped per tenant, profile, provider and embedding model and expire after
+// their TTL.
+// Without a database every call fails; the router logs the error and treats
+// it as a miss, so inference never depends on the cache.
+package responsecache
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mycelis/core/internal/cognitive"
+)
+
+// pruneInterval bounds how often Store sweeps expired rows.
+const pruneInterval = time.Minute
+
+// Store persists cached responses. Implements cognitive.ResponseCache.
+type Store struct {
+	db  *sql.DB
+	now func() time.Time
+
+	mu         sync.Mutex
+	lastPruned time.Time
+}
+
+// NewStore creates a Store backed by the shared DB. db may be nil (degraded mode).
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db, now: time.Now}
+}
+
+// Lookup returns the cached response for q, trying the exact key first and
+// then the nearest embedding at or above q.MinSimilarity. It returns nil, nil
+// on a miss.
+func (s *Store) Lookup(ctx context.Context, q cognitive.CacheLookup) (*cognitive.CacheHit, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("responsecache: database not available")
+	}
+	now := s.now().UTC()
+
+	var raw []byte
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE inference_cache SET hits = hits + 1
+		WHERE tenant_id = $1 AND profile = $2 AND provider_id = $3 AND embedding_model = $4
+		  AND request_key = $5 AND expires_at > $6
+		RETURNING response
+	`, q.Scope.TenantID, q.Scope.Profile, q.Scope.ProviderID, q.EmbedModel, q.Key, now).Scan(&raw)
+	switch {
+	case err == nil:
+		return decodeHit(raw, cognitive.CacheTierExact, 1)
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("responsecache: exact lookup failed: %w", err)
+	}
+	if len(q.Embedding) == 0 || q.EmbedModel == "" {
+		return nil, nil
+	}
+
+	var similarity float64
+	err = s.db.QueryRowContext(ctx, `
+		UPDATE inference_cache SET hits = hits + 1
+		WHERE id = (
+			SELECT id FROM inference_cache
+			WHERE tenant_id = $1 AND profile = $2 AND provider_id = $3 AND embedding_model = $4
+			  AND embedding IS NOT NULL AND vector_dims(embedding) = $5 AND expires_at > $6
+			  AND 1 - (embedding <=> $7::vector) >= $8
+			ORDER BY embedding <=> $7::vector
+			LIMIT 1
+		)
+		RETURNING response, 1 - (embedding <=> $7::vector)
+	`, q.Scope.TenantID, q.Scope.Profile, q.Scope.ProviderID, q.EmbedModel, len(q.Embedding), now,
+		formatVector(q.Embedding), q.MinSimilarity).Scan(&raw, &similarity)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("responsecache: semantic lookup failed: %w", err)
+	}
+	return decodeHit(raw, cognitive.CacheTierSemantic, similarity)
+}
+
+// Store upserts entry, replacing any previous response under the same key.
+func (s *Store) Store(ctx context.Context, entry cognitive.CacheEntry) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("responsecache: database not available")
+	}
+	raw, err := json.Marshal(entry.Response)
+	if err != nil {
+		return fmt.Errorf("responsecache: encode response: %w", err)
+	}
+	var embedding any
+	if len(entry.Embedding) > 0 {
+		embedding = formatVector(entry.Embedding)
+	}
+	now := s.now().UTC()
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO inference_cache
+			(id, tenant_id, profile, provider_id, embedding_model, request_key, embedding, response, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::vector, $8, $9, $10)
+		ON CONFLICT (tenant_id, profile, provider_id, embedding_model, request_key) DO UPDATE SET
+			embedding = EXCLUDED.embedding, response = EXCLUDED.response, hits = 0,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+	`, uuid.New().String(), entry.Scope.TenantID, entry.Scope.Profile, entry.Scope.ProviderID, entry.EmbedModel, entry.Key,
+		embedding, raw, now, entry.ExpiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("responsecache: persist failed: %w", err)
+	}
+	s.pruneExpired(ctx, now)
+	return nil
+}
+
+// Invalidate deletes entries in scope; empty scope fields match everything.
+func (s *Store) Invalidate(ctx context.Context, scope cognitive.CacheScope) (int64, error) {
+	if s == nil || s.db == nil {
+		return 0, fmt.Errorf("responsecache: database not available")
+	}
+	var where []string
+	var args []any
+	for _, filter := range []struct{ column, value string }{
+		{"tenant_id", scope.TenantID},
+		{"profile", scope.Profile},
+		{"provider_id", scope.ProviderID},
+	} {
+		if filter.value != "" {
+			args = append(args, filter.value)
+			where = append(where, fmt.Sprintf("%s = $%d", filter.column, len(args)))
+		}
+	}
+	query := "DELETE FROM inference_cache"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("responsecache: invalidate failed: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// pruneExpired deletes expired rows at most once per pruneInterval.
+func (s *Store) pruneExpired(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastPruned) < pruneInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastPruned = now
+	s.mu.Unlock()
+	// Best effort: expired rows never match, so a failed sweep only costs space.
+	_, _ = s.db.ExecContext(ctx, `DELETE FROM inference_cache WHERE expires_at <= $1`, now)
+}
+
+func decodeHit(raw []byte, tier string, similarity float64) (*cognitive.CacheHit, error) {
+	hit := &cognitive.CacheHit{Tier: tier, Similarity: similarity}
+	if err := json.Unmarshal(raw, &hit.Response); err != nil {
+		return nil, fmt.Errorf("responsecache: decode response: %w", err)
+	}
+	return hit, nil
+}
+
+// formatVector renders an embedding as a pgvector literal.
+func formatVector(v []float64) string {
+	parts := make([]string, len(v))
+	for i, f := range v {
+		parts[i] = fmt.Sprintf("%g", f)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
@@ -0,0 +1,146 @@
+package responsecache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mycelis/core/internal/cognitive"
+)
+
+var testScope = cognitive.CacheScope{TenantID: "default", Profile: "chat", ProviderID: "ollama"}
+
+func newMockStore(t *testing.T) (*Store, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	s := NewStore(db)
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	return s, mock
+}
+
+// ── Lookup ─────────────────────────────────────────────────────────
+
+func TestLookup_ExactHit(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectQuery(`UPDATE inference_cache SET hits = hits \+ 1\s+WHERE tenant_id = \$1 AND profile = \$2 AND provider_id = \$3 AND embedding_model = \$4\s+AND request_key = \$5`).
+		WithArgs("default", "chat", "ollama", "nomic-embed-text", "key-1", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"response"}).AddRow([]byte(`{"text":"ready","provider":"ollama"}`)))
+
+	hit, err := s.Lookup(context.Background(), cognitive.CacheLookup{Scope: testScope, Key: "key-1", EmbedModel: "nomic-embed-text", Embedding: []float64{0.1}})
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if hit == nil || hit.Tier != cognitive.CacheTierExact || hit.Response.Text != "ready" {
+		t.Fatalf("unexpected hit %+v", hit)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestLookup_FallsBackToSemanticTier(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectQuery("request_key = \\$5").WillReturnRows(sqlmock.NewRows([]string{"response"}))
+	mock.ExpectQuery(`embedding_model = \$4\s+AND embedding IS NOT NULL AND vector_dims\(embedding\) = \$5.*ORDER BY embedding <=> \$7::vector`).
+		WithArgs("default", "chat", "ollama", "nomic-embed-text", 2, sqlmock.AnyArg(), "[0.1,0.2]", 0.97).
+		WillReturnRows(sqlmock.NewRows([]string{"response", "similarity"}).AddRow([]byte(`{"text":"ready"}`), 0.985))
+
+	hit, err := s.Lookup(context.Background(), cognitive.CacheLookup{
+		Scope: testScope, Key: "key-2", EmbedModel: "nomic-embed-text", Embedding: []float64{0.1, 0.2}, MinSimilarity: 0.97,
+	})
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if hit == nil || hit.Tier != cognitive.CacheTierSemantic || hit.Similarity != 0.985 {
+		t.Fatalf("unexpected hit %+v", hit)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestLookup_MissWithoutEmbeddingSkipsSemanticTier(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectQuery("request_key = \\$5").WillReturnRows(sqlmock.NewRows([]string{"response"}))
+
+	hit, err := s.Lookup(context.Background(), cognitive.CacheLookup{Scope: testScope, Key: "key-3"})
+	if err != nil || hit != nil {
+		t.Fatalf("Lookup = %+v, %v; want miss", hit, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestLookup_MissWithoutEmbedModelSkipsSemanticTier(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectQuery("request_key = \\$5").WithArgs("default", "chat", "ollama", "", "key-4", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"response"}))
+
+	hit, err := s.Lookup(context.Background(), cognitive.CacheLookup{Scope: testScope, Key: "key-4", Embedding: []float64{0.1}})
+	if err != nil || hit != nil {
+		t.Fatalf("Lookup = %+v, %v; want miss", hit, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestLookup_NilDB(t *testing.T) {
+	if _, err := NewStore(nil).Lookup(context.Background(), cognitive.CacheLookup{Scope: testScope}); err == nil {
+		t.Error("expected error with nil DB")
+	}
+}
+
+// ── Store / Invalidate ─────────────────────────────────────────────
+
+func TestStore_UpsertsAndPrunesExpired(t *testing.T) {
+	s, mock := newMockStore(t)
+	expires := time.Date(2026, 3, 4, 13, 0, 0, 0, time.UTC)
+	mock.ExpectExec("INSERT INTO inference_cache").
+		WithArgs(sqlmock.AnyArg(), "default", "chat", "ollama", "nomic-embed-text", "key-1", "[0.5,0.25]", sqlmock.AnyArg(), sqlmock.AnyArg(), expires).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DELETE FROM inference_cache WHERE expires_at <= \\$1").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO inference_cache").
+		WithArgs(sqlmock.AnyArg(), "default", "chat", "ollama", "nomic-embed-text", "key-2", nil, sqlmock.AnyArg(), sqlmock.AnyArg(), expires).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	entry := cognitive.CacheEntry{Scope: testScope, Key: "key-1", EmbedModel: "nomic-embed-text", Embedding: []float64{0.5, 0.25},
+		Response: cognitive.InferResponse{Text: "ready"}, ExpiresAt: expires}
+	if err := s.Store(context.Background(), entry); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	// A second store within the prune interval does not sweep again.
+	entry.Key, entry.Embedding = "key-2", nil
+	if err := s.Store(context.Background(), entry); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestInvalidate_FiltersByScope(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectExec(`DELETE FROM inference_cache WHERE profile = \$1 AND provider_id = \$2$`).
+		WithArgs("review", "ollama").
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(`DELETE FROM inference_cache$`).WillReturnResult(sqlmock.NewResult(0, 9))
+
+	n, err := s.Invalidate(context.Background(), cognitive.CacheScope{Profile: "review", ProviderID: "ollama"})
+	if err != nil || n != 4 {
+		t.Fatalf("Invalidate = %d, %v; want 4", n, err)
+	}
+	if n, err := s.Invalidate(context.Background(), cognitive.CacheScope{}); err != nil || n != 9 {
+		t.Fatalf("Invalidate(all) = %d, %v; want 9", n, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
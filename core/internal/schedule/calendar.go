@@ -0,0 +1,135 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+// calendar holds the blackout windows and holidays a schedule skips.
+type calendar struct {
+	windows  []window
+	holidays map[string]bool // "2006-01-02" for one date, "01-02" for every year
+}
+
+// window is either a recurring time-of-day range (optionally limited to some
+// weekdays) or an absolute range of local times.
+type window struct {
+	// recurring, in minutes after midnight; end < start wraps past midnight
+	start, end int
+	days       map[time.Weekday]bool // nil means every day
+
+	// absolute
+	from, to time.Time
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+func compileCalendar(cfg protocol.ScheduleConfig, loc *time.Location) (calendar, error) {
+	cal := calendar{holidays: map[string]bool{}}
+	for i, b := range cfg.Blackouts {
+		w, err := compileWindow(b, loc)
+		if err != nil {
+			return cal, fmt.Errorf("schedule: blackouts[%d]: %w", i, err)
+		}
+		cal.windows = append(cal.windows, w)
+	}
+	for _, h := range cfg.Holidays {
+		h = strings.TrimSpace(h)
+		_, errDate := time.Parse("2006-01-02", h)
+		_, errYearly := time.Parse("01-02", h)
+		if errDate != nil && errYearly != nil {
+			return cal, fmt.Errorf("schedule: holiday %q must be YYYY-MM-DD or MM-DD", h)
+		}
+		cal.holidays[h] = true
+	}
+	return cal, nil
+}
+
+func compileWindow(b protocol.ScheduleWindow, loc *time.Location) (window, error) {
+	var w window
+	start, errStart := time.Parse("15:04", b.Start)
+	end, errEnd := time.Parse("15:04", b.End)
+	if errStart == nil && errEnd == nil {
+		w.start, w.end = start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+		if w.start == w.end {
+			return w, fmt.Errorf("window %s-%s is empty", b.Start, b.End)
+		}
+		for _, d := range b.Days {
+			name := strings.ToLower(strings.TrimSpace(d))
+			if len(name) > 3 {
+				name = name[:3] // "monday" -> "mon"
+			}
+			day, ok := weekdays[name]
+			if !ok {
+				return w, fmt.Errorf("unknown weekday %q", d)
+			}
+			if w.days == nil {
+				w.days = map[time.Weekday]bool{}
+			}
+			w.days[day] = true
+		}
+		return w, nil
+	}
+	if len(b.Days) > 0 {
+		return w, fmt.Errorf("days only apply to HH:MM windows")
+	}
+	var err error
+	if w.from, err = parseLocal(b.Start, loc); err != nil {
+		return w, err
+	}
+	if w.to, err = parseLocal(b.End, loc); err != nil {
+		return w, err
+	}
+	if !w.to.After(w.from) {
+		return w, fmt.Errorf("window %s-%s ends before it starts", b.Start, b.End)
+	}
+	return w, nil
+}
+
+func parseLocal(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q must be HH:MM, YYYY-MM-DD or YYYY-MM-DDTHH:MM", s)
+}
+
+// blocks reports whether t (already in the schedule's location) falls on a
+// holiday or inside a blackout window.
+func (c calendar) blocks(t time.Time) bool {
+	if c.holidays[t.Format("2006-01-02")] || c.holidays[t.Format("01-02")] {
+		return true
+	}
+	for _, w := range c.windows {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w window) contains(t time.Time) bool {
+	if !w.from.IsZero() {
+		return !t.Before(w.from) && t.Before(w.to)
+	}
+	m := t.Hour()*60 + t.Minute()
+	if w.start < w.end {
+		return m >= w.start && m < w.end && w.onDay(t.Weekday())
+	}
+	// Wrapping windows belong to the day they start on.
+	if m >= w.start {
+		return w.onDay(t.Weekday())
+	}
+	return m < w.end && w.onDay((t.Weekday()+6)%7)
+}
+
+func (w window) onDay(d time.Weekday) bool {
+	return w.days == nil || w.days[d]
+}
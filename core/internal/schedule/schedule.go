@@ -0,0 +1,168 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+// Misfire policies.
+const (
+	MisfireRunOnce = "run_once"
+	MisfireSkip    = "skip"
+	MisfireCatchUp = "catch_up"
+)
+
+// DefaultMisfireGrace is how late a run may start before it counts as missed.
+const DefaultMisfireGrace = 5 * time.Minute
+
+const (
+	// maxCatchUp bounds how many missed runs catch_up replays.
+	maxCatchUp = 24
+	// maxScan bounds occurrence scans, e.g. a 30s interval over a long outage
+	// or a calendar that blocks every candidate.
+	maxScan = 100000
+)
+
+// Schedule is a compiled ScheduleConfig. It is immutable and safe for
+// concurrent use.
+type Schedule struct {
+	cron     *Cron
+	expr     string
+	interval time.Duration
+	loc      *time.Location
+	calendar calendar
+	misfire  string
+	grace    time.Duration
+}
+
+// Compile validates cfg and resolves its time zone, cron expression and
+// calendar.
+func Compile(cfg protocol.ScheduleConfig) (*Schedule, error) {
+	s := &Schedule{loc: time.UTC, misfire: MisfireRunOnce, grace: DefaultMisfireGrace}
+	kind := cfg.Type
+	if kind == "" {
+		kind = "interval"
+		if cfg.CronExpr != "" {
+			kind = "cron"
+		}
+	}
+	switch kind {
+	case "cron":
+		if cfg.CronExpr == "" {
+			return nil, fmt.Errorf("schedule: cron_expr is required for cron schedules")
+		}
+		c, err := ParseCron(cfg.CronExpr)
+		if err != nil {
+			return nil, err
+		}
+		s.cron, s.expr = c, cfg.CronExpr
+	case "interval":
+		d, err := time.ParseDuration(cfg.Interval)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("schedule: interval %q must be a positive duration", cfg.Interval)
+		}
+		s.interval = d
+	default:
+		return nil, fmt.Errorf("schedule: unknown type %q", cfg.Type)
+	}
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("schedule: unknown time zone %q", cfg.Timezone)
+		}
+		s.loc = loc
+	}
+	switch cfg.Misfire {
+	case "":
+	case MisfireRunOnce, MisfireSkip, MisfireCatchUp:
+		s.misfire = cfg.Misfire
+	default:
+		return nil, fmt.Errorf("schedule: unknown misfire policy %q", cfg.Misfire)
+	}
+	if cfg.MisfireGrace != "" {
+		d, err := time.ParseDuration(cfg.MisfireGrace)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("schedule: misfire_grace %q must be a duration", cfg.MisfireGrace)
+		}
+		s.grace = d
+	}
+	cal, err := compileCalendar(cfg, s.loc)
+	if err != nil {
+		return nil, err
+	}
+	s.calendar = cal
+	return s, nil
+}
+
+// Interval is the fixed interval, or zero for cron schedules.
+func (s *Schedule) Interval() time.Duration { return s.interval }
+
+func (s *Schedule) String() string {
+	if s.cron == nil {
+		return "every " + s.interval.String()
+	}
+	return fmt.Sprintf("cron %q in %s", s.expr, s.loc)
+}
+
+// Blocked reports whether t falls on a holiday or inside a blackout window.
+func (s *Schedule) Blocked(t time.Time) bool {
+	return s.calendar.blocks(t.In(s.loc))
+}
+
+// Next returns the first run strictly after after that is not blocked, or
+// the zero time when the schedule has no further runs.
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.In(s.loc)
+	for i := 0; i < maxScan; i++ {
+		if s.cron != nil {
+			t = s.cron.Next(t)
+		} else {
+			t = t.Add(s.interval)
+		}
+		if t.IsZero() || !s.calendar.blocks(t) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Plan is what to do with a run that came due.
+type Plan struct {
+	Fire   time.Time // run to start now; zero when the misfire policy drops it
+	Next   time.Time // when to look again; zero when there are no further runs
+	Missed int       // runs dropped by the misfire policy
+}
+
+// Plan resolves the run due at due, observed at now. Runs within the misfire
+// grace start as scheduled; later ones are handled by the misfire policy.
+// Under catch_up, Next may already have passed, so callers keep planning
+// until it lies in the future.
+func (s *Schedule) Plan(due, now time.Time) Plan {
+	if !now.After(due.Add(s.grace)) {
+		return Plan{Fire: due, Next: s.Next(due)}
+	}
+	kept, total := s.missed(due, now)
+	switch s.misfire {
+	case MisfireSkip:
+		return Plan{Next: s.Next(now), Missed: total}
+	case MisfireCatchUp:
+		return Plan{Fire: kept[0], Next: s.Next(kept[0]), Missed: total - len(kept)}
+	}
+	return Plan{Fire: kept[len(kept)-1], Next: s.Next(now), Missed: total - 1}
+}
+
+// missed lists the runs from due through now, keeping the latest maxCatchUp.
+func (s *Schedule) missed(due, now time.Time) ([]time.Time, int) {
+	kept := []time.Time{due}
+	total := 1
+	for t := s.Next(due); !t.IsZero() && !t.After(now) && total < maxScan; t = s.Next(t) {
+		total++
+		kept = append(kept, t)
+		if len(kept) > maxCatchUp {
+			kept = kept[1:]
+		}
+	}
+	return kept, total
+}
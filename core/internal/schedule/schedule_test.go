@@ -0,0 +1,174 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+func mustCompile(t *testing.T, cfg protocol.ScheduleConfig) *Schedule {
+	t.Helper()
+	s, err := Compile(cfg)
+	if err != nil {
+		t.Fatalf("Compile(%+v): %v", cfg, err)
+	}
+	return s
+}
+
+func TestCronNext(t *testing.T) {
+	// Wednesday
+	base := time.Date(2026, 1, 7, 10, 17, 30, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 1, 7, 10, 30, 0, 0, time.UTC)},
+		{"0 8 * * mon-fri", time.Date(2026, 1, 8, 8, 0, 0, 0, time.UTC)},
+		{"0 9 * * SAT,sun", time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 10-18/4 * * *", time.Date(2026, 1, 7, 14, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 1st of the month or any Friday.
+		{"0 0 1 * fri", time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2026, 1, 11, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		if got := c.Next(base); !got.Equal(tt.want) {
+			t.Errorf("%q: Next = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	c, _ := ParseCron("0 0 30 2 *")
+	if got := c.Next(base); !got.IsZero() {
+		t.Errorf("impossible date: Next = %v, want zero", got)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "0 25 * * *", "0 0 * * funday", "*/0 * * * *", "5-1 * * * *", "@every"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q): expected error", expr)
+		}
+	}
+}
+
+func TestNext_BusinessDaysInZone(t *testing.T) {
+	s := mustCompile(t, protocol.ScheduleConfig{
+		CronExpr: "0 8 * * mon-fri",
+		Timezone: "Europe/Berlin",
+		Holidays: []string{"12-25", "2026-12-24"},
+	})
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	// Wednesday 23 Dec after the run: Thursday 24th and Friday 25th are
+	// holidays, then the weekend, so Monday 28th.
+	got := s.Next(time.Date(2026, 12, 23, 9, 0, 0, 0, berlin))
+	if want := time.Date(2026, 12, 28, 8, 0, 0, 0, berlin); !got.Equal(want) {
+		t.Fatalf("Next = %v, want %v", got, want)
+	}
+
+	// 08:00 local stays 08:00 across the DST change (UTC offset moves).
+	got = s.Next(time.Date(2026, 3, 27, 9, 0, 0, 0, berlin))
+	if got.In(berlin).Hour() != 8 || got.UTC().Hour() != 6 {
+		t.Fatalf("after DST: Next = %v (UTC %v)", got, got.UTC())
+	}
+}
+
+func TestBlackouts(t *testing.T) {
+	s := mustCompile(t, protocol.ScheduleConfig{
+		Interval: "1h",
+		Blackouts: []protocol.ScheduleWindow{
+			{Start: "22:00", End: "06:00", Days: []string{"friday"}},
+			{Start: "2026-01-07T12:00", End: "2026-01-07T15:00"},
+		},
+	})
+	tests := []struct {
+		at      time.Time
+		blocked bool
+	}{
+		{time.Date(2026, 1, 9, 23, 0, 0, 0, time.UTC), true},  // Friday night
+		{time.Date(2026, 1, 10, 3, 0, 0, 0, time.UTC), true},  // wraps into Saturday
+		{time.Date(2026, 1, 8, 23, 0, 0, 0, time.UTC), false}, // Thursday night
+		{time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2026, 1, 7, 15, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := s.Blocked(tt.at); got != tt.blocked {
+			t.Errorf("Blocked(%v) = %v, want %v", tt.at, got, tt.blocked)
+		}
+	}
+	if got := s.Next(time.Date(2026, 1, 7, 11, 0, 0, 0, time.UTC)); !got.Equal(time.Date(2026, 1, 7, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("Next across maintenance window = %v", got)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, cfg := range []protocol.ScheduleConfig{
+		{},
+		{Type: "cron"},
+		{Type: "weekly", Interval: "1h"},
+		{Interval: "-5m"},
+		{CronExpr: "@daily", Timezone: "Mars/Olympus"},
+		{CronExpr: "@daily", Misfire: "panic"},
+		{CronExpr: "@daily", Holidays: []string{"christmas"}},
+		{CronExpr: "@daily", Blackouts: []protocol.ScheduleWindow{{Start: "10:00", End: "10:00"}}},
+		{CronExpr: "@daily", Blackouts: []protocol.ScheduleWindow{{Start: "2026-02-01", End: "2026-01-01"}}},
+		{CronExpr: "@daily", Blackouts: []protocol.ScheduleWindow{{Start: "22:00", End: "06:00", Days: []string{"someday"}}}},
+	} {
+		if _, err := Compile(cfg); err == nil {
+			t.Errorf("Compile(%+v): expected error", cfg)
+		}
+	}
+}
+
+func TestPlan_Misfire(t *testing.T) {
+	due := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC) // Monday
+	later := time.Date(2026, 1, 8, 9, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 8, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		misfire    string
+		now        time.Time
+		fire, next time.Time
+		missed     int
+	}{
+		// Within the grace period the run starts as scheduled.
+		{"skip", due.Add(time.Minute), due, day(6), 0},
+		{"", later, day(8), day(9), 3},
+		{"run_once", later, day(8), day(9), 3},
+		{"skip", later, time.Time{}, day(9), 4},
+		{"catch_up", later, due, day(6), 0},
+	}
+	for _, tt := range tests {
+		s := mustCompile(t, protocol.ScheduleConfig{CronExpr: "0 8 * * mon-fri", Misfire: tt.misfire})
+		p := s.Plan(due, tt.now)
+		if !p.Fire.Equal(tt.fire) || !p.Next.Equal(tt.next) || p.Missed != tt.missed {
+			t.Errorf("%q: Plan = %+v, want fire %v next %v missed %d", tt.misfire, p, tt.fire, tt.next, tt.missed)
+		}
+	}
+}
+
+func TestPlan_CatchUpIsBounded(t *testing.T) {
+	s := mustCompile(t, protocol.ScheduleConfig{Interval: "1h", Misfire: MisfireCatchUp})
+	due := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := due.Add(100 * time.Hour)
+
+	fired := 0
+	for p := s.Plan(due, now); ; p = s.Plan(p.Next, now) {
+		if !p.Fire.IsZero() {
+			fired++
+		}
+		if p.Next.After(now) {
+			break
+		}
+	}
+	if fired != maxCatchUp {
+		t.Fatalf("fired %d runs, want %d", fired, maxCatchUp)
+	}
+}
@@ -39,14 +39,14 @@ func (s *Service) searchLocalSources(ctx context.Context, req Request, resp Resp
 	} else {
 		err = fmt.Errorf("embedding engine not configured")
 	}
-	var results []memory.VectorResult
-	if err == nil {
-		results, err = s.mem.SemanticSearchWithOptions(ctx, vec, opts)
-	} else {
+	resp.Metadata["retrieval"] = "hybrid_rrf"
+	if err != nil {
+		vec = nil
+		resp.Metadata["retrieval"] = "full_text"
 		resp.Metadata["semantic_fallback"] = "text_search"
 		resp.Metadata["semantic_fallback_reason"] = "embedding_unavailable"
-		results, err = s.mem.TextSearchWithOptions(ctx, req.Query, opts)
 	}
+	results, err := s.mem.HybridSearch(ctx, req.Query, vec, opts)
 	if err != nil {
 		return resp, fmt.Errorf("local-source search failed: %w", err)
 	}
@@ -237,17 +237,17 @@ func TestServiceLocalAPIMissingEndpointBlocks(t *testing.T) {
 	}
 }
 
-func TestServiceLocalSourcesFallsBackToTextSearchWhenEmbeddingFails(t *testing.T) {
+func TestServiceLocalSourcesFallsBackToFullTextWhenEmbeddingFails(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("sqlmock: %v", err)
 	}
 	t.Cleanup(func() { db.Close() })
 
-	rows := sqlmock.NewRows([]string{"id", "content", "metadata", "created_at"}).
-		AddRow("vec-1", "latest research retained context", `{"title":"Research note","visibility":"global"}`, time.Now())
-	mock.ExpectQuery("SELECT id, content, metadata, created_at").
-		WithArgs("default", "%latest%", "%research%", 2).
+	rows := sqlmock.NewRows([]string{"id", "content", "metadata", "score", "created_at"}).
+		AddRow("vec-1", "latest research retained context", `{"title":"Research note","visibility":"global"}`, 0.3, time.Now())
+	mock.ExpectQuery("FROM context_vectors, to_tsquery\\('english', \\$2\\) AS q").
+		WithArgs("default", "latest | research", 20).
 		WillReturnRows(rows)
 
 	svc := NewService(Config{Provider: ProviderLocalSources, MaxResults: 2}, failingEmbedder{}, memory.NewServiceWithDB(db))
@@ -0,0 +1,154 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// sealedV1 prefixes values sealed under the scrypt-derived key. Values
+// without it were sealed by earlier releases under sha256(passphrase); they
+// still open and Migrate re-seals them.
+const sealedV1 = 0x01
+
+// scrypt cost parameters (the interactive-login recommendation) and the salt
+// size stored once per store in secrets_kdf.
+const (
+	scryptN  = 1 << 15
+	scryptR  = 8
+	scryptP  = 1
+	saltSize = 16
+)
+
+// loadSalt returns the store's salt, creating it on first use.
+func loadSalt(ctx context.Context, db *sql.DB) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("secrets: salt: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO secrets_kdf (id, salt) VALUES (1, $1) ON CONFLICT (id) DO NOTHING`, salt); err != nil {
+		return nil, fmt.Errorf("secrets: store salt: %w", err)
+	}
+	if err := db.QueryRowContext(ctx, `SELECT salt FROM secrets_kdf WHERE id = 1`).Scan(&salt); err != nil {
+		return nil, fmt.Errorf("secrets: load salt: %w", err)
+	}
+	return salt, nil
+}
+
+// deriveKeys returns the AEAD for new values, keyed by scrypt over the
+// passphrase and salt, and the legacy sha256-keyed AEAD for old ones.
+func deriveKeys(passphrase string, salt []byte) (cipher.AEAD, cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("secrets: derive key: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	legacyKey := sha256.Sum256([]byte(passphrase))
+	legacy, err := newAEAD(legacyKey[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return aead, legacy, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("secrets: init cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("secrets: init gcm: %w", err)
+	}
+	return aead, nil
+}
+
+// seal encrypts value for name in the current format.
+func (s *Store) seal(name, value string) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("secrets: nonce: %w", err)
+	}
+	return s.aead.Seal(append([]byte{sealedV1}, nonce...), nonce, []byte(value), []byte(name)), nil
+}
+
+// open decrypts a stored value and reports whether it is in the legacy
+// format. A legacy value may start with the version byte by chance, so a
+// versioned open that fails falls back to the legacy key.
+func (s *Store) open(name string, sealed []byte) ([]byte, bool, error) {
+	size := s.aead.NonceSize()
+	if len(sealed) > size && sealed[0] == sealedV1 {
+		body := sealed[1:]
+		if plain, err := s.aead.Open(nil, body[:size], body[size:], []byte(name)); err == nil {
+			return plain, false, nil
+		}
+	}
+	if len(sealed) < size {
+		return nil, false, fmt.Errorf("secrets: %q is corrupt", name)
+	}
+	plain, err := s.legacy.Open(nil, sealed[:size], sealed[size:], []byte(name))
+	if err != nil {
+		return nil, false, fmt.Errorf("secrets: decrypt %q: wrong %s or tampered value", name, KeyEnv)
+	}
+	return plain, true, nil
+}
+
+// Migrate re-seals every value still in the legacy format under the
+// derived key and returns how many it rewrote. A value changed since it was
+// read is left for the next run.
+func (s *Store) Migrate(ctx context.Context) (int, error) {
+	if err := s.ready(); err != nil {
+		return 0, err
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT name, ciphertext FROM secrets ORDER BY name`)
+	if err != nil {
+		return 0, fmt.Errorf("secrets: migrate: %w", err)
+	}
+	type stored struct {
+		name   string
+		sealed []byte
+	}
+	var all []stored
+	for rows.Next() {
+		var row stored
+		if err := rows.Scan(&row.name, &row.sealed); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("secrets: migrate: %w", err)
+		}
+		all = append(all, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("secrets: migrate: %w", err)
+	}
+
+	migrated := 0
+	for _, row := range all {
+		plain, legacy, err := s.open(row.name, row.sealed)
+		if err != nil || !legacy {
+			continue
+		}
+		resealed, err := s.seal(row.name, string(plain))
+		if err != nil {
+			return migrated, err
+		}
+		res, err := s.db.ExecContext(ctx, `UPDATE secrets SET ciphertext = $2 WHERE name = $1 AND ciphertext = $3`,
+			row.name, resealed, row.sealed)
+		if err != nil {
+			return migrated, fmt.Errorf("secrets: migrate %q: %w", row.name, err)
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			migrated++
+		}
+	}
+	return migrated, nil
+}
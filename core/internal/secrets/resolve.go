@@ -0,0 +1,96 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Reference prefixes understood by Resolve.
+const (
+	EnvPrefix    = "env:"
+	SecretPrefix = "secret:"
+)
+
+// EnvRefPrefix is the only environment variable prefix env: references may
+// read. Whoever writes a reference chooses where its value is sent, so the
+// rest of the process environment stays out of reach.
+const EnvRefPrefix = "MYCELIS_MCP_"
+
+var placeholderPattern = regexp.MustCompile(`\$\{((?:env|secret):[^}]+)\}`)
+
+// EnvAllowed reports whether an env: reference may read the variable name.
+// The store passphrase is never readable, whatever its name.
+func EnvAllowed(name string) bool {
+	return name != KeyEnv && strings.HasPrefix(name, EnvRefPrefix) && len(name) > len(EnvRefPrefix)
+}
+
+// CheckEnvRefs returns an error for the first env: reference in value, bare
+// or as a ${env:…} placeholder, that EnvAllowed rejects.
+func CheckEnvRefs(value string) error {
+	refs := []string{strings.TrimSpace(value)}
+	for _, match := range placeholderPattern.FindAllStringSubmatch(value, -1) {
+		refs = append(refs, match[1])
+	}
+	for _, ref := range refs {
+		if name, ok := strings.CutPrefix(ref, EnvPrefix); ok && !EnvAllowed(name) {
+			return fmt.Errorf("secrets: env:%s is not allowed; env: references must name a %s* variable", name, EnvRefPrefix)
+		}
+	}
+	return nil
+}
+
+// IsRef reports whether ref is an "env:NAME" or "secret:NAME" reference.
+func IsRef(ref string) bool {
+	ref = strings.TrimSpace(ref)
+	return (strings.HasPrefix(ref, EnvPrefix) && len(ref) > len(EnvPrefix)) ||
+		(strings.HasPrefix(ref, SecretPrefix) && len(ref) > len(SecretPrefix))
+}
+
+// HasPlaceholder reports whether value contains a ${env:…} or ${secret:…}
+// placeholder.
+func HasPlaceholder(value string) bool {
+	return placeholderPattern.MatchString(value)
+}
+
+// Resolve returns the value ref points at. "env:NAME" reads the process
+// environment, limited to EnvAllowed names, and "secret:NAME" reads store,
+// which may be nil when only environment references are expected.
+func Resolve(ctx context.Context, store *Store, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	switch {
+	case strings.HasPrefix(ref, EnvPrefix):
+		if err := CheckEnvRefs(ref); err != nil {
+			return "", err
+		}
+		name := strings.TrimPrefix(ref, EnvPrefix)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("secrets: environment variable %s is not set", name)
+		}
+		return value, nil
+	case strings.HasPrefix(ref, SecretPrefix):
+		return store.Get(ctx, strings.TrimPrefix(ref, SecretPrefix))
+	default:
+		return "", fmt.Errorf("secrets: %q is not an env: or secret: reference", ref)
+	}
+}
+
+// Expand replaces every ${env:NAME} and ${secret:NAME} placeholder in value,
+// so "Bearer ${secret:github-token}" becomes a usable header value.
+func Expand(ctx context.Context, store *Store, value string) (string, error) {
+	var firstErr error
+	out := placeholderPattern.ReplaceAllStringFunc(value, func(match string) string {
+		resolved, err := Resolve(ctx, store, placeholderPattern.FindStringSubmatch(match)[1])
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return resolved
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return out, nil
+}
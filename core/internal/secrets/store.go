@@ -0,0 +1,162 @@
+// Package secrets keeps named credentials encrypted at rest and resolves the
+// secret references ("env:NAME", "secret:NAME") that integrations store in
+// place of plaintext. Values are sealed with AES-256-GCM under a key derived
+// with scrypt from MYCELIS_SECRETS_KEY and a salt stored with the secrets;
+// the row name is bound as additional data so a ciphertext cannot be
+// replayed under another name.
+// Without the key no Store is built and only env: references resolve.
+package secrets
+
+import (
+	"context"
+	"crypto/cipher"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// KeyEnv names the environment variable holding the store passphrase.
+const KeyEnv = "MYCELIS_SECRETS_KEY"
+
+// ErrNotFound is returned when no secret is stored under a name.
+var ErrNotFound = errors.New("secrets: not found")
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]{0,127}$`)
+
+// Entry describes a stored secret without its value.
+type Entry struct {
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Store persists encrypted secrets in the secrets table.
+type Store struct {
+	db     *sql.DB
+	aead   cipher.AEAD
+	legacy cipher.AEAD // opens values sealed before the key was derived with scrypt
+}
+
+// NewStore creates a Store sealing values under passphrase, loading or
+// creating the store's salt. db may be nil (degraded mode); an empty
+// passphrase is rejected.
+func NewStore(ctx context.Context, db *sql.DB, passphrase string) (*Store, error) {
+	if strings.TrimSpace(passphrase) == "" {
+		return nil, fmt.Errorf("secrets: %s is not set", KeyEnv)
+	}
+	if db == nil {
+		return &Store{}, nil
+	}
+	salt, err := loadSalt(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	aead, legacy, err := deriveKeys(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db, aead: aead, legacy: legacy}, nil
+}
+
+// NewStoreFromEnv creates a Store keyed by MYCELIS_SECRETS_KEY.
+func NewStoreFromEnv(ctx context.Context, db *sql.DB) (*Store, error) {
+	return NewStore(ctx, db, os.Getenv(KeyEnv))
+}
+
+// ValidName reports whether name may be used as a secret name.
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Put stores value under name, replacing any previous value.
+func (s *Store) Put(ctx context.Context, name, value string) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	if !ValidName(name) {
+		return fmt.Errorf("secrets: invalid name %q", name)
+	}
+	sealed, err := s.seal(name, value)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO secrets (name, ciphertext)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE
+		SET ciphertext = EXCLUDED.ciphertext, updated_at = NOW()
+	`, name, sealed)
+	if err != nil {
+		return fmt.Errorf("secrets: put %q: %w", name, err)
+	}
+	return nil
+}
+
+// Get returns the plaintext stored under name, or ErrNotFound.
+func (s *Store) Get(ctx context.Context, name string) (string, error) {
+	if err := s.ready(); err != nil {
+		return "", err
+	}
+	var sealed []byte
+	err := s.db.QueryRowContext(ctx, `SELECT ciphertext FROM secrets WHERE name = $1`, name).Scan(&sealed)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("secrets: get %q: %w", name, err)
+	}
+	plain, _, err := s.open(name, sealed)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// Delete removes the secret stored under name.
+func (s *Store) Delete(ctx context.Context, name string) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM secrets WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("secrets: delete %q: %w", name, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return nil
+}
+
+// List returns the stored secret names, never their values.
+func (s *Store) List(ctx context.Context) ([]Entry, error) {
+	if err := s.ready(); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT name, updated_at FROM secrets ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("secrets: list: %w", err)
+	}
+	defer rows.Close()
+	entries := []Entry{}
+	for rows.Next() {
+		var e Entry
+		if err := rows.Scan(&e.Name, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("secrets: scan: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (s *Store) ready() error {
+	if s == nil {
+		return fmt.Errorf("secrets: store not configured (set %s)", KeyEnv)
+	}
+	if s.db == nil {
+		return fmt.Errorf("secrets: database not available")
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package secrets
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// captureArg matches any []byte argument and keeps it for later expectations.
+type captureArg struct{ dst *[]byte }
+
+func (c captureArg) Match(v driver.Value) bool {
+	b, ok := v.([]byte)
+	*c.dst = b
+	return ok
+}
+
+// expectSalt expects a store to load salt from secrets_kdf.
+func expectSalt(mock sqlmock.Sqlmock, salt string) {
+	mock.ExpectExec("INSERT INTO secrets_kdf").WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT salt FROM secrets_kdf").WillReturnRows(sqlmock.NewRows([]string{"salt"}).AddRow([]byte(salt)))
+}
+
+func TestStore_SealsValuesAndBindsThemToTheirName(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	expectSalt(mock, "salt-one")
+	store, err := NewStore(context.Background(), db, "correct horse")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	var sealed []byte
+	mock.ExpectExec("INSERT INTO secrets").WithArgs("github-token", captureArg{&sealed}).WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := store.Put(context.Background(), "github-token", "ghp_live"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if strings.Contains(string(sealed), "ghp_live") || sealed[0] != sealedV1 {
+		t.Fatal("plaintext reached the database")
+	}
+
+	mock.ExpectQuery("SELECT ciphertext FROM secrets").WithArgs("github-token").
+		WillReturnRows(sqlmock.NewRows([]string{"ciphertext"}).AddRow(sealed))
+	if got, err := store.Get(context.Background(), "github-token"); err != nil || got != "ghp_live" {
+		t.Fatalf("Get = %q, %v", got, err)
+	}
+
+	// The same ciphertext under another name, or under another key, is refused.
+	mock.ExpectQuery("SELECT ciphertext FROM secrets").WithArgs("other").
+		WillReturnRows(sqlmock.NewRows([]string{"ciphertext"}).AddRow(sealed))
+	if _, err := store.Get(context.Background(), "other"); err == nil {
+		t.Fatal("ciphertext replayed under another name was accepted")
+	}
+	expectSalt(mock, "salt-one")
+	wrongKey, _ := NewStore(context.Background(), db, "battery staple")
+	mock.ExpectQuery("SELECT ciphertext FROM secrets").WithArgs("github-token").
+		WillReturnRows(sqlmock.NewRows([]string{"ciphertext"}).AddRow(sealed))
+	if _, err := wrongKey.Get(context.Background(), "github-token"); err == nil {
+		t.Fatal("value opened with the wrong key")
+	}
+	// The same passphrase under another store's salt derives another key.
+	expectSalt(mock, "salt-two")
+	otherSalt, _ := NewStore(context.Background(), db, "correct horse")
+	mock.ExpectQuery("SELECT ciphertext FROM secrets").WithArgs("github-token").
+		WillReturnRows(sqlmock.NewRows([]string{"ciphertext"}).AddRow(sealed))
+	if _, err := otherSalt.Get(context.Background(), "github-token"); err == nil {
+		t.Fatal("value opened under another salt")
+	}
+
+	mock.ExpectQuery("SELECT ciphertext FROM secrets").WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"ciphertext"}))
+	if _, err := store.Get(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing secret error = %v, want ErrNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStore_MigratesLegacyValues(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	expectSalt(mock, "salt-one")
+	store, err := NewStore(context.Background(), db, "correct horse")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	// Sealed as earlier releases did: nonce || ciphertext under sha256(passphrase).
+	nonce := make([]byte, store.legacy.NonceSize())
+	legacy := store.legacy.Seal(nonce, nonce, []byte("ghp_old"), []byte("github-token"))
+	current, _ := store.seal("api-key", "sk_new")
+
+	mock.ExpectQuery("SELECT ciphertext FROM secrets").WithArgs("github-token").
+		WillReturnRows(sqlmock.NewRows([]string{"ciphertext"}).AddRow(legacy))
+	if got, err := store.Get(context.Background(), "github-token"); err != nil || got != "ghp_old" {
+		t.Fatalf("legacy Get = %q, %v", got, err)
+	}
+
+	var resealed []byte
+	mock.ExpectQuery("SELECT name, ciphertext FROM secrets").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "ciphertext"}).AddRow("api-key", current).AddRow("github-token", legacy))
+	mock.ExpectExec("UPDATE secrets SET ciphertext").WithArgs("github-token", captureArg{&resealed}, legacy).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if n, err := store.Migrate(context.Background()); err != nil || n != 1 {
+		t.Fatalf("Migrate = %d, %v; want the one legacy value", n, err)
+	}
+	if plain, wasLegacy, err := store.open("github-token", resealed); err != nil || wasLegacy || string(plain) != "ghp_old" {
+		t.Fatalf("re-sealed value = %q legacy=%t %v", plain, wasLegacy, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewStore_RequiresPassphrase(t *testing.T) {
+	if _, err := NewStore(context.Background(), nil, " "); err == nil {
+		t.Fatal("empty passphrase accepted")
+	}
+	var unconfigured *Store
+	if _, err := unconfigured.Get(context.Background(), "x"); err == nil || !strings.Contains(err.Error(), KeyEnv) {
+		t.Fatalf("nil store error = %v, want hint about %s", err, KeyEnv)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	t.Setenv("MYCELIS_MCP_TEST_TOKEN", "abc")
+	got, err := Expand(context.Background(), nil, "Bearer ${env:MYCELIS_MCP_TEST_TOKEN}")
+	if err != nil || got != "Bearer abc" {
+		t.Fatalf("Expand = %q, %v", got, err)
+	}
+	if got, _ := Expand(context.Background(), nil, "plain"); got != "plain" {
+		t.Fatalf("plain value changed to %q", got)
+	}
+	if _, err := Expand(context.Background(), nil, "${env:MYCELIS_MCP_TEST_UNSET}"); err == nil {
+		t.Fatal("unset env reference resolved")
+	}
+	if _, err := Expand(context.Background(), nil, "${secret:github-token}"); err == nil {
+		t.Fatal("secret reference resolved without a store")
+	}
+	t.Setenv("MYCELIS_TEST_TOKEN", "abc")
+	t.Setenv(KeyEnv, "passphrase")
+	for _, ref := range []string{"${env:MYCELIS_TEST_TOKEN}", "${env:" + KeyEnv + "}", "${env:HOME}"} {
+		if got, err := Expand(context.Background(), nil, ref); err == nil {
+			t.Fatalf("Expand(%s) = %q; want the variable out of reach", ref, got)
+		}
+	}
+	if err := CheckEnvRefs("env:" + KeyEnv); err == nil {
+		t.Fatal("CheckEnvRefs accepted the store passphrase")
+	}
+	if !IsRef("secret:github-token") || IsRef("secret:") || IsRef("ghp_live") {
+		t.Fatal("IsRef misclassified a reference")
+	}
+}
@@ -25,10 +25,12 @@ import (
 	"github.com/mycelis/core/internal/router"
 	"github.com/mycelis/core/internal/runs"
 	"github.com/mycelis/core/internal/searchcap"
+	"github.com/mycelis/core/internal/secrets"
 	"github.com/mycelis/core/internal/signal"
 	"github.com/mycelis/core/internal/state"
 	"github.com/mycelis/core/internal/swarm"
 	"github.com/mycelis/core/internal/triggers"
+	"github.com/mycelis/core/internal/usage"
 	"github.com/nats-io/nats.go"
 )
 
@@ -44,6 +46,7 @@ type AdminServer struct {
 	Soma          *swarm.Soma
 	NC            *nats.Conn // NATS for chat request-reply routing
 	Stream        *signal.StreamHandler
+	Sessions      *SessionHub // resumable WebSocket sessions on /api/v1/ws
 	MetaArchitect *cognitive.MetaArchitect
 	Overseer      *overseer.Engine     // Phase 5.2: Trust Economy
 	Archivist     *memory.Archivist    // Phase 5.3: RAG Persistence
@@ -51,18 +54,22 @@ type AdminServer struct {
 	MCP           *mcp.Service         // Phase 7.0: MCP Ingress
 	MCPPool       *mcp.ClientPool      // Phase 7.0: MCP Ingress
 	MCPLibrary    *mcp.Library         // Phase 7.7: Curated MCP Library
+	Secrets       *secrets.Store       // encrypted credentials behind secret: references
 	Catalogue     *catalogue.Service   // Phase 7.5: Agent Catalogue
 	Artifacts     *artifacts.Service   // Phase 7.5: Agent Outputs
 	Exchange      *exchange.Service    // Managed exchange channels, threads, and artifacts
 	Comms         *comms.Gateway       // External communication providers (whatsapp/telegram/slack/etc.)
 	Events        *events.Store        // V7: persistent mission event audit trail
 	Runs          *runs.Manager        // V7: mission run lifecycle management
+	Usage         *usage.Ledger        // inference token + cost ledger
 	Reactive      *reactive.Engine     // watches NATS topics for active profiles
 	Triggers      *triggers.Store      // trigger rule CRUD + in-memory cache
 	TriggerEngine *triggers.Engine     // evaluates rules against CTS events
 	Conversations *conversations.Store // full-fidelity agent conversation turns
 	Inception     *inception.Store     // inception recipe CRUD + search
 	MCPToolSets   *mcp.ToolSetService  // tool set CRUD
+	// Background re-embedding of context vectors when the embed model changes.
+	Embeddings *memory.EmbeddingMigrator
 	// Root-admin collaboration groups (DB-backed), with live bus monitor for status UI.
 	GroupBus *GroupBusMonitor
 	// V8 AI Organization entry flow support.
@@ -75,6 +82,7 @@ type AdminServer struct {
 	Search              *searchcap.Service
 	Capabilities        *capabilities.Service
 	MCPToolExecutor     swarm.MCPToolExecutor
+	InternalTools       *swarm.InternalToolRegistry // tools published by the MCP server endpoint
 }
 
 func NewAdminServer(r *router.Router, guard *governance.Guard, mem *memory.Service, db *sql.DB, cog *cognitive.Router, prov *provisioning.Engine, reg *registry.Service, soma *swarm.Soma, nc *nats.Conn, stream *signal.StreamHandler, architect *cognitive.MetaArchitect, ov *overseer.Engine, arch *memory.Archivist, mcpSvc *mcp.Service, mcpPool *mcp.ClientPool, mcpLib *mcp.Library, cat *catalogue.Service, art *artifacts.Service, ex *exchange.Service, evStore *events.Store, runsManager *runs.Manager) *AdminServer {
@@ -101,6 +109,7 @@ func NewAdminServer(r *router.Router, guard *governance.Guard, mem *memory.Servi
 		Soma:                soma,
 		NC:                  nc,
 		Stream:              stream,
+		Sessions:            NewSessionHub(),
 		MetaArchitect:       architect,
 		Overseer:            ov,
 		Archivist:           arch,
@@ -167,15 +176,16 @@ func (s *AdminServer) handleApprovalAction(w http.ResponseWriter, r *http.Reques
 
 	approved := payload.Action == "APPROVE"
 
-	msg, err := s.Guard.Resolve(id, approved, "admin-api")
+	approver, role := approvalVoter(r, "admin-api")
+	outcome, err := s.Guard.Decide(id, approver, role, approved)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		http.Error(w, err.Error(), approvalDecisionStatus(err))
 		return
 	}
 
-	if approved && msg != nil {
+	if outcome.Message != nil {
 		// Re-inject into the system
-		if err := s.Router.PublishDirect(msg); err != nil {
+		if err := s.Router.PublishDirect(outcome.Message); err != nil {
 			log.Printf("Failed to re-publish approved msg: %v", err)
 			http.Error(w, "Failed to re-publish", http.StatusInternalServerError)
 			return
@@ -183,6 +193,10 @@ func (s *AdminServer) handleApprovalAction(w http.ResponseWriter, r *http.Reques
 	}
 
 	w.WriteHeader(http.StatusOK)
+	if outcome.Status == governance.ApprovalPending {
+		w.Write([]byte(`{"status":"pending"}`))
+		return
+	}
 	w.Write([]byte(`{"status":"resolved"}`))
 }
 
@@ -19,6 +19,9 @@ func (s *AdminServer) RegisterRoutes(mux *http.ServeMux) {
 		})
 	}
 
+	// Bidirectional session: chat, interjections, approvals and run events.
+	mux.HandleFunc("GET /api/v1/ws", s.HandleSessionSocket)
+
 	mux.HandleFunc("/api/v1/memory/stream", s.GetMemoryStream)
 	mux.HandleFunc("/api/v1/cognitive/infer", s.handleInfer)
 	mux.HandleFunc("/api/v1/cognitive/config", s.HandleCognitiveConfig)
@@ -26,6 +29,7 @@ func (s *AdminServer) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/cognitive/status", s.HandleCognitiveStatus)
 	mux.HandleFunc("PUT /api/v1/cognitive/profiles", s.HandleUpdateProfiles)
 	mux.HandleFunc("PUT /api/v1/cognitive/providers/{id}", s.HandleUpdateProvider)
+	mux.HandleFunc("DELETE /api/v1/cognitive/cache", s.HandleInvalidateCognitiveCache)
 	mux.HandleFunc("/api/v1/chat", s.HandleChat)
 
 	mux.HandleFunc("POST /api/v1/council/{member}/chat", s.HandleCouncilChat)
@@ -133,6 +137,11 @@ func (s *AdminServer) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/memory/sitreps", s.HandleListSitReps)
 	mux.HandleFunc("/api/v1/memory/deployment-context", s.HandleDeploymentContext)
 	mux.HandleFunc("/api/v1/memory/temp", s.HandleTempMemory)
+	mux.HandleFunc("GET /api/v1/memory/embeddings", s.HandleListEmbeddingMigrations)
+	mux.HandleFunc("POST /api/v1/memory/embeddings/migrations", s.HandleStartEmbeddingMigration)
+	mux.HandleFunc("GET /api/v1/memory/embeddings/migrations/{id}", s.HandleGetEmbeddingMigration)
+	mux.HandleFunc("POST /api/v1/memory/embeddings/migrations/{id}/cancel", s.HandleCancelEmbeddingMigration)
+	mux.HandleFunc("POST /api/v1/memory/embeddings/migrations/{id}/resume", s.HandleResumeEmbeddingMigration)
 	mux.HandleFunc("GET /api/v1/sensors", s.HandleSensors)
 	mux.HandleFunc("GET /api/v1/comms/providers", s.HandleCommsProviders)
 	mux.HandleFunc("POST /api/v1/comms/send", s.HandleCommsSend)
@@ -151,7 +160,12 @@ func (s *AdminServer) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("DELETE /api/v1/mcp/servers/{id}", s.handleMCPDelete)
 	mux.HandleFunc("POST /api/v1/mcp/servers/{id}/tools/{tool}/call", s.handleMCPToolCall)
 	mux.HandleFunc("GET /api/v1/mcp/tools", s.handleMCPToolsList)
+	mux.HandleFunc("GET /api/v1/mcp/resources", s.handleMCPResourcesList)
+	mux.HandleFunc("GET /api/v1/mcp/prompts", s.handleMCPPromptsList)
 	mux.HandleFunc("GET /api/v1/mcp/activity", s.handleMCPActivity)
+	mux.HandleFunc("POST /api/v1/mcp/servers/{id}/oauth/authorize", s.HandleMCPOAuthAuthorize)
+	mux.HandleFunc("POST /api/v1/mcp/oauth/complete", s.HandleMCPOAuthComplete)
+	mux.Handle(mcpServePath, s.MCPServeHandler())
 	mux.HandleFunc("GET /api/v1/mcp/library", s.handleMCPLibrary)
 	mux.HandleFunc("POST /api/v1/mcp/library/inspect", s.handleMCPLibraryInspect)
 	mux.HandleFunc("POST /api/v1/mcp/library/install", s.handleMCPLibraryInstall)
@@ -159,6 +173,7 @@ func (s *AdminServer) RegisterRoutes(mux *http.ServeMux) {
 
 	mux.HandleFunc("GET /api/v1/governance/policy", s.handleGetPolicy)
 	mux.HandleFunc("PUT /api/v1/governance/policy", s.handleUpdatePolicy)
+	mux.HandleFunc("POST /api/v1/governance/policy/simulate", s.handleSimulatePolicy)
 	mux.HandleFunc("GET /api/v1/governance/pending", s.handleGetPendingApprovals)
 	mux.HandleFunc("POST /api/v1/governance/resolve/{id}", s.handleResolveApproval)
 
@@ -189,6 +204,8 @@ func (s *AdminServer) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("PUT /api/v1/brains/{id}", s.HandleUpdateBrain)
 	mux.HandleFunc("DELETE /api/v1/brains/{id}", s.HandleDeleteBrain)
 	mux.HandleFunc("POST /api/v1/brains/{id}/probe", s.HandleProbeBrain)
+	mux.HandleFunc("GET /api/v1/brains/{id}/models", s.HandleListBrainModels)
+	mux.HandleFunc("POST /api/v1/brains/{id}/models/pull", s.HandlePullBrainModel)
 
 	mux.HandleFunc("GET /api/v1/context/snapshots", s.HandleListSnapshots)
 	mux.HandleFunc("POST /api/v1/context/snapshot", s.HandleCreateSnapshot)
@@ -202,12 +219,15 @@ func (s *AdminServer) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/runs", s.handleListRuns)
 	mux.HandleFunc("GET /api/v1/runs/{id}/events", s.handleGetRunEvents)
 	mux.HandleFunc("GET /api/v1/runs/{id}/chain", s.handleGetRunChain)
+	mux.HandleFunc("GET /api/v1/runs/{id}/usage", s.HandleGetRunUsage)
+	mux.HandleFunc("GET /api/v1/usage", s.HandleUsageSummary)
 	mux.HandleFunc("GET /api/v1/triggers", s.HandleListTriggers)
 	mux.HandleFunc("POST /api/v1/triggers", s.HandleCreateTrigger)
 	mux.HandleFunc("PUT /api/v1/triggers/{id}", s.HandleUpdateTrigger)
 	mux.HandleFunc("DELETE /api/v1/triggers/{id}", s.HandleDeleteTrigger)
 	mux.HandleFunc("POST /api/v1/triggers/{id}/toggle", s.HandleToggleTrigger)
 	mux.HandleFunc("GET /api/v1/triggers/{id}/history", s.HandleTriggerHistory)
+	mux.HandleFunc("POST /api/v1/triggers/{id}/test", s.HandleTestTrigger)
 	mux.HandleFunc("POST /api/v1/triggers/{id}/history/{executionId}/approval", s.HandleScheduleHandoffApproval)
 
 	mux.HandleFunc("GET /api/v1/services/status", s.HandleServicesStatus)
@@ -233,4 +253,8 @@ func (s *AdminServer) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /api/v1/mcp/toolsets", s.handleCreateToolSet)
 	mux.HandleFunc("PUT /api/v1/mcp/toolsets/{id}", s.handleUpdateToolSet)
 	mux.HandleFunc("DELETE /api/v1/mcp/toolsets/{id}", s.handleDeleteToolSet)
+
+	mux.HandleFunc("GET /api/v1/secrets", s.HandleListSecrets)
+	mux.HandleFunc("PUT /api/v1/secrets/{name...}", s.HandlePutSecret)
+	mux.HandleFunc("DELETE /api/v1/secrets/{name...}", s.HandleDeleteSecret)
 }
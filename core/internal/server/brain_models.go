@@ -0,0 +1,85 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/mycelis/core/internal/cognitive"
+)
+
+// brainCatalog resolves the model catalog for provider id, writing the error
+// response and returning false when it is unavailable.
+func (s *AdminServer) brainCatalog(w http.ResponseWriter, id string) (cognitive.ModelCatalog, bool) {
+	if id == "" {
+		respondError(w, "Missing provider ID", http.StatusBadRequest)
+		return nil, false
+	}
+	if s.Cognitive == nil {
+		respondError(w, "Cognitive system offline", http.StatusServiceUnavailable)
+		return nil, false
+	}
+	adapter, ok := s.Cognitive.Adapters[id]
+	if !ok {
+		respondError(w, "Provider not found or not initialized", http.StatusNotFound)
+		return nil, false
+	}
+	catalog, ok := adapter.(cognitive.ModelCatalog)
+	if !ok {
+		respondError(w, "Provider does not support model management", http.StatusBadRequest)
+		return nil, false
+	}
+	return catalog, true
+}
+
+// GET /api/v1/brains/{id}/models — installed models with capabilities and tier.
+func (s *AdminServer) HandleListBrainModels(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	catalog, ok := s.brainCatalog(w, id)
+	if !ok {
+		return
+	}
+
+	models, err := catalog.ListModels(r.Context())
+	if err != nil {
+		respondError(w, "Failed to list models: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	_, available := cognitive.FindModel(models, catalog.ConfiguredModel())
+
+	respondJSON(w, map[string]any{"ok": true, "data": map[string]any{
+		"id":              id,
+		"model_id":        catalog.ConfiguredModel(),
+		"model_available": available,
+		"models":          models,
+	}})
+}
+
+// POST /api/v1/brains/{id}/models/pull — download a model onto the provider.
+// Body {"model": "..."} is optional; the configured model is pulled by default.
+func (s *AdminServer) HandlePullBrainModel(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	catalog, ok := s.brainCatalog(w, id)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Model string `json:"model"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, "Bad JSON", http.StatusBadRequest)
+		return
+	}
+
+	info, err := catalog.PullModel(r.Context(), req.Model)
+	if err != nil {
+		log.Printf("PullModel %s failed: %v", id, err)
+		respondError(w, "Failed to pull model: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	respondJSON(w, map[string]any{"ok": true, "data": map[string]any{"id": id, "model": info}})
+}
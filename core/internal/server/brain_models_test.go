@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/mycelis/core/internal/cognitive"
+)
+
+// catalogAdapter is a stubAdapter that also manages its own models.
+type catalogAdapter struct {
+	stubAdapter
+	model     string
+	installed []cognitive.ModelInfo
+	pulled    []string
+}
+
+func (c *catalogAdapter) ConfiguredModel() string { return c.model }
+
+func (c *catalogAdapter) ListModels(context.Context) ([]cognitive.ModelInfo, error) {
+	return c.installed, nil
+}
+
+func (c *catalogAdapter) PullModel(_ context.Context, name string) (*cognitive.ModelInfo, error) {
+	if name == "" {
+		name = c.model
+	}
+	c.pulled = append(c.pulled, name)
+	info := cognitive.ModelInfo{Name: name, Tier: cognitive.TierB}
+	c.installed = append(c.installed, info)
+	return &info, nil
+}
+
+func catalogServer(t *testing.T, catalog *catalogAdapter) *AdminServer {
+	t.Helper()
+	return newTestServer(withCognitive(t,
+		map[string]cognitive.ProviderConfig{
+			"ollama": {Type: "ollama", Endpoint: "http://localhost:11434/v1", ModelID: catalog.model, Enabled: true},
+			"vllm":   {Type: "openai_compatible", Endpoint: "http://localhost:8000/v1", ModelID: "mixtral", Enabled: true},
+		},
+		map[string]cognitive.LLMProvider{"ollama": catalog, "vllm": &stubAdapter{healthy: true}},
+	))
+}
+
+func TestHandleListBrains_ReportsMissingLocalModel(t *testing.T) {
+	catalog := &catalogAdapter{
+		stubAdapter: stubAdapter{healthy: true},
+		model:       "qwen3:14b",
+		installed:   []cognitive.ModelInfo{{Name: "llama3.2:latest", Tier: cognitive.TierC}},
+	}
+	s := catalogServer(t, catalog)
+
+	mux := setupMux(t, "GET /api/v1/brains", s.HandleListBrains)
+	rr := doRequest(t, mux, "GET", "/api/v1/brains", "")
+	assertStatus(t, rr, http.StatusOK)
+
+	var resp struct {
+		Data []BrainEntry `json:"data"`
+	}
+	assertJSON(t, rr, &resp)
+	for _, b := range resp.Data {
+		switch b.ID {
+		case "ollama":
+			if b.Status != "model_missing" || b.ModelAvailable == nil || *b.ModelAvailable || len(b.Models) != 1 {
+				t.Errorf("unexpected ollama entry %+v", b)
+			}
+		case "vllm":
+			if b.Status != "online" || b.ModelAvailable != nil || b.Models != nil {
+				t.Errorf("non-catalog provider should not report models: %+v", b)
+			}
+		}
+	}
+}
+
+func TestHandlePullBrainModel_PullsConfiguredModel(t *testing.T) {
+	catalog := &catalogAdapter{stubAdapter: stubAdapter{healthy: true}, model: "qwen3:14b"}
+	s := catalogServer(t, catalog)
+
+	mux := setupMux(t, "POST /api/v1/brains/{id}/models/pull", s.HandlePullBrainModel)
+	rr := doRequest(t, mux, "POST", "/api/v1/brains/ollama/models/pull", "{}")
+	assertStatus(t, rr, http.StatusOK)
+	if len(catalog.pulled) != 1 || catalog.pulled[0] != "qwen3:14b" {
+		t.Fatalf("pulled %v, want [qwen3:14b]", catalog.pulled)
+	}
+
+	mux = setupMux(t, "GET /api/v1/brains/{id}/models", s.HandleListBrainModels)
+	rr = doRequest(t, mux, "GET", "/api/v1/brains/ollama/models", "")
+	assertStatus(t, rr, http.StatusOK)
+	var resp struct {
+		Data struct {
+			ModelAvailable bool                  `json:"model_available"`
+			Models         []cognitive.ModelInfo `json:"models"`
+		} `json:"data"`
+	}
+	assertJSON(t, rr, &resp)
+	if !resp.Data.ModelAvailable || len(resp.Data.Models) != 1 {
+		t.Fatalf("expected pulled model to be listed, got %+v", resp.Data)
+	}
+}
+
+func TestHandleListBrainModels_RejectsNonCatalogProvider(t *testing.T) {
+	s := catalogServer(t, &catalogAdapter{model: "qwen3:14b"})
+
+	mux := setupMux(t, "GET /api/v1/brains/{id}/models", s.HandleListBrainModels)
+	assertStatus(t, doRequest(t, mux, "GET", "/api/v1/brains/vllm/models", ""), http.StatusBadRequest)
+	assertStatus(t, doRequest(t, mux, "GET", "/api/v1/brains/missing/models", ""), http.StatusNotFound)
+}
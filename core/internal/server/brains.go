@@ -20,7 +20,9 @@ func (s *AdminServer) HandleListBrains(w http.ResponseWriter, r *http.Request) {
 	entries := make([]BrainEntry, 0, len(s.Cognitive.Config.Providers))
 	for id, prov := range s.Cognitive.Config.Providers {
 		prov = cognitive.NormalizeProviderTokenDefaults(prov)
-		entries = append(entries, brainEntryFromProvider(id, prov, s.brainStatus(r.Context(), id, prov.Enabled)))
+		entry := brainEntryFromProvider(id, prov, s.brainStatus(r.Context(), id, prov.Enabled))
+		s.describeBrainModels(r.Context(), id, &entry)
+		entries = append(entries, entry)
 	}
 
 	respondJSON(w, map[string]any{"ok": true, "data": entries})
@@ -86,10 +88,11 @@ func (s *AdminServer) HandleUpdateBrainPolicy(w http.ResponseWriter, r *http.Req
 	}
 
 	var req struct {
-		UsagePolicy        string   `json:"usage_policy"`
-		TokenBudgetProfile string   `json:"token_budget_profile"`
-		MaxOutputTokens    int      `json:"max_output_tokens"`
-		RolesAllowed       []string `json:"roles_allowed"`
+		UsagePolicy        string                     `json:"usage_policy"`
+		TokenBudgetProfile string                     `json:"token_budget_profile"`
+		MaxOutputTokens    int                        `json:"max_output_tokens"`
+		RolesAllowed       []string                   `json:"roles_allowed"`
+		Pricing            *cognitive.ProviderPricing `json:"pricing"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		respondError(w, "Bad JSON", http.StatusBadRequest)
@@ -108,6 +111,9 @@ func (s *AdminServer) HandleUpdateBrainPolicy(w http.ResponseWriter, r *http.Req
 	if len(req.RolesAllowed) > 0 {
 		prov.RolesAllowed = req.RolesAllowed
 	}
+	if req.Pricing != nil {
+		prov.Pricing = req.Pricing
+	}
 	prov = cognitive.NormalizeProviderTokenDefaults(prov)
 	s.Cognitive.Config.Providers[id] = prov
 
@@ -124,6 +130,7 @@ func (s *AdminServer) HandleUpdateBrainPolicy(w http.ResponseWriter, r *http.Req
 		"token_budget_profile": prov.TokenBudgetProfile,
 		"max_output_tokens":    prov.MaxOutputTokens,
 		"roles_allowed":        prov.RolesAllowed,
+		"pricing":              prov.Pricing,
 	}})
 }
 
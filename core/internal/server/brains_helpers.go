@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"log"
 	"regexp"
 	"time"
 
@@ -12,18 +13,23 @@ var validProviderID = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
 
 // BrainEntry is the enriched provider info returned by GET /api/v1/brains.
 type BrainEntry struct {
-	ID                 string   `json:"id"`
-	Type               string   `json:"type"`
-	Endpoint           string   `json:"endpoint,omitempty"`
-	ModelID            string   `json:"model_id"`
-	Location           string   `json:"location"`
-	DataBoundary       string   `json:"data_boundary"`
-	UsagePolicy        string   `json:"usage_policy"`
-	TokenBudgetProfile string   `json:"token_budget_profile"`
-	MaxOutputTokens    int      `json:"max_output_tokens"`
-	RolesAllowed       []string `json:"roles_allowed"`
-	Enabled            bool     `json:"enabled"`
-	Status             string   `json:"status"`
+	ID                 string                     `json:"id"`
+	Type               string                     `json:"type"`
+	Endpoint           string                     `json:"endpoint,omitempty"`
+	ModelID            string                     `json:"model_id"`
+	Location           string                     `json:"location"`
+	DataBoundary       string                     `json:"data_boundary"`
+	UsagePolicy        string                     `json:"usage_policy"`
+	TokenBudgetProfile string                     `json:"token_budget_profile"`
+	MaxOutputTokens    int                        `json:"max_output_tokens"`
+	RolesAllowed       []string                   `json:"roles_allowed"`
+	Enabled            bool                       `json:"enabled"`
+	Status             string                     `json:"status"`
+	Pricing            *cognitive.ProviderPricing `json:"pricing,omitempty"`
+	// Set for providers with a model catalog (Ollama) that answered the probe.
+	ModelAvailable *bool                 `json:"model_available,omitempty"`
+	Tier           cognitive.Tier        `json:"tier,omitempty"`
+	Models         []cognitive.ModelInfo `json:"models,omitempty"`
 }
 
 type brainUpsertRequest struct {
@@ -89,6 +95,7 @@ func brainEntryFromProvider(id string, prov cognitive.ProviderConfig, status str
 		RolesAllowed:       prov.RolesAllowed,
 		Enabled:            prov.Enabled,
 		Status:             status,
+		Pricing:            prov.Pricing,
 	}
 }
 
@@ -108,3 +115,27 @@ func (s *AdminServer) brainStatus(ctx context.Context, id string, enabled bool)
 	}
 	return "offline"
 }
+
+// describeBrainModels fills in the installed models for catalog providers.
+// A reachable daemon that lacks the configured model reports "model_missing".
+func (s *AdminServer) describeBrainModels(ctx context.Context, id string, entry *BrainEntry) {
+	catalog, ok := s.Cognitive.Adapters[id].(cognitive.ModelCatalog)
+	if !ok || entry.Status != "online" {
+		return
+	}
+	listCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	models, err := catalog.ListModels(listCtx)
+	cancel()
+	if err != nil {
+		log.Printf("brains: list models for %s failed: %v", id, err)
+		return
+	}
+	info, found := cognitive.FindModel(models, entry.ModelID)
+	entry.Models = models
+	entry.ModelAvailable = &found
+	if found {
+		entry.Tier = info.Tier
+	} else {
+		entry.Status = "model_missing"
+	}
+}
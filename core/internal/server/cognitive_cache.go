@@ -0,0 +1,43 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mycelis/core/internal/cognitive"
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+// cacheInvalidatePayload is the data envelope for cache invalidation.
+type cacheInvalidatePayload struct {
+	Scope   cognitive.CacheScope `json:"scope"`
+	Removed int64                `json:"removed"`
+}
+
+// HandleInvalidateCognitiveCache drops cached inference responses.
+// DELETE /api/v1/cognitive/cache?tenant_id=&profile=&provider_id=
+// Omitted filters match everything; no filters clears the whole cache.
+func (s *AdminServer) HandleInvalidateCognitiveCache(w http.ResponseWriter, r *http.Request) {
+	if s.Cognitive == nil {
+		respondAPIError(w, "cognitive engine not initialized", http.StatusServiceUnavailable)
+		return
+	}
+	params := r.URL.Query()
+	scope := cognitive.CacheScope{
+		TenantID:   strings.TrimSpace(params.Get("tenant_id")),
+		Profile:    strings.TrimSpace(params.Get("profile")),
+		ProviderID: strings.TrimSpace(params.Get("provider_id")),
+	}
+
+	removed, err := s.Cognitive.InvalidateCache(r.Context(), scope)
+	switch {
+	case errors.Is(err, cognitive.ErrCacheDisabled):
+		respondAPIError(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		respondAPIError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(cacheInvalidatePayload{Scope: scope, Removed: removed}))
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mycelis/core/internal/cognitive"
+	"github.com/mycelis/core/internal/responsecache"
+)
+
+// ── DELETE /api/v1/cognitive/cache ─────────────────────────────────
+
+func TestHandleInvalidateCognitiveCache_DeletesScope(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock (cache): %v", err)
+	}
+	defer db.Close()
+	s := newTestServer(withCognitive(t, map[string]cognitive.ProviderConfig{}, map[string]cognitive.LLMProvider{}))
+	s.Cognitive.SetResponseCache(responsecache.NewStore(db))
+
+	mock.ExpectExec(`DELETE FROM inference_cache WHERE tenant_id = \$1 AND profile = \$2$`).
+		WithArgs("org-1", "review").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	mux := setupMux(t, "DELETE /api/v1/cognitive/cache", s.HandleInvalidateCognitiveCache)
+	rr := doRequest(t, mux, "DELETE", "/api/v1/cognitive/cache?tenant_id=org-1&profile=review", "")
+
+	assertStatus(t, rr, http.StatusOK)
+	var resp struct {
+		OK   bool `json:"ok"`
+		Data struct {
+			Scope   cognitive.CacheScope `json:"scope"`
+			Removed int64                `json:"removed"`
+		} `json:"data"`
+	}
+	assertJSON(t, rr, &resp)
+	if !resp.OK || resp.Data.Removed != 3 || resp.Data.Scope.Profile != "review" {
+		t.Fatalf("unexpected payload %+v", resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestHandleInvalidateCognitiveCache_NoCache(t *testing.T) {
+	s := newTestServer(withCognitive(t, map[string]cognitive.ProviderConfig{}, map[string]cognitive.LLMProvider{}))
+	mux := setupMux(t, "DELETE /api/v1/cognitive/cache", s.HandleInvalidateCognitiveCache)
+
+	rr := doRequest(t, mux, "DELETE", "/api/v1/cognitive/cache", "")
+	assertStatus(t, rr, http.StatusServiceUnavailable)
+}
@@ -20,11 +20,15 @@ import (
 // maintain multi-turn context. The NATS payload is a JSON array of
 // {role, content} objects; the agent's handleDirectRequest detects JSON arrays
 // and reconstructs prior turns.
+//
+// Callers may opt into incremental SSE deltas; see cognitive_chat_stream.go.
 func (s *AdminServer) HandleChat(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	w, r, finishStream := beginChatStream(w, r)
+	defer finishStream()
 
 	var req struct {
 		Messages       []chatRequestMessage `json:"messages"`
@@ -62,20 +66,7 @@ func (s *AdminServer) HandleChat(w http.ResponseWriter, r *http.Request) {
 
 	req.Messages = normalizeRetryRequest(req.Messages)
 	latestUserText := latestUserMessageContent(req.Messages)
-	if isRuntimeStateQuestion(latestUserText) {
-		s.respondRuntimeStateSummary(w, r, req.OrganizationID, req.TeamID, req.TeamName)
-		return
-	}
-	if isServiceInventoryQuestion(latestUserText) {
-		s.respondServiceInventorySummary(w, r)
-		return
-	}
-	if isSearchCapabilityQuestion(latestUserText) {
-		s.respondSearchCapabilitySummary(w, r)
-		return
-	}
-	if query, ok := shouldHandleDirectSearch(latestUserText); ok {
-		s.respondDirectSearchAnswer(w, r, query)
+	if s.respondDirectChatAnswer(w, r, latestUserText, req.OrganizationID, req.TeamID, req.TeamName) {
 		return
 	}
 
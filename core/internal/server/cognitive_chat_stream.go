@@ -0,0 +1,187 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mycelis/core/pkg/protocol"
+	"github.com/nats-io/nats.go"
+)
+
+// Streaming chat (SSE)
+//
+// Clients opt in with `Accept: text/event-stream` or `?stream=true` on
+// /api/v1/chat and /api/v1/council/{member}/chat. Responses that complete
+// before the agent produces text (validation errors, direct runtime answers)
+// stay plain JSON. Once the first delta arrives the response switches to SSE:
+//
+//	event: delta   data: {"attempt":1,"segment":0,"text":"..."}
+//	event: result  data: {"status":200,"response":<APIResponse>}
+//
+// A change in attempt or segment means the agent started a new completion;
+// the client replaces its visible draft. The result event carries the exact
+// payload the non-streaming endpoint would have returned.
+
+type chatStreamContextKey struct{}
+
+// chatStreamDeltaEvent is the SSE payload for one agent delta.
+type chatStreamDeltaEvent struct {
+	Attempt int    `json:"attempt"`
+	Segment int    `json:"segment"`
+	Text    string `json:"text"`
+}
+
+// chatStreamResultEvent is the terminal SSE payload.
+type chatStreamResultEvent struct {
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response"`
+}
+
+// chatStreamWriter buffers the handler's normal response and upgrades to SSE
+// on the first streamed delta.
+type chatStreamWriter struct {
+	w       http.ResponseWriter
+	header  http.Header
+	status  int
+	body    bytes.Buffer
+	started bool
+	attempt int
+}
+
+func wantsChatStream(r *http.Request) bool {
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(r.URL.Query().Get("stream"))) {
+	case "1", "true":
+		return true
+	}
+	return false
+}
+
+// beginChatStream wraps w when the caller asked for streaming. The returned
+// finish func must be deferred; it is a no-op for non-streaming requests.
+func beginChatStream(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, *http.Request, func()) {
+	if !wantsChatStream(r) {
+		return w, r, func() {}
+	}
+	sw := &chatStreamWriter{w: w, header: make(http.Header)}
+	return sw, r.WithContext(context.WithValue(r.Context(), chatStreamContextKey{}, sw)), sw.finish
+}
+
+func chatStreamFromContext(ctx context.Context) *chatStreamWriter {
+	sw, _ := ctx.Value(chatStreamContextKey{}).(*chatStreamWriter)
+	return sw
+}
+
+func (sw *chatStreamWriter) Header() http.Header { return sw.header }
+
+func (sw *chatStreamWriter) WriteHeader(status int) {
+	if sw.status == 0 {
+		sw.status = status
+	}
+}
+
+func (sw *chatStreamWriter) Write(b []byte) (int, error) {
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	return sw.body.Write(b)
+}
+
+func (sw *chatStreamWriter) start() {
+	if sw.started {
+		return
+	}
+	sw.started = true
+	h := sw.w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	sw.w.WriteHeader(http.StatusOK)
+}
+
+func (sw *chatStreamWriter) event(name string, payload any) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(sw.w, "event: %s\ndata: %s\n\n", name, raw)
+	if flusher, ok := sw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// writeDelta emits one agent delta, upgrading the response to SSE if needed.
+func (sw *chatStreamWriter) writeDelta(data []byte) {
+	var delta protocol.ChatStreamDelta
+	if err := json.Unmarshal(data, &delta); err != nil || delta.Text == "" {
+		return
+	}
+	sw.start()
+	sw.event("delta", chatStreamDeltaEvent{Attempt: sw.attempt, Segment: delta.Segment, Text: delta.Text})
+}
+
+func (sw *chatStreamWriter) finish() {
+	status := sw.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if !sw.started {
+		for key, values := range sw.header {
+			sw.w.Header()[key] = values
+		}
+		sw.w.WriteHeader(status)
+		sw.w.Write(sw.body.Bytes())
+		return
+	}
+	body := bytes.TrimSpace(sw.body.Bytes())
+	if !json.Valid(body) {
+		body, _ = json.Marshal(protocol.NewAPIError(string(body)))
+	}
+	sw.event("result", chatStreamResultEvent{Status: status, Response: body})
+}
+
+// requestChatAgentStreaming sends the chat request with a stream subject header
+// and relays deltas to sw until the agent's final reply arrives.
+func (s *AdminServer) requestChatAgentStreaming(ctx context.Context, subject string, payload []byte, sw *chatStreamWriter) (*nats.Msg, error) {
+	inbox := nats.NewInbox()
+	deltas := make(chan *nats.Msg, 256)
+	sub, err := s.NC.ChanSubscribe(inbox, deltas)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	req := nats.NewMsg(subject)
+	req.Data = payload
+	req.Header.Set(protocol.HeaderChatStreamSubject, inbox)
+	sw.attempt++
+
+	type reply struct {
+		msg *nats.Msg
+		err error
+	}
+	done := make(chan reply, 1)
+	go func() {
+		msg, err := s.NC.RequestMsgWithContext(ctx, req)
+		done <- reply{msg: msg, err: err}
+	}()
+
+	for {
+		select {
+		case delta := <-deltas:
+			sw.writeDelta(delta.Data)
+		case res := <-done:
+			// Deltas published before the reply are already queued locally.
+			for len(deltas) > 0 {
+				sw.writeDelta((<-deltas).Data)
+			}
+			return res.msg, res.err
+		}
+	}
+}
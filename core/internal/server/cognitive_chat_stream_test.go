@@ -0,0 +1,141 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mycelis/core/internal/cognitive"
+	"github.com/mycelis/core/pkg/protocol"
+	"github.com/nats-io/nats.go"
+)
+
+func newStreamingChatTestServer(t *testing.T, handler nats.MsgHandler) *AdminServer {
+	t.Helper()
+	s := newTestServer(withNATS(t))
+	s.Cognitive = &cognitive.Router{
+		Config: &cognitive.BrainConfig{
+			Profiles:  map[string]string{"chat": "mock"},
+			Providers: map[string]cognitive.ProviderConfig{"mock": {Type: "mock", Enabled: true, ModelID: "test-model"}},
+		},
+		Adapters: map[string]cognitive.LLMProvider{"mock": cognitiveTestProvider{}},
+	}
+	if _, err := s.NC.Subscribe("swarm.council.admin.request", handler); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := s.NC.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	return s
+}
+
+func parseSSEEvents(t *testing.T, body string) []struct{ name, data string } {
+	t.Helper()
+	var events []struct{ name, data string }
+	for _, block := range strings.Split(strings.TrimSpace(body), "\n\n") {
+		var name, data string
+		for _, line := range strings.Split(block, "\n") {
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+		events = append(events, struct{ name, data string }{name, data})
+	}
+	return events
+}
+
+func TestHandleChat_StreamsAgentDeltasAsSSE(t *testing.T) {
+	var s *AdminServer
+	s = newStreamingChatTestServer(t, func(msg *nats.Msg) {
+		streamSubject := msg.Header.Get(protocol.HeaderChatStreamSubject)
+		if streamSubject == "" {
+			t.Errorf("expected %s header on streaming chat request", protocol.HeaderChatStreamSubject)
+		}
+		for _, text := range []string{"Runtime ", "is healthy."} {
+			raw, _ := json.Marshal(protocol.ChatStreamDelta{Text: text})
+			_ = s.NC.Publish(streamSubject, raw)
+		}
+		msg.Respond([]byte(`{"text":"Runtime is healthy.","provider_id":"mock","model_used":"test-model"}`))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/chat", bytes.NewBufferString(`{"messages":[{"role":"user","content":"Summarize the launch plan."}]}`))
+	req.Header.Set("Accept", "text/event-stream")
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(s.HandleChat).ServeHTTP(rr, req)
+
+	if ct := rr.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q body=%s", ct, rr.Body.String())
+	}
+	events := parseSSEEvents(t, rr.Body.String())
+	if len(events) != 3 {
+		t.Fatalf("expected 2 deltas + result, got %d: %s", len(events), rr.Body.String())
+	}
+	var streamed string
+	for _, ev := range events[:2] {
+		if ev.name != "delta" {
+			t.Fatalf("event = %q, want delta", ev.name)
+		}
+		var delta chatStreamDeltaEvent
+		if err := json.Unmarshal([]byte(ev.data), &delta); err != nil {
+			t.Fatalf("decode delta: %v", err)
+		}
+		if delta.Attempt != 1 {
+			t.Fatalf("delta.Attempt = %d, want 1", delta.Attempt)
+		}
+		streamed += delta.Text
+	}
+	if streamed != "Runtime is healthy." {
+		t.Fatalf("streamed = %q", streamed)
+	}
+
+	if events[2].name != "result" {
+		t.Fatalf("last event = %q, want result", events[2].name)
+	}
+	var result chatStreamResultEvent
+	if err := json.Unmarshal([]byte(events[2].data), &result); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	if result.Status != http.StatusOK {
+		t.Fatalf("result.Status = %d", result.Status)
+	}
+	var apiResp protocol.APIResponse
+	if err := json.Unmarshal(result.Response, &apiResp); err != nil || !apiResp.OK {
+		t.Fatalf("result response not OK: %s (%v)", result.Response, err)
+	}
+}
+
+func TestHandleChat_StreamRequestWithoutDeltasStaysJSON(t *testing.T) {
+	s := newStreamingChatTestServer(t, func(msg *nats.Msg) {
+		msg.Respond([]byte(`{"text":"Runtime is healthy.","provider_id":"mock","model_used":"test-model"}`))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/chat?stream=true", bytes.NewBufferString(`{"messages":[{"role":"user","content":"Summarize the launch plan."}]}`))
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(s.HandleChat).ServeHTTP(rr, req)
+
+	assertStatus(t, rr, http.StatusOK)
+	if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	var resp protocol.APIResponse
+	assertJSON(t, rr, &resp)
+	if !resp.OK {
+		t.Fatalf("expected OK response, got %+v", resp)
+	}
+}
+
+func TestHandleChat_StreamRequestValidationErrorKeepsStatus(t *testing.T) {
+	s := newTestServer()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/chat", bytes.NewBufferString(`{"messages":[]}`))
+	req.Header.Set("Accept", "text/event-stream")
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(s.HandleChat).ServeHTTP(rr, req)
+
+	assertStatus(t, rr, http.StatusBadRequest)
+}
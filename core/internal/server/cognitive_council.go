@@ -70,7 +70,10 @@ func (s *AdminServer) HandleListCouncilMembers(w http.ResponseWriter, r *http.Re
 // POST /api/v1/council/{member}/chat
 // Routes user conversation to a specific council member via NATS request-reply.
 // Returns a CTS envelope wrapped in APIResponse with trust score and provenance.
+// Supports the same opt-in SSE streaming as /api/v1/chat.
 func (s *AdminServer) HandleCouncilChat(w http.ResponseWriter, r *http.Request) {
+	w, r, finishStream := beginChatStream(w, r)
+	defer finishStream()
 	memberID := r.PathValue("member")
 	if memberID == "" {
 		respondAPIError(w, "Missing council member ID", http.StatusBadRequest)
@@ -131,6 +131,26 @@ func (s *AdminServer) buildRuntimeStateAnswer(organizationID, teamID, teamName s
 	return strings.Join(lines, "\n")
 }
 
+// respondDirectChatAnswer answers runtime-state, service-inventory, and search
+// questions without a Soma round trip. It reports whether it responded.
+func (s *AdminServer) respondDirectChatAnswer(w http.ResponseWriter, r *http.Request, latestUserText, organizationID, teamID, teamName string) bool {
+	switch {
+	case isRuntimeStateQuestion(latestUserText):
+		s.respondRuntimeStateSummary(w, r, organizationID, teamID, teamName)
+	case isServiceInventoryQuestion(latestUserText):
+		s.respondServiceInventorySummary(w, r)
+	case isSearchCapabilityQuestion(latestUserText):
+		s.respondSearchCapabilitySummary(w, r)
+	default:
+		query, ok := shouldHandleDirectSearch(latestUserText)
+		if !ok {
+			return false
+		}
+		s.respondDirectSearchAnswer(w, r, query)
+	}
+	return true
+}
+
 func (s *AdminServer) respondRuntimeStateSummary(w http.ResponseWriter, r *http.Request, organizationID, teamID, teamName string) {
 	auditEventID, _ := s.createAuditEvent(
 		protocol.TemplateChatToAnswer, "admin",
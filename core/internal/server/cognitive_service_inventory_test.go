@@ -34,10 +34,10 @@ func TestIsServiceInventoryQuestionDistinguishesRawToolDebug(t *testing.T) {
 
 func TestHandleChat_ServiceInventoryUsesUserLanguage(t *testing.T) {
 	opt, mock := withMCPDB(t)
-	mock.ExpectQuery("SELECT id, name, transport, command, args, env, url, headers, status, error_message, created_at, updated_at FROM mcp_servers").
+	mock.ExpectQuery("SELECT id, name, transport, command, args, env, url, headers, auth, sandbox, status, error_message, created_at, updated_at FROM mcp_servers").
 		WillReturnRows(sqlmock.NewRows(mcpServerColumns()).
-			AddRow(uuid.New(), "filesystem", "stdio", "filesystem", []byte(`[]`), []byte(`{}`), "", []byte(`{}`), "connected", nil, time.Now(), time.Now()).
-			AddRow(uuid.New(), "fetch", "stdio", "fetch", []byte(`[]`), []byte(`{}`), "", []byte(`{}`), "error", "failed initialization", time.Now(), time.Now()))
+			AddRow(uuid.New(), "filesystem", "stdio", "filesystem", []byte(`[]`), []byte(`{}`), "", []byte(`{}`), nil, nil, "connected", nil, time.Now(), time.Now()).
+			AddRow(uuid.New(), "fetch", "stdio", "fetch", []byte(`[]`), []byte(`{}`), "", []byte(`{}`), nil, nil, "error", "failed initialization", time.Now(), time.Now()))
 	s := newTestServer(opt)
 
 	reqBody := bytes.NewBufferString(`{"messages":[{"role":"user","content":"list of services?"}]}`)
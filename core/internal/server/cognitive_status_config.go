@@ -13,7 +13,8 @@ import (
 )
 
 // GET /api/v1/cognitive/status
-// Returns health and configuration of all cognitive engines (text + configured media).
+// Returns health and configuration of all cognitive engines (text + configured media),
+// plus per-provider circuit breaker state and recent health history.
 func (s *AdminServer) HandleCognitiveStatus(w http.ResponseWriter, r *http.Request) {
 	if s.Cognitive == nil || s.Cognitive.Config == nil {
 		respondJSON(w, map[string]any{"text": map[string]string{"status": "offline"}, "media": map[string]string{"status": "offline"}})
@@ -113,7 +114,11 @@ func (s *AdminServer) HandleCognitiveStatus(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
-	respondJSON(w, result)
+	respondJSON(w, map[string]any{
+		"text":      result["text"],
+		"media":     result["media"],
+		"providers": s.Cognitive.ProviderHealth(),
+	})
 }
 
 // POST /api/v1/cognitive/infer
@@ -51,3 +51,36 @@ func TestHandleCognitiveStatus_SkipsDisabledTextProviders(t *testing.T) {
 		t.Fatalf("disabled provider should not make text online: %#v", text)
 	}
 }
+
+func TestHandleCognitiveStatus_ReportsProviderHealth(t *testing.T) {
+	router := &cognitive.Router{
+		Config: &cognitive.BrainConfig{
+			Providers: map[string]cognitive.ProviderConfig{
+				"remote": {Type: "openai", ModelID: "gpt-test", Location: "remote", Enabled: true},
+			},
+			Profiles: map[string]string{"chat": "remote"},
+		},
+		Adapters: map[string]cognitive.LLMProvider{"remote": &cognitiveStatusProbe{healthy: true}},
+	}
+	if _, err := router.InferWithContract(context.Background(), cognitive.InferRequest{Profile: "chat", Prompt: "hi"}); err != nil {
+		t.Fatalf("InferWithContract: %v", err)
+	}
+	s := &AdminServer{Cognitive: router}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/cognitive/status", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(s.HandleCognitiveStatus).ServeHTTP(rr, req)
+
+	var resp struct {
+		Providers []cognitive.ProviderHealth `json:"providers"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Providers) != 1 || resp.Providers[0].ProviderID != "remote" || resp.Providers[0].State != cognitive.CircuitClosed {
+		t.Fatalf("unexpected provider health %+v", resp.Providers)
+	}
+	if len(resp.Providers[0].History) != 1 || !resp.Providers[0].History[0].OK {
+		t.Fatalf("expected one successful history entry, got %+v", resp.Providers[0].History)
+	}
+}
@@ -25,7 +25,12 @@ func (s *AdminServer) requestChatAgent(parent context.Context, subject string, m
 	reqCtx, cancel := context.WithTimeout(parent, chatAgentRequestTimeout())
 	defer cancel()
 
-	msg, err := s.NC.RequestWithContext(reqCtx, subject, payload)
+	var msg *nats.Msg
+	if sw := chatStreamFromContext(parent); sw != nil {
+		msg, err = s.requestChatAgentStreaming(reqCtx, subject, payload, sw)
+	} else {
+		msg, err = s.NC.RequestWithContext(reqCtx, subject, payload)
+	}
 	if err != nil {
 		return chatAgentResult{}, err
 	}
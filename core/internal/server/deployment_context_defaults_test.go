@@ -48,7 +48,7 @@ func TestHandleDeploymentContext_PostNormalizesAdminSomaDefaults(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).
 			AddRow("cccccccc-cccc-cccc-cccc-cccccccccccc", now))
 	mock.ExpectExec("INSERT INTO context_vectors").
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	body := `{
@@ -129,7 +129,7 @@ func TestHandleDeploymentContext_PostStoresUserPrivateGoalContext(t *testing.T)
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).
 			AddRow("dddddddd-dddd-dddd-dddd-dddddddddddd", now))
 	mock.ExpectExec("INSERT INTO context_vectors").
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	body := `{
@@ -204,7 +204,7 @@ func TestHandleDeploymentContext_PostStoresReflectionSynthesisDefaults(t *testin
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).
 			AddRow("eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee", now))
 	mock.ExpectExec("INSERT INTO context_vectors").
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	body := `{
@@ -37,7 +37,7 @@ func TestHandleDeploymentContext_PostStoresArtifactAndVectors(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).
 			AddRow("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", now))
 	mock.ExpectExec("INSERT INTO context_vectors").
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	body := `{
@@ -96,7 +96,7 @@ func TestHandleDeploymentContext_PostStoresAdminShapedSomaContext(t *testing.T)
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).
 			AddRow("bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb", now))
 	mock.ExpectExec("INSERT INTO context_vectors").
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	body := `{
@@ -2,6 +2,8 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"log"
 	"net/http"
 	"time"
@@ -22,6 +24,40 @@ type pendingApprovalJSON struct {
 	Intent      string `json:"intent"`
 	Timestamp   string `json:"timestamp"`
 	ExpiresAt   string `json:"expires_at"`
+
+	RequiredRole string `json:"required_role,omitempty"`
+	Quorum       int    `json:"quorum"`
+	Approvals    int    `json:"approvals"`
+	Escalated    bool   `json:"escalated,omitempty"`
+}
+
+// approvalVoter identifies who is deciding an approval: the authenticated
+// identity when present, otherwise the named API surface.
+func approvalVoter(r *http.Request, fallback string) (approver, role string) {
+	id := IdentityFromContext(r.Context())
+	if id == nil {
+		return fallback, ""
+	}
+	approver = id.Username
+	if approver == "" {
+		approver = id.UserID
+	}
+	if approver == "" {
+		approver = fallback
+	}
+	role = id.EffectiveRole
+	if role == "" {
+		role = id.Role
+	}
+	return approver, role
+}
+
+// approvalDecisionStatus maps a Guard.Decide error to an HTTP status.
+func approvalDecisionStatus(err error) int {
+	if errors.Is(err, governance.ErrApproverNotEligible) {
+		return http.StatusForbidden
+	}
+	return http.StatusNotFound
 }
 
 // handleGetPolicy returns the current governance policy configuration as JSON.
@@ -47,8 +83,14 @@ func (s *AdminServer) handleUpdatePolicy(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		http.Error(w, `{"error":"failed to read body"}`, http.StatusBadRequest)
+		return
+	}
 	var cfg governance.PolicyConfig
-	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+	if err := json.Unmarshal(body, &cfg); err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
 		return
@@ -61,6 +103,14 @@ func (s *AdminServer) handleUpdatePolicy(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Compile every rule; errors point at their line in the request body
+	if errs := governance.ValidatePolicy(&cfg, body); len(errs) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]any{"error": "invalid policy", "errors": errs})
+		return
+	}
+
 	// Update in-memory config
 	s.Guard.UpdatePolicyConfig(&cfg)
 
@@ -109,6 +159,12 @@ func (s *AdminServer) handleGetPendingApprovals(w http.ResponseWriter, r *http.R
 		if req.ExpiresAt != nil {
 			item.ExpiresAt = req.ExpiresAt.AsTime().Format(time.RFC3339)
 		}
+		if state, ok := s.Guard.GetApproval(req.RequestId); ok {
+			item.RequiredRole = state.RequiredRole
+			item.Quorum = state.Quorum
+			item.Approvals = state.Approvals()
+			item.Escalated = state.Escalated
+		}
 
 		result = append(result, item)
 	}
@@ -116,7 +172,9 @@ func (s *AdminServer) handleGetPendingApprovals(w http.ResponseWriter, r *http.R
 	respondJSON(w, result)
 }
 
-// handleResolveApproval resolves a pending approval request by approving or rejecting it.
+// handleResolveApproval records the caller's vote on a pending approval
+// request. The request settles on the first rejection or once its quorum of
+// approvals is reached.
 // POST /api/v1/governance/resolve/{id}
 func (s *AdminServer) handleResolveApproval(w http.ResponseWriter, r *http.Request) {
 	if s.Guard == nil {
@@ -148,17 +206,18 @@ func (s *AdminServer) handleResolveApproval(w http.ResponseWriter, r *http.Reque
 	}
 
 	approved := payload.Action == "APPROVE"
-	msg, err := s.Guard.Resolve(reqID, approved, "governance-api")
+	approver, role := approvalVoter(r, "governance-api")
+	outcome, err := s.Guard.Decide(reqID, approver, role, approved)
 	if err != nil {
 		w.Header().Set("Content-Type", "application/json")
-		http.Error(w, `{"error":"`+err.Error()+`"}`, http.StatusNotFound)
+		http.Error(w, `{"error":"`+err.Error()+`"}`, approvalDecisionStatus(err))
 		return
 	}
 
-	// If approved and there is a message to re-inject, publish it back into the system
-	if approved && msg != nil {
+	// Once quorum approves, re-inject the intercepted message into the system
+	if outcome.Message != nil {
 		if s.Router != nil {
-			if err := s.Router.PublishDirect(msg); err != nil {
+			if err := s.Router.PublishDirect(outcome.Message); err != nil {
 				log.Printf("Failed to re-publish approved message %s: %v", reqID, err)
 				w.Header().Set("Content-Type", "application/json")
 				http.Error(w, `{"error":"resolved but failed to re-publish message"}`, http.StatusInternalServerError)
@@ -167,9 +226,16 @@ func (s *AdminServer) handleResolveApproval(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
-	respondJSON(w, map[string]string{
-		"status":     "resolved",
-		"request_id": reqID,
-		"action":     payload.Action,
+	status := "resolved"
+	if outcome.Status == governance.ApprovalPending {
+		status = "pending"
+	}
+	respondJSON(w, map[string]any{
+		"status":          status,
+		"request_id":      reqID,
+		"action":          payload.Action,
+		"approval_status": outcome.Status,
+		"approvals":       outcome.Approvals,
+		"quorum":          outcome.Quorum,
 	})
 }
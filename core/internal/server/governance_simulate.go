@@ -0,0 +1,100 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/mycelis/core/internal/governance"
+)
+
+const (
+	defaultSimulationDays  = 7
+	maxSimulationDays      = 90
+	defaultSimulationLimit = 10000
+	maxSimulationLimit     = 100000
+)
+
+// policySimulationRequest is the body of POST /api/v1/governance/policy/simulate.
+type policySimulationRequest struct {
+	Policy *governance.PolicyConfig `json:"policy"`
+	Days   int                      `json:"days,omitempty"`  // history window, default 7
+	Limit  int                      `json:"limit,omitempty"` // per source, default 10000
+}
+
+// handleSimulatePolicy replays the last N days of intercepted messages and
+// mission events against a candidate policy and reports which decisions
+// would change, grouped by team and intent. Nothing is applied.
+// POST /api/v1/governance/policy/simulate
+func (s *AdminServer) handleSimulatePolicy(w http.ResponseWriter, r *http.Request) {
+	if s.Guard == nil {
+		respondError(w, "Governance engine not initialized", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req policySimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if req.Policy == nil {
+		respondError(w, "policy is required", http.StatusBadRequest)
+		return
+	}
+	if errs := governance.ValidatePolicy(req.Policy, nil); len(errs) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]any{"error": "invalid policy", "errors": errs})
+		return
+	}
+	days := clampInt(req.Days, defaultSimulationDays, maxSimulationDays)
+	limit := clampInt(req.Limit, defaultSimulationLimit, maxSimulationLimit)
+	since := time.Now().UTC().Add(-time.Duration(days) * 24 * time.Hour)
+
+	var inputs []governance.SimulationInput
+	decisions, err := s.Guard.DecisionHistory(r.Context(), since, limit)
+	switch {
+	case errors.Is(err, governance.ErrNoDecisionLog) && s.Events == nil:
+		respondError(w, "no decision history available (database offline)", http.StatusServiceUnavailable)
+		return
+	case err != nil && !errors.Is(err, governance.ErrNoDecisionLog):
+		log.Printf("[governance] simulation decision history: %v", err)
+		respondError(w, "failed to load decision history", http.StatusInternalServerError)
+		return
+	}
+	for _, d := range decisions {
+		inputs = append(inputs, d.Input())
+	}
+	if s.Events != nil {
+		events, err := s.Events.ListSince(r.Context(), since, limit)
+		if err != nil {
+			log.Printf("[governance] simulation mission events: %v", err)
+			respondError(w, "failed to load mission events", http.StatusInternalServerError)
+			return
+		}
+		for _, ev := range events {
+			inputs = append(inputs, governance.MissionEventInput(ev.SourceTeam, ev.SourceAgent, string(ev.EventType), ev.Payload, ev.EmittedAt))
+		}
+	}
+
+	report, err := governance.Simulate(s.Guard.GetPolicyConfig(), req.Policy, inputs)
+	if err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	report.Since = since
+	respondJSON(w, report)
+}
+
+// clampInt returns def for non-positive v and caps v at max.
+func clampInt(v, def, max int) int {
+	if v <= 0 {
+		return def
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mycelis/core/internal/governance"
+)
+
+func TestHandleSimulatePolicy(t *testing.T) {
+	s := newTestServer(withGuard(defaultTestPolicyConfig()))
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	s.Guard.SetDecisionLog(ctx, governance.NewDecisionRepository(db))
+
+	now := time.Now()
+	mock.ExpectQuery("FROM governance_decisions").
+		WithArgs(sqlmock.AnyArg(), 10000).
+		WillReturnRows(sqlmock.NewRows([]string{"team_id", "agent_id", "intent", "payload", "swarm_context", "action", "request_id", "decided_at"}).
+			AddRow("finance", "bot-1", "payment", `{"amount":500}`, `{}`, "ALLOW", "", now).
+			AddRow("finance", "bot-1", "payment", `{"amount":5}`, `{}`, "ALLOW", "", now).
+			AddRow("ops", "bot-2", "deploy", `{}`, `{"role":"sre"}`, "ALLOW", "", now))
+
+	body := `{"days": 3, "policy": {
+		"groups": [{"name": "finance", "targets": ["*"], "rules": [
+			{"intent": "payment", "condition": "amount > 100", "action": "REQUIRE_APPROVAL"},
+			{"intent": "deploy", "condition": "role != 'sre'", "action": "DENY"}
+		]}],
+		"defaults": {"default_action": "ALLOW"}
+	}}`
+	rr := doRequest(t, http.HandlerFunc(s.handleSimulatePolicy), "POST", "/api/v1/governance/policy/simulate", body)
+	assertStatus(t, rr, http.StatusOK)
+
+	var report governance.SimulationReport
+	assertJSON(t, rr, &report)
+	if report.Evaluated != 3 || report.Changed != 1 {
+		t.Fatalf("evaluated/changed = %d/%d, want 3/1", report.Evaluated, report.Changed)
+	}
+	if len(report.Groups) != 1 || report.Groups[0].TeamID != "finance" || report.Groups[0].Transitions["ALLOW->REQUIRE_APPROVAL"] != 1 {
+		t.Fatalf("unexpected groups: %+v", report.Groups)
+	}
+	if since := time.Since(report.Since); since < 71*time.Hour || since > 73*time.Hour {
+		t.Errorf("expected a 3 day window, got since=%s", report.Since)
+	}
+	if s.Guard.GetPolicyConfig().Groups[0].Name != "test-group" {
+		t.Error("simulation must not apply the candidate policy")
+	}
+}
+
+func TestHandleSimulatePolicy_InvalidPolicy(t *testing.T) {
+	s := newTestServer(withGuard(defaultTestPolicyConfig()))
+	body := `{"policy": {"groups": [{"targets": ["*"], "rules": [{"intent": "x", "condition": "a >", "action": "DENY"}]}], "defaults": {"default_action": "ALLOW"}}}`
+	rr := doRequest(t, http.HandlerFunc(s.handleSimulatePolicy), "POST", "/api/v1/governance/policy/simulate", body)
+	assertStatus(t, rr, http.StatusBadRequest)
+}
+
+func TestHandleSimulatePolicy_NoHistory(t *testing.T) {
+	s := newTestServer(withGuard(defaultTestPolicyConfig()))
+	body := `{"policy": {"groups": [], "defaults": {"default_action": "DENY"}}}`
+	rr := doRequest(t, http.HandlerFunc(s.handleSimulatePolicy), "POST", "/api/v1/governance/policy/simulate", body)
+	assertStatus(t, rr, http.StatusServiceUnavailable)
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -69,6 +70,40 @@ func TestHandleUpdatePolicy_MissingDefault(t *testing.T) {
 	assertStatus(t, rr, http.StatusBadRequest)
 }
 
+func TestHandleUpdatePolicy_InvalidRules(t *testing.T) {
+	s := newTestServer(withGuard(defaultTestPolicyConfig()))
+	body := `{
+  "groups": [{
+    "name": "finance",
+    "targets": ["*"],
+    "rules": [
+      {"intent": "payment", "condition": "amount >", "action": "REQUIRE_APPROVAL"},
+      {"intent": "refund(", "action": "HOLD"}
+    ]
+  }],
+  "defaults": {"default_action": "ALLOW"}
+}`
+	rr := doRequest(t, http.HandlerFunc(s.handleUpdatePolicy), "PUT", "/api/v1/governance/policy", body)
+	assertStatus(t, rr, http.StatusBadRequest)
+
+	var result struct {
+		Errors []governance.PolicyError `json:"errors"`
+	}
+	assertJSON(t, rr, &result)
+	if len(result.Errors) != 3 {
+		t.Fatalf("expected 3 rule errors, got %+v", result.Errors)
+	}
+	if e := result.Errors[0]; e.Path != "groups[0].rules[0].condition" || e.Line != 6 || e.Column != 51 {
+		t.Errorf("condition error not located: %+v", e)
+	}
+	if e := result.Errors[2]; e.Path != "groups[0].rules[1].action" || e.Line != 7 {
+		t.Errorf("action error not located: %+v", e)
+	}
+	if s.Guard.GetPolicyConfig().Groups[0].Name != "test-group" {
+		t.Error("invalid policy must not replace the active one")
+	}
+}
+
 func TestHandleUpdatePolicy_InvalidJSON(t *testing.T) {
 	s := newTestServer(withGuard(defaultTestPolicyConfig()))
 	rr := doRequest(t, http.HandlerFunc(s.handleUpdatePolicy), "PUT", "/api/v1/governance/policy", "not-json")
@@ -139,7 +174,7 @@ func TestHandleResolveApproval_Approve(t *testing.T) {
 	rr := doRequest(t, mux, "POST", "/api/v1/governance/resolve/req-1", `{"action":"APPROVE"}`)
 	assertStatus(t, rr, http.StatusOK)
 
-	var result map[string]string
+	var result map[string]any
 	assertJSON(t, rr, &result)
 	if result["status"] != "resolved" {
 		t.Errorf("Expected status 'resolved', got %q", result["status"])
@@ -166,13 +201,66 @@ func TestHandleResolveApproval_Reject(t *testing.T) {
 	rr := doRequest(t, mux, "POST", "/api/v1/governance/resolve/req-2", `{"action":"REJECT"}`)
 	assertStatus(t, rr, http.StatusOK)
 
-	var result map[string]string
+	var result map[string]any
 	assertJSON(t, rr, &result)
 	if result["action"] != "REJECT" {
 		t.Errorf("Expected action 'REJECT', got %q", result["action"])
 	}
 }
 
+func TestHandleResolveApproval_Quorum(t *testing.T) {
+	cfg := defaultTestPolicyConfig()
+	cfg.Groups[0].Rules = []governance.PolicyRule{{
+		Intent:   "payment",
+		Action:   governance.ActionRequireApproval,
+		Approval: &governance.ApprovalRule{Role: "finance", Quorum: 2},
+	}}
+	s := newTestServer(withGuard(cfg))
+	_, _, reqID := s.Guard.Intercept(&pb.MsgEnvelope{
+		SourceAgentId: "agent-1",
+		Payload:       &pb.MsgEnvelope_Event{Event: &pb.EventPayload{EventType: "payment"}},
+	})
+	if reqID == "" {
+		t.Fatal("expected the payment to be held for approval")
+	}
+
+	mux := setupMux(t, "POST /api/v1/governance/resolve/{id}", s.handleResolveApproval)
+	path := "/api/v1/governance/resolve/" + reqID
+	vote := func(name, role string) *httptest.ResponseRecorder {
+		return doAuthenticatedRequestAs(t, mux, "POST", path, `{"action":"APPROVE"}`,
+			&RequestIdentity{UserID: name, Username: name, Role: role})
+	}
+
+	assertStatus(t, vote("mallory", "viewer"), http.StatusForbidden)
+
+	rr := vote("alice", "finance")
+	assertStatus(t, rr, http.StatusOK)
+	var result map[string]any
+	assertJSON(t, rr, &result)
+	if result["status"] != "pending" || result["approvals"] != float64(1) || result["quorum"] != float64(2) {
+		t.Fatalf("expected 1/2 pending, got %+v", result)
+	}
+
+	pending := doRequest(t, http.HandlerFunc(s.handleGetPendingApprovals), "GET", "/api/v1/governance/pending", "")
+	var items []pendingApprovalJSON
+	assertJSON(t, pending, &items)
+	if len(items) != 1 || items[0].RequiredRole != "finance" || items[0].Approvals != 1 || items[0].Quorum != 2 {
+		t.Fatalf("unexpected pending approvals: %+v", items)
+	}
+
+	assertStatus(t, vote("alice", "finance"), http.StatusForbidden)
+
+	rr = vote("bob", "finance")
+	assertStatus(t, rr, http.StatusOK)
+	assertJSON(t, rr, &result)
+	if result["status"] != "resolved" || result["approval_status"] != governance.ApprovalApproved {
+		t.Fatalf("expected quorum to approve, got %+v", result)
+	}
+	if len(s.Guard.ListPending()) != 0 {
+		t.Errorf("expected no pending approvals, got %d", len(s.Guard.ListPending()))
+	}
+}
+
 func TestHandleResolveApproval_InvalidAction(t *testing.T) {
 	s := newTestServer(withGuard(defaultTestPolicyConfig()))
 	mux := setupMux(t, "POST /api/v1/governance/resolve/{id}", s.handleResolveApproval)
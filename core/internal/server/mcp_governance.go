@@ -96,7 +96,7 @@ func mcpLibraryLocality(entry *mcp.LibraryEntry) string {
 	if slices.Contains(tags, "remote") {
 		return "remote"
 	}
-	if entry.Transport == "sse" {
+	if mcp.IsHTTPTransport(entry.Transport) {
 		if endpoint := strings.TrimSpace(entry.URL); endpoint != "" {
 			if isLoopbackURL(endpoint) {
 				return "local"
@@ -121,7 +121,7 @@ func mcpLibraryDeploymentBoundary(entry *mcp.LibraryEntry) string {
 	if slices.Contains(tags, "remote") {
 		return "remote_mcp"
 	}
-	if entry.Transport == "sse" {
+	if mcp.IsHTTPTransport(entry.Transport) {
 		if endpoint := strings.TrimSpace(entry.URL); endpoint != "" {
 			if isLoopbackURL(endpoint) {
 				return "local_loopback"
@@ -136,7 +136,7 @@ func mcpLibraryCredentialBoundary(entry *mcp.LibraryEntry) string {
 	if entry == nil {
 		return "unknown"
 	}
-	if entry.HasRequiredSecretEnvVar() {
+	if entry.HasRequiredSecretEnvVar() || mcpLibraryCarriesCredentials(entry) {
 		return "secret_required"
 	}
 	if len(entry.DeclaredEnvKeys()) > 0 {
@@ -184,6 +184,22 @@ func mcpLibraryRiskLevel(entry *mcp.LibraryEntry) string {
 	return "low"
 }
 
+// mcpLibrarySandboxPosture reports whether the entry runs under a sandbox
+// profile by default. Remote servers run elsewhere and are not sandboxed.
+func mcpLibrarySandboxPosture(entry *mcp.LibraryEntry) string {
+	switch {
+	case entry == nil:
+		return "unknown"
+	case mcp.IsHTTPTransport(entry.Transport):
+		return "not_applicable"
+	case entry.Sandbox == nil:
+		return "unsandboxed"
+	case entry.Sandbox.Network:
+		return "sandboxed_network"
+	}
+	return "sandboxed"
+}
+
 func normalizeMCPEntryTags(tags []string) []string {
 	out := make([]string, 0, len(tags))
 	for _, tag := range tags {
@@ -223,6 +239,7 @@ func buildMCPLibraryInspectionReport(entry *mcp.LibraryEntry, ctx mcpGovernanceC
 		"secrets_declared":       sortedMCPLibraryEnvKeys(entry),
 		"bundle_install_path":    "curated_library_only",
 		"bundle_version_posture": mcpLibraryBundleVersionPosture(entry),
+		"sandbox":                mcpLibrarySandboxPosture(entry),
 		"decision":               decision.Decision,
 		"reasons":                decision.Reasons,
 		"governance":             decision,
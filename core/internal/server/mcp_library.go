@@ -12,12 +12,15 @@ import (
 type mcpLibraryRequest struct {
 	Name              string               `json:"name"`
 	Env               map[string]string    `json:"env,omitempty"`
+	Headers           map[string]string    `json:"headers,omitempty"` // merged over the entry's headers
+	Auth              *mcp.AuthConfig      `json:"auth,omitempty"`    // replaces the entry's auth
+	Sandbox           *mcp.SandboxProfile  `json:"sandbox,omitempty"` // replaces the entry's sandbox profile
 	GovernanceContext mcpGovernanceContext `json:"governance_context,omitempty"`
 }
 
 type mcpPreparedLibraryRequest struct {
 	Request    mcpLibraryRequest
-	Entry      *mcp.LibraryEntry
+	Entry      *mcp.LibraryEntry // curated entry with the request's overrides applied
 	Inspection map[string]any
 }
 
@@ -148,16 +151,30 @@ func (s *AdminServer) prepareMCPLibraryRequest(w http.ResponseWriter, r *http.Re
 		return mcpPreparedLibraryRequest{}, false
 	}
 
+	merged, err := applyMCPLibraryOverrides(r, entry, req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, err.Error()), http.StatusForbidden)
+		return mcpPreparedLibraryRequest{}, false
+	}
+
 	inspectCtx := normalizeMCPGovernanceContext(r, req.GovernanceContext)
 	return mcpPreparedLibraryRequest{
 		Request:    req,
-		Entry:      entry,
-		Inspection: buildMCPLibraryInspectionReport(entry, inspectCtx),
+		Entry:      merged,
+		Inspection: buildMCPLibraryInspectionReport(merged, inspectCtx),
 	}, true
 }
 
 func (s *AdminServer) installMCPLibraryEntry(w http.ResponseWriter, r *http.Request, prepared mcpPreparedLibraryRequest, logAction string) (mcpLibraryInstallResult, bool) {
 	cfg := prepared.Entry.ToServerConfig(prepared.Request.Env)
+	if err := mcp.ValidateRemoteConfig(cfg); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"install failed: %s"}`, err.Error()), http.StatusBadRequest)
+		return mcpLibraryInstallResult{}, false
+	}
+	if err := mcp.ValidateSandbox(cfg); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"install failed: %s"}`, err.Error()), http.StatusBadRequest)
+		return mcpLibraryInstallResult{}, false
+	}
 	runtimeCfg, err := mcp.ApplyRuntimeDefaults(cfg)
 	if err != nil {
 		http.Error(w, fmt.Sprintf(`{"error":"install failed: prepare runtime defaults: %s"}`, err.Error()), http.StatusInternalServerError)
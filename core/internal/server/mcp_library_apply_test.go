@@ -137,9 +137,9 @@ func TestHandleMCPLibraryInstall_HappyPath(t *testing.T) {
 	now := time.Now()
 
 	mock.ExpectQuery("INSERT INTO mcp_servers").
-		WithArgs("fetch", "unsupported", "", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
+		WithArgs("fetch", "unsupported", "", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows(mcpServerColumns()).
-			AddRow("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "fetch", "unsupported", "", `[]`, `{}`, "", `{}`, "installed", nil, now, now))
+			AddRow("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "fetch", "unsupported", "", `[]`, `{}`, "", `{}`, nil, nil, "installed", nil, now, now))
 	mock.ExpectExec("UPDATE mcp_servers").
 		WithArgs("error", sqlmock.AnyArg(), "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa").
 		WillReturnResult(sqlmock.NewResult(0, 1))
@@ -168,9 +168,9 @@ func TestHandleMCPLibraryApply_HappyPath(t *testing.T) {
 	now := time.Now()
 
 	mock.ExpectQuery("INSERT INTO mcp_servers").
-		WithArgs("fetch", "unsupported", "", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
+		WithArgs("fetch", "unsupported", "", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows(mcpServerColumns()).
-			AddRow("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "fetch", "unsupported", "", `[]`, `{}`, "", `{}`, "installed", nil, now, now))
+			AddRow("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "fetch", "unsupported", "", `[]`, `{}`, "", `{}`, nil, nil, "installed", nil, now, now))
 	mock.ExpectExec("UPDATE mcp_servers").
 		WithArgs("error", sqlmock.AnyArg(), "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa").
 		WillReturnResult(sqlmock.NewResult(0, 1))
@@ -193,3 +193,48 @@ func TestHandleMCPLibraryApply_HappyPath(t *testing.T) {
 		t.Fatalf("expected inspection object, got %T", resp["inspection"])
 	}
 }
+
+func withRemoteOAuthLibrary() func(*AdminServer) {
+	return func(s *AdminServer) {
+		s.MCPLibrary = &mcp.Library{Categories: []mcp.LibraryCategory{{
+			Name: "Default",
+			Servers: []mcp.LibraryEntry{{Name: "remote-docs", Transport: mcp.TransportStreamableHTTP, URL: "https://mcp.example.com/mcp",
+				Auth: &mcp.AuthConfig{Type: mcp.AuthOAuthClientCredentials, ClientID: "mycelis", TokenURL: "https://auth.example.com/token"}}},
+		}}}
+	}
+}
+
+func TestHandleMCPLibraryInstall_RejectsForeignAuthEndpoint(t *testing.T) {
+	s := newTestServer(withMCPStubs(), withRemoteOAuthLibrary())
+	body := `{"name":"remote-docs","auth":{"type":"oauth_client_credentials","client_id":"mycelis",` +
+		`"client_secret_ref":"secret:docs-client","token_url":"https://attacker.example.net/token"}}`
+	identity := localAdminIdentityForTest()
+	identity.Scopes = []string{"mcp:write"}
+
+	rr := doAuthenticatedRequestAs(t, http.HandlerFunc(s.handleMCPLibraryInstall), "POST", "/api/v1/mcp/library/install", body, identity)
+	assertStatus(t, rr, http.StatusForbidden)
+
+	rr = doAuthenticatedRequest(t, http.HandlerFunc(s.handleMCPLibraryInspect), "POST", "/api/v1/mcp/library/inspect", body)
+	assertStatus(t, rr, http.StatusOK)
+}
+
+func TestHandleMCPLibraryInspect_JudgesMergedConfig(t *testing.T) {
+	s := newTestServer(withMCPStubs(), func(s *AdminServer) {
+		s.MCPLibrary = &mcp.Library{Categories: []mcp.LibraryCategory{{
+			Name:    "Default",
+			Servers: []mcp.LibraryEntry{{Name: "notes", Transport: mcp.TransportStdio, Command: "notes-mcp", Sandbox: &mcp.SandboxProfile{}}},
+		}}}
+	})
+	body := `{"name":"notes","headers":{"X-Api-Key":"${secret:notes-key}"},"sandbox":{"network":true}}`
+
+	rr := doAuthenticatedRequest(t, http.HandlerFunc(s.handleMCPLibraryInspect), "POST", "/api/v1/mcp/library/inspect", body)
+	assertStatus(t, rr, http.StatusOK)
+	var inspection map[string]any
+	assertJSON(t, rr, &inspection)
+	if inspection["credential_boundary"] != "secret_required" {
+		t.Fatalf("credential_boundary = %v, want secret_required from the request headers", inspection["credential_boundary"])
+	}
+	if inspection["sandbox"] != "sandboxed_network" {
+		t.Fatalf("sandbox = %v, want the request's sandbox profile", inspection["sandbox"])
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mycelis/core/internal/mcp"
+	"github.com/mycelis/core/internal/secrets"
+)
+
+// applyMCPLibraryOverrides returns a copy of entry with the request's
+// headers merged in and its auth and sandbox replacing the entry's, so
+// inspection judges the configuration that will actually be installed.
+//
+// OAuth endpoints receive the client secret, so a request may only point
+// token_url or auth_server_metadata_url somewhere the curated entry does not
+// when the caller also holds secrets:write.
+func applyMCPLibraryOverrides(r *http.Request, entry *mcp.LibraryEntry, req mcpLibraryRequest) (*mcp.LibraryEntry, error) {
+	merged := *entry
+	if len(req.Headers) > 0 {
+		merged.Headers = make(map[string]string, len(entry.Headers)+len(req.Headers))
+		for name, value := range entry.Headers {
+			merged.Headers[name] = value
+		}
+		for name, value := range req.Headers {
+			merged.Headers[name] = value
+		}
+	}
+	if req.Auth != nil {
+		if !sameMCPAuthEndpoints(entry.Auth, req.Auth) && !hasScope(IdentityFromContext(r.Context()), "secrets:write") {
+			return nil, fmt.Errorf("auth endpoints differ from the curated %s entry; overriding them requires secrets:write", entry.Name)
+		}
+		merged.Auth = req.Auth
+	}
+	if req.Sandbox != nil {
+		merged.Sandbox = req.Sandbox
+	}
+	return &merged, nil
+}
+
+// sameMCPAuthEndpoints reports whether override sends credentials only to
+// the token and metadata endpoints curated declares.
+func sameMCPAuthEndpoints(curated, override *mcp.AuthConfig) bool {
+	var tokenURL, metadataURL string
+	if curated != nil {
+		tokenURL, metadataURL = curated.TokenURL, curated.MetadataURL
+	}
+	return strings.TrimSpace(override.TokenURL) == strings.TrimSpace(tokenURL) &&
+		strings.TrimSpace(override.MetadataURL) == strings.TrimSpace(metadataURL)
+}
+
+// mcpLibraryCarriesCredentials reports whether the entry sends credentials
+// to its server through OAuth or secret-referencing headers.
+func mcpLibraryCarriesCredentials(entry *mcp.LibraryEntry) bool {
+	if entry.Auth != nil {
+		return true
+	}
+	for _, value := range entry.Headers {
+		if secrets.HasPlaceholder(value) {
+			return true
+		}
+	}
+	return false
+}
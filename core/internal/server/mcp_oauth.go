@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+type mcpOAuthCompleteRequest struct {
+	State string `json:"state"`
+	Code  string `json:"code"`
+}
+
+// POST /api/v1/mcp/servers/{id}/oauth/authorize
+// Starts the authorization code + PKCE flow for an oauth_pkce server and
+// returns the URL the operator opens to grant access.
+func (s *AdminServer) HandleMCPOAuthAuthorize(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireRootAdminScope(w, r, "secrets:write"); !ok {
+		return
+	}
+	if s.MCP == nil || s.MCPPool == nil {
+		respondAPIError(w, "MCP subsystem not initialized", http.StatusServiceUnavailable)
+		return
+	}
+	serverID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondAPIError(w, "Invalid server id", http.StatusBadRequest)
+		return
+	}
+	cfg, err := s.MCP.Get(r.Context(), serverID)
+	if err != nil {
+		respondAPIError(w, "MCP server not found", http.StatusNotFound)
+		return
+	}
+	authURL, err := s.MCPPool.BeginAuthorization(r.Context(), *cfg)
+	if err != nil {
+		respondAPIError(w, "OAuth authorization failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(map[string]string{"authorization_url": authURL}))
+}
+
+// POST /api/v1/mcp/oauth/complete
+// Receives the state and code the authorization server redirected back with,
+// stores the issued tokens in the secret store and reconnects the server.
+func (s *AdminServer) HandleMCPOAuthComplete(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireRootAdminScope(w, r, "secrets:write"); !ok {
+		return
+	}
+	if s.MCP == nil || s.MCPPool == nil {
+		respondAPIError(w, "MCP subsystem not initialized", http.StatusServiceUnavailable)
+		return
+	}
+	var req mcpOAuthCompleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.State == "" || req.Code == "" {
+		respondAPIError(w, "state and code are required", http.StatusBadRequest)
+		return
+	}
+	serverID, err := s.MCPPool.CompleteAuthorization(r.Context(), req.State, req.Code)
+	if err != nil {
+		respondAPIError(w, "OAuth authorization failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	status := "connected"
+	cfg, err := s.MCP.Get(r.Context(), serverID)
+	if err == nil {
+		err = s.MCPPool.Connect(r.Context(), *cfg)
+	}
+	if err != nil {
+		log.Printf("MCP oauth: reconnect %s after authorization: %v", serverID, err)
+		status = "authorized"
+	}
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(map[string]string{"server_id": serverID.String(), "status": status}))
+}
@@ -30,7 +30,7 @@ func TestHandleMCPList_RedactsEnvAndHeaders(t *testing.T) {
 
 	mock.ExpectQuery("SELECT .+ FROM mcp_servers").
 		WillReturnRows(sqlmock.NewRows(mcpServerColumns()).
-			AddRow(serverID, "brave-search", "stdio", "npx", `[]`, `{"BRAVE_API_KEY":"live-secret"}`, "", `{"Authorization":"Bearer live-secret"}`, "connected", nil, now, now))
+			AddRow(serverID, "brave-search", "stdio", "npx", `[]`, `{"BRAVE_API_KEY":"live-secret"}`, "", `{"Authorization":"Bearer live-secret"}`, nil, nil, "connected", nil, now, now))
 	mock.ExpectQuery("SELECT .+ FROM mcp_tools").
 		WithArgs(serverUUID).
 		WillReturnRows(sqlmock.NewRows(mcpToolColumns()))
@@ -72,9 +72,9 @@ func withSecretFetchLibrary() func(*AdminServer) {
 func expectSecretFetchInstall(mock sqlmock.Sqlmock) {
 	now := time.Now()
 	mock.ExpectQuery("INSERT INTO mcp_servers").
-		WithArgs("fetch", "unsupported", "", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
+		WithArgs("fetch", "unsupported", "", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows(mcpServerColumns()).
-			AddRow("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "fetch", "unsupported", "", `[]`, `{"FETCH_TOKEN":"live-secret"}`, "", `{"Authorization":"Bearer live-secret"}`, "installed", nil, now, now))
+			AddRow("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "fetch", "unsupported", "", `[]`, `{"FETCH_TOKEN":"live-secret"}`, "", `{"Authorization":"Bearer live-secret"}`, nil, nil, "installed", nil, now, now))
 	mock.ExpectExec("UPDATE mcp_servers").
 		WithArgs("error", sqlmock.AnyArg(), "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa").
 		WillReturnResult(sqlmock.NewResult(0, 1))
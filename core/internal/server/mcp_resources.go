@@ -0,0 +1,42 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mycelis/core/internal/mcp"
+)
+
+// GET /api/v1/mcp/resources — cached resources of connected MCP servers
+func (s *AdminServer) handleMCPResourcesList(w http.ResponseWriter, r *http.Request) {
+	if s.MCP == nil {
+		http.Error(w, `{"error":"MCP subsystem not initialized"}`, http.StatusServiceUnavailable)
+		return
+	}
+	resources, err := s.MCP.ListAllResources(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"list resources failed: %s"}`, err.Error()), http.StatusInternalServerError)
+		return
+	}
+	if resources == nil {
+		resources = []mcp.ResourceDef{}
+	}
+	respondJSON(w, resources)
+}
+
+// GET /api/v1/mcp/prompts — cached prompt templates of connected MCP servers
+func (s *AdminServer) handleMCPPromptsList(w http.ResponseWriter, r *http.Request) {
+	if s.MCP == nil {
+		http.Error(w, `{"error":"MCP subsystem not initialized"}`, http.StatusServiceUnavailable)
+		return
+	}
+	prompts, err := s.MCP.ListAllPrompts(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"list prompts failed: %s"}`, err.Error()), http.StatusInternalServerError)
+		return
+	}
+	if prompts == nil {
+		prompts = []mcp.PromptDef{}
+	}
+	respondJSON(w, prompts)
+}
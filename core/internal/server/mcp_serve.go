@@ -0,0 +1,172 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	mcplib "github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+const mcpServePath = "/api/v1/mcp/serve"
+
+// mcpServedTool is one tool Mycelis publishes to external MCP clients.
+// Scope is checked against the caller's identity exactly like a REST scope.
+type mcpServedTool struct {
+	Name    string
+	Scope   string
+	Mutates bool
+}
+
+// mcpServedInternalTools are the internal registry tools exposed over MCP.
+var mcpServedInternalTools = []mcpServedTool{
+	{Name: "search_memory", Scope: "soma:work"},
+	{Name: "recall", Scope: "soma:work"},
+	{Name: "list_teams", Scope: "soma:work"},
+	{Name: "delegate_task", Scope: "soma:work", Mutates: true},
+}
+
+var (
+	mcpAskTeamTool      = mcpServedTool{Name: "ask_team", Scope: "soma:work", Mutates: true}
+	mcpReadArtifactTool = mcpServedTool{Name: "read_artifact", Scope: "outputs:read"}
+)
+
+// MCPServeHandler returns the streamable HTTP MCP endpoint. It is mounted
+// behind AuthMiddleware, so every request carries the caller's identity.
+func (s *AdminServer) MCPServeHandler() http.Handler {
+	return mcpserver.NewStreamableHTTPServer(s.NewMCPServer(), mcpserver.WithEndpointPath(mcpServePath))
+}
+
+// NewMCPServer builds the MCP server that exposes Mycelis tools.
+func (s *AdminServer) NewMCPServer() *mcpserver.MCPServer {
+	srv := mcpserver.NewMCPServer("mycelis", "1.0.0",
+		mcpserver.WithToolCapabilities(false),
+		mcpserver.WithRecovery(),
+		mcpserver.WithInstructions("Drive Mycelis teams: search memory, list teams, delegate or ask teams for work, and read the artifacts they produce."),
+	)
+	if s.InternalTools != nil {
+		for _, served := range mcpServedInternalTools {
+			tool := s.InternalTools.Get(served.Name)
+			if tool == nil {
+				continue
+			}
+			schema, _ := json.Marshal(tool.InputSchema)
+			srv.AddTool(mcplib.NewToolWithRawSchema(tool.Name, tool.Description, schema), s.governedMCPTool(served, tool.Handler))
+		}
+	}
+	srv.AddTool(mcplib.NewTool(mcpAskTeamTool.Name,
+		mcplib.WithDescription("Ask a team for bounded work and wait for its reply or degradation proof."),
+		mcplib.WithString("team_id", mcplib.Required(), mcplib.Description("The target team ID")),
+		mcplib.WithString("message", mcplib.Required(), mcplib.Description("What the team should do")),
+		mcplib.WithNumber("timeout_seconds", mcplib.Description("How long to wait for the reply (default 15, max 60)")),
+	), s.governedMCPTool(mcpAskTeamTool, s.mcpAskTeam))
+	srv.AddTool(mcplib.NewTool(mcpReadArtifactTool.Name,
+		mcplib.WithDescription("Read an artifact produced by a team, including its content and metadata."),
+		mcplib.WithString("artifact_id", mcplib.Required(), mcplib.Description("The artifact ID")),
+	), s.governedMCPTool(mcpReadArtifactTool, s.mcpReadArtifact))
+	return srv
+}
+
+// governedMCPTool enforces the tool scope and records every call in the
+// audit log, allowed or not.
+func (s *AdminServer) governedMCPTool(served mcpServedTool, run func(context.Context, map[string]any) (string, error)) mcpserver.ToolHandlerFunc {
+	return func(ctx context.Context, req mcplib.CallToolRequest) (*mcplib.CallToolResult, error) {
+		args := req.GetArguments()
+		identity := IdentityFromContext(ctx)
+		if !hasScope(identity, served.Scope) {
+			s.auditMCPToolCall(ctx, served, args, "denied", "missing scope "+served.Scope)
+			return mcplib.NewToolResultError("Missing required scope: " + served.Scope), nil
+		}
+		text, err := run(ctx, args)
+		if err != nil {
+			s.auditMCPToolCall(ctx, served, args, "failed", err.Error())
+			return mcplib.NewToolResultError(err.Error()), nil
+		}
+		s.auditMCPToolCall(ctx, served, args, "completed", "")
+		return mcplib.NewToolResultText(text), nil
+	}
+}
+
+func (s *AdminServer) auditMCPToolCall(ctx context.Context, served mcpServedTool, args map[string]any, status, reason string) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, mcpServePath, nil)
+	if err != nil {
+		return
+	}
+	template := protocol.TemplateChatToAnswer
+	if served.Mutates {
+		template = protocol.TemplateChatToProposal
+	}
+	_, _ = s.createAuditEvent(template, "mcp-server", "MCP tool called: "+served.Name,
+		attachActorIdentity(map[string]any{
+			"actor":           "MCP client",
+			"user":            auditUserLabelFromRequest(r),
+			"action":          "mcp_tool_call",
+			"result_status":   status,
+			"approval_reason": reason,
+			"capability_used": served.Name,
+			"resource":        firstNonEmptyString(args["team_id"], args["artifact_id"], args["query"]),
+		}, r),
+	)
+}
+
+// mcpAskTeam serves ask_team through the team work REST handler so both
+// surfaces record the same Active Work state.
+func (s *AdminServer) mcpAskTeam(ctx context.Context, args map[string]any) (string, error) {
+	teamID, _ := args["team_id"].(string)
+	message, _ := args["message"].(string)
+	teamID = strings.TrimSpace(teamID)
+	if teamID == "" || strings.TrimSpace(message) == "" {
+		return "", fmt.Errorf("ask_team requires 'team_id' and 'message'")
+	}
+	body := map[string]any{"message": message}
+	if timeout, ok := args["timeout_seconds"].(float64); ok {
+		body["timeout_seconds"] = int(timeout)
+	}
+	return s.callRESTForMCP(ctx, s.HandleTeamWorkAsk, http.MethodPost, teamID, body)
+}
+
+func (s *AdminServer) mcpReadArtifact(ctx context.Context, args map[string]any) (string, error) {
+	id, _ := args["artifact_id"].(string)
+	if strings.TrimSpace(id) == "" {
+		return "", fmt.Errorf("read_artifact requires 'artifact_id'")
+	}
+	return s.callRESTForMCP(ctx, s.handleGetArtifact, http.MethodGet, strings.TrimSpace(id), nil)
+}
+
+// callRESTForMCP runs a REST handler in process and returns its JSON body,
+// turning error statuses into tool errors.
+func (s *AdminServer) callRESTForMCP(ctx context.Context, handler http.HandlerFunc, method, pathID string, body any) (string, error) {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, mcpServePath, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", pathID)
+	rw := &bufferedResponseWriter{header: make(http.Header), status: http.StatusOK}
+	handler(rw, req)
+	text := strings.TrimSpace(rw.body.String())
+	if rw.status >= http.StatusBadRequest {
+		return "", fmt.Errorf("%d: %s", rw.status, text)
+	}
+	return text, nil
+}
+
+// bufferedResponseWriter captures an in-process handler response.
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Header() http.Header         { return w.header }
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) { return w.body.Write(b) }
+func (w *bufferedResponseWriter) WriteHeader(status int)      { w.status = status }
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mycelis/core/internal/swarm"
+)
+
+// dialMCPServe connects an MCP client to MCPServeHandler as a caller holding
+// the scopes in the X-Test-Scopes header.
+func dialMCPServe(t *testing.T, s *AdminServer, scopes string) *client.Client {
+	t.Helper()
+	handler := s.MCPServeHandler()
+	srv := newLocalHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity := localAdminIdentityForTest()
+		identity.Scopes = strings.Split(r.Header.Get("X-Test-Scopes"), ",")
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKeyIdentity, identity)))
+	}))
+	c, err := client.NewStreamableHttpClient(srv.URL+mcpServePath, transport.WithHTTPHeaders(map[string]string{"X-Test-Scopes": scopes}))
+	if err != nil {
+		t.Fatalf("mcp client: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if _, err := c.Initialize(context.Background(), mcp.InitializeRequest{}); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	return c
+}
+
+func callMCPServeTool(t *testing.T, c *client.Client, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	result, err := c.CallTool(context.Background(), req)
+	if err != nil {
+		t.Fatalf("call %s: %v", name, err)
+	}
+	return result
+}
+
+func TestMCPServe_ExposesSelectedToolsWithScopesAndAudit(t *testing.T) {
+	dbOpt, mock := withDB(t)
+	s := newTestServer(dbOpt, func(s *AdminServer) {
+		s.InternalTools = swarm.NewInternalToolRegistry(swarm.InternalToolDeps{})
+	})
+	c := dialMCPServe(t, s, "outputs:read")
+
+	tools, err := c.ListTools(context.Background(), mcp.ListToolsRequest{})
+	if err != nil {
+		t.Fatalf("list tools: %v", err)
+	}
+	names := map[string]bool{}
+	for _, tool := range tools.Tools {
+		names[tool.Name] = true
+	}
+	for _, want := range []string{"search_memory", "recall", "list_teams", "delegate_task", "ask_team", "read_artifact"} {
+		if !names[want] {
+			t.Errorf("tool %s not exposed; got %v", want, names)
+		}
+	}
+	if names["local_command"] || names["write_file"] {
+		t.Errorf("unselected internal tools exposed: %v", names)
+	}
+
+	mock.ExpectExec("INSERT INTO log_entries").WillReturnResult(sqlmock.NewResult(1, 1))
+	denied := callMCPServeTool(t, c, "ask_team", map[string]any{"team_id": "alpha", "message": "ship it"})
+	if !denied.IsError || !strings.Contains(denied.Content[0].(mcp.TextContent).Text, "soma:work") {
+		t.Fatalf("ask_team without soma:work = %+v, want scope error", denied)
+	}
+
+	mock.ExpectExec("INSERT INTO log_entries").WillReturnResult(sqlmock.NewResult(1, 1))
+	failed := callMCPServeTool(t, c, "read_artifact", map[string]any{"artifact_id": "not-a-uuid"})
+	if !failed.IsError {
+		t.Fatalf("read_artifact without artifacts service = %+v, want error", failed)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("audit expectations: %v", err)
+	}
+}
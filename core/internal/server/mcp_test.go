@@ -154,7 +154,7 @@ func TestHandleMCPList_HappyPath(t *testing.T) {
 	serverUUID := uuid.MustParse(serverID)
 	mock.ExpectQuery("SELECT .+ FROM mcp_servers").
 		WillReturnRows(sqlmock.NewRows(mcpServerColumns()).
-			AddRow(serverID, "filesystem", "stdio", "npx", `[]`, `{}`, "", `{}`, "connected", nil, now, now))
+			AddRow(serverID, "filesystem", "stdio", "npx", `[]`, `{}`, "", `{}`, nil, nil, "connected", nil, now, now))
 	mock.ExpectQuery("SELECT .+ FROM mcp_tools").
 		WithArgs(serverUUID).
 		WillReturnRows(sqlmock.NewRows(mcpToolColumns()).
@@ -53,7 +53,7 @@ func withExchangeDB(t *testing.T) (func(*AdminServer), sqlmock.Sqlmock) {
 }
 
 func mcpServerColumns() []string {
-	return []string{"id", "name", "transport", "command", "args", "env", "url", "headers", "status", "error_message", "created_at", "updated_at"}
+	return []string{"id", "name", "transport", "command", "args", "env", "url", "headers", "auth", "sandbox", "status", "error_message", "created_at", "updated_at"}
 }
 
 func mcpToolColumns() []string {
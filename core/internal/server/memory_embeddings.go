@@ -0,0 +1,148 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mycelis/core/internal/memory"
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+// GET /api/v1/memory/embeddings
+// Reports the active embedding model, the embedding spaces stored in
+// context_vectors and recent re-embedding migrations.
+func (s *AdminServer) HandleListEmbeddingMigrations(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireRootAdminScope(w, r, "memory:read"); !ok {
+		return
+	}
+	if !s.embeddingMigratorReady(w) {
+		return
+	}
+	spaces, err := s.Mem.EmbeddingSpaces(r.Context())
+	if err != nil {
+		respondAPIError(w, "Failed to list embedding spaces: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	migrations, err := s.Mem.ListEmbeddingMigrations(r.Context(), r.URL.Query().Get("status"), 20)
+	if err != nil {
+		respondAPIError(w, "Failed to list embedding migrations: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	activeModel := ""
+	if s.Cognitive != nil {
+		activeModel = s.Cognitive.EmbedModel()
+	}
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(map[string]any{
+		"active_model": activeModel,
+		"spaces":       spaces,
+		"migrations":   migrations,
+	}))
+}
+
+// POST /api/v1/memory/embeddings/migrations
+func (s *AdminServer) HandleStartEmbeddingMigration(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireRootAdminScope(w, r, "memory:write"); !ok {
+		return
+	}
+	if !s.embeddingMigratorReady(w) {
+		return
+	}
+	var req memory.EmbeddingMigrationRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondAPIError(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	mig, err := s.Embeddings.StartMigration(r.Context(), req, auditUserLabelFromRequest(r))
+	if err != nil {
+		respondEmbeddingMigrationError(w, err)
+		return
+	}
+	s.auditEmbeddingMigration(r, mig, "embedding_migration_start")
+	respondAPIJSON(w, http.StatusAccepted, protocol.NewAPISuccess(mig))
+}
+
+// GET /api/v1/memory/embeddings/migrations/{id}
+func (s *AdminServer) HandleGetEmbeddingMigration(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireRootAdminScope(w, r, "memory:read"); !ok {
+		return
+	}
+	if !s.embeddingMigratorReady(w) {
+		return
+	}
+	mig, err := s.Mem.GetEmbeddingMigration(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondEmbeddingMigrationError(w, err)
+		return
+	}
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(mig))
+}
+
+// POST /api/v1/memory/embeddings/migrations/{id}/cancel
+func (s *AdminServer) HandleCancelEmbeddingMigration(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireRootAdminScope(w, r, "memory:write"); !ok {
+		return
+	}
+	if !s.embeddingMigratorReady(w) {
+		return
+	}
+	mig, err := s.Embeddings.CancelMigration(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondEmbeddingMigrationError(w, err)
+		return
+	}
+	s.auditEmbeddingMigration(r, mig, "embedding_migration_cancel")
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(mig))
+}
+
+// POST /api/v1/memory/embeddings/migrations/{id}/resume
+func (s *AdminServer) HandleResumeEmbeddingMigration(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireRootAdminScope(w, r, "memory:write"); !ok {
+		return
+	}
+	if !s.embeddingMigratorReady(w) {
+		return
+	}
+	mig, err := s.Embeddings.ResumeMigration(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondEmbeddingMigrationError(w, err)
+		return
+	}
+	s.auditEmbeddingMigration(r, mig, "embedding_migration_resume")
+	respondAPIJSON(w, http.StatusAccepted, protocol.NewAPISuccess(mig))
+}
+
+func (s *AdminServer) embeddingMigratorReady(w http.ResponseWriter) bool {
+	if s.Mem == nil || s.Embeddings == nil {
+		respondAPIError(w, "Embedding migrations unavailable (memory or cognitive engine offline)", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+func respondEmbeddingMigrationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, memory.ErrMigrationNotFound):
+		respondAPIError(w, "Embedding migration not found", http.StatusNotFound)
+	case errors.Is(err, memory.ErrMigrationActive):
+		respondAPIError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, memory.ErrNoEmbedder):
+		respondAPIError(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		respondAPIError(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+func (s *AdminServer) auditEmbeddingMigration(r *http.Request, mig *memory.EmbeddingMigration, action string) {
+	_, _ = s.createAuditEvent(protocol.TemplateChatToProposal, "memory", "Embedding migration "+mig.Status+": "+mig.TargetModel,
+		attachActorIdentity(map[string]any{
+			"actor":         "Operator",
+			"user":          auditUserLabelFromRequest(r),
+			"action":        action,
+			"result_status": mig.Status,
+			"resource":      mig.ID,
+		}, r),
+	)
+}
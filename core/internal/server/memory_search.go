@@ -72,6 +72,7 @@ func (s *AdminServer) HandleMemorySearch(w http.ResponseWriter, r *http.Request)
 		Types:               searchTypes,
 		AllowGlobal:         true,
 		AllowLegacyUnscoped: teamID == "" && agentID == "",
+		QueryText:           query,
 	})
 	if err != nil {
 		w.Header().Set("Content-Type", "application/json")
@@ -232,3 +232,30 @@ func TestDepartmentAIEngineInheritance_PersistsAcrossOrganizationUpdates(t *test
 		t.Fatalf("expected delivery to inherit updated organization default, got %+v", delivery)
 	}
 }
+
+func TestHandleUpdateOrganizationAIEngine_StoresSamplingOverrides(t *testing.T) {
+	s := newTestServer(withTemplateBundlesPath(writeStarterBundle(t)))
+	created := s.organizationStore().Save(OrganizationHomePayload{
+		OrganizationSummary: OrganizationSummary{
+			ID:                      "org-123",
+			Name:                    "Northstar Labs",
+			StartMode:               OrganizationStartModeEmpty,
+			TeamLeadLabel:           "Team Lead",
+			AIEngineSettingsSummary: "Set up later in Advanced mode",
+			Status:                  "ready",
+		},
+	})
+	if _, ok := s.organizationStore().OrganizationSampling(created.ID); ok {
+		t.Fatal("expected no sampling overrides before update")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PATCH /api/v1/organizations/{id}/ai-engine", s.handleUpdateOrganizationAIEngine)
+	rr := doRequest(t, mux, "PATCH", "/api/v1/organizations/"+created.ID+"/ai-engine", `{"profile_id":"balanced","sampling":{"temperature":0.3,"max_tokens":2048}}`)
+	assertStatus(t, rr, http.StatusOK)
+
+	params, ok := s.organizationStore().OrganizationSampling(created.ID)
+	if !ok || params.Temperature == nil || *params.Temperature != 0.3 || params.MaxTokens != 2048 {
+		t.Fatalf("unexpected organization sampling %+v (ok=%v)", params, ok)
+	}
+}
@@ -103,6 +103,7 @@ func (s *AdminServer) handleUpdateOrganizationAIEngine(w http.ResponseWriter, r
 	updated, ok := s.organizationStore().Update(id, func(home OrganizationHomePayload) OrganizationHomePayload {
 		home.AIEngineProfileID = string(profile.ID)
 		home.AIEngineSettingsSummary = profile.Summary
+		home.AIEngineSampling = req.Sampling
 		return normalizeOrganizationHome(home)
 	})
 	if !ok {
@@ -0,0 +1,13 @@
+package server
+
+import "github.com/mycelis/core/pkg/protocol"
+
+// OrganizationSampling returns the AI Engine sampling overrides of an
+// organization, satisfying cognitive.OrganizationSampling.
+func (s *OrganizationStore) OrganizationSampling(organizationID string) (protocol.SamplingParams, bool) {
+	home, ok := s.Get(organizationID)
+	if !ok || home.AIEngineSampling == nil || home.AIEngineSampling.IsZero() {
+		return protocol.SamplingParams{}, false
+	}
+	return *home.AIEngineSampling, true
+}
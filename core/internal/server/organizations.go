@@ -166,6 +166,10 @@ type OrganizationSummary struct {
 	DefaultOutputModelID      string                `json:"default_output_model_id,omitempty"`
 	DefaultOutputModelSummary string                `json:"default_output_model_summary,omitempty"`
 	Status                    string                `json:"status"`
+
+	// AIEngineSampling overrides the sampling parameters of the selected
+	// AI Engine profile for every inference attributed to this organization.
+	AIEngineSampling *protocol.SamplingParams `json:"ai_engine_sampling,omitempty"`
 }
 
 type OrganizationHomePayload struct {
@@ -197,7 +201,8 @@ type TeamLeadGuidanceRequest struct {
 }
 
 type OrganizationAIEngineUpdateRequest struct {
-	ProfileID string `json:"profile_id"`
+	ProfileID string                   `json:"profile_id"`
+	Sampling  *protocol.SamplingParams `json:"sampling,omitempty"`
 }
 
 type DepartmentAIEngineUpdateRequest struct {
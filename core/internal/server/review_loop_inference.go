@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mycelis/core/internal/cognitive"
+)
+
+const (
+	reviewLoopProfile          = "reviewer"
+	reviewLoopInferenceTimeout = 20 * time.Second
+)
+
+// reviewLoopOutputSchema is the JSON Schema of ReviewLoopStructuredOutput.
+var reviewLoopOutputSchema = map[string]any{
+	"type":     "object",
+	"required": []any{"status", "findings", "suggestions"},
+	"properties": map[string]any{
+		"status":        map[string]any{"enum": []any{"healthy", "attention_needed"}},
+		"flagged_items": map[string]any{"type": "integer", "minimum": 0},
+		"findings":      map[string]any{"type": "array", "minItems": 1, "items": map[string]any{"type": "string"}},
+		"suggestions":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+	},
+}
+
+// reviewLoopOutput builds the deterministic review and, when an AI Engine is
+// available, asks the reviewer profile to refine it under
+// reviewLoopOutputSchema. Any inference or schema failure keeps the
+// deterministic review.
+func (s *AdminServer) reviewLoopOutput(home OrganizationHomePayload, owner LoopOwnerResolution) ReviewLoopStructuredOutput {
+	baseline := buildReviewLoopOutput(home, owner)
+	if s.Cognitive == nil || !s.Cognitive.ExecutionAvailability(reviewLoopProfile, "").Available {
+		return baseline
+	}
+
+	facts, _ := json.Marshal(baseline)
+	prompt := fmt.Sprintf(`You are %s, reviewing the AI Organization %q (purpose: %s).
+
+Baseline review from the organization's current setup:
+%s
+
+Refine this review. Keep every baseline finding that is still true, add only findings supported by the setup above, and keep suggestions read-only.
+Return ONLY a JSON object with "status" ("healthy" or "attention_needed"), "flagged_items", "findings" and "suggestions".`,
+		owner.Name, safeOrganizationName(home.Name), home.Purpose, facts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), reviewLoopInferenceTimeout)
+	defer cancel()
+	resp, err := s.Cognitive.InferWithContract(ctx, cognitive.InferRequest{
+		Profile:        reviewLoopProfile,
+		Prompt:         prompt,
+		ResponseSchema: reviewLoopOutputSchema,
+		Attribution:    cognitive.UsageAttribution{OrganizationID: home.ID},
+	})
+	if err != nil {
+		log.Printf("[review-loop] organization=%s owner=%s inference fallback error=%v", home.ID, owner.ID, err)
+		return baseline
+	}
+
+	var review ReviewLoopStructuredOutput
+	if err := json.Unmarshal([]byte(resp.Text), &review); err != nil {
+		log.Printf("[review-loop] organization=%s owner=%s decode fallback error=%v", home.ID, owner.ID, err)
+		return baseline
+	}
+	if review.Suggestions == nil {
+		review.Suggestions = []string{}
+	}
+	return review
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/mycelis/core/internal/cognitive"
+)
+
+func reviewerCognitive(t *testing.T, reply string) func(*AdminServer) {
+	return withCognitive(t,
+		map[string]cognitive.ProviderConfig{"ollama": {Type: "openai_compatible", ModelID: "qwen-test", Enabled: true}},
+		map[string]cognitive.LLMProvider{"ollama": &cognitive.MockAdapter{FixedResponse: reply}},
+	)
+}
+
+func TestReviewLoopOutput_UsesSchemaValidatedReview(t *testing.T) {
+	s := newTestServer(reviewerCognitive(t, "```json\n"+`{"status":"attention_needed","flagged_items":1,"findings":["Platform lacks a reviewer."],"suggestions":["Add a reviewer specialist."]}`+"\n```"))
+	home := testReviewLoopHome()
+	owner := LoopOwnerResolution{Type: LoopOwnerTypeTeam, ID: "platform", Name: "Platform"}
+
+	review := s.reviewLoopOutput(home, owner)
+	if review.Status != "attention_needed" || review.FlaggedItems != 1 || len(review.Findings) != 1 || review.Findings[0] != "Platform lacks a reviewer." {
+		t.Fatalf("expected inferred review, got %+v", review)
+	}
+}
+
+func TestReviewLoopOutput_FallsBackWhenReplyNeverMatchesSchema(t *testing.T) {
+	s := newTestServer(reviewerCognitive(t, `{"status":"great","findings":[]}`))
+	home := testReviewLoopHome()
+	owner := LoopOwnerResolution{Type: LoopOwnerTypeTeam, ID: "platform", Name: "Platform"}
+
+	review := s.reviewLoopOutput(home, owner)
+	baseline := buildReviewLoopOutput(home, owner)
+	if review.Status != baseline.Status || len(review.Findings) != len(baseline.Findings) {
+		t.Fatalf("expected deterministic baseline, got %+v", review)
+	}
+}
@@ -161,7 +161,7 @@ func (s *AdminServer) executeReviewLoop(home OrganizationHomePayload, profile Lo
 		OrganizationName: safeOrganizationName(home.Name),
 		Trigger:          trigger,
 		Owner:            owner,
-		Review:           buildReviewLoopOutput(home, owner),
+		Review:           s.reviewLoopOutput(home, owner),
 		ReviewedAt:       time.Now().UTC().Format(time.RFC3339),
 	}
 	result.ActivityStatus, result.ActivitySummary = summarizeActivityFromReview(result.Review)
@@ -7,6 +7,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/mycelis/core/internal/schedule"
 	"github.com/mycelis/core/internal/triggers"
 	"github.com/mycelis/core/pkg/protocol"
 )
@@ -33,34 +34,54 @@ func (ls *LoopScheduler) runDueScheduleTriggersAt(now time.Time) int {
 }
 
 func proposeScheduleRule(ctx context.Context, store *triggers.Store, rule triggers.TriggerRule, now time.Time) bool {
-	if rule.ScheduleIntervalSeconds <= 0 {
+	plan, ok := planScheduleRule(ctx, store, rule, now)
+	if !ok {
+		return false
+	}
+	return proposeScheduleRuleWithHandoffRefs(ctx, store, rule, plan.Fire, plan.Next, "", "", nil)
+}
+
+// planScheduleRule applies the rule's calendar and misfire policy to its due
+// run. It returns false, after recording why, when nothing should be proposed.
+func planScheduleRule(ctx context.Context, store *triggers.Store, rule triggers.TriggerRule, now time.Time) (schedule.Plan, bool) {
+	sched, err := rule.CompiledSchedule()
+	if err != nil {
 		_ = store.LogExecution(ctx, &triggers.TriggerExecution{
 			RuleID:     rule.ID,
 			EventID:    scheduleRuleEventID(rule.ID, now),
 			Status:     "skipped",
-			SkipReason: "schedule_interval_seconds required",
+			SkipReason: err.Error(),
 		})
-		return false
+		return schedule.Plan{}, false
 	}
-	return proposeScheduleRuleWithHandoffRefs(ctx, store, rule, now, "", "", nil)
+	dueAt := scheduleRuleDueAt(rule, now)
+	plan := sched.Plan(dueAt, now)
+	if !plan.Fire.IsZero() {
+		return plan, true
+	}
+	if err := store.RescheduleRule(ctx, rule.ID, plan.Next); err != nil {
+		log.Printf("[schedule-rules] reschedule after misfire failed rule=%s: %v", rule.ID, err)
+		return plan, false
+	}
+	_ = store.LogExecution(ctx, &triggers.TriggerExecution{
+		RuleID:     rule.ID,
+		EventID:    scheduleRuleEventID(rule.ID, dueAt),
+		Status:     "skipped",
+		SkipReason: fmt.Sprintf("misfire: skipped %d missed run(s)", plan.Missed),
+	})
+	return plan, false
 }
 
 func (s *AdminServer) proposeScheduleRuleHandoff(ctx context.Context, rule triggers.TriggerRule, now time.Time) bool {
 	if s == nil || s.Triggers == nil {
 		return false
 	}
-	if rule.ScheduleIntervalSeconds <= 0 {
-		_ = s.Triggers.LogExecution(ctx, &triggers.TriggerExecution{
-			RuleID:     rule.ID,
-			EventID:    scheduleRuleEventID(rule.ID, now),
-			Status:     "skipped",
-			SkipReason: "schedule_interval_seconds required",
-		})
+	plan, ok := planScheduleRule(ctx, s.Triggers, rule, now)
+	if !ok {
 		return false
 	}
 
-	dueAt := scheduleRuleDueAt(rule, now)
-	nextRun := dueAt.Add(time.Duration(rule.ScheduleIntervalSeconds) * time.Second)
+	dueAt, nextRun := plan.Fire, plan.Next
 	handoffKey := scheduleRuleHandoffKey(rule.ID, dueAt)
 	payload := scheduleRuleHandoffPayload(rule, dueAt, nextRun)
 
@@ -108,11 +129,10 @@ func (s *AdminServer) proposeScheduleRuleHandoff(ctx context.Context, rule trigg
 		}
 		return true
 	}
-	return proposeScheduleRuleWithHandoffRefs(ctx, s.Triggers, rule, dueAt, proof.ID, proof.ContractID, payload)
+	return proposeScheduleRuleWithHandoffRefs(ctx, s.Triggers, rule, dueAt, nextRun, proof.ID, proof.ContractID, payload)
 }
 
-func proposeScheduleRuleWithHandoffRefs(ctx context.Context, store *triggers.Store, rule triggers.TriggerRule, proposedAt time.Time, intentProofID, contractID string, payload json.RawMessage) bool {
-	nextRun := proposedAt.Add(time.Duration(rule.ScheduleIntervalSeconds) * time.Second)
+func proposeScheduleRuleWithHandoffRefs(ctx context.Context, store *triggers.Store, rule triggers.TriggerRule, proposedAt, nextRun time.Time, intentProofID, contractID string, payload json.RawMessage) bool {
 	if err := store.MarkScheduleProposed(ctx, rule.ID, proposedAt, nextRun); err != nil {
 		log.Printf("[schedule-rules] mark proposed failed rule=%s: %v", rule.ID, err)
 		return false
@@ -182,13 +202,17 @@ func scheduleRuleScope(rule triggers.TriggerRule) *protocol.ScopeValidation {
 }
 
 func scheduleRuleHandoffPayload(rule triggers.TriggerRule, dueAt, nextRunAt time.Time) json.RawMessage {
+	next := ""
+	if !nextRunAt.IsZero() {
+		next = nextRunAt.UTC().Format(time.RFC3339Nano)
+	}
 	payload, _ := json.Marshal(map[string]any{
 		"trigger_kind":         "schedule",
 		"target_mission_id":    rule.TargetMissionID,
 		"proof_expectations":   rule.ProofExpectations,
 		"recovery_behavior":    rule.RecoveryBehavior,
 		"due_at":               dueAt.UTC().Format(time.RFC3339Nano),
-		"next_run_at":          nextRunAt.UTC().Format(time.RFC3339Nano),
+		"next_run_at":          next,
 		"autonomous_execution": false,
 	})
 	return payload
@@ -7,6 +7,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mycelis/core/internal/registry"
 	"github.com/mycelis/core/internal/triggers"
+	"github.com/mycelis/core/pkg/protocol"
 )
 
 func TestProposeScheduleRule_RecordsProposalAndNextRun(t *testing.T) {
@@ -166,3 +167,37 @@ func TestProposeScheduleRuleHandoff_CompletesPartialExistingHandoff(t *testing.T
 		t.Fatalf("sql expectations: %v", err)
 	}
 }
+
+func TestProposeScheduleRuleHandoff_MisfireSkipAdvancesWithoutProposal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	store := triggers.NewStore(db)
+	s := &AdminServer{DB: db, Registry: &registry.Service{DB: db}, Triggers: store}
+	// Due Monday 08:00; the core was down until Thursday morning.
+	dueAt := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 1, 8, 9, 0, 0, 0, time.UTC)
+	rule := triggers.TriggerRule{
+		ID:              "r-1",
+		TriggerKind:     "schedule",
+		TargetMissionID: "mission-report",
+		Schedule:        &protocol.ScheduleConfig{CronExpr: "0 8 * * mon-fri", Misfire: "skip"},
+		NextRunAt:       &dueAt,
+	}
+
+	mock.ExpectExec("UPDATE trigger_rules SET next_run_at").
+		WithArgs(time.Date(2026, 1, 9, 8, 0, 0, 0, time.UTC), "r-1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO trigger_executions").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if s.proposeScheduleRuleHandoff(t.Context(), rule, now) {
+		t.Fatal("expected missed runs to be skipped")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mycelis/core/internal/secrets"
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+type secretPutRequest struct {
+	Value string `json:"value"`
+}
+
+// GET /api/v1/secrets
+// Lists secret names only; values are write-only through the API.
+func (s *AdminServer) HandleListSecrets(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireRootAdminScope(w, r, "secrets:read"); !ok {
+		return
+	}
+	if !s.secretStoreReady(w) {
+		return
+	}
+	entries, err := s.Secrets.List(r.Context())
+	if err != nil {
+		respondAPIError(w, "Failed to list secrets: "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(entries))
+}
+
+// PUT /api/v1/secrets/{name}
+func (s *AdminServer) HandlePutSecret(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireRootAdminScope(w, r, "secrets:write"); !ok {
+		return
+	}
+	if !s.secretStoreReady(w) {
+		return
+	}
+	name := strings.TrimSpace(r.PathValue("name"))
+	if !secrets.ValidName(name) {
+		respondAPIError(w, "Invalid secret name", http.StatusBadRequest)
+		return
+	}
+	var req secretPutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Value == "" {
+		respondAPIError(w, "Body must be {\"value\": \"...\"}", http.StatusBadRequest)
+		return
+	}
+	if err := s.Secrets.Put(r.Context(), name, req.Value); err != nil {
+		respondAPIError(w, "Failed to store secret: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.auditSecretChange(r, name, "secret_put")
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(map[string]string{"name": name, "ref": secrets.SecretPrefix + name}))
+}
+
+// DELETE /api/v1/secrets/{name}
+func (s *AdminServer) HandleDeleteSecret(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireRootAdminScope(w, r, "secrets:write"); !ok {
+		return
+	}
+	if !s.secretStoreReady(w) {
+		return
+	}
+	name := strings.TrimSpace(r.PathValue("name"))
+	err := s.Secrets.Delete(r.Context(), name)
+	if errors.Is(err, secrets.ErrNotFound) {
+		respondAPIError(w, "Secret not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		respondAPIError(w, "Failed to delete secret: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.auditSecretChange(r, name, "secret_delete")
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(map[string]string{"name": name, "status": "deleted"}))
+}
+
+func (s *AdminServer) secretStoreReady(w http.ResponseWriter) bool {
+	if s.Secrets == nil {
+		respondAPIError(w, "Secret store not configured (set "+secrets.KeyEnv+")", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+func (s *AdminServer) auditSecretChange(r *http.Request, name, action string) {
+	_, _ = s.createAuditEvent(protocol.TemplateChatToProposal, "secrets", "Secret changed: "+name,
+		attachActorIdentity(map[string]any{
+			"actor":         "Operator",
+			"user":          auditUserLabelFromRequest(r),
+			"action":        action,
+			"result_status": "completed",
+			"resource":      name,
+		}, r),
+	)
+}
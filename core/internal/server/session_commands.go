@@ -0,0 +1,161 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+// sessionCommand is a client command on the session socket.
+type sessionCommand struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	RunID      string `json:"run_id"`
+	Message    string `json:"message"`
+	AgentID    string `json:"agent_id"`
+	ApprovalID string `json:"approval_id"`
+	ProposalID string `json:"proposal_id"`
+	Action     string `json:"action"`
+
+	raw []byte
+}
+
+// runSessionCommand serves cmd through the matching REST handler, so the
+// socket and the REST API share validation, governance and responses.
+func (s *AdminServer) runSessionCommand(ss *wsSession, cmd sessionCommand) {
+	var (
+		handler http.HandlerFunc
+		body    any
+		pathID  string
+	)
+	switch cmd.Type {
+	case "chat":
+		handler, body = s.HandleChat, json.RawMessage(cmd.raw)
+	case "interject":
+		handler, pathID = s.HandleRunInterject, cmd.RunID
+		body = map[string]string{"message": cmd.Message, "agent_id": cmd.AgentID}
+	case "approval":
+		handler, pathID = s.handleResolveApproval, cmd.ApprovalID
+		body = map[string]string{"action": strings.ToUpper(cmd.Action)}
+	case "proposal":
+		pathID = cmd.ProposalID
+		switch strings.ToLower(cmd.Action) {
+		case "approve":
+			handler = s.HandleProposalApprove
+		case "reject":
+			handler = s.HandleProposalReject
+		default:
+			ss.deliver(map[string]any{"type": "error", "ref": cmd.ID, "error": "proposal action must be approve or reject"})
+			return
+		}
+	default:
+		ss.deliver(map[string]any{"type": "error", "ref": cmd.ID, "error": "unknown command type " + cmd.Type})
+		return
+	}
+	if cmd.Type != "chat" && strings.TrimSpace(pathID) == "" {
+		ss.deliver(map[string]any{"type": "error", "ref": cmd.ID, "error": cmd.Type + " requires an id"})
+		return
+	}
+
+	payload := []byte("{}")
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	req, err := http.NewRequestWithContext(ss.ctx, http.MethodPost, "/api/v1/ws/"+cmd.Type, bytes.NewReader(payload))
+	if err != nil {
+		ss.deliver(map[string]any{"type": "error", "ref": cmd.ID, "error": err.Error()})
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.SetPathValue("id", pathID)
+
+	rw := &sessionResponseWriter{session: ss, ref: cmd.ID, header: make(http.Header)}
+	handler(rw, req)
+
+	status, response := rw.result()
+	ss.deliver(map[string]any{"type": "result", "ref": cmd.ID, "command": cmd.Type, "status": status, "response": response})
+}
+
+// sessionResponseWriter captures a handler's response. Streaming chat
+// responses arrive as SSE frames, one per Write; deltas are forwarded to
+// the session as they come and the result frame replaces the body.
+type sessionResponseWriter struct {
+	session *wsSession
+	ref     string
+	header  http.Header
+	status  int
+	body    bytes.Buffer
+	sse     bool
+
+	streamed       bool
+	streamStatus   int
+	streamResponse json.RawMessage
+}
+
+func (rw *sessionResponseWriter) Header() http.Header { return rw.header }
+
+func (rw *sessionResponseWriter) WriteHeader(status int) {
+	if rw.status == 0 {
+		rw.status = status
+		rw.sse = strings.HasPrefix(rw.header.Get("Content-Type"), "text/event-stream")
+	}
+}
+
+func (rw *sessionResponseWriter) Write(b []byte) (int, error) {
+	rw.WriteHeader(http.StatusOK)
+	if !rw.sse {
+		return rw.body.Write(b)
+	}
+	var event, data string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(b)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	switch event {
+	case "delta":
+		var delta chatStreamDeltaEvent
+		if json.Unmarshal([]byte(data), &delta) == nil {
+			rw.session.emit(map[string]any{"type": "delta", "ref": rw.ref, "attempt": delta.Attempt, "segment": delta.Segment, "text": delta.Text})
+		}
+	case "result":
+		var result chatStreamResultEvent
+		if json.Unmarshal([]byte(data), &result) == nil {
+			rw.streamed, rw.streamStatus, rw.streamResponse = true, result.Status, result.Response
+		}
+	}
+	return len(b), nil
+}
+
+func (rw *sessionResponseWriter) Flush() {}
+
+// result returns the status and JSON body of the handler's response.
+// Plain-text error bodies are wrapped in the API error envelope.
+func (rw *sessionResponseWriter) result() (int, json.RawMessage) {
+	if rw.streamed {
+		return rw.streamStatus, rw.streamResponse
+	}
+	status := rw.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	body := bytes.TrimSpace(rw.body.Bytes())
+	switch {
+	case len(body) == 0 && status < http.StatusBadRequest:
+		body, _ = json.Marshal(protocol.NewAPISuccess(nil))
+	case !json.Valid(body):
+		body, _ = json.Marshal(protocol.NewAPIError(string(body)))
+	}
+	return status, body
+}
@@ -0,0 +1,251 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mycelis/core/internal/signal"
+	"github.com/mycelis/core/internal/transport/websocket"
+)
+
+const (
+	// sessionResumeWindow is how long a disconnected session, and any
+	// commands it still has running, waits for the client to come back.
+	sessionResumeWindow = 15 * time.Minute
+	// sessionOutboxSize is how many sequenced messages a session keeps for
+	// clients resuming with last_seq.
+	sessionOutboxSize = 256
+	// sessionReadTimeout drops a socket that has sent no frame, including
+	// pongs, for this long; sessionPingInterval keeps quiet ones inside it.
+	sessionReadTimeout  = 75 * time.Second
+	sessionPingInterval = 30 * time.Second
+	// sessionMaxCommands caps the commands one session runs at once.
+	sessionMaxCommands = 8
+)
+
+// SessionHub holds the WebSocket sessions of /api/v1/ws. Sessions outlive
+// their sockets so a client can reconnect and resume.
+type SessionHub struct {
+	mu           sync.Mutex
+	sessions     map[string]*wsSession
+	window       time.Duration
+	readTimeout  time.Duration
+	pingInterval time.Duration
+	maxCommands  int
+	now          func() time.Time
+}
+
+func NewSessionHub() *SessionHub {
+	return &SessionHub{
+		sessions:     make(map[string]*wsSession),
+		window:       sessionResumeWindow,
+		readTimeout:  sessionReadTimeout,
+		pingInterval: sessionPingInterval,
+		maxCommands:  sessionMaxCommands,
+		now:          time.Now,
+	}
+}
+
+// wsSession is one resumable session. Sequenced messages (session, result,
+// error) are kept in the outbox; deltas, events and pongs are not, since
+// stream events resume through the stream's own replay.
+type wsSession struct {
+	id    string
+	owner string
+	ctx   context.Context // carries the owner's identity; cancelled on expiry
+	stop  context.CancelFunc
+	// commands holds a slot for each command in flight.
+	commands chan struct{}
+
+	mu          sync.Mutex
+	conn        *websocket.Conn
+	writer      *socketWriter // writes to conn
+	seq         uint64
+	outbox      []sessionOutboxEntry
+	filter      signal.StreamFilter
+	lastEventID uint64
+	detachedAt  time.Time
+}
+
+type sessionOutboxEntry struct {
+	seq uint64
+	raw []byte
+}
+
+// open starts a new session owned by owner. ctx should carry the request
+// identity but not the request's cancellation.
+func (h *SessionHub) open(ctx context.Context, owner string) *wsSession {
+	h.sweep()
+	sessionCtx, stop := context.WithCancel(ctx)
+	ss := &wsSession{id: uuid.NewString(), owner: owner, ctx: sessionCtx, stop: stop, commands: make(chan struct{}, h.maxCommands)}
+	h.mu.Lock()
+	h.sessions[ss.id] = ss
+	h.mu.Unlock()
+	return ss
+}
+
+// resume returns the owner's session id, or nil when it expired or belongs
+// to someone else.
+func (h *SessionHub) resume(id, owner string) *wsSession {
+	h.sweep()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ss := h.sessions[id]
+	if ss == nil || ss.owner != owner {
+		return nil
+	}
+	return ss
+}
+
+// sweep ends sessions that stayed disconnected past the resume window.
+func (h *SessionHub) sweep() {
+	cutoff := h.now().Add(-h.window)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for id, ss := range h.sessions {
+		ss.mu.Lock()
+		expired := ss.conn == nil && !ss.detachedAt.IsZero() && ss.detachedAt.Before(cutoff)
+		ss.mu.Unlock()
+		if expired {
+			ss.stop()
+			delete(h.sessions, id)
+		}
+	}
+}
+
+// attach makes conn the session's socket, closing any socket it replaces.
+// It queues the session message and then every sequenced message after
+// lastSeq, under the session lock so nothing new slips in between.
+func (ss *wsSession) attach(conn *websocket.Conn, resumed bool, lastSeq uint64) {
+	ss.mu.Lock()
+	previous := ss.conn
+	if ss.writer != nil {
+		ss.writer.stop()
+	}
+	ss.conn = conn
+	ss.writer = newSocketWriter(conn)
+	ss.detachedAt = time.Time{}
+	hello := map[string]any{"type": "session", "session_id": ss.id, "resumed": resumed, "seq": ss.seq}
+	if resumed && lastSeq < ss.seq && (len(ss.outbox) == 0 || ss.outbox[0].seq > lastSeq+1) {
+		// Some results after lastSeq already left the outbox.
+		hello["missed"] = true
+	}
+	if raw, err := json.Marshal(hello); err == nil {
+		ss.writeLocked(raw, nil)
+	}
+	if resumed {
+		for _, entry := range ss.outbox {
+			if entry.seq > lastSeq {
+				ss.writeLocked(entry.raw, nil)
+			}
+		}
+	}
+	ss.mu.Unlock()
+	if previous != nil {
+		previous.Close()
+	}
+}
+
+// detach releases conn if it is still the session's socket.
+func (ss *wsSession) detach(conn *websocket.Conn, at time.Time) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.conn == conn {
+		ss.writer.stop()
+		ss.conn, ss.writer = nil, nil
+		ss.detachedAt = at
+	}
+}
+
+// deliver sends a sequenced message, keeping it for resumes.
+func (ss *wsSession) deliver(fields map[string]any) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.seq++
+	fields["seq"] = ss.seq
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+	ss.outbox = append(ss.outbox, sessionOutboxEntry{seq: ss.seq, raw: raw})
+	if len(ss.outbox) > sessionOutboxSize {
+		ss.outbox = ss.outbox[len(ss.outbox)-sessionOutboxSize:]
+	}
+	ss.writeLocked(raw, nil)
+}
+
+// emit sends a message that is not replayed on resume.
+func (ss *wsSession) emit(fields map[string]any) {
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.writeLocked(raw, nil)
+}
+
+// emitOn sends a message that is not replayed, but only while conn is still
+// the session's socket. Stream pumps use it so a socket that took over the
+// session never sees the previous subscription's events.
+func (ss *wsSession) emitOn(conn *websocket.Conn, fields map[string]any) bool {
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return false
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.conn == conn && ss.writeLocked(raw, nil)
+}
+
+// emitEvent forwards one stream event on conn and records it as delivered
+// once it was written.
+func (ss *wsSession) emitEvent(conn *websocket.Conn, ev signal.StreamEvent) {
+	fields := map[string]any{"type": "event", "id": ev.ID, "event": json.RawMessage(ev.Data)}
+	if !json.Valid([]byte(ev.Data)) {
+		fields["event"] = ev.Data
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.conn == conn {
+		ss.writeLocked(raw, func() { ss.markDelivered(ev.ID) })
+	}
+}
+
+func (ss *wsSession) markDelivered(id uint64) {
+	ss.mu.Lock()
+	if id > ss.lastEventID {
+		ss.lastEventID = id
+	}
+	ss.mu.Unlock()
+}
+
+// writeLocked queues raw for the socket; the network write happens on the
+// socket's writer, never under the session lock.
+func (ss *wsSession) writeLocked(raw []byte, sent func()) bool {
+	if ss.writer == nil {
+		return false
+	}
+	return ss.writer.send(raw, sent)
+}
+
+// streamCursor returns the filter and last delivered event id a resumed
+// session continues from.
+func (ss *wsSession) streamCursor() (signal.StreamFilter, uint64) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.filter, ss.lastEventID
+}
+
+func (ss *wsSession) setFilter(filter signal.StreamFilter) {
+	ss.mu.Lock()
+	ss.filter = filter
+	ss.mu.Unlock()
+}
@@ -0,0 +1,210 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mycelis/core/internal/signal"
+	"github.com/mycelis/core/internal/transport/websocket"
+)
+
+// WebSocket session (GET /api/v1/ws)
+//
+// One authenticated socket carries a Soma conversation, run interjections,
+// approval decisions and the workspace event stream. Browsers authenticate
+// with ?token=. The first client message opens or resumes a session:
+//
+//	{"type":"hello","filter":{"teams":["alpha"],"runs":["..."]}}
+//	{"type":"hello","session_id":"...","last_seq":12,"last_event_id":"..."}
+//
+// and the server answers {"type":"session","session_id","resumed","seq"}.
+// Commands carry a client id that the server echoes as ref:
+//
+//	{"type":"chat","id":"c1","messages":[...],"session_id":"..."}
+//	{"type":"interject","id":"c2","run_id":"...","message":"...","agent_id":"..."}
+//	{"type":"approval","id":"c3","approval_id":"...","action":"APPROVE"}
+//	{"type":"proposal","id":"c4","proposal_id":"...","action":"approve"}
+//	{"type":"ping","id":"c5"}
+//
+// Chat streams {"type":"delta","ref",attempt,segment,text} frames. Every
+// command ends with {"type":"result","ref","command","status","response"},
+// where response is the body the matching REST endpoint returns. Stream
+// events arrive as {"type":"event","id","event"}, and gaps as the same
+// dropped markers /api/v1/stream sends.
+//
+// The server pings every sessionPingInterval and drops a socket that sends
+// no frame for sessionReadTimeout. A session runs at most
+// sessionMaxCommands commands at once; more are refused with an error. A
+// client that falls sessionWriteQueue messages behind is disconnected and
+// can resume.
+//
+// Results and errors are numbered with seq. A client that reconnects within
+// the resume window with its session_id and last_seq gets the ones it
+// missed; commands keep running while it is away. Events resume from
+// last_event_id, or from the last event the session delivered.
+
+// sessionHello is the first client message.
+type sessionHello struct {
+	Type        string               `json:"type"`
+	SessionID   string               `json:"session_id"`
+	LastSeq     uint64               `json:"last_seq"`
+	LastEventID json.Number          `json:"last_event_id"`
+	Filter      *signal.StreamFilter `json:"filter"`
+}
+
+// HandleSessionSocket upgrades to a WebSocket session.
+func (s *AdminServer) HandleSessionSocket(w http.ResponseWriter, r *http.Request) {
+	if s.Sessions == nil {
+		respondAPIError(w, "session hub not initialized", http.StatusServiceUnavailable)
+		return
+	}
+	conn, err := websocket.Upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.SetReadTimeout(s.Sessions.readTimeout)
+	stopPing := make(chan struct{})
+	defer close(stopPing)
+	go pingSessionSocket(conn, s.Sessions.pingInterval, stopPing)
+
+	_, raw, err := conn.ReadMessage()
+	if err != nil {
+		return
+	}
+	var hello sessionHello
+	if err := json.Unmarshal(raw, &hello); err != nil || hello.Type != "hello" {
+		writeSocketError(conn, "", "first message must be a hello")
+		return
+	}
+
+	owner := ""
+	if identity := IdentityFromContext(r.Context()); identity != nil {
+		owner = identity.UserID
+	}
+	var ss *wsSession
+	if hello.SessionID != "" {
+		ss = s.Sessions.resume(hello.SessionID, owner)
+	}
+	resumed := ss != nil
+	if !resumed {
+		// Commands outlive the socket, so they get the identity but not the
+		// request's cancellation.
+		ss = s.Sessions.open(context.WithoutCancel(r.Context()), owner)
+	}
+	if hello.Filter != nil || !resumed {
+		var filter signal.StreamFilter
+		if hello.Filter != nil {
+			filter = *hello.Filter
+		}
+		ss.setFilter(filter)
+	}
+
+	ss.attach(conn, resumed, hello.LastSeq)
+	defer func() { ss.detach(conn, s.Sessions.now()) }()
+
+	if s.Stream != nil {
+		filter, delivered := ss.streamCursor()
+		lastEventID := hello.LastEventID.String()
+		if lastEventID == "" && resumed && delivered > 0 {
+			lastEventID = strconv.FormatUint(delivered, 10)
+		}
+		if sub := s.Stream.Subscribe(filter, lastEventID); sub != nil {
+			done, pumped := make(chan struct{}), make(chan struct{})
+			go func() {
+				defer close(pumped)
+				pumpSessionEvents(ss, conn, sub, done)
+			}()
+			defer func() {
+				close(done)
+				<-pumped
+				sub.Close()
+			}()
+		}
+	}
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			if !errors.Is(err, websocket.ErrClosed) {
+				log.Printf("[ws] session %s: %v", ss.id, err)
+			}
+			return
+		}
+		var cmd sessionCommand
+		if err := json.Unmarshal(raw, &cmd); err != nil {
+			ss.deliver(map[string]any{"type": "error", "error": "invalid JSON"})
+			continue
+		}
+		cmd.raw = raw
+		if cmd.Type == "ping" {
+			ss.emitOn(conn, map[string]any{"type": "pong", "ref": cmd.ID})
+			continue
+		}
+		select {
+		case ss.commands <- struct{}{}:
+			go func() {
+				defer func() { <-ss.commands }()
+				s.runSessionCommand(ss, cmd)
+			}()
+		default:
+			ss.deliver(map[string]any{"type": "error", "ref": cmd.ID, "error": "too many commands in flight; wait for a result"})
+		}
+	}
+}
+
+// pingSessionSocket pings conn every interval until stop.
+func pingSessionSocket(conn *websocket.Conn, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := conn.WritePing(nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// pumpSessionEvents forwards stream events to conn until done.
+func pumpSessionEvents(ss *wsSession, conn *websocket.Conn, sub *signal.Subscription, done <-chan struct{}) {
+	if sub.Gap != nil {
+		ss.emitOn(conn, droppedFields(*sub.Gap))
+	}
+	for _, ev := range sub.Backlog {
+		ss.emitEvent(conn, ev)
+	}
+	for {
+		select {
+		case <-done:
+			return
+		case ev := <-sub.Events():
+			ss.emitEvent(conn, ev)
+			if gap := sub.Dropped(); gap != nil {
+				ss.emitOn(conn, droppedFields(*gap))
+			}
+		}
+	}
+}
+
+func droppedFields(gap signal.DroppedMarker) map[string]any {
+	fields := map[string]any{"type": gap.Type, "reason": gap.Reason, "last_id": gap.LastID}
+	if gap.Count > 0 {
+		fields["count"] = gap.Count
+		fields["first_id"] = gap.FirstID
+	}
+	return fields
+}
+
+func writeSocketError(conn *websocket.Conn, ref, msg string) {
+	raw, _ := json.Marshal(map[string]any{"type": "error", "ref": ref, "error": msg})
+	_ = conn.WriteMessage(websocket.TextMessage, raw)
+}
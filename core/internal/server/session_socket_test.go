@@ -0,0 +1,249 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mycelis/core/internal/signal"
+	"github.com/mycelis/core/internal/transport/websocket"
+)
+
+// startSessionSocket serves HandleSessionSocket as the user named in the
+// X-Test-User header.
+func startSessionSocket(t *testing.T, s *AdminServer) string {
+	t.Helper()
+	srv := newLocalHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity := localAdminIdentityForTest()
+		identity.UserID = r.Header.Get("X-Test-User")
+		s.HandleSessionSocket(w, r.WithContext(context.WithValue(r.Context(), ctxKeyIdentity, identity)))
+	}))
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func dialSession(t *testing.T, addr, user string, hello map[string]any) (*websocket.Conn, map[string]any) {
+	t.Helper()
+	conn, err := websocket.Dial(addr, "/api/v1/ws", http.Header{"X-Test-User": {user}})
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	hello["type"] = "hello"
+	sendSocket(t, conn, hello)
+	session := readSocket(t, conn)
+	if session["type"] != "session" {
+		t.Fatalf("first message = %v, want session", session)
+	}
+	return conn, session
+}
+
+func sendSocket(t *testing.T, conn *websocket.Conn, msg map[string]any) {
+	t.Helper()
+	raw, _ := json.Marshal(msg)
+	if err := conn.WriteMessage(websocket.TextMessage, raw); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func readSocket(t *testing.T, conn *websocket.Conn) map[string]any {
+	t.Helper()
+	type frame struct {
+		msg map[string]any
+		err error
+	}
+	got := make(chan frame, 1)
+	go func() {
+		_, raw, err := conn.ReadMessage()
+		var msg map[string]any
+		if err == nil {
+			err = json.Unmarshal(raw, &msg)
+		}
+		got <- frame{msg, err}
+	}()
+	select {
+	case f := <-got:
+		if f.err != nil {
+			t.Fatalf("read: %v", f.err)
+		}
+		return f.msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for socket message")
+		return nil
+	}
+}
+
+func newSessionTestServer() *AdminServer {
+	return newTestServer(func(s *AdminServer) {
+		s.Sessions = NewSessionHub()
+		s.Stream = signal.NewStreamHandler()
+		s.Proposals = NewProposalStore()
+	})
+}
+
+func TestSessionSocket_RoutesCommandsAndEvents(t *testing.T) {
+	s := newSessionTestServer()
+	addr := startSessionSocket(t, s)
+	conn, _ := dialSession(t, addr, "user-1", map[string]any{"filter": map[string]any{"teams": []string{"alpha"}}})
+
+	s.Stream.Broadcast(`{"type": "run_started", "team_id": "beta"}`)
+	s.Stream.Broadcast(`{"type": "run_started", "team_id": "alpha"}`)
+	ev := readSocket(t, conn)
+	if ev["type"] != "event" || ev["event"].(map[string]any)["team_id"] != "alpha" {
+		t.Fatalf("event = %v, want the alpha run", ev)
+	}
+
+	proposal := s.Proposals.List()[0]
+	sendSocket(t, conn, map[string]any{"type": "proposal", "id": "p1", "proposal_id": proposal.ID, "action": "approve"})
+	result := readSocket(t, conn)
+	if result["type"] != "result" || result["ref"] != "p1" || result["status"] != float64(http.StatusOK) || result["seq"] != float64(1) {
+		t.Fatalf("result = %v", result)
+	}
+	if got, _ := s.Proposals.Get(proposal.ID); got.Status != ProposalApproved {
+		t.Fatalf("proposal status = %s, want approved", got.Status)
+	}
+
+	sendSocket(t, conn, map[string]any{"type": "approval", "id": "a1"})
+	if msg := readSocket(t, conn); msg["type"] != "error" || msg["ref"] != "a1" {
+		t.Fatalf("approval without id = %v, want error", msg)
+	}
+	sendSocket(t, conn, map[string]any{"type": "ping", "id": "k"})
+	if msg := readSocket(t, conn); msg["type"] != "pong" || msg["ref"] != "k" {
+		t.Fatalf("ping reply = %v", msg)
+	}
+}
+
+func TestSessionSocket_ResumesMissedResultsAndEvents(t *testing.T) {
+	s := newSessionTestServer()
+	addr := startSessionSocket(t, s)
+	conn, session := dialSession(t, addr, "user-1", map[string]any{})
+	id := session["session_id"].(string)
+
+	proposals := s.Proposals.List()
+	sendSocket(t, conn, map[string]any{"type": "proposal", "id": "p1", "proposal_id": proposals[0].ID, "action": "approve"})
+	readSocket(t, conn)
+	sendSocket(t, conn, map[string]any{"type": "proposal", "id": "p2", "proposal_id": proposals[1].ID, "action": "reject"})
+	readSocket(t, conn)
+	s.Stream.Broadcast(`{"type": "run_started", "n": 1}`)
+	lastEvent := readSocket(t, conn)["id"]
+	conn.Close()
+
+	s.Stream.Broadcast(`{"type": "run_completed", "n": 2}`)
+	resumed, session := dialSession(t, addr, "user-1", map[string]any{"session_id": id, "last_seq": 1, "last_event_id": lastEvent})
+	if session["resumed"] != true || session["session_id"] != id {
+		t.Fatalf("session = %v, want resumed %s", session, id)
+	}
+	var sawResult, sawEvent bool
+	for i := 0; i < 2; i++ {
+		msg := readSocket(t, resumed)
+		switch msg["type"] {
+		case "result":
+			sawResult = msg["ref"] == "p2" && msg["seq"] == float64(2)
+		case "event":
+			sawEvent = msg["event"].(map[string]any)["n"] == float64(2)
+		}
+	}
+	if !sawResult || !sawEvent {
+		t.Fatalf("resume replay: result=%v event=%v", sawResult, sawEvent)
+	}
+
+	_, other := dialSession(t, addr, "user-2", map[string]any{"session_id": id})
+	if other["resumed"] != false || other["session_id"] == id {
+		t.Fatalf("another user resumed the session: %v", other)
+	}
+}
+
+func TestSessionSocket_RefusesCommandsOverTheInFlightCap(t *testing.T) {
+	s := newSessionTestServer()
+	s.Sessions.maxCommands = 1
+	addr := startSessionSocket(t, s)
+	conn, session := dialSession(t, addr, "user-1", map[string]any{})
+
+	s.Sessions.mu.Lock()
+	ss := s.Sessions.sessions[session["session_id"].(string)]
+	s.Sessions.mu.Unlock()
+	ss.commands <- struct{}{} // a command still running
+
+	proposal := s.Proposals.List()[0]
+	sendSocket(t, conn, map[string]any{"type": "proposal", "id": "p1", "proposal_id": proposal.ID, "action": "approve"})
+	if msg := readSocket(t, conn); msg["type"] != "error" || msg["ref"] != "p1" {
+		t.Fatalf("command over the cap = %v, want error", msg)
+	}
+
+	<-ss.commands
+	sendSocket(t, conn, map[string]any{"type": "proposal", "id": "p2", "proposal_id": proposal.ID, "action": "approve"})
+	if msg := readSocket(t, conn); msg["type"] != "result" || msg["ref"] != "p2" {
+		t.Fatalf("command after a slot freed = %v, want result", msg)
+	}
+}
+
+func TestSessionSocket_DropsSilentSockets(t *testing.T) {
+	s := newSessionTestServer()
+	s.Sessions.readTimeout = 100 * time.Millisecond
+	addr := startSessionSocket(t, s)
+	conn, err := websocket.Dial(addr, "/api/v1/ws", http.Header{"X-Test-User": {"user-1"}})
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	closed := make(chan error, 1)
+	go func() {
+		_, _, err := conn.ReadMessage()
+		closed <- err
+	}()
+	select {
+	case err := <-closed:
+		if err == nil {
+			t.Fatal("expected the server to close a socket that never sent hello")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("silent socket was never dropped")
+	}
+}
+
+func TestSessionSocket_DisconnectsClientsThatStopReading(t *testing.T) {
+	s := newSessionTestServer()
+	addr := startSessionSocket(t, s)
+	_, session := dialSession(t, addr, "user-1", map[string]any{})
+	id := session["session_id"].(string)
+	ss := s.Sessions.resume(id, "user-1")
+
+	// The client never reads again: deliveries must not block on it, nor
+	// must the hub for other sessions.
+	chunk := strings.Repeat("x", 16<<10)
+	delivered := make(chan struct{})
+	go func() {
+		defer close(delivered)
+		for i := 0; i < 4*sessionWriteQueue; i++ {
+			ss.deliver(map[string]any{"type": "result", "response": chunk})
+		}
+	}()
+	select {
+	case <-delivered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("deliver blocked on a client that stopped reading")
+	}
+	if other := s.Sessions.open(context.Background(), "user-2"); other == nil {
+		t.Fatal("open failed")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		ss.mu.Lock()
+		detached := ss.conn == nil
+		ss.mu.Unlock()
+		if detached {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("stalled socket was never dropped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if s.Sessions.resume(id, "user-1") == nil {
+		t.Fatal("dropped session should stay resumable")
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import "github.com/mycelis/core/internal/transport/websocket"
+
+// sessionWriteQueue is how many messages may wait for a session's socket.
+// A client that falls further behind is disconnected and can resume.
+const sessionWriteQueue = 2 * sessionOutboxSize
+
+// socketWriter owns the message writes to one session socket. Messages are
+// queued under the session lock and written by the writer's goroutine, so
+// a client that stops reading never holds the session lock, or the hub
+// sweeping it, on a network write.
+type socketWriter struct {
+	conn    *websocket.Conn
+	queue   chan queuedMessage
+	dropped bool // guarded by the session lock
+}
+
+type queuedMessage struct {
+	raw  []byte
+	sent func() // called once raw was written, if set
+}
+
+func newSocketWriter(conn *websocket.Conn) *socketWriter {
+	w := &socketWriter{conn: conn, queue: make(chan queuedMessage, sessionWriteQueue)}
+	go w.run()
+	return w
+}
+
+func (w *socketWriter) run() {
+	for msg := range w.queue {
+		if err := w.conn.WriteMessage(websocket.TextMessage, msg.raw); err != nil {
+			// Closing ends the socket's read loop, which detaches it.
+			w.conn.Close()
+			for range w.queue {
+			}
+			return
+		}
+		if msg.sent != nil {
+			msg.sent()
+		}
+	}
+}
+
+// send queues raw for the socket. When the queue is full the socket is
+// closed instead and send reports false from then on. Callers hold the
+// session lock.
+func (w *socketWriter) send(raw []byte, sent func()) bool {
+	if w.dropped {
+		return false
+	}
+	select {
+	case w.queue <- queuedMessage{raw: raw, sent: sent}:
+		return true
+	default:
+		w.dropped = true
+		go w.conn.Close()
+		return false
+	}
+}
+
+// stop ends the writer goroutine once it has drained the queue. Callers
+// hold the session lock and drop their reference to w.
+func (w *socketWriter) stop() { close(w.queue) }
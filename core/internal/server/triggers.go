@@ -11,9 +11,11 @@ package server
 //   DELETE /api/v1/triggers/{id}         — delete a rule
 //   POST   /api/v1/triggers/{id}/toggle  — activate/deactivate
 //   GET    /api/v1/triggers/{id}/history — execution history
+//   POST   /api/v1/triggers/{id}/test    — dry-run against a stored event
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 
@@ -23,20 +25,21 @@ import (
 
 // triggerRuleRequest is the JSON body for create/update.
 type triggerRuleRequest struct {
-	Name                    string          `json:"name"`
-	Description             string          `json:"description,omitempty"`
-	TriggerKind             string          `json:"trigger_kind"`
-	EventPattern            string          `json:"event_pattern"`
-	Condition               json.RawMessage `json:"condition"`
-	TargetMissionID         string          `json:"target_mission_id"`
-	Mode                    string          `json:"mode"`
-	CooldownSeconds         int             `json:"cooldown_seconds"`
-	ScheduleIntervalSeconds int             `json:"schedule_interval_seconds"`
-	ProofExpectations       string          `json:"proof_expectations"`
-	RecoveryBehavior        string          `json:"recovery_behavior"`
-	MaxDepth                int             `json:"max_depth"`
-	MaxActiveRuns           int             `json:"max_active_runs"`
-	IsActive                bool            `json:"is_active"`
+	Name                    string                   `json:"name"`
+	Description             string                   `json:"description,omitempty"`
+	TriggerKind             string                   `json:"trigger_kind"`
+	EventPattern            string                   `json:"event_pattern"`
+	Condition               json.RawMessage          `json:"condition"`
+	TargetMissionID         string                   `json:"target_mission_id"`
+	Mode                    string                   `json:"mode"`
+	CooldownSeconds         int                      `json:"cooldown_seconds"`
+	ScheduleIntervalSeconds int                      `json:"schedule_interval_seconds"`
+	Schedule                *protocol.ScheduleConfig `json:"schedule,omitempty"`
+	ProofExpectations       string                   `json:"proof_expectations"`
+	RecoveryBehavior        string                   `json:"recovery_behavior"`
+	MaxDepth                int                      `json:"max_depth"`
+	MaxActiveRuns           int                      `json:"max_active_runs"`
+	IsActive                bool                     `json:"is_active"`
 }
 
 // GET /api/v1/triggers
@@ -96,6 +99,7 @@ func (s *AdminServer) HandleCreateTrigger(w http.ResponseWriter, r *http.Request
 		Mode:                    req.Mode,
 		CooldownSeconds:         req.CooldownSeconds,
 		ScheduleIntervalSeconds: req.ScheduleIntervalSeconds,
+		Schedule:                req.Schedule,
 		ProofExpectations:       req.ProofExpectations,
 		RecoveryBehavior:        req.RecoveryBehavior,
 		MaxDepth:                req.MaxDepth,
@@ -105,7 +109,7 @@ func (s *AdminServer) HandleCreateTrigger(w http.ResponseWriter, r *http.Request
 
 	if err := s.Triggers.Create(r.Context(), rule); err != nil {
 		log.Printf("HandleCreateTrigger: %v", err)
-		respondAPIError(w, "Failed to create trigger: "+err.Error(), http.StatusInternalServerError)
+		respondAPIError(w, "Failed to create trigger: "+err.Error(), triggerWriteStatus(err))
 		return
 	}
 
@@ -150,6 +154,7 @@ func (s *AdminServer) HandleUpdateTrigger(w http.ResponseWriter, r *http.Request
 		Mode:                    req.Mode,
 		CooldownSeconds:         req.CooldownSeconds,
 		ScheduleIntervalSeconds: req.ScheduleIntervalSeconds,
+		Schedule:                req.Schedule,
 		ProofExpectations:       req.ProofExpectations,
 		RecoveryBehavior:        req.RecoveryBehavior,
 		MaxDepth:                req.MaxDepth,
@@ -159,7 +164,7 @@ func (s *AdminServer) HandleUpdateTrigger(w http.ResponseWriter, r *http.Request
 
 	if err := s.Triggers.Update(r.Context(), rule); err != nil {
 		log.Printf("HandleUpdateTrigger: %v", err)
-		respondAPIError(w, "Failed to update trigger: "+err.Error(), http.StatusInternalServerError)
+		respondAPIError(w, "Failed to update trigger: "+err.Error(), triggerWriteStatus(err))
 		return
 	}
 
@@ -252,3 +257,13 @@ func (s *AdminServer) HandleTriggerHistory(w http.ResponseWriter, r *http.Reques
 
 	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(execs))
 }
+
+// triggerWriteStatus maps rule validation failures to 400 and everything
+// else to 500.
+func triggerWriteStatus(err error) int {
+	var invalid *triggers.InvalidRuleError
+	if errors.As(err, &invalid) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+// POST /api/v1/triggers/{id}/test
+// Body: {"event_id": "...", "condition": {...}}. condition is optional and
+// replaces the stored one, so a draft can be tried before it is saved.
+// Nothing fires; the response is the pattern match and condition trace.
+func (s *AdminServer) HandleTestTrigger(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		respondAPIError(w, "Missing trigger ID", http.StatusBadRequest)
+		return
+	}
+	if s.Triggers == nil || s.TriggerEngine == nil {
+		respondAPIError(w, "Trigger engine not initialized", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req struct {
+		EventID   string          `json:"event_id"`
+		Condition json.RawMessage `json:"condition"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondAPIError(w, "Bad JSON", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.EventID) == "" {
+		respondAPIError(w, "event_id is required", http.StatusBadRequest)
+		return
+	}
+
+	rule, err := s.Triggers.Get(r.Context(), id)
+	if err != nil {
+		log.Printf("HandleTestTrigger: %v", err)
+		respondAPIError(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	if rule == nil {
+		respondAPIError(w, "Trigger not found", http.StatusNotFound)
+		return
+	}
+
+	result, err := s.TriggerEngine.ExplainRule(r.Context(), rule, req.EventID, req.Condition)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondAPIError(w, "Event not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("HandleTestTrigger: %v", err)
+		respondAPIError(w, "Failed to evaluate trigger: "+err.Error(), triggerWriteStatus(err))
+		return
+	}
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(result))
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mycelis/core/internal/events"
+	"github.com/mycelis/core/internal/triggers"
+)
+
+func withTriggerEngine(t *testing.T) (func(*AdminServer), sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock (triggers): %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return func(s *AdminServer) {
+		s.Triggers = triggers.NewStore(db)
+		s.TriggerEngine = triggers.NewEngine(s.Triggers, events.NewStore(db, nil), nil, nil)
+	}, mock
+}
+
+func expectTriggerRule(mock sqlmock.Sqlmock, condition string) {
+	now := time.Now()
+	mock.ExpectQuery("SELECT .+ FROM trigger_rules").
+		WithArgs("r-1").
+		WillReturnRows(sqlmock.NewRows(triggerRuleColumns).
+			AddRow("r-1", "default", "Big orders", "", "event", "mission.completed",
+				[]byte(condition), "m-target", "propose", 60, 5, 3, true, nil, 0, nil, "", "", nil, now, now))
+}
+
+func expectMissionEvent(mock sqlmock.Sqlmock, payload string) {
+	mock.ExpectQuery("SELECT .+ FROM mission_events").
+		WithArgs("ev-1").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "run_id", "tenant_id", "event_type", "severity",
+			"source_agent", "source_team", "payload", "audit_event_id", "emitted_at",
+		}).AddRow("ev-1", "", "default", "mission.completed", "info", "soma", "sales", payload, "", time.Now()))
+}
+
+func TestHandleTestTrigger_ReturnsTrace(t *testing.T) {
+	opt, mock := withTriggerEngine(t)
+	s := newTestServer(opt)
+	expectTriggerRule(mock, `{"field": "payload.amount", "op": "gt", "value": 1000}`)
+	expectMissionEvent(mock, `{"amount": 250}`)
+
+	mux := setupMux(t, "POST /api/v1/triggers/{id}/test", s.HandleTestTrigger)
+	rr := doRequest(t, mux, "POST", "/api/v1/triggers/r-1/test", `{"event_id": "ev-1"}`)
+	assertStatus(t, rr, http.StatusOK)
+
+	var resp struct {
+		Data triggers.RuleEvaluation `json:"data"`
+	}
+	assertJSON(t, rr, &resp)
+	if !resp.Data.PatternMatched || resp.Data.Matched {
+		t.Fatalf("evaluation = %+v", resp.Data)
+	}
+	if resp.Data.Condition.Reason != "payload.amount was 250, want gt 1000" {
+		t.Errorf("reason = %q", resp.Data.Condition.Reason)
+	}
+	if len(resp.Data.Condition.Steps) != 1 || resp.Data.Condition.Steps[0].Actual != 250.0 {
+		t.Errorf("steps = %+v", resp.Data.Condition.Steps)
+	}
+}
+
+func TestHandleTestTrigger_DraftCondition(t *testing.T) {
+	opt, mock := withTriggerEngine(t)
+	s := newTestServer(opt)
+	expectTriggerRule(mock, `{}`)
+	expectMissionEvent(mock, `{"amount": 250}`)
+
+	mux := setupMux(t, "POST /api/v1/triggers/{id}/test", s.HandleTestTrigger)
+	rr := doRequest(t, mux, "POST", "/api/v1/triggers/r-1/test",
+		`{"event_id": "ev-1", "condition": {"field": "amount", "op": "<", "value": 500}}`)
+	assertStatus(t, rr, http.StatusOK)
+
+	var resp struct {
+		Data triggers.RuleEvaluation `json:"data"`
+	}
+	assertJSON(t, rr, &resp)
+	if !resp.Data.Matched {
+		t.Fatalf("evaluation = %+v", resp.Data)
+	}
+}
+
+func TestHandleTestTrigger_Errors(t *testing.T) {
+	opt, mock := withTriggerEngine(t)
+	s := newTestServer(opt)
+	mux := setupMux(t, "POST /api/v1/triggers/{id}/test", s.HandleTestTrigger)
+
+	rr := doRequest(t, mux, "POST", "/api/v1/triggers/r-1/test", `{}`)
+	assertStatus(t, rr, http.StatusBadRequest)
+
+	expectTriggerRule(mock, `{}`)
+	rr = doRequest(t, mux, "POST", "/api/v1/triggers/r-1/test",
+		`{"event_id": "ev-1", "condition": {"field": "amount", "op": "approx", "value": 1}}`)
+	assertStatus(t, rr, http.StatusBadRequest)
+
+	expectTriggerRule(mock, `{}`)
+	mock.ExpectQuery("SELECT .+ FROM mission_events").WillReturnRows(sqlmock.NewRows(nil))
+	rr = doRequest(t, mux, "POST", "/api/v1/triggers/r-1/test", `{"event_id": "ev-missing"}`)
+	assertStatus(t, rr, http.StatusNotFound)
+}
+
+func TestHandleCreateTrigger_InvalidConditionIsBadRequest(t *testing.T) {
+	tsOpt, _ := withTriggerStore(t)
+	s := newTestServer(tsOpt)
+
+	body := `{
+		"name": "Bad condition",
+		"event_pattern": "mission.completed",
+		"target_mission_id": "m-1",
+		"condition": {"field": "payload.ticket", "op": "matches", "value": "("}
+	}`
+	mux := setupMux(t, "POST /api/v1/triggers", s.HandleCreateTrigger)
+	rr := doRequest(t, mux, "POST", "/api/v1/triggers", body)
+	assertStatus(t, rr, http.StatusBadRequest)
+}
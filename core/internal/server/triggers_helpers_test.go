@@ -11,7 +11,7 @@ var triggerRuleColumns = []string{
 	"id", "tenant_id", "name", "description", "trigger_kind", "event_pattern",
 	"condition", "target_mission_id", "mode", "cooldown_seconds",
 	"max_depth", "max_active_runs", "is_active", "last_fired_at",
-	"schedule_interval_seconds", "next_run_at", "proof_expectations", "recovery_behavior",
+	"schedule_interval_seconds", "next_run_at", "proof_expectations", "recovery_behavior", "schedule_spec",
 	"created_at", "updated_at",
 }
 
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mycelis/core/internal/usage"
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+// usageSummaryPayload is the data envelope for usage aggregation endpoints.
+type usageSummaryPayload struct {
+	GroupBy string         `json:"group_by,omitempty"`
+	Buckets []usage.Bucket `json:"buckets"`
+}
+
+// HandleUsageSummary aggregates the inference usage ledger.
+// GET /api/v1/usage?group_by=run|team|agent|organization|provider|model|profile|day
+// Optional filters: organization_id, team_id, agent_id, run_id, provider_id,
+// model_id, profile, since, until (RFC3339).
+func (s *AdminServer) HandleUsageSummary(w http.ResponseWriter, r *http.Request) {
+	if s.Usage == nil {
+		respondAPIError(w, "usage ledger not initialized", http.StatusServiceUnavailable)
+		return
+	}
+	params := r.URL.Query()
+	q := usage.Query{
+		GroupBy:        strings.TrimSpace(params.Get("group_by")),
+		OrganizationID: strings.TrimSpace(params.Get("organization_id")),
+		TeamID:         strings.TrimSpace(params.Get("team_id")),
+		AgentID:        strings.TrimSpace(params.Get("agent_id")),
+		RunID:          strings.TrimSpace(params.Get("run_id")),
+		ProviderID:     strings.TrimSpace(params.Get("provider_id")),
+		ModelID:        strings.TrimSpace(params.Get("model_id")),
+		Profile:        strings.TrimSpace(params.Get("profile")),
+	}
+	if !usage.ValidGroupBy(q.GroupBy) {
+		respondAPIError(w, "unsupported group_by: use run, team, agent, organization, provider, model, profile, or day", http.StatusBadRequest)
+		return
+	}
+	var ok bool
+	if q.Since, ok = parseUsageTime(w, params.Get("since"), "since"); !ok {
+		return
+	}
+	if q.Until, ok = parseUsageTime(w, params.Get("until"), "until"); !ok {
+		return
+	}
+
+	buckets, err := s.Usage.Summarize(r.Context(), q)
+	if err != nil {
+		respondAPIError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(usageSummaryPayload{GroupBy: q.GroupBy, Buckets: buckets}))
+}
+
+// HandleGetRunUsage returns what one run cost, broken down by agent.
+// GET /api/v1/runs/{id}/usage
+func (s *AdminServer) HandleGetRunUsage(w http.ResponseWriter, r *http.Request) {
+	runID := strings.TrimSpace(r.PathValue("id"))
+	if runID == "" {
+		respondAPIError(w, "run_id is required", http.StatusBadRequest)
+		return
+	}
+	if s.Usage == nil {
+		respondAPIError(w, "usage ledger not initialized", http.StatusServiceUnavailable)
+		return
+	}
+
+	totals, err := s.Usage.Summarize(r.Context(), usage.Query{RunID: runID})
+	if err != nil {
+		respondAPIError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	byAgent, err := s.Usage.Summarize(r.Context(), usage.Query{RunID: runID, GroupBy: "agent"})
+	if err != nil {
+		respondAPIError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondAPIJSON(w, http.StatusOK, protocol.NewAPISuccess(map[string]any{
+		"run_id":   runID,
+		"totals":   totals,
+		"by_agent": byAgent,
+	}))
+}
+
+func parseUsageTime(w http.ResponseWriter, raw, name string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, true
+	}
+	parsed, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		respondAPIError(w, "invalid "+name+": expected RFC3339 timestamp", http.StatusBadRequest)
+		return time.Time{}, false
+	}
+	return parsed, true
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mycelis/core/internal/usage"
+)
+
+// withUsageLedger wires a real usage.Ledger (backed by sqlmock) onto the server.
+func withUsageLedger(t *testing.T) (func(*AdminServer), sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock (usage): %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return func(s *AdminServer) {
+		s.Usage = usage.NewLedger(db)
+	}, mock
+}
+
+func usageRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"bucket", "currency", "calls", "prompt", "completion", "total", "estimated", "cost"})
+}
+
+// ── GET /api/v1/usage ──────────────────────────────────────────────
+
+func TestHandleUsageSummary_GroupByTeam(t *testing.T) {
+	opt, mock := withUsageLedger(t)
+	s := newTestServer(opt)
+
+	mock.ExpectQuery(`SELECT COALESCE\(team_id, ''\) AS bucket.+WHERE organization_id = \$1`).
+		WithArgs("org-1").
+		WillReturnRows(usageRows().AddRow("council-core", "USD", 4, 800, 200, 1000, 0, 0.5))
+
+	mux := setupMux(t, "GET /api/v1/usage", s.HandleUsageSummary)
+	rr := doRequest(t, mux, "GET", "/api/v1/usage?group_by=team&organization_id=org-1", "")
+
+	assertStatus(t, rr, http.StatusOK)
+	var resp struct {
+		OK   bool `json:"ok"`
+		Data struct {
+			GroupBy string         `json:"group_by"`
+			Buckets []usage.Bucket `json:"buckets"`
+		} `json:"data"`
+	}
+	assertJSON(t, rr, &resp)
+	if resp.Data.GroupBy != "team" || len(resp.Data.Buckets) != 1 {
+		t.Fatalf("unexpected payload %+v", resp.Data)
+	}
+	if b := resp.Data.Buckets[0]; b.Key != "council-core" || b.TotalTokens != 1000 || b.Cost != 0.5 {
+		t.Errorf("unexpected bucket %+v", b)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestHandleUsageSummary_BadParams(t *testing.T) {
+	opt, _ := withUsageLedger(t)
+	s := newTestServer(opt)
+	mux := setupMux(t, "GET /api/v1/usage", s.HandleUsageSummary)
+
+	rr := doRequest(t, mux, "GET", "/api/v1/usage?group_by=week", "")
+	assertStatus(t, rr, http.StatusBadRequest)
+
+	rr = doRequest(t, mux, "GET", "/api/v1/usage?since=yesterday", "")
+	assertStatus(t, rr, http.StatusBadRequest)
+}
+
+func TestHandleUsageSummary_NilLedger(t *testing.T) {
+	s := newTestServer()
+	mux := setupMux(t, "GET /api/v1/usage", s.HandleUsageSummary)
+	rr := doRequest(t, mux, "GET", "/api/v1/usage", "")
+	assertStatus(t, rr, http.StatusServiceUnavailable)
+}
+
+// ── GET /api/v1/runs/{id}/usage ────────────────────────────────────
+
+func TestHandleGetRunUsage(t *testing.T) {
+	opt, mock := withUsageLedger(t)
+	s := newTestServer(opt)
+
+	mock.ExpectQuery(`SELECT 'total' AS bucket.+WHERE run_id = \$1`).
+		WithArgs("run-1").
+		WillReturnRows(usageRows().AddRow("total", "USD", 3, 300, 90, 390, 1, 0.02))
+	mock.ExpectQuery(`SELECT COALESCE\(agent_id, ''\) AS bucket.+WHERE run_id = \$1`).
+		WithArgs("run-1").
+		WillReturnRows(usageRows().
+			AddRow("architect", "USD", 2, 200, 60, 260, 0, 0.015).
+			AddRow("coder", "USD", 1, 100, 30, 130, 1, 0.005))
+
+	mux := setupMux(t, "GET /api/v1/runs/{id}/usage", s.HandleGetRunUsage)
+	rr := doRequest(t, mux, "GET", "/api/v1/runs/run-1/usage", "")
+
+	assertStatus(t, rr, http.StatusOK)
+	var resp struct {
+		Data struct {
+			RunID   string         `json:"run_id"`
+			Totals  []usage.Bucket `json:"totals"`
+			ByAgent []usage.Bucket `json:"by_agent"`
+		} `json:"data"`
+	}
+	assertJSON(t, rr, &resp)
+	if resp.Data.RunID != "run-1" || len(resp.Data.Totals) != 1 || len(resp.Data.ByAgent) != 2 {
+		t.Fatalf("unexpected payload %+v", resp.Data)
+	}
+	if resp.Data.Totals[0].EstimatedCalls != 1 {
+		t.Errorf("expected estimated call count to survive, got %+v", resp.Data.Totals[0])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
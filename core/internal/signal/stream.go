@@ -5,25 +5,57 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/mycelis/core/internal/memory"
 )
 
-// StreamHandler manages SSE connections and broadcasts events
+const (
+	// DefaultReplayBuffer is how many recent events are kept for clients
+	// reconnecting with Last-Event-ID.
+	DefaultReplayBuffer = 1024
+	// clientBuffer is how many events may queue for a slow client before it
+	// starts missing them.
+	clientBuffer = 64
+)
+
+// StreamHandler manages SSE connections and broadcasts events. Every event
+// gets an increasing id; the most recent ones are kept so reconnecting
+// clients can resume where they left off.
 type StreamHandler struct {
-	clients map[chan string]bool
+	clients map[*streamClient]bool
 	mu      sync.RWMutex
+	base    uint64 // ids issued by this handler are greater than base
+	lastID  uint64
+	history []StreamEvent
+	limit   int
+}
+
+// StreamEvent is one broadcast message and its stream id.
+type StreamEvent struct {
+	ID   uint64
+	Data string
+	meta eventMeta
 }
 
 func NewStreamHandler() *StreamHandler {
+	// Ids start from the clock so they keep increasing across restarts and
+	// a Last-Event-ID from an earlier process reads as outside the buffer.
+	base := uint64(time.Now().UnixMicro())
 	return &StreamHandler{
-		clients: make(map[chan string]bool),
+		clients: make(map[*streamClient]bool),
+		base:    base,
+		lastID:  base,
+		limit:   DefaultReplayBuffer,
 	}
 }
 
-// HandleStream handles the SSE connection
+// HandleStream handles the SSE connection. The type, team, run and
+// organization query parameters filter events; Last-Event-ID (or the
+// last_event_id parameter) replays what the client missed.
 func (s *StreamHandler) HandleStream(w http.ResponseWriter, r *http.Request) {
 	// Verify the ResponseWriter supports streaming
 	flusher, ok := w.(http.Flusher)
@@ -32,15 +64,23 @@ func (s *StreamHandler) HandleStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	sub := s.Subscribe(ParseStreamFilter(r.URL.Query()), lastEventID)
 	// Guard: if clients map is nil (zero-value struct), reject gracefully
-	s.mu.Lock()
-	if s.clients == nil {
-		s.mu.Unlock()
+	if sub == nil {
 		w.Header().Set("Content-Type", "application/json")
 		http.Error(w, `{"error":"stream handler not initialized"}`, http.StatusServiceUnavailable)
 		return
 	}
-	s.mu.Unlock()
+
+	// Cleanup on disconnect
+	defer func() {
+		sub.Close()
+		log.Println("SSE Client Disconnected")
+	}()
 
 	// Set SSE headers
 	w.Header().Set("Content-Type", "text/event-stream")
@@ -48,27 +88,16 @@ func (s *StreamHandler) HandleStream(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Connection", "keep-alive")
 	// CORS handled by mux-level middleware — no wildcard override here
 
-	// Create channel for this client
-	clientChan := make(chan string, 8)
-
-	// Register client
-	s.mu.Lock()
-	s.clients[clientChan] = true
-	s.mu.Unlock()
-
-	// Cleanup on disconnect
-	defer func() {
-		s.mu.Lock()
-		delete(s.clients, clientChan)
-		close(clientChan)
-		s.mu.Unlock()
-		log.Println("SSE Client Disconnected")
-	}()
-
 	log.Println("SSE Client Connected")
 
 	// Send connected event
 	fmt.Fprintf(w, "data: %s\n\n", `{"type": "connected", "timestamp": "`+time.Now().Format(time.RFC3339)+`"}`)
+	if sub.Gap != nil {
+		writeDropped(w, *sub.Gap)
+	}
+	for _, ev := range sub.Backlog {
+		writeEvent(w, ev)
+	}
 	flusher.Flush()
 
 	// Stream loop — respects client disconnect via request context
@@ -77,27 +106,63 @@ func (s *StreamHandler) HandleStream(w http.ResponseWriter, r *http.Request) {
 		select {
 		case <-ctx.Done():
 			return
-		case msg, open := <-clientChan:
-			if !open {
-				return
+		case ev := <-sub.Events():
+			writeEvent(w, ev)
+			if gap := sub.Dropped(); gap != nil {
+				writeDropped(w, *gap)
 			}
-			fmt.Fprintf(w, "data: %s\n\n", msg)
 			flusher.Flush()
 		}
 	}
 }
 
-// Broadcast sends a message to all connected clients
+// replayLocked returns the buffered events after lastEventID that pass
+// filter, and a dropped marker when some have left the buffer.
+func (s *StreamHandler) replayLocked(lastEventID string, filter StreamFilter) ([]StreamEvent, *DroppedMarker) {
+	after, err := strconv.ParseUint(strings.TrimSpace(lastEventID), 10, 64)
+	if err != nil || after >= s.lastID {
+		return nil, nil
+	}
+	var gap *DroppedMarker
+	oldest := s.lastID + 1
+	if len(s.history) > 0 {
+		oldest = s.history[0].ID
+	}
+	if after+1 < oldest {
+		gap = &DroppedMarker{Type: "dropped", Reason: "replay_window", LastID: oldest - 1}
+		if after >= s.base {
+			gap.FirstID = after + 1
+			gap.Count = oldest - after - 1
+		}
+	}
+	var backlog []StreamEvent
+	for _, ev := range s.history {
+		if ev.ID > after && filter.matches(ev.meta) {
+			backlog = append(backlog, ev)
+		}
+	}
+	return backlog, gap
+}
+
+// Broadcast sends a message to all connected clients whose filter it
+// passes and keeps it for replay. Clients too slow to keep up are told how
+// many events they missed instead of losing them silently.
 func (s *StreamHandler) Broadcast(msg string) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	meta := parseEventMeta(msg)
 
-	for clientChan := range s.clients {
-		select {
-		case clientChan <- msg:
-		default:
-			// fast fail
-		}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.clients == nil {
+		return
+	}
+	s.lastID++
+	ev := StreamEvent{ID: s.lastID, Data: msg, meta: meta}
+	s.history = append(s.history, ev)
+	if len(s.history) > s.limit {
+		s.history = s.history[len(s.history)-s.limit:]
+	}
+	for client := range s.clients {
+		client.offer(ev)
 	}
 }
 
@@ -108,3 +173,64 @@ func (s *StreamHandler) BroadcastLogEntry(entry *memory.LogEntry) {
 		entry.Source, entry.Level, entry.Message, entry.Timestamp.Format(time.RFC3339), string(ctxJSON))
 	s.Broadcast(jsonMsg)
 }
+
+// streamClient is one SSE connection. Once its buffer fills it drops every
+// event until the writer has drained the buffer and reported the gap, so
+// the dropped marker sits exactly where the missing events would have been.
+type streamClient struct {
+	ch     chan StreamEvent
+	filter StreamFilter
+
+	mu           sync.Mutex
+	dropped      uint64
+	firstDropped uint64
+	lastDropped  uint64
+}
+
+func (c *streamClient) offer(ev StreamEvent) {
+	if !c.filter.matches(ev.meta) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dropped == 0 {
+		select {
+		case c.ch <- ev:
+			return
+		default:
+			c.firstDropped = ev.ID
+		}
+	}
+	c.dropped++
+	c.lastDropped = ev.ID
+}
+
+// resume returns the pending dropped marker once the buffer is empty.
+func (c *streamClient) resume() *DroppedMarker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dropped == 0 || len(c.ch) > 0 {
+		return nil
+	}
+	gap := &DroppedMarker{Type: "dropped", Reason: "slow_client", Count: c.dropped, FirstID: c.firstDropped, LastID: c.lastDropped}
+	c.dropped = 0
+	return gap
+}
+
+// DroppedMarker tells a client which event ids it did not receive.
+type DroppedMarker struct {
+	Type    string `json:"type"`
+	Reason  string `json:"reason"` // "slow_client" | "replay_window"
+	Count   uint64 `json:"count,omitempty"`
+	FirstID uint64 `json:"first_id,omitempty"`
+	LastID  uint64 `json:"last_id"`
+}
+
+func writeEvent(w http.ResponseWriter, ev StreamEvent) {
+	fmt.Fprintf(w, "id: %d\ndata: %s\n\n", ev.ID, ev.Data)
+}
+
+func writeDropped(w http.ResponseWriter, gap DroppedMarker) {
+	data, _ := json.Marshal(gap)
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
@@ -0,0 +1,103 @@
+package signal
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// eventMeta is what stream filters match on, read from the broadcast JSON.
+type eventMeta struct {
+	Type           string
+	TeamID         string
+	RunID          string
+	OrganizationID string
+}
+
+// parseEventMeta reads type, team, run and organization from the top level
+// of a broadcast message, then from its meta, context and payload objects.
+// Activity messages carry the team only in their swarm.team.<id> topic.
+func parseEventMeta(msg string) eventMeta {
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(msg), &doc); err != nil {
+		return eventMeta{}
+	}
+	scopes := []map[string]any{doc}
+	for _, key := range []string{"meta", "context", "payload"} {
+		if nested, ok := doc[key].(map[string]any); ok {
+			scopes = append(scopes, nested)
+		}
+	}
+	lookup := func(key string) string {
+		for _, scope := range scopes {
+			if v, ok := scope[key].(string); ok && v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	meta := eventMeta{
+		Type:           lookup("type"),
+		TeamID:         lookup("team_id"),
+		RunID:          lookup("run_id"),
+		OrganizationID: lookup("organization_id"),
+	}
+	if meta.TeamID == "" {
+		if topic, _ := doc["topic"].(string); strings.HasPrefix(topic, "swarm.team.") {
+			meta.TeamID, _, _ = strings.Cut(strings.TrimPrefix(topic, "swarm.team."), ".")
+		}
+	}
+	return meta
+}
+
+// StreamFilter limits a connection to matching events. Each field is a set
+// of accepted values; an empty set accepts everything, and a non-empty set
+// rejects events that do not carry that field.
+type StreamFilter struct {
+	Types         []string `json:"types,omitempty"`
+	Teams         []string `json:"teams,omitempty"`
+	Runs          []string `json:"runs,omitempty"`
+	Organizations []string `json:"organizations,omitempty"`
+}
+
+// ParseStreamFilter reads comma-separated type, team, run and organization
+// query parameters.
+func ParseStreamFilter(q url.Values) StreamFilter {
+	return StreamFilter{
+		Types:         splitFilterValues(q["type"]),
+		Teams:         splitFilterValues(q["team"]),
+		Runs:          splitFilterValues(q["run"]),
+		Organizations: splitFilterValues(q["organization"]),
+	}
+}
+
+func splitFilterValues(values []string) []string {
+	var out []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+func (f StreamFilter) matches(m eventMeta) bool {
+	return filterAccepts(f.Types, m.Type) &&
+		filterAccepts(f.Teams, m.TeamID) &&
+		filterAccepts(f.Runs, m.RunID) &&
+		filterAccepts(f.Organizations, m.OrganizationID)
+}
+
+func filterAccepts(accepted []string, value string) bool {
+	if len(accepted) == 0 {
+		return true
+	}
+	for _, a := range accepted {
+		if a == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package signal
+
+// Subscription is one consumer of the stream: an SSE connection or an
+// in-process reader such as a WebSocket session.
+type Subscription struct {
+	handler *StreamHandler
+	client  *streamClient
+
+	// Backlog holds the buffered events after the requested last event id;
+	// Gap marks events that had already left the replay buffer.
+	Backlog []StreamEvent
+	Gap     *DroppedMarker
+}
+
+// Subscribe registers a consumer for events passing filter. A non-empty
+// lastEventID replays what was broadcast after it. Registering and reading
+// the backlog happen under one lock, so nothing is missed or sent twice.
+// It returns nil when the handler is not initialized.
+func (s *StreamHandler) Subscribe(filter StreamFilter, lastEventID string) *Subscription {
+	client := &streamClient{ch: make(chan StreamEvent, clientBuffer), filter: filter}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.clients == nil {
+		return nil
+	}
+	s.clients[client] = true
+	backlog, gap := s.replayLocked(lastEventID, filter)
+	return &Subscription{handler: s, client: client, Backlog: backlog, Gap: gap}
+}
+
+// Events delivers live events after the backlog.
+func (sub *Subscription) Events() <-chan StreamEvent { return sub.client.ch }
+
+// Dropped returns a marker for events this subscriber was too slow to take,
+// once the events queued before them have been read. Call it after each
+// received event.
+func (sub *Subscription) Dropped() *DroppedMarker { return sub.client.resume() }
+
+// Close stops delivery.
+func (sub *Subscription) Close() {
+	sub.handler.mu.Lock()
+	delete(sub.handler.clients, sub.client)
+	sub.handler.mu.Unlock()
+}
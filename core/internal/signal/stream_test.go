@@ -0,0 +1,147 @@
+package signal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sseEvent struct {
+	ID   string
+	Data map[string]any
+}
+
+// openStream connects to the handler and returns its events, starting
+// with the "connected" event.
+func openStream(t *testing.T, h *StreamHandler, query, lastEventID string) <-chan sseEvent {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleStream))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		srv.Close()
+	})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/?"+query, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	events := make(chan sseEvent, 64)
+	go func() {
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		var ev sseEvent
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				ev.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &ev.Data)
+			case line == "":
+				events <- ev
+				ev = sseEvent{}
+			}
+		}
+	}()
+	if first := nextEvent(t, events); first.Data["type"] != "connected" {
+		t.Fatalf("first event = %v, want connected", first.Data)
+	}
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+	select {
+	case ev := <-events:
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for stream event")
+		return sseEvent{}
+	}
+}
+
+func TestStream_FiltersAndNumbersEvents(t *testing.T) {
+	h := NewStreamHandler()
+	events := openStream(t, h, "team=alpha&type=activity,thread_event", "")
+
+	h.Broadcast(`{"type": "activity", "topic": "swarm.team.beta.internal.command", "message": "skip"}`)
+	h.Broadcast(`{"type": "log", "context": {"team_id": "alpha"}}`)
+	h.Broadcast(`{"type": "activity", "topic": "swarm.team.alpha.internal.command", "message": "one"}`)
+	h.Broadcast(`{"type": "thread_event", "meta": {"team_id": "alpha", "run_id": "run-1"}}`)
+
+	first, second := nextEvent(t, events), nextEvent(t, events)
+	if first.Data["message"] != "one" || second.Data["type"] != "thread_event" {
+		t.Fatalf("events = %v, %v", first.Data, second.Data)
+	}
+	if first.ID != fmt.Sprint(h.base+3) || second.ID != fmt.Sprint(h.base+4) {
+		t.Fatalf("ids = %s, %s; want %d, %d", first.ID, second.ID, h.base+3, h.base+4)
+	}
+}
+
+func TestStream_ReplaysAfterLastEventID(t *testing.T) {
+	h := NewStreamHandler()
+	for i := 1; i <= 3; i++ {
+		h.Broadcast(fmt.Sprintf(`{"type": "run_completed", "n": %d}`, i))
+	}
+
+	events := openStream(t, h, "", fmt.Sprint(h.base+1))
+	for _, want := range []float64{2, 3} {
+		if ev := nextEvent(t, events); ev.Data["n"] != want {
+			t.Fatalf("replayed %v, want n=%v", ev.Data, want)
+		}
+	}
+	h.Broadcast(`{"type": "run_completed", "n": 4}`)
+	if ev := nextEvent(t, events); ev.Data["n"] != float64(4) {
+		t.Fatalf("live event after replay = %v", ev.Data)
+	}
+}
+
+func TestStream_ReportsEventsOutsideReplayWindow(t *testing.T) {
+	h := NewStreamHandler()
+	h.limit = 2
+	for i := 1; i <= 4; i++ {
+		h.Broadcast(fmt.Sprintf(`{"type": "approval_requested", "n": %d}`, i))
+	}
+
+	events := openStream(t, h, "", fmt.Sprint(h.base+1))
+	gap := nextEvent(t, events)
+	if gap.Data["type"] != "dropped" || gap.Data["reason"] != "replay_window" || gap.Data["count"] != float64(1) {
+		t.Fatalf("gap marker = %v", gap.Data)
+	}
+	if ev := nextEvent(t, events); ev.Data["n"] != float64(3) {
+		t.Fatalf("first replayed = %v, want n=3", ev.Data)
+	}
+}
+
+func TestStreamClient_SlowClientGetsDroppedMarker(t *testing.T) {
+	c := &streamClient{ch: make(chan StreamEvent, 2)}
+	for id := uint64(1); id <= 4; id++ {
+		c.offer(StreamEvent{ID: id})
+	}
+	<-c.ch
+	if gap := c.resume(); gap != nil {
+		t.Fatalf("marker before the buffer drained: %+v", gap)
+	}
+	// Still lagging: new events are dropped until the gap is reported.
+	c.offer(StreamEvent{ID: 5})
+	<-c.ch
+
+	gap := c.resume()
+	if gap == nil || gap.Count != 3 || gap.FirstID != 3 || gap.LastID != 5 || gap.Reason != "slow_client" {
+		t.Fatalf("marker = %+v, want 3 dropped (3-5)", gap)
+	}
+	c.offer(StreamEvent{ID: 6})
+	if ev := <-c.ch; ev.ID != 6 {
+		t.Fatalf("after resume got id %d, want 6", ev.ID)
+	}
+}
@@ -147,6 +147,7 @@ func (s *Soma) ActivateBlueprint(bp *protocol.MissionBlueprint, sensorConfigs ma
 			if s.conversationLogger != nil {
 				team.SetConversationLogger(s.conversationLogger)
 			}
+			team.SetOrganizationID(s.organizationID())
 
 			if err := team.Start(); err != nil {
 				outcomes <- startOutcome{err: fmt.Sprintf("team %s: %v", m.ID, err)}
@@ -31,6 +31,7 @@ type Agent struct {
 	cancel             context.CancelFunc
 	eventEmitter       protocol.EventEmitter
 	runID              string
+	organizationID     string
 	conversationLogger protocol.ConversationLogger
 	sessionID          string
 	turnIndex          int
@@ -62,6 +63,9 @@ func (a *Agent) SetEventEmitter(emitter protocol.EventEmitter, runID string) {
 	a.runID = runID
 }
 
+// SetOrganizationID attributes this agent's inference usage to an organization.
+func (a *Agent) SetOrganizationID(id string) { a.organizationID = id }
+
 func (a *Agent) SetConversationLogger(logger protocol.ConversationLogger) {
 	a.conversationLogger = logger
 }
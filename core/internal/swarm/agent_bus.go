@@ -167,7 +167,7 @@ func (a *Agent) handleDirectRequest(msg *nats.Msg) {
 	}
 	input, history := a.parseConversationPayload(msg.Data)
 	log.Printf("Agent [%s] direct request (%d prior turns): %s", a.Manifest.ID, len(history), truncateLog(input, 200))
-	result := a.processMessageStructured(input, history)
+	result := a.processMessageStreaming(input, history, a.streamSinkFromMsg(msg))
 	if msg.Reply != "" {
 		if respBytes, err := json.Marshal(result); err == nil {
 			msg.Respond(respBytes)
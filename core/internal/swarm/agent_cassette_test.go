@@ -0,0 +1,71 @@
+package swarm
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/mycelis/core/internal/cognitive"
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+func cassetteToolAgent(t *testing.T, adapter cognitive.LLMProvider, exec *countingToolExecutor) *Agent {
+	t.Helper()
+	router := &cognitive.Router{
+		Config: &cognitive.BrainConfig{
+			Profiles:  map[string]string{"chat": "cassette"},
+			Providers: map[string]cognitive.ProviderConfig{"cassette": {Type: "cassette", Enabled: true, ModelID: "test-model"}},
+		},
+		Adapters: map[string]cognitive.LLMProvider{"cassette": adapter},
+	}
+	agent := NewAgent(context.Background(), protocol.AgentManifest{
+		ID:       "admin",
+		Role:     "admin",
+		Provider: "cassette",
+		Tools:    []string{"read_file"},
+	}, "admin-core", nil, router, exec)
+	agent.SetInternalTools(NewInternalToolRegistry(InternalToolDeps{}))
+	agent.SetToolDescriptions(map[string]string{"read_file": "Read a workspace file."})
+	return agent
+}
+
+func TestProcessMessageStructured_ReplaysRecordedToolLoop(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tool_loop.json")
+	upstream := &nativeToolProvider{responses: []*cognitive.InferResponse{
+		{ToolCalls: []cognitive.ToolCall{{ID: "call_1", Name: "read_file", Arguments: map[string]any{"path": "notes.md"}}}, Provider: "mock", ModelUsed: "test-model"},
+		{Text: "notes.md lists the launch checklist.", Provider: "mock", ModelUsed: "test-model"},
+	}}
+	recorder, err := cognitive.NewCassetteAdapter(cognitive.ProviderConfig{
+		Cassette: &cognitive.CassetteConfig{Path: path, Mode: cognitive.CassetteRecord},
+	}, upstream)
+	if err != nil {
+		t.Fatalf("NewCassetteAdapter(record): %v", err)
+	}
+	recorded := cassetteToolAgent(t, recorder, &countingToolExecutor{serverID: InternalServerID}).
+		processMessageStructured("read notes.md", nil)
+	if len(recorder.Interactions()) != 2 {
+		t.Fatalf("recorded %d interactions, want 2", len(recorder.Interactions()))
+	}
+
+	player, err := cognitive.NewCassetteAdapter(cognitive.ProviderConfig{
+		Cassette: &cognitive.CassetteConfig{Path: path},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewCassetteAdapter(replay): %v", err)
+	}
+	exec := &countingToolExecutor{serverID: InternalServerID}
+	replayed := cassetteToolAgent(t, player, exec).processMessageStructured("read notes.md", nil)
+
+	if replayed.Availability != nil {
+		t.Fatalf("replay blocked: %+v", replayed.Availability)
+	}
+	if replayed.Text != recorded.Text || replayed.Text != "notes.md lists the launch checklist." {
+		t.Fatalf("replayed text = %q, recorded %q", replayed.Text, recorded.Text)
+	}
+	if exec.callCalls != 1 {
+		t.Fatalf("CallTool called %d times on replay, want 1", exec.callCalls)
+	}
+	if len(upstream.calls) != 2 {
+		t.Fatalf("upstream called %d times, want 2 (recording only)", len(upstream.calls))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mycelis/core/internal/cognitive"
 	"github.com/mycelis/core/pkg/protocol"
 	"github.com/nats-io/nats.go"
 	"google.golang.org/protobuf/proto"
@@ -113,16 +114,19 @@ func (a *Agent) StartHeartbeat() {
 
 func (a *Agent) Stop() { a.cancel() }
 
-func (a *Agent) buildToolsBlock() string {
+// advertisedTool is one manifest tool the agent can describe to the model.
+type advertisedTool struct {
+	Name        string
+	Description string
+}
+
+// advertisedTools resolves manifest tool bindings to the described tools the
+// model may call, skipping wildcards, toolsets and duplicates.
+func (a *Agent) advertisedTools() []advertisedTool {
 	if len(a.Manifest.Tools) == 0 || len(a.toolDescs) == 0 {
-		return ""
+		return nil
 	}
-	var sb strings.Builder
-	sb.WriteString("\n\n## YOUR TOOLS (you MUST use these — never describe them to the user)\n")
-	sb.WriteString("To call a tool, output ONLY this JSON (no markdown fences around it):\n")
-	sb.WriteString(`{"tool_call": {"name": "TOOL_NAME", "arguments": {"key": "value"}}}`)
-	sb.WriteString("\n\nThe system executes the tool and returns the result to you. ONE tool per response.\n")
-	sb.WriteString("NEVER show tool_call JSON to the user as an example. NEVER say \"you can use\". Just call it.\n\n")
+	var tools []advertisedTool
 	seen := make(map[string]bool)
 	for _, toolName := range a.Manifest.Tools {
 		displayName := toolName
@@ -141,16 +145,54 @@ func (a *Agent) buildToolsBlock() string {
 			continue
 		}
 		if desc, ok := a.toolDescs[displayName]; ok {
-			sb.WriteString(fmt.Sprintf("- **%s**: %s\n", displayName, desc))
+			tools = append(tools, advertisedTool{Name: displayName, Description: desc})
 			seen[displayName] = true
 		} else if desc, ok := a.toolDescs[toolName]; ok {
-			sb.WriteString(fmt.Sprintf("- **%s**: %s\n", toolName, desc))
+			tools = append(tools, advertisedTool{Name: toolName, Description: desc})
 			seen[toolName] = true
 		}
 	}
+	return tools
+}
+
+func (a *Agent) buildToolsBlock() string {
+	tools := a.advertisedTools()
+	if len(tools) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n\n## YOUR TOOLS (you MUST use these — never describe them to the user)\n")
+	sb.WriteString("To call a tool, output ONLY this JSON (no markdown fences around it):\n")
+	sb.WriteString(`{"tool_call": {"name": "TOOL_NAME", "arguments": {"key": "value"}}}`)
+	sb.WriteString("\n\nThe system executes the tool and returns the result to you. ONE tool per response.\n")
+	sb.WriteString("NEVER show tool_call JSON to the user as an example. NEVER say \"you can use\". Just call it.\n\n")
+	for _, tool := range tools {
+		sb.WriteString(fmt.Sprintf("- **%s**: %s\n", tool.Name, tool.Description))
+	}
 	return sb.String()
 }
 
+// toolDefinitions returns the advertised tools as native function-calling
+// definitions. Internal tools carry their registered input schema; MCP tools
+// fall back to an open object schema.
+func (a *Agent) toolDefinitions() []cognitive.ToolDefinition {
+	tools := a.advertisedTools()
+	if len(tools) == 0 {
+		return nil
+	}
+	defs := make([]cognitive.ToolDefinition, 0, len(tools))
+	for _, tool := range tools {
+		def := cognitive.ToolDefinition{Name: tool.Name, Description: tool.Description}
+		if a.internalTools != nil {
+			if internal := a.internalTools.Get(tool.Name); internal != nil {
+				def.Parameters = internal.InputSchema
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs
+}
+
 type toolOutputEnvelope struct {
 	Message   string                     `json:"message"`
 	Artifact  *protocol.ChatArtifactRef  `json:"artifact,omitempty"`
@@ -0,0 +1,130 @@
+package swarm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mycelis/core/internal/cognitive"
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+type nativeToolProvider struct {
+	responses []*cognitive.InferResponse
+	calls     []cognitive.InferOptions
+}
+
+func (p *nativeToolProvider) Infer(_ context.Context, _ string, opts cognitive.InferOptions) (*cognitive.InferResponse, error) {
+	p.calls = append(p.calls, opts)
+	resp := p.responses[0]
+	if len(p.responses) > 1 {
+		p.responses = p.responses[1:]
+	}
+	return resp, nil
+}
+
+func (p *nativeToolProvider) Probe(context.Context) (bool, error) {
+	return true, nil
+}
+
+func TestProcessMessageStructured_ExecutesNativeToolCalls(t *testing.T) {
+	provider := &nativeToolProvider{responses: []*cognitive.InferResponse{
+		{ToolCalls: []cognitive.ToolCall{{ID: "call_1", Name: "read_file", Arguments: map[string]any{"path": "notes.md"}}}, Provider: "mock", ModelUsed: "test-model"},
+		{Text: "notes.md lists the launch checklist.", Provider: "mock", ModelUsed: "test-model"},
+	}}
+	router := &cognitive.Router{
+		Config: &cognitive.BrainConfig{
+			Profiles: map[string]string{"chat": "mock"},
+			Providers: map[string]cognitive.ProviderConfig{
+				"mock": {Type: "mock", Enabled: true, ModelID: "test-model"},
+			},
+		},
+		Adapters: map[string]cognitive.LLMProvider{"mock": provider},
+	}
+
+	exec := &countingToolExecutor{serverID: InternalServerID}
+	agent := NewAgent(context.Background(), protocol.AgentManifest{
+		ID:       "admin",
+		Role:     "admin",
+		Provider: "mock",
+		Tools:    []string{"read_file"},
+	}, "admin-core", nil, router, exec)
+	agent.SetInternalTools(NewInternalToolRegistry(InternalToolDeps{}))
+	agent.SetToolDescriptions(map[string]string{"read_file": "Read a workspace file."})
+
+	result := agent.processMessageStructured("read notes.md", nil)
+
+	if exec.callCalls != 1 {
+		t.Fatalf("CallTool called %d times, want 1", exec.callCalls)
+	}
+	if result.Text != "notes.md lists the launch checklist." {
+		t.Fatalf("text = %q", result.Text)
+	}
+	if len(provider.calls) != 2 {
+		t.Fatalf("provider called %d times, want 2", len(provider.calls))
+	}
+	tools := provider.calls[0].Tools
+	if len(tools) != 1 || tools[0].Name != "read_file" {
+		t.Fatalf("tools = %+v, want read_file", tools)
+	}
+	if tools[0].Parameters["type"] != "object" {
+		t.Fatalf("expected internal input schema, got %+v", tools[0].Parameters)
+	}
+	followUp := provider.calls[1].Messages
+	assistant, toolTurn := followUp[len(followUp)-2], followUp[len(followUp)-1]
+	if assistant.Role != "assistant" || len(assistant.ToolCalls) != 1 || assistant.ToolCalls[0].ID != "call_1" {
+		t.Fatalf("expected the native call echoed on the assistant turn, got %+v", assistant)
+	}
+	if strings.Contains(assistant.Content, `"tool_call"`) {
+		t.Fatalf("native call rendered as text: %q", assistant.Content)
+	}
+	if toolTurn.Role != "tool" || toolTurn.ToolCallID != "call_1" {
+		t.Fatalf("expected a tool turn keyed by call_1, got %+v", toolTurn)
+	}
+}
+
+func TestProcessMessageStructured_ExecutesEveryNativeToolCall(t *testing.T) {
+	provider := &nativeToolProvider{responses: []*cognitive.InferResponse{
+		{ToolCalls: []cognitive.ToolCall{
+			{ID: "call_a", Name: "read_file", Arguments: map[string]any{"path": "a.md"}},
+			{Name: "read_file", Arguments: map[string]any{"path": "b.md"}},
+		}, Provider: "mock", ModelUsed: "test-model"},
+		{Text: "Both files read.", Provider: "mock", ModelUsed: "test-model"},
+	}}
+	router := &cognitive.Router{
+		Config: &cognitive.BrainConfig{
+			Profiles:  map[string]string{"chat": "mock"},
+			Providers: map[string]cognitive.ProviderConfig{"mock": {Type: "mock", Enabled: true, ModelID: "test-model"}},
+		},
+		Adapters: map[string]cognitive.LLMProvider{"mock": provider},
+	}
+	exec := &countingToolExecutor{serverID: InternalServerID}
+	agent := NewAgent(context.Background(), protocol.AgentManifest{
+		ID: "admin", Role: "admin", Provider: "mock", Tools: []string{"read_file"},
+	}, "admin-core", nil, router, exec)
+	agent.SetInternalTools(NewInternalToolRegistry(InternalToolDeps{}))
+
+	result := agent.processMessageStructured("read a.md and b.md", nil)
+
+	if exec.callCalls != 2 {
+		t.Fatalf("CallTool called %d times, want 2", exec.callCalls)
+	}
+	if len(provider.calls) != 2 || result.Text != "Both files read." {
+		t.Fatalf("provider called %d times, text %q; want one re-inference after both calls", len(provider.calls), result.Text)
+	}
+	followUp := provider.calls[1].Messages
+	turns := followUp[len(followUp)-3:]
+	if len(turns[0].ToolCalls) != 2 || turns[1].ToolCallID != "call_a" || turns[2].Role != "tool" || turns[2].ToolCallID != turns[0].ToolCalls[1].ID || turns[2].ToolCallID == "" {
+		t.Fatalf("expected one tool turn per call keyed by ID, got %+v", turns)
+	}
+}
+
+func TestAgentToolLoopResult_FallsBackToTextParsing(t *testing.T) {
+	var result agentToolLoopResult
+	result.adopt(&cognitive.InferResponse{Text: `{"tool_call":{"name":"recall","arguments":{"query":"launch"}}}`})
+
+	call := result.toolCall()
+	if call == nil || call.Name != "recall" || call.Arguments["query"] != "launch" {
+		t.Fatalf("toolCall() = %+v", call)
+	}
+}
@@ -1,6 +1,7 @@
 package swarm
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -26,6 +27,12 @@ func (a *Agent) processMessage(input string, priorHistory []cognitive.ChatMessag
 }
 
 func (a *Agent) processMessageStructured(input string, priorHistory []cognitive.ChatMessage) ProcessResult {
+	return a.processMessageStreaming(input, priorHistory, nil)
+}
+
+// processMessageStreaming is processMessageStructured with an optional stream
+// sink; every inference in the turn, including tool-loop re-inference, streams.
+func (a *Agent) processMessageStreaming(input string, priorHistory []cognitive.ChatMessage, onStream cognitive.StreamFunc) ProcessResult {
 	if a.brain == nil {
 		log.Printf("Agent [%s] has no brain. Skipping inference.", a.Manifest.ID)
 		return ProcessResult{Availability: &cognitive.ExecutionAvailability{
@@ -39,7 +46,15 @@ func (a *Agent) processMessageStructured(input string, priorHistory []cognitive.
 	}
 
 	req, profile := a.buildInferRequest(input, priorHistory)
+	req.OnStream = onStream
 	resp, err := a.brain.InferWithContract(a.ctx, req)
+	var budgetErr *cognitive.BudgetExceededError
+	if errors.As(err, &budgetErr) {
+		log.Printf("Agent [%s] paused: %v", a.Manifest.ID, err)
+		availability := budgetErr.Availability(profile)
+		a.logTurn("assistant", availability.Summary, "", "", "", nil, "", "")
+		return ProcessResult{Availability: &availability}
+	}
 	if err != nil {
 		log.Printf("Agent [%s] brain freeze: %v", a.Manifest.ID, err)
 		availability := a.brain.ExecutionAvailability(profile, a.Manifest.Provider)
@@ -89,7 +104,16 @@ func (a *Agent) buildInferRequest(input string, priorHistory []cognitive.ChatMes
 	if a.internalTools != nil {
 		sys += a.internalTools.BuildContext(a.Manifest.ID, a.TeamID, a.Manifest.Role, a.TeamInputs, a.TeamDeliveries, input)
 	}
-	sys += a.buildToolsBlock()
+	profile := "chat"
+	if a.Manifest.Model != "" {
+		profile = a.Manifest.Model
+	}
+	// Providers with native function calling receive the definitions in
+	// req.Tools; the text tool_call protocol would only compete with them.
+	nativeTools := a.toolExecutor != nil && a.brain.SupportsNativeTools(profile, a.Manifest.Provider)
+	if !nativeTools {
+		sys += a.buildToolsBlock()
+	}
 
 	messages := []cognitive.ChatMessage{{Role: "system", Content: sys}}
 	if len(priorHistory) > 0 {
@@ -99,11 +123,12 @@ func (a *Agent) buildInferRequest(input string, priorHistory []cognitive.ChatMes
 	a.logTurn("system", sys, "", "", "", nil, "", "")
 	a.logTurn("user", input, "", "", "", nil, "", "")
 
-	profile := "chat"
-	if a.Manifest.Model != "" {
-		profile = a.Manifest.Model
+	req := cognitive.InferRequest{Profile: profile, Provider: a.Manifest.Provider, Messages: messages, Sampling: a.Manifest.Sampling}
+	req.Attribution = cognitive.UsageAttribution{OrganizationID: a.organizationID, TeamID: a.TeamID, AgentID: a.Manifest.ID, RunID: a.runID}
+	if a.toolExecutor != nil {
+		req.Tools = a.toolDefinitions()
 	}
-	return cognitive.InferRequest{Profile: profile, Provider: a.Manifest.Provider, Messages: messages}, profile
+	return req, profile
 }
 
 func runtimeResponseDirective() string {
@@ -0,0 +1,113 @@
+package swarm
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/mycelis/core/internal/cognitive"
+	"github.com/mycelis/core/pkg/protocol"
+	"github.com/nats-io/nats.go"
+)
+
+// chatStreamPublisher forwards readable completion deltas to the requester's
+// stream subject. A "{" is buffered only while the text after it could still
+// open a {"tool_call" payload and is released as soon as it diverges; once a
+// tool_call is confirmed the rest of the segment is held back. The final
+// reply carries the clean text.
+type chatStreamPublisher struct {
+	nc      *nats.Conn
+	subject string
+	segment int
+	held    bool
+	pending string // text from a "{" that may still open a tool_call
+}
+
+// Results of matching buffered text against the tool_call opening.
+const (
+	toolCallDiverged = iota
+	toolCallPossible
+	toolCallConfirmed
+)
+
+func newChatStreamPublisher(nc *nats.Conn, subject string) *chatStreamPublisher {
+	return &chatStreamPublisher{nc: nc, subject: subject}
+}
+
+// streamSinkFromMsg returns a stream sink when the request asked for deltas.
+func (a *Agent) streamSinkFromMsg(msg *nats.Msg) cognitive.StreamFunc {
+	if a.nc == nil || msg == nil || msg.Header == nil {
+		return nil
+	}
+	subject := strings.TrimSpace(msg.Header.Get(protocol.HeaderChatStreamSubject))
+	if subject == "" {
+		return nil
+	}
+	return newChatStreamPublisher(a.nc, subject).publish
+}
+
+func (p *chatStreamPublisher) publish(chunk cognitive.StreamChunk) {
+	if chunk.Done {
+		if !p.held {
+			p.emit(p.pending)
+		}
+		p.segment++
+		p.held, p.pending = false, ""
+		return
+	}
+	if p.held {
+		return
+	}
+	buf := p.pending + chunk.Delta
+	p.pending = ""
+	var out strings.Builder
+	for buf != "" {
+		idx := strings.IndexByte(buf, '{')
+		if idx < 0 {
+			out.WriteString(buf)
+			break
+		}
+		out.WriteString(buf[:idx])
+		rest := buf[idx:]
+		switch matchToolCallOpening(rest) {
+		case toolCallConfirmed:
+			p.held = true
+			buf = ""
+		case toolCallPossible:
+			p.pending = rest
+			buf = ""
+		default:
+			out.WriteByte('{')
+			buf = rest[1:]
+		}
+	}
+	p.emit(out.String())
+}
+
+func (p *chatStreamPublisher) emit(text string) {
+	if text == "" {
+		return
+	}
+	raw, err := json.Marshal(protocol.ChatStreamDelta{Segment: p.segment, Text: text})
+	if err != nil {
+		return
+	}
+	if err := p.nc.Publish(p.subject, raw); err != nil {
+		log.Printf("chat stream publish failed on [%s]: %v", p.subject, err)
+	}
+}
+
+// matchToolCallOpening reports whether text, which starts with "{", opens a
+// tool_call payload the way parseToolCall accepts it: the brace, optional
+// whitespace, then "tool_call" in quotes.
+func matchToolCallOpening(text string) int {
+	const key = `"tool_call"`
+	rest := strings.TrimLeft(text[1:], " \t\r\n")
+	switch {
+	case strings.HasPrefix(rest, key):
+		return toolCallConfirmed
+	case strings.HasPrefix(key, rest):
+		return toolCallPossible
+	}
+	return toolCallDiverged
+}
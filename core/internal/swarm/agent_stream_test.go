@@ -0,0 +1,168 @@
+package swarm
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mycelis/core/internal/cognitive"
+	"github.com/mycelis/core/pkg/protocol"
+	"github.com/nats-io/nats.go"
+)
+
+type streamingTestProvider struct {
+	deltas []string
+}
+
+func (p *streamingTestProvider) Infer(context.Context, string, cognitive.InferOptions) (*cognitive.InferResponse, error) {
+	return &cognitive.InferResponse{Text: "unused", Provider: "stream", ModelUsed: "stream-model"}, nil
+}
+
+func (p *streamingTestProvider) InferStream(_ context.Context, _ string, _ cognitive.InferOptions, onDelta func(string)) (*cognitive.InferResponse, error) {
+	text := ""
+	for _, delta := range p.deltas {
+		onDelta(delta)
+		text += delta
+	}
+	return &cognitive.InferResponse{Text: text, Provider: "stream", ModelUsed: "stream-model"}, nil
+}
+
+func (p *streamingTestProvider) Probe(context.Context) (bool, error) { return true, nil }
+
+func TestHandleDirectRequest_PublishesStreamDeltasToHeaderSubject(t *testing.T) {
+	_, nc := startTestNATS(t)
+	router := &cognitive.Router{
+		Config: &cognitive.BrainConfig{
+			Profiles:  map[string]string{"chat": "stream"},
+			Providers: map[string]cognitive.ProviderConfig{"stream": {Type: "mock", Enabled: true, ModelID: "stream-model"}},
+		},
+		Adapters: map[string]cognitive.LLMProvider{"stream": &streamingTestProvider{deltas: []string{"Runtime ", "is healthy."}}},
+	}
+	agent := NewAgent(context.Background(), protocol.AgentManifest{ID: "admin", Role: "admin"}, "admin-core", nc, router, nil)
+
+	deltas := make(chan *nats.Msg, 8)
+	sub, err := nc.ChanSubscribe("_INBOX.stream-test", deltas)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+	if _, err := nc.Subscribe("swarm.council.admin.request", agent.handleDirectRequest); err != nil {
+		t.Fatalf("subscribe agent: %v", err)
+	}
+
+	req := nats.NewMsg("swarm.council.admin.request")
+	req.Header.Set(protocol.HeaderChatStreamSubject, "_INBOX.stream-test")
+	req.Data = []byte("status?")
+	reply, err := nc.RequestMsg(req, 3*time.Second)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	var result ProcessResult
+	if err := json.Unmarshal(reply.Data, &result); err != nil {
+		t.Fatalf("decode reply: %v", err)
+	}
+	if result.Text != "Runtime is healthy." {
+		t.Fatalf("result.Text = %q", result.Text)
+	}
+
+	var got string
+	for len(got) < len(result.Text) {
+		select {
+		case msg := <-deltas:
+			var delta protocol.ChatStreamDelta
+			if err := json.Unmarshal(msg.Data, &delta); err != nil {
+				t.Fatalf("decode delta: %v", err)
+			}
+			if delta.Segment != 0 {
+				t.Fatalf("delta.Segment = %d, want 0", delta.Segment)
+			}
+			got += delta.Text
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for deltas, got %q", got)
+		}
+	}
+	if got != "Runtime is healthy." {
+		t.Fatalf("streamed text = %q", got)
+	}
+}
+
+func TestChatStreamPublisher_HoldsBackToolCallJSON(t *testing.T) {
+	_, nc := startTestNATS(t)
+	deltas := make(chan *nats.Msg, 8)
+	sub, err := nc.ChanSubscribe("_INBOX.stream-gate", deltas)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	pub := newChatStreamPublisher(nc, "_INBOX.stream-gate")
+	pub.publish(cognitive.StreamChunk{Delta: "Checking. "})
+	pub.publish(cognitive.StreamChunk{Delta: `{"tool_call":`})
+	pub.publish(cognitive.StreamChunk{Delta: `{"name":"read_file"}}`})
+	pub.publish(cognitive.StreamChunk{Done: true})
+	pub.publish(cognitive.StreamChunk{Delta: "Done."})
+	if err := nc.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	var got []protocol.ChatStreamDelta
+	for len(got) < 2 {
+		select {
+		case msg := <-deltas:
+			var delta protocol.ChatStreamDelta
+			_ = json.Unmarshal(msg.Data, &delta)
+			got = append(got, delta)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out, got %+v", got)
+		}
+	}
+	if got[0] != (protocol.ChatStreamDelta{Segment: 0, Text: "Checking. "}) || got[1] != (protocol.ChatStreamDelta{Segment: 1, Text: "Done."}) {
+		t.Fatalf("unexpected deltas %+v", got)
+	}
+	select {
+	case msg := <-deltas:
+		t.Fatalf("unexpected extra delta %s", msg.Data)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestChatStreamPublisher_ReleasesJSONThatIsNotAToolCall(t *testing.T) {
+	_, nc := startTestNATS(t)
+	deltas := make(chan *nats.Msg, 16)
+	sub, err := nc.ChanSubscribe("_INBOX.stream-json", deltas)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	pub := newChatStreamPublisher(nc, "_INBOX.stream-json")
+	for _, delta := range []string{"Set ", `{"po`, `rt": 80}`, " then ", "{ ", `"tool`, `_call": {"name":"write_file"}}`, " ignored"} {
+		pub.publish(cognitive.StreamChunk{Delta: delta})
+	}
+	pub.publish(cognitive.StreamChunk{Done: true})
+	pub.publish(cognitive.StreamChunk{Delta: "Saved {"})
+	pub.publish(cognitive.StreamChunk{Done: true})
+	if err := nc.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	streamed := map[int]string{}
+	for done := false; !done; {
+		select {
+		case msg := <-deltas:
+			var delta protocol.ChatStreamDelta
+			_ = json.Unmarshal(msg.Data, &delta)
+			streamed[delta.Segment] += delta.Text
+		case <-time.After(100 * time.Millisecond):
+			done = true
+		}
+	}
+	if streamed[0] != `Set {"port": 80} then ` {
+		t.Fatalf("segment 0 = %q, want the JSON prose and nothing of the tool call", streamed[0])
+	}
+	if streamed[1] != "Saved {" {
+		t.Fatalf("segment 1 = %q, want the trailing brace flushed at the segment end", streamed[1])
+	}
+}
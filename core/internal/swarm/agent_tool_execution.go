@@ -113,17 +113,17 @@ func (a *Agent) executeToolIteration(i int, req *cognitive.InferRequest, toolCal
 		a.persistMCPExchangeResult(serverID, toolCall.Name, "completed", preview, map[string]any{"arguments": toolCall.Arguments, "result_preview": preview})
 		a.publishToolBusSignal(protocol.PayloadKindResult, protocol.SourceKindMCP, map[string]any{"state": "completed", "tool": toolCall.Name, "server_id": serverID.String(), "iteration": i + 1, "result_preview": truncateLog(toolResult, 500), "team_input": fmt.Sprintf(protocol.TopicTeamInternalTrigger, a.TeamID)})
 	}
-	req.Messages = append(req.Messages,
-		cognitive.ChatMessage{Role: "assistant", Content: result.responseText},
-		cognitive.ChatMessage{Role: "user", Content: fmt.Sprintf("Tool result from %s:\n%s\n\nContinue your response:", toolCall.Name, toolResult)},
-	)
+	if result.answerNativeCall(toolResult) {
+		return true
+	}
+	req.Messages = append(req.Messages, result.feedbackMessages(toolCall.Name, toolResult)...)
 	updated, err := a.brain.InferWithContract(a.ctx, *req)
 	if err != nil {
 		log.Printf("Agent [%s] re-inference failed: %v", a.Manifest.ID, err)
+		result.abandon(toolResult)
 		return false
 	}
-	result.resp = updated
-	result.responseText = updated.Text
+	result.adopt(updated)
 	return true
 }
 
@@ -14,39 +14,114 @@ type agentToolLoopResult struct {
 	toolsUsed     []string
 	artifacts     []protocol.ChatArtifactRef
 	consultations []protocol.ConsultationEntry
+	// round counts adopted responses; the loop works through the calls of
+	// one response before counting an iteration.
+	round int
+	// nativeCalls are the structured tool calls of the current response,
+	// nextCall indexes the first one without a result and toolResults holds
+	// the tool turns answering the calls before it.
+	nativeCalls []cognitive.ToolCall
+	nextCall    int
+	toolResults []cognitive.ChatMessage
+}
+
+// adopt records a new inference response and queues its native tool calls.
+// Calls without a provider ID get one so their results can be keyed to them.
+func (r *agentToolLoopResult) adopt(resp *cognitive.InferResponse) {
+	r.round++
+	r.resp = resp
+	r.responseText = resp.Text
+	r.nativeCalls, r.nextCall, r.toolResults = nil, 0, nil
+	for i, call := range resp.ToolCalls {
+		if call.ID == "" {
+			call.ID = fmt.Sprintf("call_%d_%d", r.round, i+1)
+		}
+		if call.Arguments == nil {
+			call.Arguments = map[string]any{}
+		}
+		r.nativeCalls = append(r.nativeCalls, call)
+	}
+}
+
+// abandon ends the current response after a failed re-inference, leaving
+// feedback as the reply.
+func (r *agentToolLoopResult) abandon(feedback string) {
+	r.round++
+	r.responseText = feedback
+	r.nativeCalls, r.nextCall, r.toolResults = nil, 0, nil
+}
+
+// toolCall returns the pending tool call, preferring the adapter's structured
+// calls in order and falling back to parsing the response text for models
+// without native function calling.
+func (r *agentToolLoopResult) toolCall() *toolCallPayload {
+	if len(r.nativeCalls) > 0 {
+		if r.nextCall >= len(r.nativeCalls) {
+			return nil
+		}
+		call := r.nativeCalls[r.nextCall]
+		return &toolCallPayload{Name: call.Name, Arguments: call.Arguments}
+	}
+	return parseToolCall(r.responseText)
+}
+
+// answerNativeCall records feedback as the result of the pending native call
+// and reports whether other calls of the same response still await results.
+func (r *agentToolLoopResult) answerNativeCall(feedback string) bool {
+	if r.nextCall >= len(r.nativeCalls) {
+		return false
+	}
+	r.toolResults = append(r.toolResults, cognitive.ChatMessage{Role: "tool", ToolCallID: r.nativeCalls[r.nextCall].ID, Content: feedback})
+	r.nextCall++
+	return r.nextCall < len(r.nativeCalls)
+}
+
+// feedbackMessages returns the turns handing tool feedback back to the model:
+// the assistant turn with its native calls followed by one tool turn per
+// call, or the text prompt for a tool_call emitted as JSON.
+func (r *agentToolLoopResult) feedbackMessages(toolName, feedback string) []cognitive.ChatMessage {
+	if len(r.toolResults) > 0 {
+		turns := []cognitive.ChatMessage{{Role: "assistant", Content: r.responseText, ToolCalls: r.nativeCalls}}
+		return append(turns, r.toolResults...)
+	}
+	return []cognitive.ChatMessage{
+		{Role: "assistant", Content: r.responseText},
+		{Role: "user", Content: fmt.Sprintf("Tool result from %s:\n%s\n\nContinue your response:", toolName, feedback)},
+	}
 }
 
 func (a *Agent) runToolLoop(input string, priorHistory []cognitive.ChatMessage, req *cognitive.InferRequest, resp *cognitive.InferResponse, profile string) agentToolLoopResult {
-	result := agentToolLoopResult{resp: resp, responseText: resp.Text}
+	var result agentToolLoopResult
+	result.adopt(resp)
 	if a.toolExecutor == nil || len(a.Manifest.Tools) == 0 {
 		return result
 	}
 
 	directAnswerPreferred := preferDirectDraftResponse(input)
 	reinferWithToolFeedback := func(toolName string, feedback string) bool {
-		req.Messages = append(req.Messages, cognitive.ChatMessage{Role: "assistant", Content: result.responseText})
-		req.Messages = append(req.Messages, cognitive.ChatMessage{Role: "user", Content: fmt.Sprintf("Tool result from %s:\n%s\n\nContinue your response:", toolName, feedback)})
+		if result.answerNativeCall(feedback) {
+			return true
+		}
+		req.Messages = append(req.Messages, result.feedbackMessages(toolName, feedback)...)
 		updated, inferErr := a.brain.InferWithContract(a.ctx, *req)
 		if inferErr != nil {
 			log.Printf("Agent [%s] re-inference after tool feedback failed: %v", a.Manifest.ID, inferErr)
-			result.responseText = feedback
+			result.abandon(feedback)
 			return false
 		}
-		result.resp = updated
-		result.responseText = updated.Text
+		result.adopt(updated)
 		return true
 	}
 
 	preflightDone := map[string]bool{}
 	failedToolCalls := map[string]int{}
-	if parseToolCall(result.responseText) == nil && responseSuggestsUnexecutedAction(result.responseText) {
+	if result.toolCall() == nil && responseSuggestsUnexecutedAction(result.responseText) {
 		req.Messages = append(req.Messages,
-			cognitive.ChatMessage{Role: "system", Content: "Policy correction: do not provide step-by-step plans when tools are available. Emit exactly one tool_call JSON now for the user's actionable request, or return a concrete blocker."},
+			cognitive.ChatMessage{Role: "system", Content: "Policy correction: do not provide step-by-step plans when tools are available. Call exactly one tool now for the user's actionable request, or return a concrete blocker."},
 			cognitive.ChatMessage{Role: "user", Content: "Re-answer the latest request now under the policy correction."},
 		)
 		if repaired, repairErr := a.brain.InferWithContract(a.ctx, *req); repairErr == nil && repaired != nil {
-			result.resp = repaired
-			result.responseText = repaired.Text
+			result.adopt(repaired)
 		}
 	}
 
@@ -60,32 +135,29 @@ func (a *Agent) runToolLoop(input string, priorHistory []cognitive.ChatMessage,
 				log.Printf("Agent [%s] interjection re-inference failed: %v", a.Manifest.ID, err)
 				break
 			}
-			result.resp = updated
-			result.responseText = updated.Text
+			result.adopt(updated)
 		}
 
-		toolCall := parseToolCall(result.responseText)
-		if toolCall == nil {
-			break
-		}
-		autofillToolArguments(toolCall, input)
-		if blocksProposalPlanningTool(toolCall.Name) {
-			log.Printf("Agent [%s] proposal-planning tool captured without execution: %s", a.Manifest.ID, toolCall.Name)
-			result.toolsUsed = append(result.toolsUsed, toolCall.Name)
-			a.logTurn("tool_call", result.responseText, "", "", toolCall.Name, toolCall.Arguments, "", "")
-			break
-		}
-		if directAnswerPreferred && shouldAvoidToolsForDirectDraft(toolCall.Name) {
-			if !reinferWithToolFeedback(toolCall.Name, "Policy correction: the user asked for text content in this chat. Respond with the requested content directly. Do not call tools unless they explicitly asked to read or write files, save output, inspect runtime state, execute commands, or route work to other teams.") {
-				break
+		round := result.round
+		for result.round == round {
+			toolCall := result.toolCall()
+			if toolCall == nil {
+				return result
+			}
+			autofillToolArguments(toolCall, input)
+			if blocksProposalPlanningTool(toolCall.Name) {
+				log.Printf("Agent [%s] proposal-planning tool captured without execution: %s", a.Manifest.ID, toolCall.Name)
+				result.toolsUsed = append(result.toolsUsed, toolCall.Name)
+				a.logTurn("tool_call", result.responseText, "", "", toolCall.Name, toolCall.Arguments, "", "")
+				return result
+			}
+			if directAnswerPreferred && shouldAvoidToolsForDirectDraft(toolCall.Name) {
+				reinferWithToolFeedback(toolCall.Name, "Policy correction: the user asked for text content in this chat. Respond with the requested content directly. Do not call tools unless they explicitly asked to read or write files, save output, inspect runtime state, execute commands, or route work to other teams.")
+				continue
+			}
+			if a.prepareToolCall(input, toolCall, failedToolCalls, preflightDone, reinferWithToolFeedback, &result) {
+				a.executeToolIteration(i, req, toolCall, failedToolCalls, reinferWithToolFeedback, &result)
 			}
-			continue
-		}
-		if !a.prepareToolCall(input, toolCall, failedToolCalls, preflightDone, reinferWithToolFeedback, &result) {
-			continue
-		}
-		if !a.executeToolIteration(i, req, toolCall, failedToolCalls, reinferWithToolFeedback, &result) {
-			continue
 		}
 	}
 
@@ -106,6 +106,8 @@ type InternalToolRegistry struct {
 	exchange  *exchange.Service
 	search    *searchcap.Service
 	somaRef   *Soma
+
+	mcpResources MCPResourceProvider
 }
 
 // InternalToolDeps bundles all optional dependencies for the internal tools.
@@ -120,6 +122,9 @@ type InternalToolDeps struct {
 	DB        *sql.DB
 	Exchange  *exchange.Service
 	Search    *searchcap.Service
+	// MCPResources backs the MCP resource and prompt tools; nil leaves them
+	// reporting that no MCP runtime is connected.
+	MCPResources MCPResourceProvider
 }
 
 // NewInternalToolRegistry creates and populates the built-in tool set.
@@ -136,6 +141,8 @@ func NewInternalToolRegistry(deps InternalToolDeps) *InternalToolRegistry {
 		db:        deps.DB,
 		exchange:  deps.Exchange,
 		search:    deps.Search,
+
+		mcpResources: deps.MCPResources,
 	}
 	r.registerAll()
 	return r
@@ -176,4 +183,5 @@ func (r *InternalToolRegistry) registerAll() {
 	r.registerDocsTools()
 	r.registerMemoryAndArtifactTools()
 	r.registerExecutionAndMediaTools()
+	r.registerMCPResourceTools()
 }
@@ -195,8 +195,8 @@ func (r *InternalToolRegistry) handleSearchMemory(ctx context.Context, args map[
 	if query == "" {
 		return "", fmt.Errorf("search_memory requires 'query'")
 	}
-	if r.brain == nil || r.mem == nil {
-		return "Memory search unavailable — cognitive engine or memory service offline.", nil
+	if r.mem == nil {
+		return "Memory search unavailable — memory service offline.", nil
 	}
 	limit := 5
 	if l, ok := args["limit"].(float64); ok && l > 0 {
@@ -207,11 +207,7 @@ func (r *InternalToolRegistry) handleSearchMemory(ctx context.Context, args map[
 	if singleType := stringValue(args["type"]); singleType != "" {
 		searchTypes = append(searchTypes, singleType)
 	}
-	vec, err := r.brain.Embed(ctx, query, "")
-	if err != nil {
-		return "Embedding failed — no embed provider available.", nil
-	}
-	results, err := r.mem.SemanticSearchWithOptions(ctx, vec, memory.SemanticSearchOptions{
+	results, err := r.mem.HybridSearch(ctx, query, embedQuery(ctx, r.brain, query), memory.SemanticSearchOptions{
 		Limit:               limit,
 		TenantID:            scope.TenantID,
 		TeamID:              scope.TeamID,
@@ -47,7 +47,8 @@ func recallVectorRecipes(ctx context.Context, brain *cognitive.Router, mem *memo
 	if brain == nil || mem == nil {
 		return nil
 	}
-	vec, err := brain.Embed(ctx, fmt.Sprintf("[inception] %s", query), "")
+	queryText := fmt.Sprintf("[inception] %s", query)
+	vec, err := brain.Embed(ctx, queryText, "")
 	if err != nil {
 		return nil
 	}
@@ -60,6 +61,7 @@ func recallVectorRecipes(ctx context.Context, brain *cognitive.Router, mem *memo
 		Types:               []string{"inception_recipe"},
 		AllowGlobal:         true,
 		AllowLegacyUnscoped: scope.TeamID == "" && scope.AgentID == "",
+		QueryText:           queryText,
 	})
 	if err != nil {
 		return nil
@@ -0,0 +1,108 @@
+package swarm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mycelis/core/internal/mcp"
+)
+
+// MCPResourceProvider reads the resources and prompts of connected MCP
+// servers. mcp.ToolExecutorAdapter satisfies it.
+type MCPResourceProvider interface {
+	ListResources(ctx context.Context) ([]mcp.ResourceDef, error)
+	ListPrompts(ctx context.Context) ([]mcp.PromptDef, error)
+	ReadResource(ctx context.Context, serverName, uri string) (string, error)
+	SubscribeResource(ctx context.Context, serverName, uri string) error
+	GetPrompt(ctx context.Context, serverName, name string, args map[string]string) (string, error)
+}
+
+func (r *InternalToolRegistry) mcpResourceProvider() (MCPResourceProvider, error) {
+	if r.mcpResources == nil {
+		return nil, fmt.Errorf("MCP resources are not available: no MCP runtime connected")
+	}
+	return r.mcpResources, nil
+}
+
+func (r *InternalToolRegistry) handleListMCPResources(ctx context.Context, args map[string]any) (string, error) {
+	provider, err := r.mcpResourceProvider()
+	if err != nil {
+		return "", err
+	}
+	resources, err := provider.ListResources(ctx)
+	if err != nil {
+		return "", err
+	}
+	server := stringValue(args["server"])
+	out := make([]mcp.ResourceDef, 0, len(resources))
+	for _, res := range resources {
+		if server == "" || res.ServerName == server {
+			out = append(out, res)
+		}
+	}
+	return mustJSON(map[string]any{"resources": out, "count": len(out)}), nil
+}
+
+func (r *InternalToolRegistry) handleReadMCPResource(ctx context.Context, args map[string]any) (string, error) {
+	server, uri := stringValue(args["server"]), stringValue(args["uri"])
+	if server == "" || uri == "" {
+		return "", fmt.Errorf("read_mcp_resource requires 'server' and 'uri'")
+	}
+	provider, err := r.mcpResourceProvider()
+	if err != nil {
+		return "", err
+	}
+	return provider.ReadResource(ctx, server, uri)
+}
+
+func (r *InternalToolRegistry) handleSubscribeMCPResource(ctx context.Context, args map[string]any) (string, error) {
+	server, uri := stringValue(args["server"]), stringValue(args["uri"])
+	if server == "" || uri == "" {
+		return "", fmt.Errorf("subscribe_mcp_resource requires 'server' and 'uri'")
+	}
+	provider, err := r.mcpResourceProvider()
+	if err != nil {
+		return "", err
+	}
+	if err := provider.SubscribeResource(ctx, server, uri); err != nil {
+		return "", err
+	}
+	return mustJSON(map[string]any{"subscribed": true, "server": server, "uri": uri}), nil
+}
+
+func (r *InternalToolRegistry) handleListMCPPrompts(ctx context.Context, args map[string]any) (string, error) {
+	provider, err := r.mcpResourceProvider()
+	if err != nil {
+		return "", err
+	}
+	prompts, err := provider.ListPrompts(ctx)
+	if err != nil {
+		return "", err
+	}
+	server := stringValue(args["server"])
+	out := make([]mcp.PromptDef, 0, len(prompts))
+	for _, p := range prompts {
+		if server == "" || p.ServerName == server {
+			out = append(out, p)
+		}
+	}
+	return mustJSON(map[string]any{"prompts": out, "count": len(out)}), nil
+}
+
+func (r *InternalToolRegistry) handleGetMCPPrompt(ctx context.Context, args map[string]any) (string, error) {
+	server, name := stringValue(args["server"]), stringValue(args["name"])
+	if server == "" || name == "" {
+		return "", fmt.Errorf("get_mcp_prompt requires 'server' and 'name'")
+	}
+	provider, err := r.mcpResourceProvider()
+	if err != nil {
+		return "", err
+	}
+	promptArgs := map[string]string{}
+	if raw, ok := args["arguments"].(map[string]any); ok {
+		for k, v := range raw {
+			promptArgs[k] = fmt.Sprint(v)
+		}
+	}
+	return provider.GetPrompt(ctx, server, name, promptArgs)
+}
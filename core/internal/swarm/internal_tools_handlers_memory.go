@@ -193,7 +193,7 @@ func (r *InternalToolRegistry) handleRecall(ctx context.Context, args map[string
 
 	scope := resolveMemoryScope(ctx, args)
 	results := recallStructuredMemories(ctx, r.db, query, category, limit, scope)
-	results = append(results, recallVectorMemories(ctx, r.brain, r.mem, query, limit, scope)...)
+	results = append(results, recallHybridMemories(ctx, r.brain, r.mem, query, limit, scope)...)
 	if results == nil {
 		results = []memoryResult{}
 	}
@@ -66,15 +66,11 @@ func recallStructuredMemories(ctx context.Context, db *sql.DB, query, category s
 	return results
 }
 
-func recallVectorMemories(ctx context.Context, brain *cognitive.Router, mem *memory.Service, query string, limit int, scope memoryScope) []memoryResult {
-	if brain == nil || mem == nil {
+func recallHybridMemories(ctx context.Context, brain *cognitive.Router, mem *memory.Service, query string, limit int, scope memoryScope) []memoryResult {
+	if mem == nil {
 		return nil
 	}
-	vec, err := brain.Embed(ctx, query, "")
-	if err != nil {
-		return nil
-	}
-	vecResults, err := mem.SemanticSearchWithOptions(ctx, vec, memory.SemanticSearchOptions{
+	vecResults, err := mem.HybridSearch(ctx, query, embedQuery(ctx, brain, query), memory.SemanticSearchOptions{
 		Limit:               limit,
 		TenantID:            scope.TenantID,
 		TeamID:              scope.TeamID,
@@ -88,11 +84,25 @@ func recallVectorMemories(ctx context.Context, brain *cognitive.Router, mem *mem
 	}
 	results := make([]memoryResult, 0, len(vecResults))
 	for _, vr := range vecResults {
-		results = append(results, memoryResult{Content: vr.Content, Score: vr.Score, Source: "vector"})
+		results = append(results, memoryResult{Content: vr.Content, Score: vr.Score, Source: "hybrid"})
 	}
 	return results
 }
 
+// embedQuery embeds query for hybrid recall, returning nil when no embed
+// provider is available so the search falls back to full-text only.
+func embedQuery(ctx context.Context, brain *cognitive.Router, query string) []float64 {
+	if brain == nil {
+		return nil
+	}
+	vec, err := brain.Embed(ctx, query, "")
+	if err != nil {
+		log.Printf("memory recall: embedding unavailable, using full-text only: %v", err)
+		return nil
+	}
+	return vec
+}
+
 func (r *InternalToolRegistry) summarizeConversation(ctx context.Context, messagesText string) (parsedConversationSummary, error) {
 	req := cognitive.InferRequest{
 		Profile: "chat",
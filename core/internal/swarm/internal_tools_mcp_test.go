@@ -0,0 +1,72 @@
+package swarm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mycelis/core/internal/mcp"
+)
+
+type fakeMCPResources struct {
+	subscribed []string
+}
+
+func (f *fakeMCPResources) ListResources(context.Context) ([]mcp.ResourceDef, error) {
+	return []mcp.ResourceDef{
+		{ServerName: "filesystem", URI: "file:///workspace/README.md", Name: "README"},
+		{ServerName: "postgres", URI: "postgres://main/schema", Name: "schema"},
+	}, nil
+}
+
+func (f *fakeMCPResources) ListPrompts(context.Context) ([]mcp.PromptDef, error) {
+	return []mcp.PromptDef{{ServerName: "postgres", Name: "explain_query"}}, nil
+}
+
+func (f *fakeMCPResources) ReadResource(_ context.Context, server, uri string) (string, error) {
+	return server + ":" + uri, nil
+}
+
+func (f *fakeMCPResources) SubscribeResource(_ context.Context, server, uri string) error {
+	f.subscribed = append(f.subscribed, server+" "+uri)
+	return nil
+}
+
+func (f *fakeMCPResources) GetPrompt(_ context.Context, server, name string, args map[string]string) (string, error) {
+	return "user: explain " + args["query"], nil
+}
+
+func TestInternalMCPResourceTools(t *testing.T) {
+	provider := &fakeMCPResources{}
+	registry := NewInternalToolRegistry(InternalToolDeps{MCPResources: provider})
+	ctx := context.Background()
+
+	listed, err := registry.Get("list_mcp_resources").Handler(ctx, map[string]any{"server": "postgres"})
+	if err != nil {
+		t.Fatalf("list_mcp_resources: %v", err)
+	}
+	if !strings.Contains(listed, "postgres://main/schema") || strings.Contains(listed, "README") {
+		t.Fatalf("expected only postgres resources: %s", listed)
+	}
+	read, err := registry.Get("read_mcp_resource").Handler(ctx, map[string]any{"server": "postgres", "uri": "postgres://main/schema"})
+	if err != nil || read != "postgres:postgres://main/schema" {
+		t.Fatalf("read_mcp_resource = %q, %v", read, err)
+	}
+	if _, err := registry.Get("subscribe_mcp_resource").Handler(ctx, map[string]any{"server": "filesystem", "uri": "file:///workspace/README.md"}); err != nil || len(provider.subscribed) != 1 {
+		t.Fatalf("subscribe_mcp_resource: %v, %v", err, provider.subscribed)
+	}
+	prompt, err := registry.Get("get_mcp_prompt").Handler(ctx, map[string]any{"server": "postgres", "name": "explain_query", "arguments": map[string]any{"query": "select 1"}})
+	if err != nil || prompt != "user: explain select 1" {
+		t.Fatalf("get_mcp_prompt = %q, %v", prompt, err)
+	}
+}
+
+func TestInternalMCPResourceToolsWithoutRuntime(t *testing.T) {
+	registry := NewInternalToolRegistry(InternalToolDeps{})
+	if _, err := registry.Get("list_mcp_prompts").Handler(context.Background(), map[string]any{}); err == nil {
+		t.Fatal("expected list_mcp_prompts to fail without an MCP runtime")
+	}
+	if _, err := registry.Get("read_mcp_resource").Handler(context.Background(), map[string]any{"server": "postgres"}); err == nil {
+		t.Fatal("expected read_mcp_resource to require uri")
+	}
+}
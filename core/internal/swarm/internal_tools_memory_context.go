@@ -82,6 +82,7 @@ func (r *InternalToolRegistry) writeDeploymentContext(sb *strings.Builder, agent
 		Types:               []string{"customer_context", "company_knowledge", "soma_operating_context", "user_private_context", "reflection_synthesis"},
 		AllowGlobal:         true,
 		AllowLegacyUnscoped: false,
+		QueryText:           query,
 	})
 	if err != nil || len(results) == 0 {
 		return
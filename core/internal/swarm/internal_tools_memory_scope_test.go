@@ -61,8 +61,13 @@ func TestHandleSearchMemory_UsesInvocationTeamScope(t *testing.T) {
 		AddRow("vec-1", "team memory", `{"team_id":"alpha","visibility":"team"}`, 0.88, time.Now())
 
 	mock.ExpectQuery("SELECT id, content, metadata, 1 - \\(embedding <=> \\$1::vector\\) AS score, created_at").
-		WithArgs(sqlmock.AnyArg(), "default", "alpha", "lead-alpha", 5).
+		WithArgs(sqlmock.AnyArg(), "default", "alpha", "lead-alpha", 20).
 		WillReturnRows(nowRows)
+	mock.ExpectQuery("ts_rank_cd\\(content_tsv, q\\)").
+		WithArgs("default", "alpha", "lead-alpha", "planning | memory", 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "content", "metadata", "score", "created_at"}).
+			AddRow("vec-1", "team memory", `{"team_id":"alpha","visibility":"team"}`, 0.4, time.Now()).
+			AddRow("vec-2", "planning notes", `{"team_id":"alpha","visibility":"team"}`, 0.2, time.Now()))
 
 	registry := NewInternalToolRegistry(InternalToolDeps{
 		Brain: newFakeBrain(fakeMemoryProvider{embedVec: []float64{0.1, 0.2}}),
@@ -77,8 +82,8 @@ func TestHandleSearchMemory_UsesInvocationTeamScope(t *testing.T) {
 	if err != nil {
 		t.Fatalf("handleSearchMemory: %v", err)
 	}
-	if out == "" {
-		t.Fatal("expected search output")
+	if !strings.Contains(out, `"matched_by":["vector","lexical"]`) || !strings.Contains(out, "planning notes") {
+		t.Fatalf("expected fused vector and lexical hits, got %s", out)
 	}
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Fatalf("expectations: %v", err)
@@ -127,7 +132,7 @@ func TestHandleSummarizeConversation_PromotesDurableMemory(t *testing.T) {
 		WithArgs("lead-alpha", "Saved summary", sqlmock.AnyArg(), sqlmock.AnyArg(), "treat the user as a collaborator", sqlmock.AnyArg(), 0).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("sum-1"))
 	mock.ExpectExec("INSERT INTO context_vectors").
-		WithArgs("[conversation] Saved summary", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs("[conversation] Saved summary", sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	registry := NewInternalToolRegistry(InternalToolDeps{
@@ -173,7 +178,7 @@ func TestHandleLoadDeploymentContext_PersistsArtifactAndVectors(t *testing.T) {
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", time.Now()))
 	mock.ExpectExec("INSERT INTO context_vectors").
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	registry := NewInternalToolRegistry(InternalToolDeps{
@@ -226,7 +231,7 @@ func TestHandlePromoteDeploymentContext_PromotesCustomerContextToCompanyKnowledg
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb", time.Now()))
 	mock.ExpectExec("INSERT INTO context_vectors").
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "", sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	registry := NewInternalToolRegistry(InternalToolDeps{
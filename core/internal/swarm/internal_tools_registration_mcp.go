@@ -0,0 +1,9 @@
+package swarm
+
+func (r *InternalToolRegistry) registerMCPResourceTools() {
+	r.tools["list_mcp_resources"] = &InternalTool{Name: "list_mcp_resources", Description: "List resources (files, schemas, documents) published by connected MCP servers, optionally for one server.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"server": map[string]any{"type": "string", "description": "Optional MCP server name"}}}, Handler: r.handleListMCPResources}
+	r.tools["read_mcp_resource"] = &InternalTool{Name: "read_mcp_resource", Description: "Read one resource from a connected MCP server by URI and return its text.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"server": map[string]any{"type": "string", "description": "MCP server name from list_mcp_resources"}, "uri": map[string]any{"type": "string", "description": "Resource URI"}}, "required": []string{"server", "uri"}}, Handler: r.handleReadMCPResource}
+	r.tools["subscribe_mcp_resource"] = &InternalTool{Name: "subscribe_mcp_resource", Description: "Ask a connected MCP server to report changes to a resource; updates appear as mcp_resource_updated events.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"server": map[string]any{"type": "string", "description": "MCP server name"}, "uri": map[string]any{"type": "string", "description": "Resource URI"}}, "required": []string{"server", "uri"}}, Handler: r.handleSubscribeMCPResource}
+	r.tools["list_mcp_prompts"] = &InternalTool{Name: "list_mcp_prompts", Description: "List prompt templates published by connected MCP servers with their arguments.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"server": map[string]any{"type": "string", "description": "Optional MCP server name"}}}, Handler: r.handleListMCPPrompts}
+	r.tools["get_mcp_prompt"] = &InternalTool{Name: "get_mcp_prompt", Description: "Render a prompt template from a connected MCP server with the given arguments.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"server": map[string]any{"type": "string", "description": "MCP server name"}, "name": map[string]any{"type": "string", "description": "Prompt name from list_mcp_prompts"}, "arguments": map[string]any{"type": "object", "description": "Prompt arguments as name→value pairs"}}, "required": []string{"server", "name"}}, Handler: r.handleGetMCPPrompt}
+}
@@ -1,12 +1,12 @@
 package swarm
 
 func (r *InternalToolRegistry) registerMemoryAndArtifactTools() {
-	r.tools["search_memory"] = &InternalTool{Name: "search_memory", Description: "Semantic search over durable memory with optional team, agent, visibility, and type scope.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"query": map[string]any{"type": "string", "description": "The search query text"}, "limit": map[string]any{"type": "integer", "description": "Max results (default 5)"}, "team_id": map[string]any{"type": "string", "description": "Optional team scope override"}, "agent_id": map[string]any{"type": "string", "description": "Optional private-agent scope override"}, "type": map[string]any{"type": "string", "description": "Optional durable memory type filter"}, "types": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Optional durable memory type filters"}, "visibility": map[string]any{"type": "string", "description": "Optional visibility override: private, team, global"}}, "required": []string{"query"}}, Handler: r.handleSearchMemory}
+	r.tools["search_memory"] = &InternalTool{Name: "search_memory", Description: "Hybrid full-text and semantic search over durable memory with optional team, agent, visibility, and type scope.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"query": map[string]any{"type": "string", "description": "The search query text"}, "limit": map[string]any{"type": "integer", "description": "Max results (default 5)"}, "team_id": map[string]any{"type": "string", "description": "Optional team scope override"}, "agent_id": map[string]any{"type": "string", "description": "Optional private-agent scope override"}, "type": map[string]any{"type": "string", "description": "Optional durable memory type filter"}, "types": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Optional durable memory type filters"}, "visibility": map[string]any{"type": "string", "description": "Optional visibility override: private, team, global"}}, "required": []string{"query"}}, Handler: r.handleSearchMemory}
 	r.tools["web_search"] = &InternalTool{Name: "web_search", Description: "Search through the governed Mycelis Search API. Online search is allowed without extra confirmation when configured; disclose provider/path/source boundary and treat external results as leads.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"query": map[string]any{"type": "string", "description": "Search query text"}, "source_id": map[string]any{"type": "string", "description": "Optional configured search source id from Resources"}, "source_scope": map[string]any{"type": "string", "description": "local_sources, web, or all"}, "max_results": map[string]any{"type": "integer", "description": "Max results"}, "time_range": map[string]any{"type": "string", "description": "Optional web recency hint such as day, month, or year"}, "team_id": map[string]any{"type": "string", "description": "Optional team scope for local-source search"}, "host_id": map[string]any{"type": "string", "description": "Optional host scope for selected sources"}, "agent_id": map[string]any{"type": "string", "description": "Optional agent scope for local-source search"}, "visibility": map[string]any{"type": "string", "description": "Optional visibility filter for local-source search"}, "types": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Optional local-source memory types"}}, "required": []string{"query"}}, Handler: r.handleWebSearch}
 	r.tools["load_deployment_context"] = &InternalTool{Name: "load_deployment_context", Description: "Load governed knowledge into the separate context store Soma uses for customer-provided context, approved company knowledge, admin-owned Soma operating context, user-private content, and reflection/synthesis memory, distinct from Soma memory.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"knowledge_class": map[string]any{"type": "string", "description": "customer_context, company_knowledge, soma_operating_context, user_private_context, or reflection_synthesis"}, "title": map[string]any{"type": "string", "description": "Human-readable title for the governed knowledge entry"}, "content": map[string]any{"type": "string", "description": "The document or note content to ingest"}, "content_type": map[string]any{"type": "string", "description": "Optional content type, default text/markdown"}, "source_label": map[string]any{"type": "string", "description": "Optional provenance label such as filename or source name"}, "source_kind": map[string]any{"type": "string", "description": "Optional source kind: user_document, user_note, user_record, diary_entry, finance_record, workspace_file, web_research, lesson, inferred_pattern, contradiction, trajectory_shift, meta_observation, synthesis_note"}, "team_id": map[string]any{"type": "string", "description": "Optional team scope override"}, "agent_id": map[string]any{"type": "string", "description": "Optional agent owner override"}, "visibility": map[string]any{"type": "string", "description": "Optional visibility override: private, team, global"}, "sensitivity_class": map[string]any{"type": "string", "description": "Optional sensitivity: role_scoped, team_scoped, restricted"}, "trust_class": map[string]any{"type": "string", "description": "Optional trust class: user_provided, validated_external, bounded_external, trusted_internal"}, "soma_context_kind": map[string]any{"type": "string", "description": "For soma_operating_context: identity, operating_stance, output_specificity, or policy"}, "output_specificity": map[string]any{"type": "string", "description": "Optional shared output-specificity posture: concise, balanced, detailed, or executive"}, "content_domain": map[string]any{"type": "string", "description": "Optional user-content domain: private_records, diary, finance, health, legal, creative, operations, reflection"}, "target_goal_sets": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Optional goal sets this context should support"}, "tags": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Optional classification tags"}}, "required": []string{"title", "content"}}, Handler: r.handleLoadDeploymentContext}
 	r.tools["promote_deployment_context"] = &InternalTool{Name: "promote_deployment_context", Description: "Promote an existing customer-context entry into approved company knowledge with preserved lineage. This is distinct from ordinary Soma memory and should remain approval-backed.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"source_artifact_id": map[string]any{"type": "string", "description": "Artifact ID of the existing customer_context entry to promote"}, "title": map[string]any{"type": "string", "description": "Optional replacement title for the promoted company knowledge entry"}, "content": map[string]any{"type": "string", "description": "Optional replacement content; defaults to the source artifact content"}, "content_type": map[string]any{"type": "string", "description": "Optional content type, default text/markdown"}, "source_label": map[string]any{"type": "string", "description": "Optional provenance label for the promoted entry"}, "source_kind": map[string]any{"type": "string", "description": "Optional source kind override"}, "team_id": map[string]any{"type": "string", "description": "Optional team scope override"}, "agent_id": map[string]any{"type": "string", "description": "Optional agent owner override"}, "visibility": map[string]any{"type": "string", "description": "Optional visibility override: private, team, global"}, "sensitivity_class": map[string]any{"type": "string", "description": "Optional sensitivity override: role_scoped, team_scoped, restricted"}, "trust_class": map[string]any{"type": "string", "description": "Optional trust class override; defaults to trusted_internal"}, "tags": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Optional classification tags"}}, "required": []string{"source_artifact_id"}}, Handler: r.handlePromoteDeploymentContext}
 	r.tools["remember"] = &InternalTool{Name: "remember", Description: "Store a learned fact, user preference, or goal into persistent memory.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"category": map[string]any{"type": "string", "description": "Category: user_preference, goal, fact, decision, lesson_learned"}, "content": map[string]any{"type": "string", "description": "The information to remember"}, "context": map[string]any{"type": "string", "description": "Optional context about when/why this was learned"}, "team_id": map[string]any{"type": "string", "description": "Optional team ownership override"}, "agent_id": map[string]any{"type": "string", "description": "Optional private owner override"}, "visibility": map[string]any{"type": "string", "description": "Optional visibility override: private, team, global"}}, "required": []string{"category", "content"}}, Handler: r.handleRemember}
-	r.tools["recall"] = &InternalTool{Name: "recall", Description: "Recall stored memories by semantic similarity. Searches both vector embeddings and structured records.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"query": map[string]any{"type": "string", "description": "What to recall — natural language query"}, "category": map[string]any{"type": "string", "description": "Optional filter"}, "limit": map[string]any{"type": "integer", "description": "Max results (default 5)"}, "team_id": map[string]any{"type": "string", "description": "Optional team scope override"}, "agent_id": map[string]any{"type": "string", "description": "Optional private-agent scope override"}, "visibility": map[string]any{"type": "string", "description": "Optional visibility override: private, team, global"}}, "required": []string{"query"}}, Handler: r.handleRecall}
+	r.tools["recall"] = &InternalTool{Name: "recall", Description: "Recall stored memories by meaning and keywords. Searches structured records and retained context ranked by fused full-text and vector relevance.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"query": map[string]any{"type": "string", "description": "What to recall — natural language query"}, "category": map[string]any{"type": "string", "description": "Optional filter"}, "limit": map[string]any{"type": "integer", "description": "Max results (default 5)"}, "team_id": map[string]any{"type": "string", "description": "Optional team scope override"}, "agent_id": map[string]any{"type": "string", "description": "Optional private-agent scope override"}, "visibility": map[string]any{"type": "string", "description": "Optional visibility override: private, team, global"}}, "required": []string{"query"}}, Handler: r.handleRecall}
 	r.tools["temp_memory_write"] = &InternalTool{Name: "temp_memory_write", Description: "Persist a temporary working-memory checkpoint (restart-safe) for lead-agent continuity.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"channel": map[string]any{"type": "string", "description": "Channel key"}, "content": map[string]any{"type": "string", "description": "Checkpoint content"}, "owner_agent_id": map[string]any{"type": "string", "description": "Optional owner identity"}, "ttl_minutes": map[string]any{"type": "integer", "description": "Optional expiration in minutes"}, "metadata": map[string]any{"type": "object", "description": "Optional structured metadata"}}, "required": []string{"channel", "content"}}, Handler: r.handleTempMemoryWrite}
 	r.tools["temp_memory_read"] = &InternalTool{Name: "temp_memory_read", Description: "Read recent temporary working-memory checkpoints for a channel.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"channel": map[string]any{"type": "string", "description": "Channel key"}, "limit": map[string]any{"type": "integer", "description": "Max entries (default 10)"}}, "required": []string{"channel"}}, Handler: r.handleTempMemoryRead}
 	r.tools["temp_memory_clear"] = &InternalTool{Name: "temp_memory_clear", Description: "Clear a temporary working-memory channel.", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"channel": map[string]any{"type": "string", "description": "Channel key"}}, "required": []string{"channel"}}, Handler: r.handleTempMemoryClear}
@@ -8,6 +8,7 @@ import (
 	"github.com/mycelis/core/internal/cognitive"
 	"github.com/mycelis/core/internal/governance"
 	"github.com/mycelis/core/internal/signal"
+	natstransport "github.com/mycelis/core/internal/transport/nats"
 	"github.com/mycelis/core/pkg/protocol"
 	"github.com/nats-io/nats.go"
 )
@@ -33,6 +34,8 @@ type Soma struct {
 	eventEmitter       protocol.EventEmitter
 	conversationLogger protocol.ConversationLogger
 	providerPolicy     ProviderPolicy
+	durable            *natstransport.Durable
+	scheduleRuns       TeamScheduleStore
 }
 
 // NewSoma creates a new Soma instance with composite tool support.
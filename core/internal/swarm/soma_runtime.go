@@ -22,7 +22,7 @@ func (s *Soma) Start() error {
 		log.Printf("WARN: Failed to load team manifests: %v", err)
 	}
 	for _, m := range manifests {
-		team := NewTeam(s.applyProviderPolicy(m), s.nc, s.brain, s.toolExecutor)
+		team := s.newTeam(m)
 		s.configureTeam(team, toolDescs)
 		s.teams[m.ID] = team
 		if err := team.Start(); err != nil {
@@ -39,6 +39,17 @@ func (s *Soma) Start() error {
 	return nil
 }
 
+func (s *Soma) newTeam(manifest *TeamManifest) *Team {
+	team := NewTeam(s.applyProviderPolicy(manifest), s.nc, s.brain, s.toolExecutor)
+	if s.durable != nil {
+		team.SetDurableDelivery(s.durable)
+	}
+	if s.scheduleRuns != nil {
+		team.SetScheduleStore(s.scheduleRuns)
+	}
+	return team
+}
+
 func (s *Soma) configureTeam(team *Team, toolDescs map[string]string) {
 	if len(toolDescs) > 0 {
 		team.SetToolDescriptions(toolDescs)
@@ -52,6 +63,19 @@ func (s *Soma) configureTeam(team *Team, toolDescs map[string]string) {
 	if s.conversationLogger != nil {
 		team.SetConversationLogger(s.conversationLogger)
 	}
+	team.SetOrganizationID(s.organizationID())
+}
+
+// organizationID returns the active runtime organization, used to attribute
+// inference usage. Empty when no organization is bootstrapped.
+func (s *Soma) organizationID() string {
+	if s.registry == nil {
+		return ""
+	}
+	if org := s.registry.RuntimeOrganization(); org != nil {
+		return org.ID
+	}
+	return ""
 }
 
 // handleGlobalInput processes raw external signals after guard validation.
@@ -72,7 +96,7 @@ func (s *Soma) SpawnTeam(manifest *TeamManifest) error {
 		return fmt.Errorf("team %s already exists", manifest.ID)
 	}
 
-	team := NewTeam(s.applyProviderPolicy(manifest), s.nc, s.brain, s.toolExecutor)
+	team := s.newTeam(manifest)
 	if s.internalTools != nil {
 		s.configureTeam(team, s.internalTools.ListDescriptions())
 	}
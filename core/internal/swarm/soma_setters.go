@@ -2,6 +2,7 @@ package swarm
 
 import (
 	"github.com/google/uuid"
+	natstransport "github.com/mycelis/core/internal/transport/nats"
 	"github.com/mycelis/core/pkg/protocol"
 )
 
@@ -24,3 +25,15 @@ func (s *Soma) SetMCPToolDescs(descs map[string]string) {
 func (s *Soma) SetProviderPolicy(policy ProviderPolicy) {
 	s.providerPolicy = policy.Clone()
 }
+
+// SetDurableDelivery enables JetStream durable delivery for teams started
+// from now on.
+func (s *Soma) SetDurableDelivery(durable *natstransport.Durable) {
+	s.durable = durable
+}
+
+// SetTeamScheduleStore persists team schedule runs for teams started from
+// now on.
+func (s *Soma) SetTeamScheduleStore(store TeamScheduleStore) {
+	s.scheduleRuns = store
+}
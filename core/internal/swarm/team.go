@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/mycelis/core/internal/cognitive"
+	natstransport "github.com/mycelis/core/internal/transport/nats"
 	"github.com/mycelis/core/pkg/protocol"
 	"github.com/nats-io/nats.go"
 )
@@ -45,13 +46,17 @@ type Team struct {
 	mu                  sync.Mutex
 	sensorConfigs       map[string]SensorConfig
 	scheduler           *TeamScheduler
+	scheduleRuns        TeamScheduleStore
 	eventEmitter        protocol.EventEmitter
 	runID               string
+	organizationID      string
 	conversationLogger  protocol.ConversationLogger
 	compositeExec       *CompositeToolExecutor
 	mcpServerNames      map[uuid.UUID]string
 	mcpToolDescs        map[string]string
 	pendingCorrelations []teamCommandCorrelation
+	durable             *natstransport.Durable
+	durableSubs         []*nats.Subscription
 }
 
 type teamCommandCorrelation struct {
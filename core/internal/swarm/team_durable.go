@@ -0,0 +1,121 @@
+package swarm
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	natstransport "github.com/mycelis/core/internal/transport/nats"
+	"github.com/nats-io/nats.go"
+)
+
+// SetDurableDelivery switches the team's inputs to JetStream durable
+// consumers. Commands sent while the team is down are delivered when it
+// starts, and accepted asks that were never answered are replayed.
+func (t *Team) SetDurableDelivery(durable *natstransport.Durable) {
+	t.durable = durable
+}
+
+// pendingAsk is an accepted, correlated ask kept until the team answers it.
+type pendingAsk struct {
+	Subject string `json:"subject"`
+	Data    []byte `json:"data"`
+}
+
+// subscribeInputs listens on the manifest inputs, through durable consumers
+// when durable delivery is enabled. Inputs the stream does not cover fall
+// back to core subscriptions.
+func (t *Team) subscribeInputs() {
+	for _, subject := range t.Manifest.Inputs {
+		if t.durable != nil {
+			consumer := natstransport.ConsumerName("team_" + t.Manifest.ID + "_" + subject)
+			sub, err := t.durable.Subscribe(subject, consumer, t.handleDurableTrigger)
+			if err == nil {
+				t.mu.Lock()
+				t.durableSubs = append(t.durableSubs, sub)
+				t.mu.Unlock()
+				log.Printf("Team [%s] Listening on [%s] (durable)", t.Manifest.Name, subject)
+				continue
+			}
+			log.Printf("Team [%s] durable input [%s] unavailable, using core NATS: %v", t.Manifest.Name, subject, err)
+		}
+		if _, err := t.nc.Subscribe(subject, t.handleTrigger); err != nil {
+			log.Printf("Team [%s] Failed to subscribe to input [%s]: %v", t.Manifest.Name, subject, err)
+		} else {
+			log.Printf("Team [%s] Listening on [%s]", t.Manifest.Name, subject)
+		}
+	}
+}
+
+// handleDurableTrigger forwards a durable input. Returning an error leaves
+// the message unacked so it is redelivered.
+func (t *Team) handleDurableTrigger(msg *nats.Msg) error {
+	return t.dispatchTrigger(msg.Subject, msg.Data)
+}
+
+// holdPendingAsk records an ask so a restarted team can replay it.
+func (t *Team) holdPendingAsk(subject string, data []byte, correlation *teamCommandCorrelation) error {
+	if t.durable == nil {
+		return nil
+	}
+	raw, err := json.Marshal(pendingAsk{Subject: subject, Data: data})
+	if err != nil {
+		return err
+	}
+	return t.durable.SavePending(t.pendingAskKey(correlation.WorkItemID), raw)
+}
+
+// releasePendingAsk forgets an answered ask.
+func (t *Team) releasePendingAsk(correlation *teamCommandCorrelation) {
+	if t.durable == nil || correlation == nil || correlation.WorkItemID == "" {
+		return
+	}
+	if err := t.durable.DeletePending(t.pendingAskKey(correlation.WorkItemID)); err != nil {
+		log.Printf("Team [%s] failed to release pending ask %s: %v", t.Manifest.Name, correlation.WorkItemID, err)
+	}
+}
+
+// replayPendingAsks re-dispatches asks accepted by an earlier instance of
+// this team that never got a response.
+func (t *Team) replayPendingAsks() {
+	if t.durable == nil {
+		return
+	}
+	held, err := t.durable.ListPending(t.pendingAskKey(""))
+	if err != nil {
+		log.Printf("Team [%s] failed to list pending asks: %v", t.Manifest.Name, err)
+		return
+	}
+	for key, raw := range held {
+		var ask pendingAsk
+		if err := json.Unmarshal(raw, &ask); err != nil {
+			log.Printf("Team [%s] dropping unreadable pending ask %s: %v", t.Manifest.Name, key, err)
+			_ = t.durable.DeletePending(key)
+			continue
+		}
+		log.Printf("Team [%s] replaying pending ask %s", t.Manifest.Name, key)
+		if err := t.dispatchTrigger(ask.Subject, ask.Data); err != nil {
+			log.Printf("Team [%s] failed to replay pending ask %s: %v", t.Manifest.Name, key, err)
+		}
+	}
+}
+
+func (t *Team) pendingAskKey(workItemID string) string {
+	return natstransport.ConsumerName(t.Manifest.ID) + "/" + natstransport.ConsumerName(workItemID)
+}
+
+// stopDurableInputs detaches the durable consumers; they keep collecting
+// work until the team starts again.
+func (t *Team) stopDurableInputs() {
+	t.mu.Lock()
+	subs := t.durableSubs
+	t.durableSubs = nil
+	t.mu.Unlock()
+	for _, sub := range subs {
+		_ = sub.Unsubscribe()
+	}
+}
+
+// durableSettleTimeout bounds how long Start waits for agents to subscribe
+// before replaying work.
+const durableSettleTimeout = 2 * time.Second
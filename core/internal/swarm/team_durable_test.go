@@ -0,0 +1,116 @@
+package swarm
+
+import (
+	"testing"
+	"time"
+
+	natstransport "github.com/mycelis/core/internal/transport/nats"
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+func startTestJetStream(t *testing.T) *natsserver.Server {
+	t.Helper()
+	srv, err := natsserver.NewServer(&natsserver.Options{
+		Host:      "127.0.0.1",
+		Port:      reserveSwarmTestPort(t),
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("nats server: %v", err)
+	}
+	srv.Start()
+	if !srv.ReadyForConnections(3 * time.Second) {
+		t.Fatal("nats server not ready")
+	}
+	t.Cleanup(func() {
+		srv.Shutdown()
+		srv.WaitForShutdown()
+	})
+	return srv
+}
+
+// startDurableTeam starts the test-core team on its own connection, as a
+// separate process would.
+func startDurableTeam(t *testing.T, srv *natsserver.Server) (*Team, *nats.Conn, *natstransport.Durable) {
+	t.Helper()
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	durable, err := natstransport.EnableDurable(nc, natstransport.DurableConfig{})
+	if err != nil {
+		t.Fatalf("EnableDurable: %v", err)
+	}
+	team := NewTeam(&TeamManifest{
+		ID:         "test-core",
+		Name:       "Test Core",
+		Type:       TeamTypeAction,
+		Inputs:     []string{"swarm.team.test-core.internal.command"},
+		Deliveries: []string{"swarm.team.test-core.signal.result"},
+	}, nc, nil, nil)
+	team.SetDurableDelivery(durable)
+	if err := team.Start(); err != nil {
+		t.Fatalf("team start: %v", err)
+	}
+	return team, nc, durable
+}
+
+func TestTeam_DurableDeliveryReplaysWorkAcrossRestart(t *testing.T) {
+	srv := startTestJetStream(t)
+	observer, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("connect observer: %v", err)
+	}
+	defer observer.Close()
+
+	triggers := make(chan struct{}, 4)
+	if _, err := observer.Subscribe("swarm.team.test-core.internal.trigger", func(*nats.Msg) {
+		triggers <- struct{}{}
+	}); err != nil {
+		t.Fatalf("subscribe internal trigger: %v", err)
+	}
+	results := make(chan *nats.Msg, 4)
+	if _, err := observer.Subscribe("swarm.team.test-core.signal.result", func(msg *nats.Msg) { results <- msg }); err != nil {
+		t.Fatalf("subscribe result: %v", err)
+	}
+	observer.Flush()
+
+	const answeredLater = "11111111-1111-1111-1111-111111111111"
+	const sentWhileDown = "22222222-2222-2222-2222-222222222222"
+
+	first, firstConn, _ := startDurableTeam(t, srv)
+	publishCorrelatedCommand(t, observer, answeredLater, "run-1")
+	waitForInternalTriggers(t, triggers, 1)
+
+	// The team dies before answering, and more work arrives while it is down.
+	first.Stop()
+	firstConn.Close()
+	publishCorrelatedCommand(t, observer, sentWhileDown, "run-2")
+	observer.Flush()
+
+	second, secondConn, durable := startDurableTeam(t, srv)
+	defer secondConn.Close()
+	defer second.Stop()
+	waitForInternalTriggers(t, triggers, 2)
+
+	for _, want := range []struct{ workID, text string }{
+		{answeredLater, "first ready"},
+		{sentWhileDown, "second ready"},
+	} {
+		if err := observer.Publish("swarm.team.test-core.internal.response", []byte(want.text)); err != nil {
+			t.Fatalf("publish response: %v", err)
+		}
+		_, projected := decodeTeamSignalPayload(t, waitForTeamSignal(t, results, want.text).Data)
+		assertProjectedWorkOutput(t, projected, want.workID, want.text)
+	}
+
+	pending, err := durable.ListPending("test-core/")
+	if err != nil {
+		t.Fatalf("ListPending: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("answered asks still pending: %v", pending)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/mycelis/core/internal/mcp"
+	"github.com/mycelis/core/internal/schedule"
 	"github.com/mycelis/core/pkg/protocol"
 )
 
@@ -16,14 +17,6 @@ func (t *Team) Start() error {
 	log.Printf("Team [%s] (%s) Online.", t.Manifest.Name, t.Manifest.Type)
 	t.normalizeRuntimeProviderRouting()
 
-	for _, subject := range t.Manifest.Inputs {
-		if _, err := t.nc.Subscribe(subject, t.handleTrigger); err != nil {
-			log.Printf("Team [%s] Failed to subscribe to input [%s]: %v", t.Manifest.Name, subject, err)
-		} else {
-			log.Printf("Team [%s] Listening on [%s]", t.Manifest.Name, subject)
-		}
-	}
-
 	for _, manifest := range t.Manifest.Members {
 		member := manifest
 		if member.Provider == "" && t.Manifest.Provider != "" {
@@ -46,11 +39,20 @@ func (t *Team) Start() error {
 		t.injectAgentToolDescriptions(agent, member.Tools)
 		t.injectAgentRuntimeBindings(agent)
 		agent.SetTeamTopology(t.Manifest.Inputs, t.Manifest.Deliveries)
-		go agent.Start()
+		agent.Start()
 	}
 
 	internalResponse := fmt.Sprintf(protocol.TopicTeamInternalRespond, t.Manifest.ID)
 	t.nc.Subscribe(internalResponse, t.handleResponse)
+
+	// Inputs open last so replayed and queued work finds the agents listening.
+	if t.durable != nil {
+		if err := t.nc.FlushTimeout(durableSettleTimeout); err != nil {
+			log.Printf("Team [%s] flush before replay failed: %v", t.Manifest.Name, err)
+		}
+		t.replayPendingAsks()
+	}
+	t.subscribeInputs()
 	t.startScheduler()
 	return nil
 }
@@ -95,33 +97,33 @@ func (t *Team) injectAgentRuntimeBindings(agent *Agent) {
 	if t.conversationLogger != nil {
 		agent.SetConversationLogger(t.conversationLogger)
 	}
+	agent.SetOrganizationID(t.organizationID)
 }
 
 func (t *Team) startScheduler() {
-	if t.Manifest.Schedule == nil || t.Manifest.Schedule.Interval == "" {
-		return
-	}
-
-	interval, err := time.ParseDuration(t.Manifest.Schedule.Interval)
-	if err != nil {
-		log.Printf("Team [%s] invalid schedule interval %q: %v", t.Manifest.Name, t.Manifest.Schedule.Interval, err)
-		return
-	}
-	if interval <= 0 {
+	cfg := t.Manifest.Schedule
+	if cfg == nil || (cfg.Interval == "" && cfg.CronExpr == "") {
 		return
 	}
 
 	const minInterval = 30 * time.Second
-	if interval < minInterval {
+	clamped := *cfg
+	if interval, err := time.ParseDuration(cfg.Interval); err == nil && interval > 0 && interval < minInterval {
 		log.Printf("WARN: Team [%s] schedule interval %s below minimum, clamping to %s", t.Manifest.Name, interval, minInterval)
-		interval = minInterval
+		clamped.Interval = minInterval.String()
+	}
+	sched, err := schedule.Compile(clamped)
+	if err != nil {
+		log.Printf("Team [%s] invalid schedule: %v", t.Manifest.Name, err)
+		return
 	}
 
 	schedCtx, schedCancel := context.WithCancel(t.ctx)
 	t.scheduler = &TeamScheduler{
 		teamID:   t.Manifest.ID,
-		interval: interval,
+		schedule: sched,
 		nc:       t.nc,
+		runs:     t.scheduleRuns,
 		ctx:      schedCtx,
 		cancel:   schedCancel,
 	}
@@ -163,6 +165,7 @@ func (t *Team) Stop() {
 	if t.scheduler != nil {
 		t.scheduler.Stop()
 	}
+	t.stopDurableInputs()
 	if t.cancel != nil {
 		t.cancel()
 	}
@@ -7,39 +7,85 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/mycelis/core/internal/schedule"
 	"github.com/mycelis/core/pkg/protocol"
 	"github.com/nats-io/nats.go"
 )
 
-// TeamScheduler triggers a team on a configurable interval.
-// Reuses the Archivist timer-loop pattern: ticker + context cancellation.
+// TeamScheduleStore remembers when each team schedule last fired, so runs
+// missed while the process was down are resolved by the misfire policy on
+// the next start. Implemented by triggers.Store.
+type TeamScheduleStore interface {
+	LastTeamScheduleRun(ctx context.Context, teamID string) (time.Time, error)
+	RecordTeamScheduleRun(ctx context.Context, teamID string, firedAt time.Time) error
+}
+
+// TeamScheduler triggers a team on its schedule: a fixed interval or a cron
+// expression with a calendar. Runs missed while the process was suspended,
+// busy or stopped are resolved by the schedule's misfire policy.
 type TeamScheduler struct {
 	teamID            string
-	interval          time.Duration
+	schedule          *schedule.Schedule
 	nc                *nats.Conn
+	runs              TeamScheduleStore // nil: no history, every start is a first start
 	ctx               context.Context
 	cancel            context.CancelFunc
 	triggerInProgress atomic.Bool
 }
 
-// Start runs the scheduler loop. Triggers immediately on first run, then on each tick.
-// Blocks until the context is cancelled.
+// Start runs the scheduler loop. A schedule that fired before resumes from
+// the run after its last fire, so missed runs go through the misfire policy.
+// Otherwise interval schedules trigger immediately and cron schedules wait
+// for their first matching time. Blocks until the context is cancelled.
 func (ts *TeamScheduler) Start() {
-	ticker := time.NewTicker(ts.interval)
-	defer ticker.Stop()
+	log.Printf("TeamScheduler [%s]: active (%s)", ts.teamID, ts.schedule)
 
-	log.Printf("TeamScheduler [%s]: active (every %s)", ts.teamID, ts.interval)
-	ts.trigger() // Immediate first run
+	now := time.Now()
+	due, resumed := ts.resumeDue()
+	if !resumed {
+		if ts.schedule.Interval() > 0 && !ts.schedule.Blocked(now) {
+			ts.trigger(now) // Immediate first run
+		}
+		due = ts.schedule.Next(now)
+	}
 
-	for {
+	for !due.IsZero() {
+		timer := time.NewTimer(time.Until(due))
 		select {
 		case <-ts.ctx.Done():
+			timer.Stop()
 			log.Printf("TeamScheduler [%s]: stopped", ts.teamID)
 			return
-		case <-ticker.C:
-			ts.trigger()
+		case <-timer.C:
 		}
+
+		plan := ts.schedule.Plan(due, time.Now())
+		if plan.Missed > 0 {
+			log.Printf("TeamScheduler [%s]: misfire — dropped %d missed run(s)", ts.teamID, plan.Missed)
+		}
+		if !plan.Fire.IsZero() {
+			ts.trigger(plan.Fire)
+		}
+		due = plan.Next
 	}
+	log.Printf("TeamScheduler [%s]: no further runs scheduled", ts.teamID)
+}
+
+// resumeDue returns the first run after the last recorded fire. It reports
+// false when there is no history to resume from.
+func (ts *TeamScheduler) resumeDue() (time.Time, bool) {
+	if ts.runs == nil {
+		return time.Time{}, false
+	}
+	last, err := ts.runs.LastTeamScheduleRun(ts.ctx, ts.teamID)
+	if err != nil {
+		log.Printf("TeamScheduler [%s]: last run unavailable, starting fresh: %v", ts.teamID, err)
+		return time.Time{}, false
+	}
+	if last.IsZero() {
+		return time.Time{}, false
+	}
+	return ts.schedule.Next(last), true
 }
 
 // Stop cancels the scheduler loop.
@@ -49,7 +95,7 @@ func (ts *TeamScheduler) Stop() {
 	}
 }
 
-func (ts *TeamScheduler) trigger() {
+func (ts *TeamScheduler) trigger(scheduledFor time.Time) {
 	// Phase 0 safety: prevent overlapping triggers
 	if !ts.triggerInProgress.CompareAndSwap(false, true) {
 		log.Printf("TeamScheduler [%s]: skipping — previous trigger still in progress", ts.teamID)
@@ -58,10 +104,16 @@ func (ts *TeamScheduler) trigger() {
 	defer ts.triggerInProgress.Store(false)
 
 	subject := fmt.Sprintf(protocol.TopicTeamInternalTrigger, ts.teamID)
-	payload := fmt.Sprintf(`{"triggered_by":"scheduler","timestamp":"%s"}`, time.Now().Format(time.RFC3339))
+	payload := fmt.Sprintf(`{"triggered_by":"scheduler","timestamp":"%s","scheduled_for":"%s"}`,
+		time.Now().Format(time.RFC3339), scheduledFor.Format(time.RFC3339))
 	if err := ts.nc.Publish(subject, []byte(payload)); err != nil {
 		log.Printf("TeamScheduler [%s]: trigger failed: %v", ts.teamID, err)
-	} else {
-		log.Printf("TeamScheduler [%s]: triggered", ts.teamID)
+		return
+	}
+	log.Printf("TeamScheduler [%s]: triggered", ts.teamID)
+	if ts.runs != nil {
+		if err := ts.runs.RecordTeamScheduleRun(ts.ctx, ts.teamID, scheduledFor); err != nil {
+			log.Printf("TeamScheduler [%s]: last run not recorded: %v", ts.teamID, err)
+		}
 	}
 }
@@ -0,0 +1,109 @@
+package swarm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mycelis/core/internal/schedule"
+	"github.com/mycelis/core/pkg/protocol"
+	"github.com/nats-io/nats.go"
+)
+
+// memoryScheduleRuns is an in-process TeamScheduleStore.
+type memoryScheduleRuns struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+func (m *memoryScheduleRuns) LastTeamScheduleRun(_ context.Context, teamID string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last[teamID], nil
+}
+
+func (m *memoryScheduleRuns) RecordTeamScheduleRun(_ context.Context, teamID string, firedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.last[teamID] = firedAt
+	return nil
+}
+
+// startRestartedScheduler runs an hourly scheduler whose last run was 2h30m
+// ago, so the slot 1h30m ago was missed while the process was down, and
+// returns the scheduled_for times it triggers.
+func startRestartedScheduler(t *testing.T, misfire string) (<-chan time.Time, *memoryScheduleRuns, time.Time) {
+	t.Helper()
+	_, nc := startTestNATS(t)
+	sched, err := schedule.Compile(protocol.ScheduleConfig{Interval: "1h", Misfire: misfire})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	last := time.Now().Add(-150 * time.Minute).Truncate(time.Second)
+	runs := &memoryScheduleRuns{last: map[string]time.Time{"team-a": last}}
+
+	fired := make(chan time.Time, 4)
+	sub, err := nc.Subscribe(fmt.Sprintf(protocol.TopicTeamInternalTrigger, "team-a"), func(msg *nats.Msg) {
+		var payload struct {
+			ScheduledFor time.Time `json:"scheduled_for"`
+		}
+		if err := json.Unmarshal(msg.Data, &payload); err == nil {
+			fired <- payload.ScheduledFor
+		}
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	t.Cleanup(func() { _ = sub.Unsubscribe() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ts := &TeamScheduler{teamID: "team-a", schedule: sched, nc: nc, runs: runs, ctx: ctx, cancel: cancel}
+	done := make(chan struct{})
+	go func() {
+		ts.Start()
+		close(done)
+	}()
+	t.Cleanup(func() {
+		ts.Stop()
+		<-done
+	})
+	return fired, runs, last
+}
+
+func TestTeamScheduler_RestartFiresMissedSlotOnce(t *testing.T) {
+	fired, runs, last := startRestartedScheduler(t, schedule.MisfireRunOnce)
+
+	want := last.Add(2 * time.Hour) // latest missed slot, 30m ago
+	select {
+	case got := <-fired:
+		if !got.Equal(want) {
+			t.Fatalf("scheduled_for = %s, want the latest missed slot %s", got, want)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("restart did not fire the missed slot")
+	}
+	select {
+	case got := <-fired:
+		t.Fatalf("extra trigger for %s; run_once fires a single missed run", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+	if got, _ := runs.LastTeamScheduleRun(context.Background(), "team-a"); !got.Equal(want) {
+		t.Fatalf("recorded last run = %s, want %s", got, want)
+	}
+}
+
+func TestTeamScheduler_RestartSkipsMissedSlots(t *testing.T) {
+	fired, runs, last := startRestartedScheduler(t, schedule.MisfireSkip)
+
+	select {
+	case got := <-fired:
+		t.Fatalf("trigger for %s; skip drops missed runs and must not fire on start", got)
+	case <-time.After(300 * time.Millisecond):
+	}
+	if got, _ := runs.LastTeamScheduleRun(context.Background(), "team-a"); !got.Equal(last) {
+		t.Fatalf("recorded last run = %s, want it unchanged at %s", got, last)
+	}
+}
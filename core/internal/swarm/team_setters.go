@@ -26,6 +26,16 @@ func (t *Team) SetEventEmitter(emitter protocol.EventEmitter, runID string) {
 	t.runID = runID
 }
 
+// SetOrganizationID attributes the team's inference usage to an organization.
+func (t *Team) SetOrganizationID(id string) {
+	t.organizationID = id
+}
+
+// SetScheduleStore persists the team schedule's last run across restarts.
+func (t *Team) SetScheduleStore(store TeamScheduleStore) {
+	t.scheduleRuns = store
+}
+
 // SetConversationLogger wires the V7 conversation logger into this team.
 func (t *Team) SetConversationLogger(logger protocol.ConversationLogger) {
 	t.conversationLogger = logger
@@ -14,13 +14,20 @@ import (
 
 // handleTrigger receives an external signal and broadens it to the internal team bus.
 func (t *Team) handleTrigger(msg *nats.Msg) {
-	log.Printf("Team [%s] Triggered by [%s]", t.Manifest.Name, msg.Subject)
+	_ = t.dispatchTrigger(msg.Subject, msg.Data)
+}
+
+func (t *Team) dispatchTrigger(subject string, data []byte) error {
+	log.Printf("Team [%s] Triggered by [%s]", t.Manifest.Name, subject)
 	internalSubject := fmt.Sprintf(protocol.TopicTeamInternalTrigger, t.Manifest.ID)
-	payload := normalizeCommandPayload(msg.Data)
-	if correlation := extractTeamCommandCorrelation(t.Manifest.ID, msg.Data, payload); correlation != nil {
+	payload := normalizeCommandPayload(data)
+	if correlation := extractTeamCommandCorrelation(t.Manifest.ID, data, payload); correlation != nil {
+		if err := t.holdPendingAsk(subject, data, correlation); err != nil {
+			return err
+		}
 		t.rememberCommandCorrelation(*correlation)
 	}
-	t.nc.Publish(internalSubject, payload)
+	return t.nc.Publish(internalSubject, payload)
 }
 
 func normalizeCommandPayload(data []byte) []byte {
@@ -109,9 +116,12 @@ func (t *Team) rememberCommandCorrelation(correlation teamCommandCorrelation) {
 func (t *Team) responseCommandCorrelation(raw []byte) *teamCommandCorrelation {
 	if explicit := correlationFromPayload(raw); explicit != nil {
 		explicit.TeamID = firstNonEmptySignalString(explicit.TeamID, t.Manifest.ID)
+		t.releasePendingAsk(explicit)
 		return explicit
 	}
-	return t.consumeCommandCorrelation()
+	correlation := t.consumeCommandCorrelation()
+	t.releasePendingAsk(correlation)
+	return correlation
 }
 
 func (t *Team) consumeCommandCorrelation() *teamCommandCorrelation {
@@ -0,0 +1,257 @@
+package nats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Durable delivery defaults. The stream captures team commands, team results
+// and dead letters; internal.trigger stays off the stream because agents
+// answer requests on it.
+const (
+	DefaultDurableStream = "MYCELIS_SWARM"
+	DefaultPendingBucket = "MYCELIS_PENDING_ASKS"
+	DeadLetterPrefix     = "swarm.deadletter"
+)
+
+var DefaultDurableSubjects = []string{
+	"swarm.team.*.internal.command",
+	"swarm.team.*.signal.result",
+	DeadLetterPrefix + ".>",
+}
+
+// Dead letter headers.
+const (
+	HeaderDeadLetterSubject    = "Mycelis-Original-Subject"
+	HeaderDeadLetterDeliveries = "Mycelis-Deliveries"
+	HeaderDeadLetterError      = "Mycelis-Error"
+)
+
+// DurableConfig tunes JetStream-backed delivery. Zero values use defaults.
+type DurableConfig struct {
+	Stream     string
+	Subjects   []string
+	MaxAge     time.Duration // stream retention; default 24h
+	MaxDeliver int           // deliveries before a message is dead-lettered; default 5
+	AckWait    time.Duration // redelivery timeout for unacked messages; default 30s
+	RetryDelay time.Duration // redelivery delay after a handler error; default 5s
+	PendingTTL time.Duration // how long accepted asks stay replayable; default 30m
+}
+
+func (c DurableConfig) withDefaults() DurableConfig {
+	if c.Stream == "" {
+		c.Stream = DefaultDurableStream
+	}
+	if len(c.Subjects) == 0 {
+		c.Subjects = DefaultDurableSubjects
+	}
+	if c.MaxAge <= 0 {
+		c.MaxAge = 24 * time.Hour
+	}
+	if c.MaxDeliver <= 0 {
+		c.MaxDeliver = 5
+	}
+	if c.AckWait <= 0 {
+		c.AckWait = 30 * time.Second
+	}
+	if c.RetryDelay <= 0 {
+		c.RetryDelay = 5 * time.Second
+	}
+	if c.PendingTTL <= 0 {
+		c.PendingTTL = 30 * time.Minute
+	}
+	return c
+}
+
+// Durable is the opt-in JetStream delivery mode: a stream over the swarm
+// subjects, durable consumers with ack/redelivery, a dead-letter subject for
+// messages that exhaust MaxDeliver, and a bucket of accepted asks that are
+// replayed when their team restarts.
+type Durable struct {
+	nc       *nats.Conn
+	js       nats.JetStreamContext
+	pending  nats.KeyValue
+	cfg      DurableConfig
+	advisory *nats.Subscription
+}
+
+// EnableDurable creates or updates the stream and pending-ask bucket. It
+// fails when the server has JetStream disabled.
+func EnableDurable(nc *nats.Conn, cfg DurableConfig) (*Durable, error) {
+	cfg = cfg.withDefaults()
+	js, err := nc.JetStream()
+	if err != nil {
+		return nil, err
+	}
+	stream := &nats.StreamConfig{
+		Name:     cfg.Stream,
+		Subjects: cfg.Subjects,
+		MaxAge:   cfg.MaxAge,
+		Storage:  nats.FileStorage,
+		// Publishers use core NATS, some with request/reply; the stream must
+		// not answer their reply subjects with publish acks.
+		NoAck: true,
+	}
+	if _, err := js.StreamInfo(cfg.Stream); errors.Is(err, nats.ErrStreamNotFound) {
+		_, err = js.AddStream(stream)
+		if err != nil {
+			return nil, fmt.Errorf("create stream %s: %w", cfg.Stream, err)
+		}
+	} else if err != nil {
+		return nil, err
+	} else if _, err := js.UpdateStream(stream); err != nil {
+		return nil, fmt.Errorf("update stream %s: %w", cfg.Stream, err)
+	}
+	pending, err := js.CreateKeyValue(&nats.KeyValueConfig{Bucket: DefaultPendingBucket, TTL: cfg.PendingTTL})
+	if err != nil {
+		return nil, fmt.Errorf("create bucket %s: %w", DefaultPendingBucket, err)
+	}
+	d := &Durable{nc: nc, js: js, pending: pending, cfg: cfg}
+	advisory := fmt.Sprintf("$JS.EVENT.ADVISORY.CONSUMER.MAX_DELIVERIES.%s.*", cfg.Stream)
+	if d.advisory, err = nc.Subscribe(advisory, d.handleMaxDeliveries); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Subscribe delivers subject through the durable consumer named consumer,
+// creating it on first use. A nil handler error acks the message; an error
+// redelivers it after RetryDelay, and the last allowed delivery goes to the
+// dead-letter subject instead. Messages stored while nobody was subscribed
+// are delivered on subscribe. Unsubscribing keeps the consumer.
+func (d *Durable) Subscribe(subject, consumer string, handler func(*nats.Msg) error) (*nats.Subscription, error) {
+	consumer = ConsumerName(consumer)
+	if _, err := d.js.ConsumerInfo(d.cfg.Stream, consumer); errors.Is(err, nats.ErrConsumerNotFound) {
+		_, err = d.js.AddConsumer(d.cfg.Stream, &nats.ConsumerConfig{
+			Durable:        consumer,
+			DeliverSubject: "_MYCELIS.deliver." + consumer,
+			FilterSubject:  subject,
+			DeliverPolicy:  nats.DeliverAllPolicy,
+			AckPolicy:      nats.AckExplicitPolicy,
+			AckWait:        d.cfg.AckWait,
+			MaxDeliver:     d.cfg.MaxDeliver,
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	return d.js.Subscribe(subject, func(msg *nats.Msg) {
+		d.settle(msg, handler(msg))
+	}, nats.Bind(d.cfg.Stream, consumer), nats.ManualAck())
+}
+
+func (d *Durable) settle(msg *nats.Msg, err error) {
+	if err == nil {
+		_ = msg.Ack()
+		return
+	}
+	deliveries := 1
+	if meta, metaErr := msg.Metadata(); metaErr == nil {
+		deliveries = int(meta.NumDelivered)
+	}
+	if deliveries < d.cfg.MaxDeliver {
+		_ = msg.NakWithDelay(d.cfg.RetryDelay)
+		return
+	}
+	d.deadLetter(msg.Subject, msg.Data, deliveries, err.Error())
+	_ = msg.Term()
+}
+
+// handleMaxDeliveries dead-letters messages whose handler never settled
+// them, e.g. because the process died mid-delivery.
+func (d *Durable) handleMaxDeliveries(msg *nats.Msg) {
+	var advisory struct {
+		Stream     string `json:"stream"`
+		StreamSeq  uint64 `json:"stream_seq"`
+		Deliveries uint64 `json:"deliveries"`
+	}
+	if err := json.Unmarshal(msg.Data, &advisory); err != nil || advisory.StreamSeq == 0 {
+		return
+	}
+	stored, err := d.js.GetMsg(advisory.Stream, advisory.StreamSeq)
+	if err != nil {
+		log.Printf("[nats] dead letter lookup for %s #%d failed: %v", advisory.Stream, advisory.StreamSeq, err)
+		return
+	}
+	d.deadLetter(stored.Subject, stored.Data, int(advisory.Deliveries), "ack wait exceeded")
+}
+
+func (d *Durable) deadLetter(subject string, data []byte, deliveries int, reason string) {
+	out := nats.NewMsg(DeadLetterPrefix + "." + subject)
+	out.Data = data
+	out.Header.Set(HeaderDeadLetterSubject, subject)
+	out.Header.Set(HeaderDeadLetterDeliveries, strconv.Itoa(deliveries))
+	out.Header.Set(HeaderDeadLetterError, reason)
+	if err := d.nc.PublishMsg(out); err != nil {
+		log.Printf("[nats] dead letter for %s lost: %v", subject, err)
+		return
+	}
+	log.Printf("[nats] dead-lettered %s after %d deliveries: %s", subject, deliveries, reason)
+}
+
+// SavePending records an accepted ask under key until DeletePending.
+func (d *Durable) SavePending(key string, data []byte) error {
+	_, err := d.pending.Put(key, data)
+	return err
+}
+
+// DeletePending forgets an ask once it has been answered.
+func (d *Durable) DeletePending(key string) error {
+	err := d.pending.Delete(key)
+	if errors.Is(err, nats.ErrKeyNotFound) {
+		return nil
+	}
+	return err
+}
+
+// ListPending returns the unanswered asks whose key starts with prefix.
+func (d *Durable) ListPending(prefix string) (map[string][]byte, error) {
+	keys, err := d.pending.Keys()
+	if errors.Is(err, nats.ErrNoKeysFound) {
+		return map[string][]byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]byte)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		entry, err := d.pending.Get(key)
+		if err != nil {
+			continue // expired or answered since Keys
+		}
+		out[key] = entry.Value()
+	}
+	return out, nil
+}
+
+// Close stops dead-letter advisories. Streams, consumers and pending asks
+// stay on the server.
+func (d *Durable) Close() {
+	if d != nil && d.advisory != nil {
+		_ = d.advisory.Unsubscribe()
+	}
+}
+
+// ConsumerName maps an arbitrary label to a valid durable consumer name or
+// bucket key segment.
+func ConsumerName(label string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, label)
+}
@@ -0,0 +1,174 @@
+package nats
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+func startJetStreamTestServer(t *testing.T) *nats.Conn {
+	t.Helper()
+	srv, err := natsserver.NewServer(&natsserver.Options{
+		Host:      "127.0.0.1",
+		Port:      nextTransportTestNATSPort(t),
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("new server: %v", err)
+	}
+	srv.Start()
+	if !srv.ReadyForConnections(3 * time.Second) {
+		t.Fatal("nats server not ready")
+	}
+	client, err := Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() {
+		client.Conn.Close()
+		srv.Shutdown()
+		srv.WaitForShutdown()
+	})
+	return client.Conn
+}
+
+func TestDurable_DeliversMessagesPublishedWhileUnsubscribed(t *testing.T) {
+	nc := startJetStreamTestServer(t)
+	d, err := EnableDurable(nc, DurableConfig{})
+	if err != nil {
+		t.Fatalf("EnableDurable: %v", err)
+	}
+	defer d.Close()
+
+	const subject = "swarm.team.alpha.internal.command"
+	got := make(chan string, 4)
+	sub, err := d.Subscribe(subject, "team-alpha", func(msg *nats.Msg) error {
+		got <- string(msg.Data)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if err := nc.Publish(subject, []byte("first")); err != nil {
+		t.Fatal(err)
+	}
+	if msg := waitForString(t, got); msg != "first" {
+		t.Fatalf("got %q, want first", msg)
+	}
+
+	// The team goes down; work sent meanwhile waits in the stream.
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatalf("Unsubscribe: %v", err)
+	}
+	if err := nc.Publish(subject, []byte("while down")); err != nil {
+		t.Fatal(err)
+	}
+	nc.Flush()
+
+	if _, err := d.Subscribe(subject, "team-alpha", func(msg *nats.Msg) error {
+		got <- string(msg.Data)
+		return nil
+	}); err != nil {
+		t.Fatalf("resubscribe: %v", err)
+	}
+	if msg := waitForString(t, got); msg != "while down" {
+		t.Fatalf("got %q after restart, want the message sent while down", msg)
+	}
+	select {
+	case msg := <-got:
+		t.Fatalf("acked message %q was redelivered", msg)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestDurable_DeadLettersAfterMaxDeliver(t *testing.T) {
+	nc := startJetStreamTestServer(t)
+	d, err := EnableDurable(nc, DurableConfig{MaxDeliver: 3, RetryDelay: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("EnableDurable: %v", err)
+	}
+	defer d.Close()
+
+	const subject = "swarm.team.alpha.internal.command"
+	dead := make(chan *nats.Msg, 2)
+	if _, err := nc.Subscribe(DeadLetterPrefix+".>", func(msg *nats.Msg) { dead <- msg }); err != nil {
+		t.Fatal(err)
+	}
+	attempts := make(chan struct{}, 8)
+	if _, err := d.Subscribe(subject, "team-alpha", func(*nats.Msg) error {
+		attempts <- struct{}{}
+		return errors.New("agent unavailable")
+	}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if err := nc.Publish(subject, []byte("poison")); err != nil {
+		t.Fatal(err)
+	}
+
+	var msg *nats.Msg
+	select {
+	case msg = <-dead:
+	case <-time.After(3 * time.Second):
+		t.Fatal("no dead letter")
+	}
+	if msg.Subject != DeadLetterPrefix+"."+subject || string(msg.Data) != "poison" {
+		t.Fatalf("dead letter = %s %q", msg.Subject, msg.Data)
+	}
+	if msg.Header.Get(HeaderDeadLetterDeliveries) != "3" || msg.Header.Get(HeaderDeadLetterError) != "agent unavailable" {
+		t.Fatalf("dead letter headers = %v", msg.Header)
+	}
+	if len(attempts) != 3 {
+		t.Fatalf("handler ran %d times, want 3", len(attempts))
+	}
+	select {
+	case extra := <-dead:
+		t.Fatalf("duplicate dead letter %q", extra.Data)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestDurable_PendingAsks(t *testing.T) {
+	nc := startJetStreamTestServer(t)
+	d, err := EnableDurable(nc, DurableConfig{})
+	if err != nil {
+		t.Fatalf("EnableDurable: %v", err)
+	}
+	defer d.Close()
+
+	if got, err := d.ListPending("alpha/"); err != nil || len(got) != 0 {
+		t.Fatalf("empty ListPending = %v, %v", got, err)
+	}
+	for _, key := range []string{"alpha/w1", "alpha/w2", "beta/w3"} {
+		if err := d.SavePending(key, []byte(key)); err != nil {
+			t.Fatalf("SavePending(%s): %v", key, err)
+		}
+	}
+	if err := d.DeletePending("alpha/w1"); err != nil {
+		t.Fatalf("DeletePending: %v", err)
+	}
+	if err := d.DeletePending("alpha/missing"); err != nil {
+		t.Fatalf("DeletePending(missing): %v", err)
+	}
+	got, err := d.ListPending("alpha/")
+	if err != nil {
+		t.Fatalf("ListPending: %v", err)
+	}
+	if len(got) != 1 || string(got["alpha/w2"]) != "alpha/w2" {
+		t.Fatalf("ListPending = %v", got)
+	}
+}
+
+func waitForString(t *testing.T, ch <-chan string) string {
+	t.Helper()
+	select {
+	case s := <-ch:
+		return s
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+		return ""
+	}
+}
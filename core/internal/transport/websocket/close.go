@@ -0,0 +1,35 @@
+package websocket
+
+import (
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+// Close status codes (RFC 6455 section 7.4.1).
+const (
+	closeNormal        = 1000
+	closeProtocolError = 1002
+	closeInvalidData   = 1007
+)
+
+// closePayload builds a close frame body: the status code, then the reason.
+func closePayload(code uint16, reason string) []byte {
+	return append(binary.BigEndian.AppendUint16(nil, code), reason...)
+}
+
+// validClosePayload reports whether a received close frame body is empty or
+// a code a peer may send followed by a UTF-8 reason.
+func validClosePayload(payload []byte) bool {
+	if len(payload) == 0 {
+		return true
+	}
+	if len(payload) == 1 || !utf8.Valid(payload[2:]) {
+		return false
+	}
+	switch code := binary.BigEndian.Uint16(payload); {
+	case code >= 1000 && code <= 1003, code >= 1007 && code <= 1014:
+		return true // 1004-1006 and 1015 are reserved for local use
+	default:
+		return code >= 3000 && code <= 4999
+	}
+}
@@ -0,0 +1,30 @@
+package websocket
+
+import (
+	"errors"
+	"time"
+)
+
+// SetReadTimeout makes ReadMessage fail once no frame, data or control,
+// has arrived for d. Each frame pushes the deadline out again, so a peer
+// that answers pings stays connected while idle. Zero disables it.
+func (c *Conn) SetReadTimeout(d time.Duration) { c.readIdle = d }
+
+// SetWriteTimeout bounds how long each frame write may block; a write that
+// runs past it fails and the connection should be dropped. Zero disables it.
+func (c *Conn) SetWriteTimeout(d time.Duration) { c.writeTimeout = d }
+
+// WritePing sends a ping control frame. The peer's pong counts as a frame
+// for SetReadTimeout.
+func (c *Conn) WritePing(data []byte) error {
+	if len(data) > 125 {
+		return errors.New("websocket: ping payload too large")
+	}
+	return c.writeFrame(opPing, data)
+}
+
+func (c *Conn) extendReadDeadline() {
+	if c.readIdle > 0 {
+		_ = c.conn.SetReadDeadline(time.Now().Add(c.readIdle))
+	}
+}
@@ -0,0 +1,124 @@
+package websocket
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// exchange feeds client frames to a server Conn until ReadMessage fails and
+// returns the close frame body the server sent back with that error.
+func exchange(t *testing.T, frames ...[]byte) ([]byte, error) {
+	t.Helper()
+	server, client := net.Pipe()
+	defer client.Close()
+	srv := &Conn{conn: server, br: bufio.NewReader(server), readLimit: DefaultReadLimit, writeTimeout: time.Second}
+	peer := &Conn{conn: client, br: bufio.NewReader(client), client: true, readLimit: DefaultReadLimit}
+
+	go func() {
+		for _, frame := range frames {
+			if _, err := client.Write(frame); err != nil {
+				return
+			}
+		}
+	}()
+	reply := make(chan []byte, 1)
+	go func() {
+		_, op, payload, err := peer.readFrame()
+		if err != nil || op != opClose {
+			payload = nil
+		}
+		reply <- payload
+	}()
+
+	var err error
+	for err == nil {
+		_, _, err = srv.ReadMessage()
+	}
+	select {
+	case payload := <-reply:
+		return payload, err
+	case <-time.After(2 * time.Second):
+		t.Fatal("server sent no close frame")
+		return nil, nil
+	}
+}
+
+func clientFrame(op int, payload []byte, fin bool) []byte {
+	frame := (&Conn{client: true}).frame(op, payload)
+	if !fin {
+		frame[0] &^= 0x80
+	}
+	return frame
+}
+
+func closeCode(t *testing.T, payload []byte) uint16 {
+	t.Helper()
+	if len(payload) < 2 {
+		t.Fatalf("close payload = %q, want a status code", payload)
+	}
+	return binary.BigEndian.Uint16(payload)
+}
+
+func TestConn_RejectsReservedBits(t *testing.T) {
+	for _, rsv := range []byte{0x40, 0x20, 0x10} {
+		frame := clientFrame(TextMessage, []byte("hi"), true)
+		frame[0] |= rsv
+		reply, err := exchange(t, frame)
+		if err == nil || errors.Is(err, ErrClosed) {
+			t.Fatalf("rsv %#x: err = %v, want a protocol error", rsv, err)
+		}
+		if code := closeCode(t, reply); code != closeProtocolError {
+			t.Fatalf("rsv %#x: close code = %d, want %d", rsv, code, closeProtocolError)
+		}
+	}
+}
+
+func TestConn_RejectsTextThatIsNotUTF8(t *testing.T) {
+	// A rune split across fragments is fine; only the whole message counts.
+	first, rest := clientFrame(TextMessage, []byte("\xce"), false), clientFrame(opContinuation, []byte("\xba"), true)
+	server, client := net.Pipe()
+	defer client.Close()
+	srv := &Conn{conn: server, br: bufio.NewReader(server), readLimit: DefaultReadLimit}
+	go func() {
+		client.Write(first)
+		client.Write(rest)
+	}()
+	if op, data, err := srv.ReadMessage(); err != nil || op != TextMessage || string(data) != "κ" {
+		t.Fatalf("split rune = %d %q %v", op, data, err)
+	}
+
+	reply, err := exchange(t, clientFrame(TextMessage, []byte("ok"), false), clientFrame(opContinuation, []byte{0xff}, true))
+	if err == nil {
+		t.Fatal("invalid UTF-8 was accepted")
+	}
+	if code := closeCode(t, reply); code != closeInvalidData {
+		t.Fatalf("close code = %d, want %d", code, closeInvalidData)
+	}
+}
+
+func TestConn_ValidatesCloseCodesBeforeEchoing(t *testing.T) {
+	for _, tc := range []struct {
+		payload []byte
+		want    uint16
+	}{
+		{closePayload(4000, "bye"), 4000},
+		{closePayload(closeNormal, ""), closeNormal},
+		{closePayload(1005, ""), closeProtocolError}, // reserved: never sent on the wire
+		{closePayload(999, ""), closeProtocolError},
+		{closePayload(2000, ""), closeProtocolError},
+		{[]byte{0x03}, closeProtocolError},
+		{closePayload(closeNormal, "\xff"), closeProtocolError},
+	} {
+		reply, err := exchange(t, clientFrame(opClose, tc.payload, true))
+		if code := closeCode(t, reply); code != tc.want {
+			t.Fatalf("close %q: replied %d, want %d", tc.payload, code, tc.want)
+		}
+		if echoed := tc.want != closeProtocolError; echoed != errors.Is(err, ErrClosed) {
+			t.Fatalf("close %q: err = %v", tc.payload, err)
+		}
+	}
+}
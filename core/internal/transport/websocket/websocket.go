@@ -0,0 +1,243 @@
+// Package websocket is a small RFC 6455 implementation: the opening
+// handshake on both sides, message framing with fragmentation, and the
+// ping/pong/close control frames. Extensions and subprotocols are not
+// negotiated.
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Message opcodes.
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+
+	opContinuation = 0
+	opClose        = 8
+	opPing         = 9
+	opPong         = 10
+)
+
+// DefaultReadLimit bounds the size of one incoming message.
+const DefaultReadLimit = 4 << 20
+
+// DefaultWriteTimeout bounds how long one frame may take to write, so a
+// peer that stops reading cannot hold a writer forever.
+const DefaultWriteTimeout = 10 * time.Second
+
+// closeTimeout bounds the best-effort close frame sent before the
+// connection is torn down.
+const closeTimeout = time.Second
+
+// ErrClosed is returned by ReadMessage once the peer closed the connection.
+var ErrClosed = errors.New("websocket: connection closed")
+
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Conn is one WebSocket connection. Reads must come from a single
+// goroutine; writes may come from several.
+type Conn struct {
+	conn      net.Conn
+	br        *bufio.Reader
+	client    bool // clients mask what they send
+	readLimit int64
+	readIdle  time.Duration // see SetReadTimeout
+
+	writeTimeout time.Duration // see SetWriteTimeout
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+}
+
+// SetReadLimit sets the largest message ReadMessage accepts.
+func (c *Conn) SetReadLimit(n int64) { c.readLimit = n }
+
+// ReadMessage returns the next text or binary message, answering pings and
+// reassembling fragments on the way.
+func (c *Conn) ReadMessage() (int, []byte, error) {
+	var (
+		op      int
+		message []byte
+	)
+	for {
+		fin, frameOp, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+		switch frameOp {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			if !validClosePayload(payload) {
+				return 0, nil, c.fail("invalid close frame")
+			}
+			c.closeWith(payload)
+			return 0, nil, ErrClosed
+		case opContinuation:
+			if op == 0 {
+				return 0, nil, c.fail("unexpected continuation frame")
+			}
+		case TextMessage, BinaryMessage:
+			if op != 0 {
+				return 0, nil, c.fail("new message inside a fragmented one")
+			}
+			op = frameOp
+		default:
+			return 0, nil, c.fail(fmt.Sprintf("unknown opcode %d", frameOp))
+		}
+		if int64(len(message)+len(payload)) > c.readLimit {
+			return 0, nil, c.fail("message too large")
+		}
+		message = append(message, payload...)
+		if !fin {
+			continue
+		}
+		if op == TextMessage && !utf8.Valid(message) {
+			return 0, nil, c.failWith(closeInvalidData, "invalid UTF-8 in text message")
+		}
+		return op, message, nil
+	}
+}
+
+func (c *Conn) readFrame() (bool, int, []byte, error) {
+	var head [2]byte
+	c.extendReadDeadline()
+	if _, err := io.ReadFull(c.br, head[:]); err != nil {
+		return false, 0, nil, err
+	}
+	if head[0]&0x70 != 0 {
+		return false, 0, nil, c.fail("reserved bits set") // no extension was negotiated
+	}
+	fin := head[0]&0x80 != 0
+	op := int(head[0] & 0x0f)
+	masked := head[1]&0x80 != 0
+	if masked == c.client {
+		return false, 0, nil, c.fail("bad frame masking")
+	}
+	length := uint64(head[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if op >= opClose && (length > 125 || !fin) {
+		return false, 0, nil, c.fail("bad control frame")
+	}
+	if length > uint64(c.readLimit) {
+		return false, 0, nil, c.fail("message too large")
+	}
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return fin, op, payload, nil
+}
+
+// WriteMessage sends one unfragmented text or binary message.
+func (c *Conn) WriteMessage(op int, data []byte) error {
+	return c.writeFrame(op, data)
+}
+
+func (c *Conn) writeFrame(op int, payload []byte) error {
+	frame := c.frame(op, payload)
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.writeTimeout > 0 {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(c.writeTimeout))
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+func (c *Conn) frame(op int, payload []byte) []byte {
+	frame := []byte{0x80 | byte(op), 0}
+	switch n := len(payload); {
+	case n <= 125:
+		frame[1] = byte(n)
+	case n <= 0xffff:
+		frame[1] = 126
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame[1] = 127
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	if !c.client {
+		return append(frame, payload...)
+	}
+	frame[1] |= 0x80
+	var mask [4]byte
+	_, _ = rand.Read(mask[:])
+	frame = append(frame, mask[:]...)
+	start := len(frame)
+	frame = append(frame, payload...)
+	for i := range payload {
+		frame[start+i] ^= mask[i%4]
+	}
+	return frame
+}
+
+// Close sends a normal-closure frame and closes the connection.
+func (c *Conn) Close() error {
+	return c.closeWith(closePayload(closeNormal, ""))
+}
+
+func (c *Conn) fail(reason string) error {
+	return c.failWith(closeProtocolError, reason)
+}
+
+func (c *Conn) failWith(code uint16, reason string) error {
+	c.closeWith(closePayload(code, reason))
+	return errors.New("websocket: " + reason)
+}
+
+// closeWith sends a close frame carrying payload, if the write side is free,
+// and closes the underlying connection either way. A writer blocked on a
+// peer that stopped reading keeps writeMu until its deadline; closing the
+// connection under it releases it at once instead.
+func (c *Conn) closeWith(payload []byte) error {
+	var err error
+	c.closeOnce.Do(func() {
+		if c.writeMu.TryLock() {
+			_ = c.conn.SetWriteDeadline(time.Now().Add(closeTimeout))
+			_, _ = c.conn.Write(c.frame(opClose, payload))
+			c.writeMu.Unlock()
+		}
+		err = c.conn.Close()
+	})
+	return err
+}
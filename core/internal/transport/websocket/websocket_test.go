@@ -0,0 +1,234 @@
+package websocket
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAcceptKey(t *testing.T) {
+	// The worked example from RFC 6455 section 1.3.
+	if got := AcceptKey("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("AcceptKey = %q", got)
+	}
+}
+
+func startEchoServer(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			op, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(op, data); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func TestConn_EchoesTextAndLargeBinaryMessages(t *testing.T) {
+	conn, err := Dial(startEchoServer(t), "/", nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	large := bytes.Repeat([]byte("x"), 70000) // 64-bit length encoding
+	for _, msg := range []struct {
+		op   int
+		data []byte
+	}{
+		{TextMessage, []byte(`{"type":"ping"}`)},
+		{BinaryMessage, bytes.Repeat([]byte{7}, 300)},
+		{BinaryMessage, large},
+	} {
+		if err := conn.WriteMessage(msg.op, msg.data); err != nil {
+			t.Fatalf("WriteMessage: %v", err)
+		}
+		op, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("ReadMessage: %v", err)
+		}
+		if op != msg.op || !bytes.Equal(data, msg.data) {
+			t.Fatalf("echo = op %d, %d bytes; want op %d, %d bytes", op, len(data), msg.op, len(msg.data))
+		}
+	}
+}
+
+func TestConn_AnswersPingAndClose(t *testing.T) {
+	conn, err := Dial(startEchoServer(t), "/", nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	if err := conn.writeFrame(opPing, []byte("hi")); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.WriteMessage(TextMessage, []byte("after ping")); err != nil {
+		t.Fatal(err)
+	}
+	// The pong is consumed by ReadMessage; the echo follows.
+	if _, data, err := conn.ReadMessage(); err != nil || string(data) != "after ping" {
+		t.Fatalf("ReadMessage = %q, %v", data, err)
+	}
+	if err := conn.writeFrame(opClose, []byte{0x03, 0xe8}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := conn.ReadMessage(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("after close: %v, want ErrClosed", err)
+	}
+}
+
+// startPingingServer reads with a short timeout while pinging every 20ms and
+// reports how the read ended.
+func startPingingServer(t *testing.T) (string, <-chan error) {
+	t.Helper()
+	ended := make(chan error, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetReadTimeout(80 * time.Millisecond)
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			ticker := time.NewTicker(20 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					_ = conn.WritePing(nil)
+				}
+			}
+		}()
+		_, _, err = conn.ReadMessage()
+		ended <- err
+	}))
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://"), ended
+}
+
+func TestConn_ReadTimeoutDropsSilentPeers(t *testing.T) {
+	addr, ended := startPingingServer(t)
+	conn, err := Dial(addr, "/", nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	// Never reading means never answering pings.
+	select {
+	case err := <-ended:
+		if err == nil {
+			t.Fatal("read ended without an error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("silent peer was never dropped")
+	}
+}
+
+func TestConn_PongsKeepIdlePeersConnected(t *testing.T) {
+	addr, ended := startPingingServer(t)
+	conn, err := Dial(addr, "/", nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case err := <-ended:
+		t.Fatalf("peer answering pings was dropped: %v", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+	if err := conn.WriteMessage(TextMessage, []byte("still here")); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-ended; err != nil {
+		t.Fatalf("read after idle period: %v", err)
+	}
+}
+
+func TestUpgrade_RejectsPlainRequests(t *testing.T) {
+	rr := httptest.NewRecorder()
+	if _, err := Upgrade(rr, httptest.NewRequest(http.MethodGet, "/", nil)); err == nil {
+		t.Fatal("expected error")
+	}
+	if rr.Code != http.StatusUpgradeRequired {
+		t.Fatalf("status = %d, want 426", rr.Code)
+	}
+}
+
+// stalledConn returns the server side of a connection whose peer never
+// reads.
+func stalledConn(t *testing.T, writeTimeout time.Duration) *Conn {
+	t.Helper()
+	server, client := net.Pipe()
+	t.Cleanup(func() { client.Close() })
+	return &Conn{conn: server, br: bufio.NewReader(server), readLimit: DefaultReadLimit, writeTimeout: writeTimeout}
+}
+
+func TestConn_WriteTimeoutFailsWritesToStalledPeers(t *testing.T) {
+	conn := stalledConn(t, 50*time.Millisecond)
+	defer conn.Close()
+
+	start := time.Now()
+	err := conn.WriteMessage(TextMessage, []byte("unread"))
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("WriteMessage err = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("write took %v", elapsed)
+	}
+}
+
+func TestConn_CloseDoesNotWaitForABlockedWriter(t *testing.T) {
+	conn := stalledConn(t, 0)
+	written := make(chan error, 1)
+	go func() { written <- conn.WriteMessage(TextMessage, []byte("unread")) }()
+	time.Sleep(20 * time.Millisecond) // let the writer block holding writeMu
+
+	closed := make(chan struct{})
+	go func() {
+		conn.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close blocked behind the stalled writer")
+	}
+	select {
+	case err := <-written:
+		if err == nil {
+			t.Fatal("blocked write succeeded after Close")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close did not release the blocked writer")
+	}
+}
@@ -0,0 +1,217 @@
+package triggers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mycelis/core/internal/expr"
+)
+
+// Trigger conditions are expressions in the internal/expr language, stored
+// either as a JSON string:
+//
+//	"payload.amount > 100 && run.status == 'completed'"
+//
+// or as a JSON tree whose clauses compile to expr programs:
+//
+//	{"all": [
+//	  {"field": "payload.amount", "op": "gt", "value": 100},
+//	  {"any": [
+//	    {"field": "run.status", "op": "eq", "value": "completed"},
+//	    {"not": {"field": "payload.tags", "op": "contains", "value": "draft"}}
+//	  ]}
+//	]}
+//
+// A plain object of field/value pairs ({"payload.status": "ready"}) is the
+// original equality form and still means "all fields equal". Strings may
+// also appear as items of all/any/not. The tree is kept so explain traces
+// can report each clause.
+
+// conditionOps maps accepted operator spellings to their canonical name.
+var conditionOps = map[string]string{
+	"eq": "eq", "==": "eq", "ne": "ne", "!=": "ne",
+	"gt": "gt", ">": "gt", "gte": "gte", ">=": "gte",
+	"lt": "lt", "<": "lt", "lte": "lte", "<=": "lte",
+	"in": "in", "not_in": "not_in", "contains": "contains", "not_contains": "not_contains",
+	"matches": "matches", "exists": "exists", "not_exists": "not_exists",
+}
+
+// conditionNode is a compiled condition: a group (all/any/not), a clause or
+// an expression. Clauses and expressions evaluate through prog.
+type conditionNode struct {
+	path     string // location in the condition, e.g. condition.all[1].any[0]
+	group    string // "all" | "any" | "not"; empty for clauses
+	children []*conditionNode
+
+	field string // empty for expressions
+	op    string // "expr" for expressions
+	value any
+	prog  *expr.Program
+}
+
+// compileCondition parses and validates a rule condition. Empty conditions
+// compile to nil, which always matches.
+func compileCondition(raw json.RawMessage) (*conditionNode, error) {
+	if !hasTriggerCondition(raw) {
+		return nil, nil
+	}
+	var tree any
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		return nil, fmt.Errorf("condition JSON is invalid: %w", err)
+	}
+	return compileConditionNode("condition", tree)
+}
+
+func compileConditionNode(path string, tree any) (*conditionNode, error) {
+	if src, ok := tree.(string); ok {
+		prog, err := expr.Compile(src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return &conditionNode{path: path, op: "expr", prog: prog}, nil
+	}
+	obj, ok := tree.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be an object or an expression string", path)
+	}
+	for _, group := range []string{"all", "any", "not"} {
+		sub, ok := obj[group]
+		if !ok {
+			continue
+		}
+		if len(obj) != 1 {
+			return nil, fmt.Errorf("%s: %q cannot be combined with other keys", path, group)
+		}
+		node := &conditionNode{path: path, group: group}
+		if group == "not" {
+			child, err := compileConditionNode(path+".not", sub)
+			if err != nil {
+				return nil, err
+			}
+			node.children = []*conditionNode{child}
+			return node, nil
+		}
+		items, ok := sub.([]any)
+		if !ok || len(items) == 0 {
+			return nil, fmt.Errorf("%s.%s: must be a non-empty list", path, group)
+		}
+		for i, item := range items {
+			child, err := compileConditionNode(fmt.Sprintf("%s.%s[%d]", path, group, i), item)
+			if err != nil {
+				return nil, err
+			}
+			node.children = append(node.children, child)
+		}
+		return node, nil
+	}
+	if _, ok := obj["field"]; ok {
+		return compileClause(path, obj)
+	}
+	return compileEqualityMap(path, obj)
+}
+
+func compileClause(path string, obj map[string]any) (*conditionNode, error) {
+	field, _ := obj["field"].(string)
+	if strings.TrimSpace(field) == "" {
+		return nil, fmt.Errorf("%s.field: must be a non-empty string", path)
+	}
+	opName, _ := obj["op"].(string)
+	if opName == "" {
+		opName = "eq"
+	}
+	op, ok := conditionOps[strings.ToLower(opName)]
+	if !ok {
+		return nil, fmt.Errorf("%s.op: unknown operator %q", path, opName)
+	}
+	for key := range obj {
+		if key != "field" && key != "op" && key != "value" {
+			return nil, fmt.Errorf("%s: unknown key %q", path, key)
+		}
+	}
+	value, hasValue := obj["value"]
+	node := &conditionNode{path: path, field: field, op: op, value: value}
+	switch op {
+	case "exists", "not_exists":
+		if hasValue {
+			return nil, fmt.Errorf("%s.value: %s takes no value", path, op)
+		}
+	case "in", "not_in":
+		if _, ok := value.([]any); !ok {
+			return nil, fmt.Errorf("%s.value: %s needs a list", path, op)
+		}
+	case "matches":
+		if _, ok := value.(string); !ok {
+			return nil, fmt.Errorf("%s.value: matches needs a string pattern", path)
+		}
+	default:
+		if !hasValue {
+			return nil, fmt.Errorf("%s.value: %s needs a value", path, op)
+		}
+	}
+	return node, node.compileClause()
+}
+
+// compileEqualityMap turns the original {"key": expected} form into an
+// "all" group of eq clauses, in key order for a stable trace.
+func compileEqualityMap(path string, obj map[string]any) (*conditionNode, error) {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	node := &conditionNode{path: path, group: "all"}
+	for _, key := range keys {
+		child := &conditionNode{path: path + "." + key, field: key, op: "eq", value: obj[key]}
+		if err := child.compileClause(); err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, child)
+	}
+	return node, nil
+}
+
+// validateRule checks the fields each trigger kind requires and the
+// rule's condition.
+func validateRule(r *TriggerRule) error {
+	if !isValidTriggerKind(r.TriggerKind) {
+		return &InvalidRuleError{fmt.Errorf("triggers: trigger_kind must be event or schedule")}
+	}
+	if r.TriggerKind != "schedule" && r.EventPattern == "" {
+		return &InvalidRuleError{fmt.Errorf("triggers: event_pattern is required")}
+	}
+	if r.TriggerKind == "schedule" {
+		if _, err := r.CompiledSchedule(); err != nil {
+			return &InvalidRuleError{err}
+		}
+	}
+	if _, err := compileCondition(r.Condition); err != nil {
+		return &InvalidRuleError{fmt.Errorf("triggers: %w", err)}
+	}
+	return nil
+}
+
+// InvalidRuleError is returned by Create and Update for rules that fail
+// validation, as opposed to storage failures.
+type InvalidRuleError struct{ Err error }
+
+func (e *InvalidRuleError) Error() string { return e.Err.Error() }
+func (e *InvalidRuleError) Unwrap() error { return e.Err }
+
+// referencesRun reports whether any clause or expression reads the source
+// run.
+func (n *conditionNode) referencesRun() bool {
+	if n == nil {
+		return false
+	}
+	if n.prog.References("run") {
+		return true
+	}
+	for _, child := range n.children {
+		if child.referencesRun() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package triggers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mycelis/core/internal/expr"
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+// conditionSubject is what a condition is evaluated against: the event and,
+// for run.* fields, the source run.
+type conditionSubject struct {
+	event  protocol.MissionEventEnvelope
+	run    map[string]interface{}
+	runErr error
+	vars   map[string]interface{}
+}
+
+// env lays out what conditions read: payload keys at the top level, so
+// "status" reads payload.status, then the event's own fields, "payload"
+// and, when loaded, "run" over them. Empty event fields are absent.
+func (s *conditionSubject) env() expr.Env {
+	if s.vars == nil {
+		s.vars = make(map[string]interface{}, len(s.event.Payload)+10)
+		for key, value := range s.event.Payload {
+			s.vars[key] = value
+		}
+		fields := map[string]string{
+			"id": s.event.ID, "event_id": s.event.ID, "mission_event_id": s.event.ID,
+			"run_id": s.event.RunID, "event_type": string(s.event.EventType), "severity": string(s.event.Severity),
+			"source_agent": s.event.SourceAgent, "source_team": s.event.SourceTeam,
+		}
+		for key, value := range fields {
+			delete(s.vars, key)
+			if value != "" {
+				s.vars[key] = value
+			}
+		}
+		delete(s.vars, "payload")
+		if s.event.Payload != nil {
+			s.vars["payload"] = s.event.Payload
+		}
+		delete(s.vars, "run")
+		if s.run != nil {
+			s.vars["run"] = s.run
+		}
+	}
+	return expr.Env{Vars: s.vars}
+}
+
+// sourceRunContext exposes the source run to conditions as run.id,
+// run.status, run.mission_id, run.depth, run.parent_run_id, run.started_at,
+// run.completed_at and run.outputs — the "outputs" of the run's most recent
+// event that reported any.
+func (e *Engine) sourceRunContext(ctx context.Context, runID string) (map[string]interface{}, error) {
+	if runID == "" {
+		return nil, fmt.Errorf("event has no source run")
+	}
+	if e.runs == nil {
+		return nil, fmt.Errorf("run manager not available")
+	}
+	run, err := e.runs.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]interface{}{
+		"id":         run.ID,
+		"status":     run.Status,
+		"mission_id": run.MissionID,
+		"depth":      run.RunDepth,
+		"started_at": run.StartedAt.UTC().Format(time.RFC3339),
+	}
+	if run.ParentRunID != "" {
+		out["parent_run_id"] = run.ParentRunID
+	}
+	if run.CompletedAt != nil {
+		out["completed_at"] = run.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	if e.events != nil {
+		timeline, err := e.events.GetRunTimeline(ctx, runID)
+		if err == nil {
+			for i := len(timeline) - 1; i >= 0; i-- {
+				if outputs, ok := timeline[i].Payload["outputs"]; ok {
+					out["outputs"] = outputs
+					break
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+func (n *conditionNode) explain(s *conditionSubject) ConditionTrace {
+	trace := ConditionTrace{Steps: []ConditionStep{}}
+	matched, reason := n.eval(s, &trace.Steps)
+	trace.Matched = matched
+	if !matched {
+		trace.Reason = reason
+	}
+	return trace
+}
+
+func (n *conditionNode) eval(s *conditionSubject, steps *[]ConditionStep) (bool, string) {
+	if n.group == "" {
+		return n.evalClause(s, steps)
+	}
+	i := len(*steps)
+	*steps = append(*steps, ConditionStep{Path: n.path, Op: n.group})
+	var result bool
+	var reason string
+	switch n.group {
+	case "all":
+		result = true
+		for _, child := range n.children {
+			if ok, why := child.eval(s, steps); !ok {
+				result, reason = false, why
+				break
+			}
+		}
+	case "any":
+		var reasons []string
+		for _, child := range n.children {
+			ok, why := child.eval(s, steps)
+			if ok {
+				result = true
+				break
+			}
+			reasons = append(reasons, why)
+		}
+		if !result {
+			reason = "none matched: " + strings.Join(reasons, "; ")
+		}
+	case "not":
+		ok, _ := n.children[0].eval(s, steps)
+		result = !ok
+		if !result {
+			reason = n.children[0].path + " matched"
+		}
+	}
+	(*steps)[i].Result = result
+	return result, reason
+}
+
+func (n *conditionNode) evalClause(s *conditionSubject, steps *[]ConditionStep) (bool, string) {
+	step := ConditionStep{Path: n.path, Field: n.field, Op: n.op, Expected: n.value, Expr: n.prog.String()}
+	matched, err := n.prog.Eval(s.env())
+	step.Result = matched
+	actual, present := nestedValue(s.vars, n.field)
+	if n.field != "" {
+		step.Present = present
+		if present {
+			step.Actual = actual
+		}
+	}
+	switch {
+	case err != nil:
+		step.Detail = err.Error()
+	case !present && s.runErr != nil && n.prog.References("run"):
+		step.Detail = "source run unavailable: " + s.runErr.Error()
+	}
+	*steps = append(*steps, step)
+	if step.Result {
+		return true, ""
+	}
+
+	subject := n.field
+	if subject == "" {
+		subject = "expression"
+	}
+	switch {
+	case step.Detail != "":
+		return false, fmt.Sprintf("%s: %s", subject, step.Detail)
+	case n.field == "":
+		return false, "expression was false: " + n.prog.String()
+	case n.op == "not_exists":
+		return false, fmt.Sprintf("%s was present", n.field)
+	case !present:
+		return false, fmt.Sprintf("%s was not present", n.field)
+	case n.op == "eq":
+		return false, fmt.Sprintf("%s was %v", n.field, actual)
+	}
+	return false, fmt.Sprintf("%s was %v, want %s %v", n.field, actual, n.op, n.value)
+}
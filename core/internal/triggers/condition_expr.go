@@ -0,0 +1,110 @@
+package triggers
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mycelis/core/internal/expr"
+)
+
+// exprComparisons maps clause operators onto expr operators.
+var exprComparisons = map[string]string{
+	"gt": ">", "gte": ">=", "lt": "<", "lte": "<=",
+	"in": "in", "not_in": "not in", "contains": "contains", "matches": "matches",
+}
+
+var (
+	exprName  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	exprIndex = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// compileClause translates a field/op/value clause into an expr program,
+// building the tree directly so clause values are never parsed as syntax.
+// Every operator but exists and not_exists requires the field to be
+// present, as the clause form always has.
+func (n *conditionNode) compileClause() error {
+	field, err := exprPath(n.field)
+	if err != nil {
+		return fmt.Errorf("%s.field: %w", n.path, err)
+	}
+	var test expr.Expr
+	switch n.op {
+	case "exists":
+		test = expr.Exists(field...)
+	case "not_exists":
+		test = expr.Not(expr.Exists(field...))
+	case "eq", "ne":
+		if test, err = exprEquals(field, n.value); err == nil && n.op == "ne" {
+			test = expr.Not(test)
+		}
+	case "not_contains":
+		if test, err = exprCompare("contains", field, n.value); err == nil {
+			test = expr.Not(test)
+		}
+	default:
+		test, err = exprCompare(exprComparisons[n.op], field, n.value)
+	}
+	if err != nil {
+		return fmt.Errorf("%s.value: %w", n.path, err)
+	}
+	if n.op != "exists" && n.op != "not_exists" {
+		test = expr.And(expr.Exists(field...), test)
+	}
+	n.prog = expr.Build(test)
+	return nil
+}
+
+// exprEquals builds eq: a list means any of its items and an object means
+// every listed sub-field equals its value.
+func exprEquals(field []string, value any) (expr.Expr, error) {
+	switch want := value.(type) {
+	case []any:
+		return exprCompare("in", field, want)
+	case map[string]any:
+		if len(want) == 0 {
+			return expr.Exists(field...), nil
+		}
+		keys := make([]string, 0, len(want))
+		for key := range want {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]expr.Expr, 0, len(keys))
+		for _, key := range keys {
+			sub, err := exprPath(key)
+			if err != nil {
+				return expr.Expr{}, err
+			}
+			part, err := exprEquals(append(slices.Clip(field), sub...), want[key])
+			if err != nil {
+				return expr.Expr{}, err
+			}
+			parts = append(parts, part)
+		}
+		return expr.And(parts[0], parts[1:]...), nil
+	}
+	return exprCompare("==", field, value)
+}
+
+// exprCompare compares the field with a clause value.
+func exprCompare(op string, field []string, value any) (expr.Expr, error) {
+	literal, err := expr.Value(value)
+	if err != nil {
+		return expr.Expr{}, fmt.Errorf("%v cannot be compared here; use a string, number, boolean, null or list", value)
+	}
+	return expr.Compare(op, expr.Path(field...), literal)
+}
+
+// exprPath splits field into the segments of a dotted path expr can address.
+func exprPath(field string) ([]string, error) {
+	segments := strings.Split(field, ".")
+	for i, segment := range segments {
+		if !exprName.MatchString(segment) && (i == 0 || !exprIndex.MatchString(segment)) {
+			return nil, fmt.Errorf("%q is not a dotted path of names and list indexes", field)
+		}
+	}
+	return segments, nil
+}
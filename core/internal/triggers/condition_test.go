@@ -0,0 +1,235 @@
+package triggers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mycelis/core/internal/events"
+	"github.com/mycelis/core/internal/runs"
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+func TestConditionOperators(t *testing.T) {
+	subject := &conditionSubject{
+		event: protocol.MissionEventEnvelope{
+			ID: "ev-1", EventType: "mission.completed", SourceTeam: "finance",
+			Payload: map[string]interface{}{
+				"amount": 120.0,
+				"tags":   []interface{}{"vip", "eu"},
+				"ticket": "OPS-42",
+				"items":  []interface{}{map[string]interface{}{"sku": "A-1"}},
+			},
+		},
+		run: map[string]interface{}{
+			"status":  "completed",
+			"outputs": map[string]interface{}{"report": "q3.pdf", "rows": 18.0},
+		},
+	}
+
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		{`{"field": "payload.amount", "op": "gt", "value": 100}`, true},
+		{`{"field": "amount", "op": "<=", "value": "100"}`, false},
+		{`{"field": "payload.ticket", "op": "matches", "value": "^OPS-[0-9]+$"}`, true},
+		{`{"field": "payload.tags", "op": "contains", "value": "vip"}`, true},
+		{`{"field": "payload.ticket", "op": "not_contains", "value": "DEV"}`, true},
+		{`{"field": "source_team", "op": "in", "value": ["finance", "ops"]}`, true},
+		{`{"field": "source_team", "op": "not_in", "value": ["finance"]}`, false},
+		{`{"field": "payload.items.0.sku", "value": "A-1"}`, true},
+		{`{"field": "payload.note", "op": "exists"}`, false},
+		{`{"field": "payload.note", "op": "not_exists"}`, true},
+		{`{"field": "payload.note", "op": "ne", "value": "x"}`, false},
+		{`{"field": "run.status", "op": "eq", "value": "completed"}`, true},
+		{`{"field": "run.outputs.rows", "op": ">=", "value": 10}`, true},
+		{`{"field": "run.outputs", "op": "contains", "value": "report"}`, true},
+		{`{"all": [{"field": "amount", "op": "gt", "value": 100}, {"not": {"field": "payload.tags", "op": "contains", "value": "draft"}}]}`, true},
+		{`{"any": [{"field": "amount", "op": "lt", "value": 10}, {"field": "run.status", "value": "failed"}]}`, false},
+		{`{"payload.ticket": "OPS-42", "event_type": "mission.completed"}`, true},
+		{`"amount > 100 && run.outputs.rows >= 10 && 'vip' in payload.tags"`, true},
+		{`{"any": ["source_team == 'ops'", {"field": "payload.ticket", "value": "OPS-42"}]}`, true},
+		{`{"not": "exists(payload.items.0.sku)"}`, false},
+		{`{"field": "run.outputs", "value": {"report": "q3.pdf"}}`, true},
+	}
+	for _, tt := range tests {
+		root, err := compileCondition(json.RawMessage(tt.condition))
+		if err != nil {
+			t.Fatalf("compile %s: %v", tt.condition, err)
+		}
+		if got := root.explain(subject); got.Matched != tt.want {
+			t.Errorf("%s: matched = %v, want %v (reason %q)", tt.condition, got.Matched, tt.want, got.Reason)
+		}
+	}
+}
+
+func TestConditionTrace(t *testing.T) {
+	root, err := compileCondition(json.RawMessage(`{"all": [
+		{"field": "payload.amount", "op": "gt", "value": 100},
+		{"any": [{"field": "payload.region", "value": "eu"}, {"field": "payload.region", "value": "us"}]},
+		{"field": "payload.never", "op": "exists"}
+	]}`))
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	trace := root.explain(&conditionSubject{event: protocol.MissionEventEnvelope{
+		Payload: map[string]interface{}{"amount": 150.0, "region": "apac"},
+	}})
+	if trace.Matched {
+		t.Fatal("expected mismatch")
+	}
+	if !strings.Contains(trace.Reason, "payload.region was apac") {
+		t.Errorf("reason = %q", trace.Reason)
+	}
+	// all, amount clause, any, two region clauses; "all" stops before the
+	// exists clause.
+	if len(trace.Steps) != 5 {
+		t.Fatalf("steps = %+v", trace.Steps)
+	}
+	if trace.Steps[0].Path != "condition" || trace.Steps[0].Result {
+		t.Errorf("root step = %+v", trace.Steps[0])
+	}
+	if s := trace.Steps[1]; s.Path != "condition.all[0]" || !s.Result || s.Actual != 150.0 {
+		t.Errorf("amount step = %+v", s)
+	}
+	if s := trace.Steps[4]; s.Path != "condition.all[1].any[1]" || s.Result {
+		t.Errorf("second region step = %+v", s)
+	}
+}
+
+func TestConditionTrace_ReportsExpressions(t *testing.T) {
+	root, err := compileCondition(json.RawMessage(`{"all": ["payload.amount > 100", {"field": "run.status", "value": "completed"}]}`))
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	if !root.referencesRun() {
+		t.Fatal("run.status clause should load the source run")
+	}
+	trace := root.explain(&conditionSubject{
+		event:  protocol.MissionEventEnvelope{Payload: map[string]interface{}{"amount": 150.0}},
+		runErr: errors.New("run not found"),
+	})
+	if trace.Matched || !strings.Contains(trace.Reason, "source run unavailable") {
+		t.Fatalf("trace = %+v, want a run-unavailable mismatch", trace)
+	}
+	if s := trace.Steps[1]; s.Op != "expr" || s.Expr != "payload.amount > 100" || !s.Result {
+		t.Errorf("expression step = %+v", s)
+	}
+	if s := trace.Steps[2]; s.Expr != `exists(run.status) && (run.status == "completed")` {
+		t.Errorf("clause step expr = %q", s.Expr)
+	}
+}
+
+func TestConditionClauseValuesAreNotSyntax(t *testing.T) {
+	subject := &conditionSubject{event: protocol.MissionEventEnvelope{
+		Payload: map[string]interface{}{"ticket": `OPS" || true || "`, "note": "plain"},
+	}}
+	for _, tt := range []struct {
+		condition string
+		want      bool
+	}{
+		{`{"field": "payload.note", "value": "x\" || true || \""}`, false},
+		{`{"field": "payload.ticket", "value": "OPS\" || true || \""}`, true},
+		{`{"field": "payload.note", "op": "in", "value": ["') || exists(payload.note) || ('"]}`, false},
+	} {
+		root, err := compileCondition(json.RawMessage(tt.condition))
+		if err != nil {
+			t.Fatalf("compile %s: %v", tt.condition, err)
+		}
+		if got := root.explain(subject); got.Matched != tt.want {
+			t.Errorf("%s: matched = %v, want %v", tt.condition, got.Matched, tt.want)
+		}
+	}
+}
+
+func TestCompileConditionErrors(t *testing.T) {
+	for _, condition := range []string{
+		`[1, 2]`,
+		`{"field": "amount", "op": "between", "value": 1}`,
+		`{"field": "amount", "op": "gt"}`,
+		`{"field": "amount", "op": "in", "value": "a"}`,
+		`{"field": "ticket", "op": "matches", "value": "("}`,
+		`{"field": "ticket", "op": "exists", "value": true}`,
+		`{"field": "", "value": 1}`,
+		`{"field": "a", "value": 1, "extra": true}`,
+		`{"all": []}`,
+		`{"all": [{"field": "a", "value": 1}], "any": []}`,
+		`{"not": [1]}`,
+		`{"field": "a"`,
+		`"amount >"`,
+		`{"field": "payload.customer-tier", "value": "gold"}`,
+		`{"field": "amount", "op": "gt", "value": {"a": 1}}`,
+		`{"all": [42]}`,
+	} {
+		if _, err := compileCondition(json.RawMessage(condition)); err == nil {
+			t.Errorf("compile %s: expected error", condition)
+		}
+	}
+}
+
+func TestCreate_InvalidConditionRejected(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	s := NewStore(db)
+
+	err = s.Create(context.Background(), &TriggerRule{
+		Name: "bad", EventPattern: "mission.completed", TargetMissionID: "m",
+		Condition: json.RawMessage(`{"field": "payload.amount", "op": "approx", "value": 1}`),
+	})
+	var invalid *InvalidRuleError
+	if !errors.As(err, &invalid) || !strings.Contains(err.Error(), "condition.op") {
+		t.Fatalf("Create error = %v, want InvalidRuleError at condition.op", err)
+	}
+}
+
+func TestEvaluateCondition_SourceRunStatusAndOutputs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	now := time.Now()
+	eventColumns := []string{
+		"id", "run_id", "tenant_id", "event_type", "severity",
+		"source_agent", "source_team", "payload", "audit_event_id", "emitted_at",
+	}
+	mock.ExpectQuery("SELECT .+ FROM mission_events").
+		WithArgs("ev-1").
+		WillReturnRows(sqlmock.NewRows(eventColumns).AddRow(
+			"ev-1", "run-1", "default", "mission.completed", "info", "soma", "team-alpha", `{}`, "", now))
+	mock.ExpectQuery("SELECT .+ FROM mission_runs WHERE").
+		WithArgs("run-1").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "mission_id", "tenant_id", "status", "run_depth", "parent_run_id", "started_at", "completed_at",
+		}).AddRow("run-1", "m-1", "default", "completed", 0, "", now, now))
+	mock.ExpectQuery("SELECT .+ FROM mission_events").
+		WithArgs("run-1").
+		WillReturnRows(sqlmock.NewRows(eventColumns).
+			AddRow("ev-0", "run-1", "default", "tool.completed", "info", "soma", "team-alpha",
+				`{"outputs": {"artifact": "draft.md"}}`, "", now).
+			AddRow("ev-1", "run-1", "default", "mission.completed", "info", "soma", "team-alpha", `{}`, "", now))
+
+	e := &Engine{events: events.NewStore(db, nil), runs: runs.NewManager(db)}
+	condition := json.RawMessage(`{"all": [
+		{"field": "run.status", "value": "completed"},
+		{"field": "run.outputs.artifact", "op": "matches", "value": "\\.md$"}
+	]}`)
+	matches, reason, err := e.evaluateCondition(context.Background(), condition, "ev-1", "run-1", "mission.completed")
+	if err != nil {
+		t.Fatalf("evaluateCondition error: %v", err)
+	}
+	if !matches {
+		t.Fatalf("expected match, reason: %s", reason)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet DB expectations: %v", err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"reflect"
 	"strconv"
 	"strings"
 
@@ -13,7 +12,28 @@ import (
 
 func hasTriggerCondition(condition json.RawMessage) bool {
 	trimmed := strings.TrimSpace(string(condition))
-	return trimmed != "" && trimmed != "{}" && trimmed != "null"
+	return trimmed != "" && trimmed != "{}" && trimmed != "null" && trimmed != `""`
+}
+
+// ConditionStep is one evaluated node of a condition.
+type ConditionStep struct {
+	Path     string `json:"path"`
+	Field    string `json:"field,omitempty"`
+	Op       string `json:"op"`
+	Expr     string `json:"expr,omitempty"` // the expr program evaluated
+	Expected any    `json:"expected,omitempty"`
+	Actual   any    `json:"actual,omitempty"`
+	Present  bool   `json:"present,omitempty"`
+	Result   bool   `json:"result"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// ConditionTrace explains how a condition was evaluated. Steps are in
+// evaluation order; groups short-circuit, so later clauses may be absent.
+type ConditionTrace struct {
+	Matched bool            `json:"matched"`
+	Reason  string          `json:"reason,omitempty"` // why it did not match
+	Steps   []ConditionStep `json:"steps"`
 }
 
 func (e *Engine) evaluateCondition(
@@ -23,12 +43,25 @@ func (e *Engine) evaluateCondition(
 	sourceRunID string,
 	eventType string,
 ) (bool, string, error) {
-	var expected map[string]interface{}
-	if err := json.Unmarshal(condition, &expected); err != nil {
-		return false, "", fmt.Errorf("condition JSON is invalid: %w", err)
+	trace, err := e.explainCondition(ctx, condition, eventID, sourceRunID, eventType)
+	return trace.Matched, trace.Reason, err
+}
+
+// explainCondition loads the event (and, when referenced, the source run)
+// and evaluates condition against it.
+func (e *Engine) explainCondition(
+	ctx context.Context,
+	condition json.RawMessage,
+	eventID string,
+	sourceRunID string,
+	eventType string,
+) (ConditionTrace, error) {
+	root, err := compileCondition(condition)
+	if err != nil {
+		return ConditionTrace{}, err
 	}
-	if len(expected) == 0 {
-		return true, "", nil
+	if root == nil {
+		return ConditionTrace{Matched: true, Steps: []ConditionStep{}}, nil
 	}
 
 	event := protocol.MissionEventEnvelope{
@@ -40,48 +73,20 @@ func (e *Engine) evaluateCondition(
 	if e.events != nil && eventID != "" {
 		loaded, err := e.events.GetEvent(ctx, eventID)
 		if err != nil {
-			return false, "", fmt.Errorf("condition event payload unavailable: %w", err)
+			return ConditionTrace{}, fmt.Errorf("condition event payload unavailable: %w", err)
 		}
 		event = *loaded
 	}
-
-	for key, want := range expected {
-		got, ok := conditionValue(event, key)
-		if !ok {
-			return false, fmt.Sprintf("%s was not present", key), nil
-		}
-		if !conditionEqual(got, want) {
-			return false, fmt.Sprintf("%s was %v", key, got), nil
-		}
-	}
-	return true, "", nil
+	return e.explainEvent(ctx, root, event), nil
 }
 
-func conditionValue(event protocol.MissionEventEnvelope, key string) (interface{}, bool) {
-	switch key {
-	case "id", "event_id", "mission_event_id":
-		return event.ID, event.ID != ""
-	case "run_id":
-		return event.RunID, event.RunID != ""
-	case "event_type":
-		return string(event.EventType), event.EventType != ""
-	case "severity":
-		return string(event.Severity), event.Severity != ""
-	case "source_agent":
-		return event.SourceAgent, event.SourceAgent != ""
-	case "source_team":
-		return event.SourceTeam, event.SourceTeam != ""
-	case "payload":
-		return event.Payload, event.Payload != nil
-	}
-
-	if strings.HasPrefix(key, "payload.") {
-		return nestedValue(event.Payload, strings.TrimPrefix(key, "payload."))
-	}
-	if got, ok := nestedValue(event.Payload, key); ok {
-		return got, true
+// explainEvent evaluates a compiled condition against a loaded event.
+func (e *Engine) explainEvent(ctx context.Context, root *conditionNode, event protocol.MissionEventEnvelope) ConditionTrace {
+	subject := &conditionSubject{event: event}
+	if root.referencesRun() {
+		subject.run, subject.runErr = e.sourceRunContext(ctx, event.RunID)
 	}
-	return false, false
+	return root.explain(subject)
 }
 
 func nestedValue(source map[string]interface{}, dottedPath string) (interface{}, bool) {
@@ -90,67 +95,22 @@ func nestedValue(source map[string]interface{}, dottedPath string) (interface{},
 	}
 	var current interface{} = source
 	for _, part := range strings.Split(dottedPath, ".") {
-		obj, ok := current.(map[string]interface{})
-		if !ok {
-			return nil, false
-		}
-		current, ok = obj[part]
-		if !ok {
-			return nil, false
-		}
-	}
-	return current, true
-}
-
-func conditionEqual(got interface{}, want interface{}) bool {
-	switch expected := want.(type) {
-	case []interface{}:
-		for _, item := range expected {
-			if conditionEqual(got, item) {
-				return true
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[part]
+			if !ok {
+				return nil, false
 			}
-		}
-		return false
-	case map[string]interface{}:
-		gotMap, ok := got.(map[string]interface{})
-		if !ok {
-			return false
-		}
-		for key, value := range expected {
-			gotValue, ok := nestedValue(gotMap, key)
-			if !ok || !conditionEqual(gotValue, value) {
-				return false
+			current = next
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
 			}
+			current = node[i]
+		default:
+			return nil, false
 		}
-		return true
-	}
-
-	if reflect.DeepEqual(got, want) {
-		return true
-	}
-	gotNumber, gotOK := numberValue(got)
-	wantNumber, wantOK := numberValue(want)
-	if gotOK && wantOK {
-		return gotNumber == wantNumber
-	}
-	return fmt.Sprint(got) == fmt.Sprint(want)
-}
-
-func numberValue(value interface{}) (float64, bool) {
-	switch v := value.(type) {
-	case int:
-		return float64(v), true
-	case int64:
-		return float64(v), true
-	case float64:
-		return v, true
-	case json.Number:
-		parsed, err := v.Float64()
-		return parsed, err == nil
-	case string:
-		parsed, err := strconv.ParseFloat(v, 64)
-		return parsed, err == nil
-	default:
-		return 0, false
 	}
+	return current, true
 }
@@ -0,0 +1,52 @@
+package triggers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RuleEvaluation is a dry-run of one rule against one stored event.
+type RuleEvaluation struct {
+	RuleID         string         `json:"rule_id"`
+	EventID        string         `json:"event_id"`
+	EventType      string         `json:"event_type"`
+	PatternMatched bool           `json:"pattern_matched"`
+	Matched        bool           `json:"matched"` // pattern and condition both match
+	Condition      ConditionTrace `json:"condition"`
+}
+
+// ExplainRule evaluates rule's event pattern and condition against a stored
+// event and returns the reasoning trace. condition, when non-empty, replaces
+// the rule's own so drafts can be tested before saving. Cooldown, recursion
+// and concurrency guards depend on live state and are not applied; nothing
+// fires and no execution is logged.
+func (e *Engine) ExplainRule(ctx context.Context, rule *TriggerRule, eventID string, condition json.RawMessage) (*RuleEvaluation, error) {
+	if len(condition) == 0 {
+		condition = rule.Condition
+	}
+	root, err := compileCondition(condition)
+	if err != nil {
+		return nil, &InvalidRuleError{fmt.Errorf("triggers: %w", err)}
+	}
+	if e.events == nil {
+		return nil, fmt.Errorf("triggers: event store not available")
+	}
+	event, err := e.events.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RuleEvaluation{
+		RuleID:         rule.ID,
+		EventID:        event.ID,
+		EventType:      string(event.EventType),
+		PatternMatched: rule.EventPattern == string(event.EventType),
+		Condition:      ConditionTrace{Matched: true, Steps: []ConditionStep{}},
+	}
+	if root != nil {
+		result.Condition = e.explainEvent(ctx, root, *event)
+	}
+	result.Matched = result.PatternMatched && result.Condition.Matched
+	return result, nil
+}
@@ -12,30 +12,32 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mycelis/core/pkg/protocol"
 )
 
 // TriggerRule is the DB + API representation of a trigger rule.
 type TriggerRule struct {
-	ID                      string          `json:"id"`
-	TenantID                string          `json:"tenant_id"`
-	Name                    string          `json:"name"`
-	Description             string          `json:"description,omitempty"`
-	TriggerKind             string          `json:"trigger_kind"`      // "event" | "schedule"
-	EventPattern            string          `json:"event_pattern"`     // e.g. "mission.completed"
-	Condition               json.RawMessage `json:"condition"`         // optional payload filter
-	TargetMissionID         string          `json:"target_mission_id"` // mission to launch
-	Mode                    string          `json:"mode"`              // "propose" | "auto_execute"
-	CooldownSeconds         int             `json:"cooldown_seconds"`
-	ScheduleIntervalSeconds int             `json:"schedule_interval_seconds,omitempty"`
-	NextRunAt               *time.Time      `json:"next_run_at,omitempty"`
-	ProofExpectations       string          `json:"proof_expectations,omitempty"`
-	RecoveryBehavior        string          `json:"recovery_behavior,omitempty"`
-	MaxDepth                int             `json:"max_depth"`       // recursion guard
-	MaxActiveRuns           int             `json:"max_active_runs"` // concurrency guard
-	IsActive                bool            `json:"is_active"`
-	LastFiredAt             *time.Time      `json:"last_fired_at,omitempty"`
-	CreatedAt               time.Time       `json:"created_at"`
-	UpdatedAt               time.Time       `json:"updated_at"`
+	ID                      string                   `json:"id"`
+	TenantID                string                   `json:"tenant_id"`
+	Name                    string                   `json:"name"`
+	Description             string                   `json:"description,omitempty"`
+	TriggerKind             string                   `json:"trigger_kind"`      // "event" | "schedule"
+	EventPattern            string                   `json:"event_pattern"`     // e.g. "mission.completed"
+	Condition               json.RawMessage          `json:"condition"`         // optional payload filter
+	TargetMissionID         string                   `json:"target_mission_id"` // mission to launch
+	Mode                    string                   `json:"mode"`              // "propose" | "auto_execute"
+	CooldownSeconds         int                      `json:"cooldown_seconds"`
+	ScheduleIntervalSeconds int                      `json:"schedule_interval_seconds,omitempty"`
+	Schedule                *protocol.ScheduleConfig `json:"schedule,omitempty"` // cron + calendar; overrides the interval
+	NextRunAt               *time.Time               `json:"next_run_at,omitempty"`
+	ProofExpectations       string                   `json:"proof_expectations,omitempty"`
+	RecoveryBehavior        string                   `json:"recovery_behavior,omitempty"`
+	MaxDepth                int                      `json:"max_depth"`       // recursion guard
+	MaxActiveRuns           int                      `json:"max_active_runs"` // concurrency guard
+	IsActive                bool                     `json:"is_active"`
+	LastFiredAt             *time.Time               `json:"last_fired_at,omitempty"`
+	CreatedAt               time.Time                `json:"created_at"`
+	UpdatedAt               time.Time                `json:"updated_at"`
 }
 
 // TriggerExecution is the audit record for a single evaluation.
@@ -96,14 +98,8 @@ func (s *Store) Create(ctx context.Context, r *TriggerRule) error {
 		return fmt.Errorf("triggers: target_mission_id is required")
 	}
 	normalizeRuleDefaults(r)
-	if !isValidTriggerKind(r.TriggerKind) {
-		return fmt.Errorf("triggers: trigger_kind must be event or schedule")
-	}
-	if r.TriggerKind != "schedule" && r.EventPattern == "" {
-		return fmt.Errorf("triggers: event_pattern is required")
-	}
-	if r.TriggerKind == "schedule" && r.ScheduleIntervalSeconds <= 0 {
-		return fmt.Errorf("triggers: schedule_interval_seconds is required")
+	if err := validateRule(r); err != nil {
+		return err
 	}
 
 	r.ID = uuid.New().String()
@@ -112,13 +108,15 @@ func (s *Store) Create(ctx context.Context, r *TriggerRule) error {
 		INSERT INTO trigger_rules
 		    (id, tenant_id, name, description, event_pattern, condition,
 		     target_mission_id, mode, cooldown_seconds, max_depth, max_active_runs, is_active,
-		     trigger_kind, schedule_interval_seconds, next_run_at, proof_expectations, recovery_behavior)
+		     trigger_kind, schedule_interval_seconds, next_run_at, proof_expectations, recovery_behavior,
+		     schedule_spec)
 		VALUES ($1, 'default', $2, NULLIF($3,''), $4, $5, $6, $7, $8, $9, $10, $11,
-		        $12, NULLIF($13,0), $14, NULLIF($15,''), NULLIF($16,''))
+		        $12, NULLIF($13,0), $14, NULLIF($15,''), NULLIF($16,''), $17)
 		RETURNING created_at, updated_at`,
 		r.ID, r.Name, r.Description, r.EventPattern, []byte(r.Condition),
 		r.TargetMissionID, r.Mode, r.CooldownSeconds, r.MaxDepth, r.MaxActiveRuns, r.IsActive,
 		r.TriggerKind, r.ScheduleIntervalSeconds, r.NextRunAt, r.ProofExpectations, r.RecoveryBehavior,
+		scheduleSpecValue(r.Schedule),
 	).Scan(&r.CreatedAt, &r.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("triggers: insert failed: %w", err)
@@ -146,14 +144,8 @@ func (s *Store) Update(ctx context.Context, r *TriggerRule) error {
 		r.Condition = json.RawMessage("{}")
 	}
 	normalizeRuleDefaults(r)
-	if !isValidTriggerKind(r.TriggerKind) {
-		return fmt.Errorf("triggers: trigger_kind must be event or schedule")
-	}
-	if r.TriggerKind != "schedule" && r.EventPattern == "" {
-		return fmt.Errorf("triggers: event_pattern is required")
-	}
-	if r.TriggerKind == "schedule" && r.ScheduleIntervalSeconds <= 0 {
-		return fmt.Errorf("triggers: schedule_interval_seconds is required")
+	if err := validateRule(r); err != nil {
+		return err
 	}
 
 	res, err := s.db.ExecContext(ctx, `
@@ -162,12 +154,13 @@ func (s *Store) Update(ctx context.Context, r *TriggerRule) error {
 		    target_mission_id=$5, mode=$6, cooldown_seconds=$7, max_depth=$8,
 		    max_active_runs=$9, is_active=$10, trigger_kind=$11,
 		    schedule_interval_seconds=NULLIF($12,0), next_run_at=$13,
-		    proof_expectations=NULLIF($14,''), recovery_behavior=NULLIF($15,''), updated_at=NOW()
-		WHERE id=$16 AND tenant_id='default'`,
+		    proof_expectations=NULLIF($14,''), recovery_behavior=NULLIF($15,''),
+		    schedule_spec=$16, updated_at=NOW()
+		WHERE id=$17 AND tenant_id='default'`,
 		r.Name, r.Description, r.EventPattern, []byte(r.Condition),
 		r.TargetMissionID, r.Mode, r.CooldownSeconds, r.MaxDepth,
 		r.MaxActiveRuns, r.IsActive, r.TriggerKind, r.ScheduleIntervalSeconds, r.NextRunAt,
-		r.ProofExpectations, r.RecoveryBehavior, r.ID)
+		r.ProofExpectations, r.RecoveryBehavior, scheduleSpecValue(r.Schedule), r.ID)
 	if err != nil {
 		return fmt.Errorf("triggers: update failed: %w", err)
 	}
@@ -201,9 +194,12 @@ func normalizeRuleDefaults(r *TriggerRule) {
 		if r.EventPattern == "" {
 			r.EventPattern = "scheduler.due"
 		}
-		if r.NextRunAt == nil && r.ScheduleIntervalSeconds > 0 {
-			next := time.Now().Add(time.Duration(r.ScheduleIntervalSeconds) * time.Second)
-			r.NextRunAt = &next
+		if r.NextRunAt == nil {
+			if sched, err := r.CompiledSchedule(); err == nil {
+				if next := sched.Next(time.Now()); !next.IsZero() {
+					r.NextRunAt = &next
+				}
+			}
 		}
 	}
 	if r.Mode == "" {
@@ -89,7 +89,7 @@ const triggerRuleSelectSQL = `
 	       condition, target_mission_id, mode, cooldown_seconds, max_depth,
 	       max_active_runs, is_active, last_fired_at, schedule_interval_seconds,
 	       next_run_at, COALESCE(proof_expectations,''), COALESCE(recovery_behavior,''),
-	       created_at, updated_at
+	       schedule_spec, created_at, updated_at
 	FROM trigger_rules`
 
 type triggerRuleScanner interface {
@@ -112,7 +112,7 @@ func triggerRuleScanTargets(r *TriggerRule, lastFired *sql.NullTime, nextRun *sq
 		&r.ID, &r.TenantID, &r.Name, &r.Description, &r.TriggerKind, &r.EventPattern,
 		&r.Condition, &r.TargetMissionID, &r.Mode, &r.CooldownSeconds,
 		&r.MaxDepth, &r.MaxActiveRuns, &r.IsActive, lastFired, &r.ScheduleIntervalSeconds, nextRun,
-		&r.ProofExpectations, &r.RecoveryBehavior,
+		&r.ProofExpectations, &r.RecoveryBehavior, scheduleSpecColumn{&r.Schedule},
 		&r.CreatedAt, &r.UpdatedAt,
 	}
 }
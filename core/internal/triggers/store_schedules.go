@@ -2,10 +2,35 @@ package triggers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/mycelis/core/internal/schedule"
+	"github.com/mycelis/core/pkg/protocol"
 )
 
+// CompiledSchedule resolves a schedule rule's cadence: its Schedule config
+// (cron expression, time zone, calendar, misfire policy) when set, with
+// schedule_interval_seconds as the interval when the config names neither.
+func (r *TriggerRule) CompiledSchedule() (*schedule.Schedule, error) {
+	var cfg protocol.ScheduleConfig
+	if r.Schedule != nil {
+		cfg = *r.Schedule
+	}
+	if cfg.CronExpr == "" && cfg.Interval == "" {
+		if r.ScheduleIntervalSeconds <= 0 {
+			return nil, fmt.Errorf("triggers: schedule_interval_seconds or schedule.cron_expr is required")
+		}
+		cfg.Interval = (time.Duration(r.ScheduleIntervalSeconds) * time.Second).String()
+	}
+	sched, err := schedule.Compile(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("triggers: %w", err)
+	}
+	return sched, nil
+}
+
 func (s *Store) ListDueScheduleRules(ctx context.Context, now time.Time, limit int) ([]TriggerRule, error) {
 	if s.db == nil {
 		return nil, fmt.Errorf("triggers: database not available")
@@ -45,7 +70,7 @@ func (s *Store) MarkScheduleProposed(ctx context.Context, id string, proposedAt
 	_, err := s.db.ExecContext(ctx, `
 		UPDATE trigger_rules
 		SET last_fired_at=$1, next_run_at=$2, updated_at=NOW()
-		WHERE id=$3 AND tenant_id='default'`, proposedAt, nextRunAt, id)
+		WHERE id=$3 AND tenant_id='default'`, proposedAt, nullableTime(nextRunAt), id)
 	if err != nil {
 		return fmt.Errorf("triggers: mark schedule proposed failed: %w", err)
 	}
@@ -53,8 +78,76 @@ func (s *Store) MarkScheduleProposed(ctx context.Context, id string, proposedAt
 	s.mu.Lock()
 	if r, ok := s.cache[id]; ok {
 		r.LastFiredAt = &proposedAt
-		r.NextRunAt = &nextRunAt
+		r.NextRunAt = timePtr(nextRunAt)
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// RescheduleRule moves a schedule rule's next run without recording a
+// proposal, e.g. when the misfire policy drops missed runs. A zero nextRunAt
+// clears it: the schedule has no further runs.
+func (s *Store) RescheduleRule(ctx context.Context, id string, nextRunAt time.Time) error {
+	if s.db == nil {
+		return fmt.Errorf("triggers: database not available")
+	}
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE trigger_rules SET next_run_at=$1, updated_at=NOW()
+		WHERE id=$2 AND tenant_id='default'`, nullableTime(nextRunAt), id)
+	if err != nil {
+		return fmt.Errorf("triggers: reschedule failed: %w", err)
+	}
+
+	s.mu.Lock()
+	if r, ok := s.cache[id]; ok {
+		r.NextRunAt = timePtr(nextRunAt)
 	}
 	s.mu.Unlock()
 	return nil
 }
+
+func nullableTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// scheduleSpecValue encodes a rule's schedule config for the JSONB column.
+func scheduleSpecValue(cfg *protocol.ScheduleConfig) any {
+	if cfg == nil {
+		return nil
+	}
+	data, _ := json.Marshal(cfg)
+	return data
+}
+
+// scheduleSpecColumn scans the nullable schedule_spec JSONB column.
+type scheduleSpecColumn struct{ dst **protocol.ScheduleConfig }
+
+func (c scheduleSpecColumn) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	}
+	*c.dst = nil
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	var cfg protocol.ScheduleConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("triggers: decode schedule_spec: %w", err)
+	}
+	*c.dst = &cfg
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mycelis/core/pkg/protocol"
 )
 
 func TestCreate_ScheduleRuleDefaultsToPropose(t *testing.T) {
@@ -55,7 +56,7 @@ func TestListDueScheduleRules(t *testing.T) {
 		WithArgs(now, 10).
 		WillReturnRows(sqlmock.NewRows(ruleColumns).
 			AddRow("r-1", "default", "Hourly review", "", "schedule", "scheduler.due",
-				[]byte(`{}`), "mission-review", "propose", 60, 5, 3, true, nil, 3600, now, "proof", "retry", now, now))
+				[]byte(`{}`), "mission-review", "propose", 60, 5, 3, true, nil, 3600, now, "proof", "retry", nil, now, now))
 
 	rules, err := s.ListDueScheduleRules(context.Background(), now, 10)
 	if err != nil {
@@ -90,3 +91,137 @@ func TestMarkScheduleProposed(t *testing.T) {
 		t.Fatalf("next_run_at = %v, want %v", got, next)
 	}
 }
+
+func TestCreate_CronScheduleRule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	s := NewStore(db)
+
+	now := time.Now()
+	mock.ExpectQuery("INSERT INTO trigger_rules").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+
+	rule := &TriggerRule{
+		Name:            "Morning report",
+		TriggerKind:     "schedule",
+		TargetMissionID: "mission-report",
+		Schedule: &protocol.ScheduleConfig{
+			CronExpr: "0 8 * * mon-fri",
+			Timezone: "Europe/Berlin",
+			Holidays: []string{"12-25"},
+		},
+		IsActive: true,
+	}
+	if err := s.Create(context.Background(), rule); err != nil {
+		t.Fatalf("Create cron schedule error: %v", err)
+	}
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	next := rule.NextRunAt.In(berlin)
+	if next.Hour() != 8 || next.Minute() != 0 || next.Weekday() == time.Saturday || next.Weekday() == time.Sunday {
+		t.Fatalf("next_run_at = %v, want 08:00 Berlin on a weekday", next)
+	}
+}
+
+func TestCreate_InvalidScheduleRejected(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	s := NewStore(db)
+
+	for _, rule := range []*TriggerRule{
+		{Name: "none", TriggerKind: "schedule", TargetMissionID: "m"},
+		{Name: "bad cron", TriggerKind: "schedule", TargetMissionID: "m",
+			Schedule: &protocol.ScheduleConfig{CronExpr: "0 8 * * weekdays"}},
+		{Name: "bad zone", TriggerKind: "schedule", TargetMissionID: "m",
+			Schedule: &protocol.ScheduleConfig{CronExpr: "@daily", Timezone: "Nowhere/City"}},
+	} {
+		if err := s.Create(context.Background(), rule); err == nil {
+			t.Errorf("Create(%s): expected validation error", rule.Name)
+		}
+	}
+}
+
+func TestListDueScheduleRules_DecodesScheduleSpec(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	s := NewStore(db)
+
+	now := time.Now()
+	spec := []byte(`{"type":"cron","cron_expr":"0 8 * * mon-fri","timezone":"Europe/Berlin","misfire":"skip"}`)
+	mock.ExpectQuery("SELECT .+ FROM trigger_rules").
+		WillReturnRows(sqlmock.NewRows(ruleColumns).
+			AddRow("r-1", "default", "Morning report", "", "schedule", "scheduler.due",
+				[]byte(`{}`), "mission-report", "propose", 60, 5, 3, true, nil, 0, now, "", "", spec, now, now))
+
+	rules, err := s.ListDueScheduleRules(context.Background(), now, 10)
+	if err != nil {
+		t.Fatalf("ListDueScheduleRules error: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Schedule == nil || rules[0].Schedule.Misfire != "skip" {
+		t.Fatalf("unexpected schedule: %+v", rules)
+	}
+	if _, err := rules[0].CompiledSchedule(); err != nil {
+		t.Fatalf("CompiledSchedule: %v", err)
+	}
+}
+
+func TestRescheduleRule_ClearsWhenNoFurtherRuns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	s := NewStore(db)
+
+	next := time.Now()
+	s.cache["r-1"] = &TriggerRule{ID: "r-1", TriggerKind: "schedule", NextRunAt: &next}
+	mock.ExpectExec("UPDATE trigger_rules SET next_run_at").
+		WithArgs(nil, "r-1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := s.RescheduleRule(context.Background(), "r-1", time.Time{}); err != nil {
+		t.Fatalf("RescheduleRule error: %v", err)
+	}
+	if s.cache["r-1"].NextRunAt != nil {
+		t.Fatalf("next_run_at = %v, want nil", s.cache["r-1"].NextRunAt)
+	}
+}
+
+func TestTeamScheduleRuns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	s := NewStore(db)
+	firedAt := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT last_fired_at FROM team_schedule_runs").WithArgs("team-a").
+		WillReturnRows(sqlmock.NewRows([]string{"last_fired_at"}))
+	mock.ExpectExec("INSERT INTO team_schedule_runs").WithArgs("team-a", firedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT last_fired_at FROM team_schedule_runs").WithArgs("team-a").
+		WillReturnRows(sqlmock.NewRows([]string{"last_fired_at"}).AddRow(firedAt))
+
+	ctx := context.Background()
+	if last, err := s.LastTeamScheduleRun(ctx, "team-a"); err != nil || !last.IsZero() {
+		t.Fatalf("first LastTeamScheduleRun = %s, %v; want zero time", last, err)
+	}
+	if err := s.RecordTeamScheduleRun(ctx, "team-a", firedAt); err != nil {
+		t.Fatalf("RecordTeamScheduleRun: %v", err)
+	}
+	if last, err := s.LastTeamScheduleRun(ctx, "team-a"); err != nil || !last.Equal(firedAt) {
+		t.Fatalf("LastTeamScheduleRun = %s, %v; want %s", last, err, firedAt)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
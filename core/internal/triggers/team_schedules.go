@@ -0,0 +1,45 @@
+package triggers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// LastTeamScheduleRun returns when the team's schedule last fired, or the
+// zero time when it never has. Implements swarm.TeamScheduleStore.
+func (s *Store) LastTeamScheduleRun(ctx context.Context, teamID string) (time.Time, error) {
+	if s.db == nil {
+		return time.Time{}, fmt.Errorf("triggers: database not available")
+	}
+	var last time.Time
+	err := s.db.QueryRowContext(ctx,
+		`SELECT last_fired_at FROM team_schedule_runs WHERE team_id = $1`, teamID).Scan(&last)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("triggers: last team schedule run: %w", err)
+	}
+	return last, nil
+}
+
+// RecordTeamScheduleRun stores firedAt as the team's last scheduled run.
+// An older run never replaces a newer one.
+func (s *Store) RecordTeamScheduleRun(ctx context.Context, teamID string, firedAt time.Time) error {
+	if s.db == nil {
+		return fmt.Errorf("triggers: database not available")
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO team_schedule_runs (team_id, last_fired_at)
+		VALUES ($1, $2)
+		ON CONFLICT (team_id) DO UPDATE SET
+			last_fired_at = GREATEST(team_schedule_runs.last_fired_at, EXCLUDED.last_fired_at), updated_at = NOW()
+	`, teamID, firedAt)
+	if err != nil {
+		return fmt.Errorf("triggers: record team schedule run: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mycelis/core/internal/cognitive"
+	"github.com/mycelis/core/internal/governance"
+	"github.com/mycelis/core/pkg/protocol"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/mycelis/core/pkg/pb/swarm"
+)
+
+// Enforcer implements cognitive.BudgetGate against the ledger. A call that
+// would cross a limit pauses its scope on a governance approval; each approval
+// extends the scope by one more multiple of its configured limit.
+type Enforcer struct {
+	ledger *Ledger
+	guard  *governance.Guard
+	events protocol.EventEmitter
+	now    func() time.Time
+
+	mu        sync.Mutex
+	scopes    map[string]*budgetState // "scope:key[:day]" -> pause state
+	store     BudgetStore             // optional; see SetStore
+	prunedDay time.Time               // states of earlier days are gone
+}
+
+type budgetState struct {
+	approvalID string // pending governance request, if any
+	grants     int    // approved extensions
+	denied     bool
+	stopped    bool      // budget.exceeded already emitted for the current pause
+	day        time.Time // the day a daily scope's state belongs to
+}
+
+// NewEnforcer creates a budget enforcer. guard and events may be nil; without
+// a guard exceeded scopes stay hard-stopped until the limit is raised.
+func NewEnforcer(ledger *Ledger, guard *governance.Guard, events protocol.EventEmitter) *Enforcer {
+	return &Enforcer{
+		ledger: ledger,
+		guard:  guard,
+		events: events,
+		now:    func() time.Time { return time.Now().UTC() },
+		scopes: make(map[string]*budgetState),
+	}
+}
+
+func (e *Enforcer) today() time.Time {
+	now := e.now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// budgetScope is one limit resolved for a specific check.
+type budgetScope struct {
+	name  string
+	key   string
+	limit cognitive.BudgetLimit
+	query Query
+	daily bool
+}
+
+// CheckBudget returns *cognitive.BudgetExceededError when the projected call
+// would cross any configured run, team, organization, or daily limit.
+func (e *Enforcer) CheckBudget(ctx context.Context, check cognitive.BudgetCheck) error {
+	if e == nil || e.ledger == nil {
+		return nil
+	}
+	day := e.today()
+	e.prune(day)
+	att := check.Attribution
+	policy := check.Policy
+
+	scopes := []budgetScope{
+		{name: cognitive.BudgetScopeRun, key: att.RunID, limit: policy.Run, query: Query{RunID: att.RunID}},
+		{name: cognitive.BudgetScopeTeam, key: att.TeamID, limit: policy.TeamLimit(att.TeamID), query: Query{TeamID: att.TeamID, Since: day}, daily: true},
+		{name: cognitive.BudgetScopeOrganization, key: att.OrganizationID, limit: policy.OrganizationLimit(att.OrganizationID), query: Query{OrganizationID: att.OrganizationID, Since: day}, daily: true},
+		{name: cognitive.BudgetScopeDaily, key: day.Format("2006-01-02"), limit: policy.Daily, query: Query{Since: day}},
+	}
+	for _, scope := range scopes {
+		if scope.key == "" || scope.limit.IsZero() {
+			continue
+		}
+		if err := e.checkScope(ctx, check, scope, day); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Enforcer) checkScope(ctx context.Context, check cognitive.BudgetCheck, scope budgetScope, day time.Time) error {
+	stateKey := scope.name + ":" + scope.key
+	var stateDay time.Time
+	if scope.daily {
+		stateKey += ":" + day.Format("2006-01-02")
+	}
+	if scope.daily || scope.name == cognitive.BudgetScopeDaily {
+		stateDay = day
+	}
+
+	e.mu.Lock()
+	state := e.scopes[stateKey]
+	e.mu.Unlock()
+	currency := check.Policy.CostCurrency()
+	exceeded := &cognitive.BudgetExceededError{Scope: scope.name, Key: scope.key, Limit: scope.limit, Currency: currency}
+	if state != nil && state.approvalID != "" {
+		// Already paused: don't spend a ledger query or raise a second approval.
+		exceeded.ApprovalID = state.approvalID
+		return exceeded
+	}
+
+	spentTokens, spentCost, err := e.spend(ctx, scope.query, currency)
+	if err != nil {
+		// A ledger outage must not take inference down with it.
+		log.Printf("[usage] budget check skipped for %s %s: %v", scope.name, scope.key, err)
+		return nil
+	}
+	exceeded.SpentTokens, exceeded.SpentCost = spentTokens, spentCost
+
+	grants := 0
+	if state != nil {
+		grants = state.grants
+	}
+	// Each approved extension adds one more multiple of the configured limit.
+	limit := cognitive.BudgetLimit{
+		MaxTokens: scope.limit.MaxTokens * int64(grants+1),
+		MaxCost:   scope.limit.MaxCost * float64(grants+1),
+	}
+	exceeded.Limit = limit
+	over := (limit.MaxTokens > 0 && spentTokens+check.ProjectedTokens > limit.MaxTokens) ||
+		(limit.MaxCost > 0 && spentCost+check.ProjectedCost > limit.MaxCost)
+	if !over {
+		return nil
+	}
+	if state != nil && state.denied {
+		exceeded.Denied = true
+		return exceeded
+	}
+
+	var first bool
+	exceeded.ApprovalID, first = e.pause(stateKey, stateDay, check, exceeded)
+	if first {
+		e.emitExceeded(ctx, check.Attribution, exceeded)
+	}
+	return exceeded
+}
+
+// spend totals ledger tokens across currencies and cost in the policy currency.
+func (e *Enforcer) spend(ctx context.Context, q Query, currency string) (int64, float64, error) {
+	buckets, err := e.ledger.Summarize(ctx, q)
+	if err != nil {
+		return 0, 0, err
+	}
+	var tokens int64
+	var cost float64
+	for _, b := range buckets {
+		tokens += b.TotalTokens
+		if b.Currency == currency {
+			cost += b.Cost
+		}
+	}
+	return tokens, cost, nil
+}
+
+// pause records the scope as stopped and, when a guard is wired, parks it on
+// a governance approval. Returns the approval request ID ("" without a guard)
+// and whether this call started the pause.
+func (e *Enforcer) pause(stateKey string, day time.Time, check cognitive.BudgetCheck, exceeded *cognitive.BudgetExceededError) (string, bool) {
+	e.mu.Lock()
+	state := e.scopes[stateKey]
+	if state == nil {
+		state = &budgetState{day: day}
+		e.scopes[stateKey] = state
+	}
+	first := !state.stopped
+	state.stopped = true
+	if state.approvalID != "" || e.guard == nil {
+		e.mu.Unlock()
+		return state.approvalID, first
+	}
+
+	data, _ := structpb.NewStruct(map[string]interface{}{
+		"scope":        exceeded.Scope,
+		"key":          exceeded.Key,
+		"run_id":       check.Attribution.RunID,
+		"max_tokens":   float64(exceeded.Limit.MaxTokens),
+		"max_cost":     exceeded.Limit.MaxCost,
+		"spent_tokens": float64(exceeded.SpentTokens),
+		"spent_cost":   exceeded.SpentCost,
+		"currency":     exceeded.Currency,
+	})
+	msg := &pb.MsgEnvelope{
+		Id:            uuid.New().String(),
+		Timestamp:     timestamppb.Now(),
+		SourceAgentId: check.Attribution.AgentID,
+		TeamId:        check.Attribution.TeamID,
+		Type:          pb.MessageType_MESSAGE_TYPE_EVENT,
+		Payload:       &pb.MsgEnvelope_Event{Event: &pb.EventPayload{EventType: string(protocol.EventBudgetExceeded), Data: data}},
+	}
+	reason := fmt.Sprintf("Budget exceeded: %s %s", exceeded.Scope, exceeded.Key)
+	state.approvalID = e.guard.RequestApproval(msg, reason, func(approved bool) {
+		e.resolve(stateKey, approved)
+	})
+	e.saveLocked(stateKey, state)
+	e.mu.Unlock()
+	return state.approvalID, first
+}
+
+// resolve applies a governance decision to a paused scope.
+func (e *Enforcer) resolve(stateKey string, approved bool) {
+	e.mu.Lock()
+	state := e.scopes[stateKey]
+	if state == nil {
+		e.mu.Unlock()
+		return
+	}
+	state.approvalID = ""
+	if approved {
+		state.grants++
+		state.denied = false
+		state.stopped = false
+	} else {
+		state.denied = true
+	}
+	e.saveLocked(stateKey, state)
+	e.mu.Unlock()
+}
+
+func (e *Enforcer) emitExceeded(ctx context.Context, att cognitive.UsageAttribution, exceeded *cognitive.BudgetExceededError) {
+	if e.events == nil || att.RunID == "" {
+		return
+	}
+	payload := map[string]interface{}{
+		"scope":        exceeded.Scope,
+		"key":          exceeded.Key,
+		"max_tokens":   exceeded.Limit.MaxTokens,
+		"max_cost":     exceeded.Limit.MaxCost,
+		"spent_tokens": exceeded.SpentTokens,
+		"spent_cost":   exceeded.SpentCost,
+		"currency":     exceeded.Currency,
+		"approval_id":  exceeded.ApprovalID,
+	}
+	if _, err := e.events.Emit(context.WithoutCancel(ctx), att.RunID, protocol.EventBudgetExceeded, protocol.SeverityWarn,
+		att.AgentID, att.TeamID, payload); err != nil {
+		log.Printf("[usage] budget.exceeded event failed for run %s: %v", att.RunID, err)
+	}
+}
@@ -0,0 +1,192 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mycelis/core/internal/governance"
+)
+
+// budgetWriteTimeout bounds state writes made from budget checks and
+// governance callbacks.
+const budgetWriteTimeout = 5 * time.Second
+
+// BudgetScopeState is the durable pause state of one budget scope.
+type BudgetScopeState struct {
+	Key        string    // "scope:key[:YYYY-MM-DD]"
+	Day        time.Time // the day the state belongs to; zero for run scopes
+	ApprovalID string    // pending governance request, if any
+	Grants     int
+	Denied     bool
+}
+
+// BudgetStore persists budget pause state so grants, denials and pending
+// approvals survive a restart. Implemented by BudgetRepository.
+type BudgetStore interface {
+	SaveBudgetScope(ctx context.Context, s BudgetScopeState) error
+	ListBudgetScopes(ctx context.Context) ([]BudgetScopeState, error)
+	PruneBudgetScopes(ctx context.Context, before time.Time) error
+}
+
+// BudgetRepository persists budget scopes in budget_scopes (migration 063).
+type BudgetRepository struct {
+	db *sql.DB
+}
+
+// NewBudgetRepository creates a repository backed by the shared DB.
+func NewBudgetRepository(db *sql.DB) *BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+// SaveBudgetScope upserts one scope's state.
+func (r *BudgetRepository) SaveBudgetScope(ctx context.Context, s BudgetScopeState) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("usage: database not available")
+	}
+	var day any
+	if !s.Day.IsZero() {
+		day = s.Day
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO budget_scopes (state_key, day, approval_id, grants, denied, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (state_key) DO UPDATE SET
+			approval_id = EXCLUDED.approval_id,
+			grants = EXCLUDED.grants,
+			denied = EXCLUDED.denied,
+			updated_at = NOW()
+	`, s.Key, day, s.ApprovalID, s.Grants, s.Denied)
+	if err != nil {
+		return fmt.Errorf("usage: persist budget scope failed: %w", err)
+	}
+	return nil
+}
+
+// ListBudgetScopes returns every saved scope.
+func (r *BudgetRepository) ListBudgetScopes(ctx context.Context) ([]BudgetScopeState, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("usage: database not available")
+	}
+	rows, err := r.db.QueryContext(ctx, `SELECT state_key, day, approval_id, grants, denied FROM budget_scopes`)
+	if err != nil {
+		return nil, fmt.Errorf("usage: list budget scopes failed: %w", err)
+	}
+	defer rows.Close()
+	var out []BudgetScopeState
+	for rows.Next() {
+		var s BudgetScopeState
+		var day sql.NullTime
+		if err := rows.Scan(&s.Key, &day, &s.ApprovalID, &s.Grants, &s.Denied); err != nil {
+			return nil, fmt.Errorf("usage: scan budget scope: %w", err)
+		}
+		s.Day = day.Time
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// PruneBudgetScopes deletes the scopes of days before the given one.
+func (r *BudgetRepository) PruneBudgetScopes(ctx context.Context, before time.Time) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("usage: database not available")
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM budget_scopes WHERE day < $1`, before); err != nil {
+		return fmt.Errorf("usage: prune budget scopes failed: %w", err)
+	}
+	return nil
+}
+
+// SetStore makes pause state durable and reloads what was saved before a
+// restart. Pending approvals are reclaimed from the guard; ones that settled
+// while nothing listened apply their stored outcome now.
+func (e *Enforcer) SetStore(ctx context.Context, store BudgetStore) error {
+	e.mu.Lock()
+	e.store = store
+	e.mu.Unlock()
+	if store == nil {
+		return nil
+	}
+	today := e.today()
+	if err := store.PruneBudgetScopes(ctx, today); err != nil {
+		return err
+	}
+	saved, err := store.ListBudgetScopes(ctx)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	e.prunedDay = today
+	for _, s := range saved {
+		e.scopes[s.Key] = &budgetState{approvalID: s.ApprovalID, grants: s.Grants, denied: s.Denied, day: s.Day, stopped: s.ApprovalID != ""}
+	}
+	e.mu.Unlock()
+
+	for _, s := range saved {
+		if s.ApprovalID == "" {
+			continue
+		}
+		key := s.Key
+		status := ""
+		if e.guard != nil {
+			status = e.guard.ReclaimApproval(ctx, s.ApprovalID, func(approved bool) { e.resolve(key, approved) })
+		}
+		switch status {
+		case governance.ApprovalPending:
+		case governance.ApprovalApproved:
+			e.resolve(key, true)
+		case governance.ApprovalDenied, governance.ApprovalExpired:
+			e.resolve(key, false)
+		default:
+			// The request is gone: let the next check raise a new one.
+			e.mu.Lock()
+			if state := e.scopes[key]; state != nil {
+				state.approvalID, state.stopped = "", false
+				e.saveLocked(key, state)
+			}
+			e.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// prune drops the state of days before day, once per day.
+func (e *Enforcer) prune(day time.Time) {
+	e.mu.Lock()
+	if !day.After(e.prunedDay) {
+		e.mu.Unlock()
+		return
+	}
+	e.prunedDay = day
+	for key, state := range e.scopes {
+		if !state.day.IsZero() && state.day.Before(day) {
+			delete(e.scopes, key)
+		}
+	}
+	store := e.store
+	e.mu.Unlock()
+	if store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), budgetWriteTimeout)
+	defer cancel()
+	if err := store.PruneBudgetScopes(ctx, day); err != nil {
+		log.Printf("[usage] budget scopes before %s not pruned: %v", day.Format("2006-01-02"), err)
+	}
+}
+
+// saveLocked writes state through the store. Callers hold e.mu, so saves of
+// one scope land in the order its state changed.
+func (e *Enforcer) saveLocked(key string, state *budgetState) {
+	if e.store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), budgetWriteTimeout)
+	defer cancel()
+	s := BudgetScopeState{Key: key, Day: state.day, ApprovalID: state.approvalID, Grants: state.grants, Denied: state.denied}
+	if err := e.store.SaveBudgetScope(ctx, s); err != nil {
+		log.Printf("[usage] budget scope %s not persisted: %v", key, err)
+	}
+}
@@ -0,0 +1,199 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mycelis/core/internal/cognitive"
+	"github.com/mycelis/core/internal/governance"
+)
+
+type memoryBudgetStore struct {
+	mu     sync.Mutex
+	scopes map[string]BudgetScopeState
+}
+
+func (s *memoryBudgetStore) SaveBudgetScope(_ context.Context, state BudgetScopeState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.scopes == nil {
+		s.scopes = make(map[string]BudgetScopeState)
+	}
+	s.scopes[state.Key] = state
+	return nil
+}
+
+func (s *memoryBudgetStore) ListBudgetScopes(context.Context) ([]BudgetScopeState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []BudgetScopeState
+	for _, state := range s.scopes {
+		out = append(out, state)
+	}
+	return out, nil
+}
+
+func (s *memoryBudgetStore) PruneBudgetScopes(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, state := range s.scopes {
+		if !state.Day.IsZero() && state.Day.Before(before) {
+			delete(s.scopes, key)
+		}
+	}
+	return nil
+}
+
+func TestEnforcer_RestartKeepsPendingApprovalAndGrants(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	guard, store := &governance.Guard{}, &memoryBudgetStore{}
+	e := NewEnforcer(NewLedger(db), guard, nil)
+	if err := e.SetStore(context.Background(), store); err != nil {
+		t.Fatalf("SetStore: %v", err)
+	}
+
+	mock.ExpectQuery("FROM inference_usage").WithArgs("run-1").WillReturnRows(spendRows(900, 0))
+	var exceeded *cognitive.BudgetExceededError
+	if err := e.CheckBudget(context.Background(), runBudgetCheck(200)); !errors.As(err, &exceeded) || exceeded.ApprovalID == "" {
+		t.Fatalf("expected a paused run, got %v", err)
+	}
+
+	// A restarted enforcer picks the pause up instead of raising a second approval.
+	restarted := NewEnforcer(NewLedger(db), guard, nil)
+	if err := restarted.SetStore(context.Background(), store); err != nil {
+		t.Fatalf("SetStore after restart: %v", err)
+	}
+	var paused *cognitive.BudgetExceededError
+	if err := restarted.CheckBudget(context.Background(), runBudgetCheck(10)); !errors.As(err, &paused) || paused.ApprovalID != exceeded.ApprovalID {
+		t.Fatalf("expected the saved pause to hold, got %v", err)
+	}
+	if pending := guard.ListPending(); len(pending) != 1 {
+		t.Fatalf("expected one approval, got %d", len(pending))
+	}
+
+	if _, err := guard.Resolve(exceeded.ApprovalID, true, "operator"); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if saved := store.scopes["run:run-1"]; saved.Grants != 1 || saved.ApprovalID != "" {
+		t.Fatalf("grant not persisted: %+v", saved)
+	}
+	again := NewEnforcer(NewLedger(db), guard, nil)
+	if err := again.SetStore(context.Background(), store); err != nil {
+		t.Fatalf("SetStore: %v", err)
+	}
+	mock.ExpectQuery("FROM inference_usage").WithArgs("run-1").WillReturnRows(spendRows(900, 0))
+	if err := again.CheckBudget(context.Background(), runBudgetCheck(200)); err != nil {
+		t.Fatalf("expected the persisted grant to extend the run, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+// settledApprovals is a governance store holding requests that settled
+// while the enforcer was down.
+type settledApprovals map[string]string
+
+func (settledApprovals) SaveApproval(context.Context, governance.Approval) error { return nil }
+func (settledApprovals) ListPendingApprovals(context.Context) ([]governance.Approval, error) {
+	return nil, nil
+}
+func (s settledApprovals) LoadApproval(_ context.Context, id string) (governance.Approval, error) {
+	status, ok := s[id]
+	if !ok {
+		return governance.Approval{}, errors.New("not found")
+	}
+	return governance.Approval{ID: id, Status: status}, nil
+}
+
+func TestEnforcer_AppliesDecisionsMadeWhileDown(t *testing.T) {
+	guard := &governance.Guard{}
+	_ = guard.SetApprovalStore(context.Background(), settledApprovals{"req-approved": governance.ApprovalApproved, "req-expired": governance.ApprovalExpired})
+	store := &memoryBudgetStore{scopes: map[string]BudgetScopeState{
+		"run:run-1": {Key: "run:run-1", ApprovalID: "req-approved"},
+		"run:run-2": {Key: "run:run-2", ApprovalID: "req-expired"},
+		"run:run-3": {Key: "run:run-3", ApprovalID: "req-gone"},
+	}}
+	e := NewEnforcer(NewLedger(nil), guard, nil)
+	if err := e.SetStore(context.Background(), store); err != nil {
+		t.Fatalf("SetStore: %v", err)
+	}
+	if saved := store.scopes["run:run-1"]; saved.Grants != 1 || saved.ApprovalID != "" {
+		t.Fatalf("approval made while down not applied: %+v", saved)
+	}
+	if saved := store.scopes["run:run-2"]; !saved.Denied || saved.ApprovalID != "" {
+		t.Fatalf("expiry made while down not applied: %+v", saved)
+	}
+	// An unknown request leaves the scope free to raise a new one.
+	if saved := store.scopes["run:run-3"]; saved.ApprovalID != "" || saved.Denied {
+		t.Fatalf("stale approval kept: %+v", saved)
+	}
+}
+
+func TestEnforcer_PrunesPastDays(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)
+	yesterday := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	store := &memoryBudgetStore{scopes: map[string]BudgetScopeState{
+		"team:alpha:2026-03-03": {Key: "team:alpha:2026-03-03", Day: yesterday, Denied: true},
+		"run:run-1":             {Key: "run:run-1", Grants: 2},
+	}}
+	e := NewEnforcer(NewLedger(nil), nil, nil)
+	e.now = func() time.Time { return now }
+	if err := e.SetStore(context.Background(), store); err != nil {
+		t.Fatalf("SetStore: %v", err)
+	}
+	if _, ok := e.scopes["team:alpha:2026-03-03"]; ok {
+		t.Fatal("yesterday's scope was restored")
+	}
+	if _, ok := store.scopes["run:run-1"]; !ok {
+		t.Fatal("run scopes are not tied to a day")
+	}
+
+	e.scopes["team:alpha:2026-03-04"] = &budgetState{day: now.Truncate(24 * time.Hour), denied: true}
+	now = now.Add(24 * time.Hour)
+	e.prune(e.today())
+	if _, ok := e.scopes["team:alpha:2026-03-04"]; ok {
+		t.Fatal("a day-old scope survived the day change")
+	}
+	if e.scopes["run:run-1"].grants != 2 {
+		t.Fatal("run scope pruned")
+	}
+}
+
+func TestBudgetRepository_PrunesByDay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	repo := NewBudgetRepository(db)
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("INSERT INTO budget_scopes").WithArgs("daily:2026-03-04", day, "req-1", 0, false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM budget_scopes WHERE day < \$1`).WithArgs(day).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectQuery("FROM budget_scopes").WillReturnRows(sqlmock.NewRows([]string{"state_key", "day", "approval_id", "grants", "denied"}).
+		AddRow("run:run-1", nil, "", 1, false))
+
+	if err := repo.SaveBudgetScope(context.Background(), BudgetScopeState{Key: "daily:2026-03-04", Day: day, ApprovalID: "req-1"}); err != nil {
+		t.Fatalf("SaveBudgetScope: %v", err)
+	}
+	if err := repo.PruneBudgetScopes(context.Background(), day); err != nil {
+		t.Fatalf("PruneBudgetScopes: %v", err)
+	}
+	scopes, err := repo.ListBudgetScopes(context.Background())
+	if err != nil || len(scopes) != 1 || !scopes[0].Day.IsZero() || scopes[0].Grants != 1 {
+		t.Fatalf("ListBudgetScopes = %+v, %v", scopes, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
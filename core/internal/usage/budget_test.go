@@ -0,0 +1,182 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mycelis/core/internal/cognitive"
+	"github.com/mycelis/core/internal/governance"
+	"github.com/mycelis/core/pkg/protocol"
+)
+
+type capturedEvent struct {
+	runID     string
+	eventType protocol.EventType
+	payload   map[string]interface{}
+}
+
+type fakeEmitter struct {
+	events []capturedEvent
+}
+
+func (f *fakeEmitter) Emit(_ context.Context, runID string, eventType protocol.EventType, _ protocol.EventSeverity,
+	_, _ string, payload map[string]interface{}) (string, error) {
+	f.events = append(f.events, capturedEvent{runID: runID, eventType: eventType, payload: payload})
+	return "ev-1", nil
+}
+
+func spendRows(tokens int64, cost float64) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"bucket", "currency", "calls", "prompt", "completion", "total", "estimated", "cost"}).
+		AddRow("total", "USD", 1, tokens, 0, tokens, 0, cost)
+}
+
+func runBudgetCheck(projected int64) cognitive.BudgetCheck {
+	return cognitive.BudgetCheck{
+		Attribution:     cognitive.UsageAttribution{TeamID: "council-core", AgentID: "architect", RunID: "run-1"},
+		Policy:          cognitive.BudgetPolicy{Run: cognitive.BudgetLimit{MaxTokens: 1000}},
+		ProjectedTokens: projected,
+	}
+}
+
+func TestEnforcer_UnderBudgetProceeds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	e := NewEnforcer(NewLedger(db), &governance.Guard{}, nil)
+
+	mock.ExpectQuery(`FROM inference_usage\s+WHERE run_id = \$1`).WithArgs("run-1").WillReturnRows(spendRows(400, 0))
+
+	if err := e.CheckBudget(context.Background(), runBudgetCheck(500)); err != nil {
+		t.Fatalf("expected call under budget to proceed, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestEnforcer_PausesRunOnApprovalAndExtendsWhenApproved(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	guard := &governance.Guard{}
+	emitter := &fakeEmitter{}
+	e := NewEnforcer(NewLedger(db), guard, emitter)
+
+	mock.ExpectQuery("FROM inference_usage").WithArgs("run-1").WillReturnRows(spendRows(900, 0))
+
+	err = e.CheckBudget(context.Background(), runBudgetCheck(200))
+	var exceeded *cognitive.BudgetExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected BudgetExceededError, got %v", err)
+	}
+	if exceeded.Scope != cognitive.BudgetScopeRun || exceeded.SpentTokens != 900 || exceeded.ApprovalID == "" {
+		t.Fatalf("unexpected stop %+v", exceeded)
+	}
+	if pending := guard.ListPending(); len(pending) != 1 || pending[0].RequestId != exceeded.ApprovalID {
+		t.Fatalf("expected governance approval %s, got %+v", exceeded.ApprovalID, pending)
+	}
+	if len(emitter.events) != 1 || emitter.events[0].eventType != protocol.EventBudgetExceeded || emitter.events[0].runID != "run-1" {
+		t.Fatalf("expected budget.exceeded on the run timeline, got %+v", emitter.events)
+	}
+
+	// While paused, calls stop without a ledger query, a second approval, or a second event.
+	err = e.CheckBudget(context.Background(), runBudgetCheck(10))
+	if !errors.As(err, &exceeded) || len(guard.ListPending()) != 1 || len(emitter.events) != 1 {
+		t.Fatalf("expected the existing pause to hold, err=%v pending=%d events=%d", err, len(guard.ListPending()), len(emitter.events))
+	}
+
+	if _, err := guard.Resolve(exceeded.ApprovalID, true, "operator"); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	mock.ExpectQuery("FROM inference_usage").WithArgs("run-1").WillReturnRows(spendRows(900, 0))
+	if err := e.CheckBudget(context.Background(), runBudgetCheck(200)); err != nil {
+		t.Fatalf("expected approved extension to allow the call, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestEnforcer_DeniedScopeStaysStopped(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	guard := &governance.Guard{}
+	e := NewEnforcer(NewLedger(db), guard, nil)
+
+	mock.ExpectQuery("FROM inference_usage").WillReturnRows(spendRows(1200, 0))
+	var exceeded *cognitive.BudgetExceededError
+	if err := e.CheckBudget(context.Background(), runBudgetCheck(0)); !errors.As(err, &exceeded) {
+		t.Fatalf("expected stop, got %v", err)
+	}
+	if _, err := guard.Resolve(exceeded.ApprovalID, false, "operator"); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	mock.ExpectQuery("FROM inference_usage").WillReturnRows(spendRows(1200, 0))
+	err = e.CheckBudget(context.Background(), runBudgetCheck(0))
+	if !errors.As(err, &exceeded) || !exceeded.Denied || exceeded.ApprovalID != "" {
+		t.Fatalf("expected denied hard stop, got %v", err)
+	}
+	if len(guard.ListPending()) != 0 {
+		t.Errorf("denied scope must not raise a new approval")
+	}
+}
+
+func TestEnforcer_DailyTeamCostLimitUsesOverride(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	e := NewEnforcer(NewLedger(db), nil, nil)
+	now := time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)
+	e.now = func() time.Time { return now }
+
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WHERE team_id = \$1 AND created_at >= \$2`).
+		WithArgs("council-core", day).
+		WillReturnRows(spendRows(10, 4.99))
+
+	check := cognitive.BudgetCheck{
+		Attribution: cognitive.UsageAttribution{TeamID: "council-core"},
+		Policy: cognitive.BudgetPolicy{
+			Team:  cognitive.BudgetLimit{MaxCost: 100},
+			Teams: map[string]cognitive.BudgetLimit{"council-core": {MaxCost: 5}},
+		},
+		ProjectedCost: 0.02,
+	}
+	var exceeded *cognitive.BudgetExceededError
+	if err := e.CheckBudget(context.Background(), check); !errors.As(err, &exceeded) {
+		t.Fatalf("expected team cost stop, got %v", err)
+	}
+	if exceeded.Scope != cognitive.BudgetScopeTeam || exceeded.Limit.MaxCost != 5 || exceeded.ApprovalID != "" {
+		t.Fatalf("unexpected stop %+v", exceeded)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestEnforcer_LedgerErrorFailsOpen(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	e := NewEnforcer(NewLedger(db), nil, nil)
+
+	mock.ExpectQuery("FROM inference_usage").WillReturnError(errors.New("connection reset"))
+	if err := e.CheckBudget(context.Background(), runBudgetCheck(5000)); err != nil {
+		t.Fatalf("expected ledger outage to fail open, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+// Package usage provides the durable inference usage ledger.
+// The cognitive router writes one record per completed inference; the
+// ledger aggregates them by run, team, agent, organization, provider, model,
+// or day so operators can answer what a mission cost.
+// Recording without a database returns an error; the router logs it and
+// the inference result is unaffected.
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mycelis/core/internal/cognitive"
+)
+
+// Dimensions the ledger can group by, mapped to their SQL expressions.
+var groupColumns = map[string]string{
+	"organization": "COALESCE(organization_id, '')",
+	"team":         "COALESCE(team_id, '')",
+	"agent":        "COALESCE(agent_id, '')",
+	"run":          "COALESCE(run_id, '')",
+	"profile":      "COALESCE(profile, '')",
+	"provider":     "provider_id",
+	"model":        "COALESCE(model_id, '')",
+	"day":          "to_char(date_trunc('day', created_at), 'YYYY-MM-DD')",
+}
+
+// Query filters and groups ledger rows. Empty fields do not filter; an empty
+// GroupBy returns a single "total" bucket per currency.
+type Query struct {
+	GroupBy        string
+	OrganizationID string
+	TeamID         string
+	AgentID        string
+	RunID          string
+	ProviderID     string
+	ModelID        string
+	Profile        string
+	Since          time.Time
+	Until          time.Time
+}
+
+// Bucket is one aggregated group of ledger rows.
+type Bucket struct {
+	Key              string  `json:"key"`
+	Calls            int64   `json:"calls"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	TotalTokens      int64   `json:"total_tokens"`
+	EstimatedCalls   int64   `json:"estimated_calls"`
+	Cost             float64 `json:"cost"`
+	Currency         string  `json:"currency"`
+}
+
+// Ledger persists inference usage records. Implements cognitive.UsageRecorder.
+type Ledger struct {
+	db *sql.DB
+}
+
+// NewLedger creates a Ledger backed by the shared DB. db may be nil (degraded mode).
+func NewLedger(db *sql.DB) *Ledger {
+	return &Ledger{db: db}
+}
+
+// ValidGroupBy reports whether groupBy is a supported aggregation dimension.
+func ValidGroupBy(groupBy string) bool {
+	if groupBy == "" {
+		return true
+	}
+	_, ok := groupColumns[groupBy]
+	return ok
+}
+
+// RecordUsage inserts one usage record.
+func (l *Ledger) RecordUsage(ctx context.Context, rec cognitive.UsageRecord) error {
+	if l == nil || l.db == nil {
+		return fmt.Errorf("usage: database not available")
+	}
+	if rec.ID == "" {
+		rec.ID = uuid.New().String()
+	}
+	if rec.CreatedAt.IsZero() {
+		rec.CreatedAt = time.Now().UTC()
+	}
+	if rec.Currency == "" {
+		rec.Currency = cognitive.DefaultUsageCurrency
+	}
+
+	_, err := l.db.ExecContext(ctx, `
+		INSERT INTO inference_usage
+			(id, organization_id, team_id, agent_id, run_id, profile, provider_id, model_id,
+			 prompt_tokens, completion_tokens, total_tokens, estimated, cost, currency, created_at)
+		VALUES ($1, NULLIF($2,''), NULLIF($3,''), NULLIF($4,''), NULLIF($5,''), NULLIF($6,''), $7, NULLIF($8,''),
+		        $9, $10, $11, $12, $13, $14, $15)
+	`, rec.ID, rec.Attribution.OrganizationID, rec.Attribution.TeamID, rec.Attribution.AgentID, rec.Attribution.RunID,
+		rec.Profile, rec.ProviderID, rec.ModelID,
+		rec.PromptTokens, rec.CompletionTokens, rec.TotalTokens, rec.Estimated, rec.Cost, rec.Currency, rec.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("usage: persist failed: %w", err)
+	}
+	return nil
+}
+
+// Summarize aggregates ledger rows matching q. Buckets are split by currency
+// so mixed price tables never sum incompatible amounts.
+func (l *Ledger) Summarize(ctx context.Context, q Query) ([]Bucket, error) {
+	if l == nil || l.db == nil {
+		return nil, fmt.Errorf("usage: database not available")
+	}
+	keyExpr := "'total'"
+	if q.GroupBy != "" {
+		col, ok := groupColumns[q.GroupBy]
+		if !ok {
+			return nil, fmt.Errorf("usage: unsupported group_by %q", q.GroupBy)
+		}
+		keyExpr = col
+	}
+
+	var where []string
+	var args []any
+	addFilter := func(column string, value any) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+	for _, filter := range []struct{ column, value string }{
+		{"organization_id", q.OrganizationID},
+		{"team_id", q.TeamID},
+		{"agent_id", q.AgentID},
+		{"run_id", q.RunID},
+		{"provider_id", q.ProviderID},
+		{"model_id", q.ModelID},
+		{"profile", q.Profile},
+	} {
+		if filter.value != "" {
+			addFilter(filter.column, filter.value)
+		}
+	}
+	if !q.Since.IsZero() {
+		args = append(args, q.Since)
+		where = append(where, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if !q.Until.IsZero() {
+		args = append(args, q.Until)
+		where = append(where, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS bucket, currency,
+		       COUNT(*), COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0),
+		       COALESCE(SUM(total_tokens), 0), COUNT(*) FILTER (WHERE estimated),
+		       COALESCE(SUM(cost), 0)::float8
+		FROM inference_usage`, keyExpr)
+	if len(where) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	query += "\n\t\tGROUP BY bucket, currency\n\t\tORDER BY SUM(cost) DESC, SUM(total_tokens) DESC"
+
+	rows, err := l.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("usage: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := []Bucket{}
+	for rows.Next() {
+		var b Bucket
+		if err := rows.Scan(&b.Key, &b.Currency, &b.Calls, &b.PromptTokens, &b.CompletionTokens,
+			&b.TotalTokens, &b.EstimatedCalls, &b.Cost); err != nil {
+			return nil, fmt.Errorf("usage: scan failed: %w", err)
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, rows.Err()
+}
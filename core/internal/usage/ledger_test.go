@@ -0,0 +1,125 @@
+package usage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mycelis/core/internal/cognitive"
+)
+
+// ── RecordUsage ────────────────────────────────────────────────────
+
+func TestRecordUsage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	l := NewLedger(db)
+
+	mock.ExpectExec("INSERT INTO inference_usage").
+		WithArgs(sqlmock.AnyArg(), "org-1", "council-core", "architect", "run-1",
+			"architect", "ollama", "qwen2.5", 120, 30, 150, false, 0.00042, "USD", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = l.RecordUsage(context.Background(), cognitive.UsageRecord{
+		Attribution:      cognitive.UsageAttribution{OrganizationID: "org-1", TeamID: "council-core", AgentID: "architect", RunID: "run-1"},
+		Profile:          "architect",
+		ProviderID:       "ollama",
+		ModelID:          "qwen2.5",
+		PromptTokens:     120,
+		CompletionTokens: 30,
+		TotalTokens:      150,
+		Cost:             0.00042,
+	})
+	if err != nil {
+		t.Fatalf("RecordUsage error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestRecordUsage_NilDB(t *testing.T) {
+	l := NewLedger(nil)
+	if err := l.RecordUsage(context.Background(), cognitive.UsageRecord{ProviderID: "ollama"}); err == nil {
+		t.Error("expected error with nil DB")
+	}
+}
+
+// ── Summarize ──────────────────────────────────────────────────────
+
+func TestSummarize_GroupByAgentWithFilters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	l := NewLedger(db)
+
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"bucket", "currency", "calls", "prompt", "completion", "total", "estimated", "cost"}).
+		AddRow("architect", "USD", 3, 900, 300, 1200, 1, 0.012).
+		AddRow("coder", "USD", 1, 100, 50, 150, 0, 0.001)
+	mock.ExpectQuery(`SELECT COALESCE\(agent_id, ''\) AS bucket.*WHERE run_id = \$1 AND created_at >= \$2.*GROUP BY bucket, currency`).
+		WithArgs("run-1", since).
+		WillReturnRows(rows)
+
+	buckets, err := l.Summarize(context.Background(), Query{GroupBy: "agent", RunID: "run-1", Since: since})
+	if err != nil {
+		t.Fatalf("Summarize error: %v", err)
+	}
+	if len(buckets) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(buckets))
+	}
+	if buckets[0].Key != "architect" || buckets[0].Calls != 3 || buckets[0].TotalTokens != 1200 || buckets[0].EstimatedCalls != 1 {
+		t.Errorf("unexpected first bucket: %+v", buckets[0])
+	}
+	if buckets[1].Cost != 0.001 || buckets[1].Currency != "USD" {
+		t.Errorf("unexpected second bucket: %+v", buckets[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet DB expectations: %v", err)
+	}
+}
+
+func TestSummarize_TotalWithoutGroupBy(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	l := NewLedger(db)
+
+	mock.ExpectQuery(`SELECT 'total' AS bucket`).
+		WillReturnRows(sqlmock.NewRows([]string{"bucket", "currency", "calls", "prompt", "completion", "total", "estimated", "cost"}))
+
+	buckets, err := l.Summarize(context.Background(), Query{})
+	if err != nil {
+		t.Fatalf("Summarize error: %v", err)
+	}
+	if buckets == nil || len(buckets) != 0 {
+		t.Errorf("expected empty non-nil slice, got %#v", buckets)
+	}
+}
+
+func TestSummarize_UnsupportedGroupBy(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	l := NewLedger(db)
+
+	if _, err := l.Summarize(context.Background(), Query{GroupBy: "tenant; DROP TABLE"}); err == nil {
+		t.Error("expected error for unsupported group_by")
+	}
+	if ValidGroupBy("week") {
+		t.Error("week should not be a valid group_by")
+	}
+	if !ValidGroupBy("") || !ValidGroupBy("model") {
+		t.Error("empty and model should be valid group_by values")
+	}
+}
@@ -0,0 +1,36 @@
+-- 051: Inference Usage Ledger
+-- One row per completed inference: tokens, provider/model, and the organization,
+-- team, agent, and run the call is attributed to. Cost is priced at write time
+-- from the provider's configured price table so later price changes do not
+-- rewrite history.
+
+CREATE TABLE IF NOT EXISTS inference_usage (
+    id                 UUID PRIMARY KEY,
+    tenant_id          TEXT NOT NULL DEFAULT 'default',
+    organization_id    TEXT,
+    team_id            TEXT,
+    agent_id           TEXT,
+    run_id             TEXT,
+    profile            TEXT,
+    provider_id        TEXT NOT NULL,
+    model_id           TEXT,
+    prompt_tokens      INT NOT NULL DEFAULT 0,
+    completion_tokens  INT NOT NULL DEFAULT 0,
+    total_tokens       INT NOT NULL DEFAULT 0,
+    estimated          BOOLEAN NOT NULL DEFAULT FALSE,
+    cost               NUMERIC(18, 8) NOT NULL DEFAULT 0,
+    currency           TEXT NOT NULL DEFAULT 'USD',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_inference_usage_created
+    ON inference_usage(created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_inference_usage_run
+    ON inference_usage(run_id) WHERE run_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_inference_usage_org
+    ON inference_usage(organization_id, created_at DESC) WHERE organization_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_inference_usage_team
+    ON inference_usage(team_id, created_at DESC) WHERE team_id IS NOT NULL;
@@ -0,0 +1,25 @@
+-- 052: Inference Response Cache
+-- Responses served by the cognitive router, keyed by a hash of the prompt,
+-- messages, and offered tools within one tenant/profile/provider scope.
+-- The embedding column backs the semantic tier (pgvector cosine distance,
+-- same 768 dimensions as context_vectors); it is NULL for exact-only entries.
+
+CREATE TABLE IF NOT EXISTS inference_cache (
+    id           UUID PRIMARY KEY,
+    tenant_id    TEXT NOT NULL DEFAULT 'default',
+    profile      TEXT NOT NULL DEFAULT '',
+    provider_id  TEXT NOT NULL,
+    request_key  TEXT NOT NULL,
+    embedding    vector(768),
+    response     JSONB NOT NULL,
+    hits         INT NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMPTZ NOT NULL,
+    UNIQUE (tenant_id, profile, provider_id, request_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inference_cache_expires
+    ON inference_cache(expires_at);
+
+CREATE INDEX IF NOT EXISTS idx_inference_cache_scope
+    ON inference_cache(tenant_id, profile, provider_id) WHERE embedding IS NOT NULL;
@@ -0,0 +1,25 @@
+-- 053: Durable Governance Approvals
+-- Pending approval requests outlive core restarts. One row per request; the
+-- guard upserts it on every vote, escalation, and settlement. The suspended
+-- bus message is kept as its protobuf encoding so approval can re-publish it.
+
+CREATE TABLE IF NOT EXISTS governance_approvals (
+    id                TEXT PRIMARY KEY,
+    reason            TEXT NOT NULL DEFAULT '',
+    original_message  BYTEA,
+    status            TEXT NOT NULL DEFAULT 'pending',
+    rule              JSONB NOT NULL DEFAULT '{}'::jsonb,
+    required_role     TEXT NOT NULL DEFAULT '',
+    quorum            INT NOT NULL DEFAULT 1,
+    escalated         BOOLEAN NOT NULL DEFAULT FALSE,
+    decisions         JSONB NOT NULL DEFAULT '[]'::jsonb,
+    core_origin       BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at        TIMESTAMPTZ NOT NULL,
+    resolved_at       TIMESTAMPTZ,
+    CONSTRAINT governance_approvals_status_check
+        CHECK (status IN ('pending', 'approved', 'denied', 'expired'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_governance_approvals_pending
+    ON governance_approvals(expires_at) WHERE status = 'pending';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS governance_decisions;
@@ -0,0 +1,20 @@
+-- 054: Governance Decision Log
+-- Every Guard.Intercept decision, with the event data and swarm context its
+-- conditions were evaluated against, so candidate policies can be replayed offline
+-- (POST /api/v1/governance/policy/simulate). Rows older than the retention
+-- window are pruned by the writer.
+
+CREATE TABLE IF NOT EXISTS governance_decisions (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    team_id       TEXT NOT NULL DEFAULT '',
+    agent_id      TEXT NOT NULL DEFAULT '',
+    intent        TEXT NOT NULL DEFAULT '',
+    payload       JSONB NOT NULL DEFAULT '{}'::jsonb,
+    swarm_context JSONB NOT NULL DEFAULT '{}'::jsonb,
+    action        TEXT NOT NULL,
+    request_id    TEXT NOT NULL DEFAULT '',
+    decided_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_governance_decisions_time
+    ON governance_decisions(decided_at DESC);
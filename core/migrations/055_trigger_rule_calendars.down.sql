@@ -0,0 +1,2 @@
+ALTER TABLE trigger_rules
+    DROP COLUMN IF EXISTS schedule_spec;
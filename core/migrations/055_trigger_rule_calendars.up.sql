@@ -0,0 +1,7 @@
+-- 055: Trigger rule calendars
+-- Schedule rules may carry a protocol.ScheduleConfig (cron expression, time
+-- zone, blackout windows, holidays, misfire policy). Rules without one keep
+-- using schedule_interval_seconds.
+
+ALTER TABLE trigger_rules
+    ADD COLUMN IF NOT EXISTS schedule_spec JSONB;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mcp_prompts;
+DROP TABLE IF EXISTS mcp_resources;
@@ -0,0 +1,29 @@
+-- 056: MCP resources and prompts
+-- Connected MCP servers may publish readable resources (files, schemas, ...)
+-- and prompt templates. Both are cached per server like mcp_tools and
+-- replaced on every discovery.
+
+CREATE TABLE IF NOT EXISTS mcp_resources (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    server_id UUID NOT NULL REFERENCES mcp_servers(id) ON DELETE CASCADE,
+    uri TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    mime_type TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(server_id, uri)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mcp_resources_server ON mcp_resources(server_id);
+
+CREATE TABLE IF NOT EXISTS mcp_prompts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    server_id UUID NOT NULL REFERENCES mcp_servers(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT,
+    arguments JSONB DEFAULT '[]',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(server_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mcp_prompts_server ON mcp_prompts(server_id);
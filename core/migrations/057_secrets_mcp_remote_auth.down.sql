@@ -0,0 +1,3 @@
+UPDATE mcp_servers SET transport = 'sse' WHERE transport = 'streamable_http';
+ALTER TABLE mcp_servers DROP COLUMN IF EXISTS auth;
+DROP TABLE IF EXISTS secrets;
@@ -0,0 +1,18 @@
+-- 057: encrypted secret store + remote MCP auth
+-- Secrets hold credentials referenced as "secret:NAME" (MCP headers, OAuth
+-- client secrets, OAuth tokens). Values are AES-GCM sealed by core; the
+-- database never sees plaintext.
+-- HTTP MCP servers choose between streamable HTTP and legacy SSE and may
+-- carry an OAuth configuration. Servers registered as "sse" before this
+-- migration were always dialled over streamable HTTP, so they keep that.
+
+CREATE TABLE IF NOT EXISTS secrets (
+    name TEXT PRIMARY KEY,
+    ciphertext BYTEA NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+ALTER TABLE mcp_servers ADD COLUMN IF NOT EXISTS auth JSONB;
+
+UPDATE mcp_servers SET transport = 'streamable_http' WHERE transport = 'sse';
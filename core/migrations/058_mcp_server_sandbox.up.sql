@@ -0,0 +1,6 @@
+-- 058: sandbox profiles for stdio MCP servers
+-- A non-null profile runs the server under bubblewrap with a read-only root,
+-- an optional writable workspace, optional network, a clean environment and
+-- cgroup v2 CPU/memory/pid limits.
+
+ALTER TABLE mcp_servers ADD COLUMN IF NOT EXISTS sandbox JSONB;
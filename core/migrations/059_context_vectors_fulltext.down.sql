@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_context_vectors_content_tsv;
+ALTER TABLE context_vectors DROP COLUMN IF EXISTS content_tsv;
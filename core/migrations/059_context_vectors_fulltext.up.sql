@@ -0,0 +1,11 @@
+-- 059: full-text search over retained context for hybrid recall
+-- Titles weigh more than body text; HybridSearch fuses this ranking with
+-- pgvector similarity using reciprocal rank fusion.
+
+ALTER TABLE context_vectors ADD COLUMN IF NOT EXISTS content_tsv tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(metadata->>'artifact_title', metadata->>'title', '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(content, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_context_vectors_content_tsv ON context_vectors USING GIN (content_tsv);
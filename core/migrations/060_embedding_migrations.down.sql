@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS embedding_migrations;
+DROP INDEX IF EXISTS idx_context_vectors_emb_nomic_embed_text_768;
+DROP INDEX IF EXISTS idx_context_vectors_embedding_model;
+DELETE FROM context_vectors WHERE embedding IS NOT NULL AND vector_dims(embedding) <> 768;
+ALTER TABLE context_vectors ALTER COLUMN embedding TYPE vector(768);
+ALTER TABLE context_vectors DROP COLUMN IF EXISTS embedding_dim;
+ALTER TABLE context_vectors DROP COLUMN IF EXISTS embedding_model;
@@ -0,0 +1,48 @@
+-- 060: embedding model tracking and re-embedding migrations
+-- Every context vector records the model and dimension that produced it, so
+-- recall only compares vectors from the same embedding space. The embedding
+-- column drops its fixed 768 width; per-model partial indexes cast it back
+-- to a typed vector. embedding_migrations tracks background re-embedding
+-- jobs with a resumable cursor.
+
+ALTER TABLE context_vectors ADD COLUMN IF NOT EXISTS embedding_model TEXT;
+ALTER TABLE context_vectors ADD COLUMN IF NOT EXISTS embedding_dim INT;
+
+UPDATE context_vectors
+SET embedding_model = 'nomic-embed-text', embedding_dim = vector_dims(embedding)
+WHERE embedding IS NOT NULL AND embedding_model IS NULL;
+
+ALTER TABLE context_vectors ALTER COLUMN embedding TYPE vector;
+
+CREATE INDEX IF NOT EXISTS idx_context_vectors_embedding_model
+    ON context_vectors(embedding_model, embedding_dim);
+
+CREATE INDEX IF NOT EXISTS idx_context_vectors_emb_nomic_embed_text_768
+    ON context_vectors USING hnsw ((embedding::vector(768)) vector_cosine_ops)
+    WHERE embedding_model = 'nomic-embed-text' AND embedding_dim = 768;
+
+CREATE TABLE IF NOT EXISTS embedding_migrations (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_model  TEXT NOT NULL DEFAULT '',  -- '' re-embeds every other model
+    target_model  TEXT NOT NULL,
+    target_dim    INT NOT NULL,
+    status        TEXT NOT NULL DEFAULT 'running', -- running | cancelled | failed | completed
+    total         INT NOT NULL DEFAULT 0,
+    processed     INT NOT NULL DEFAULT 0,
+    failed        INT NOT NULL DEFAULT 0,
+    cursor_id     UUID,
+    batch_size    INT NOT NULL DEFAULT 50,
+    per_minute    INT NOT NULL DEFAULT 600,
+    error_message TEXT,
+    created_by    TEXT NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_embedding_migrations_status
+    ON embedding_migrations(status, created_at DESC);
+
+-- At most one migration re-embeds at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_embedding_migrations_one_running
+    ON embedding_migrations((true)) WHERE status = 'running';
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_inference_cache_scope;
+ALTER TABLE inference_cache DROP CONSTRAINT IF EXISTS inference_cache_scope_key;
+DELETE FROM inference_cache WHERE embedding IS NOT NULL AND vector_dims(embedding) <> 768;
+DELETE FROM inference_cache a USING inference_cache b
+WHERE a.tenant_id = b.tenant_id AND a.profile = b.profile AND a.provider_id = b.provider_id
+  AND a.request_key = b.request_key AND a.created_at < b.created_at;
+ALTER TABLE inference_cache ALTER COLUMN embedding TYPE vector(768);
+ALTER TABLE inference_cache DROP COLUMN IF EXISTS embedding_model;
+ALTER TABLE inference_cache
+    ADD CONSTRAINT inference_cache_tenant_id_profile_provider_id_request_key_key UNIQUE (tenant_id, profile, provider_id, request_key);
+CREATE INDEX IF NOT EXISTS idx_inference_cache_scope
+    ON inference_cache(tenant_id, profile, provider_id) WHERE embedding IS NOT NULL;
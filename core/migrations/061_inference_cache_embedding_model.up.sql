@@ -0,0 +1,20 @@
+-- 061: Inference cache embedding model
+-- Semantic cache entries record the model that embedded them, and lookups
+-- only compare embeddings from the same model. The embedding column drops
+-- its fixed 768 width so any embed_model can back the semantic tier.
+
+ALTER TABLE inference_cache ADD COLUMN IF NOT EXISTS embedding_model TEXT NOT NULL DEFAULT '';
+
+UPDATE inference_cache SET embedding_model = 'nomic-embed-text'
+WHERE embedding IS NOT NULL AND embedding_model = '';
+
+ALTER TABLE inference_cache ALTER COLUMN embedding TYPE vector;
+
+ALTER TABLE inference_cache
+    DROP CONSTRAINT IF EXISTS inference_cache_tenant_id_profile_provider_id_request_key_key;
+ALTER TABLE inference_cache
+    ADD CONSTRAINT inference_cache_scope_key UNIQUE (tenant_id, profile, provider_id, embedding_model, request_key);
+
+DROP INDEX IF EXISTS idx_inference_cache_scope;
+CREATE INDEX IF NOT EXISTS idx_inference_cache_scope
+    ON inference_cache(tenant_id, profile, provider_id, embedding_model) WHERE embedding IS NOT NULL;
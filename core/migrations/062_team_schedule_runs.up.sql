@@ -0,0 +1,10 @@
+-- 062: Team schedule runs
+-- The last run each team schedule fired, so a restarted scheduler resumes
+-- from the run after it and applies the misfire policy to runs missed while
+-- the process was down.
+
+CREATE TABLE IF NOT EXISTS team_schedule_runs (
+    team_id       TEXT PRIMARY KEY,
+    last_fired_at TIMESTAMPTZ NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,16 @@
+-- 063: Budget scopes
+-- Pause state of budget scopes that crossed a limit: the pending approval,
+-- approved extensions and denials, so a restart neither forgets a grant nor
+-- raises a second approval. Rows of daily scopes are pruned once their day
+-- has passed.
+
+CREATE TABLE IF NOT EXISTS budget_scopes (
+    state_key   TEXT PRIMARY KEY,          -- scope:key[:YYYY-MM-DD]
+    day         DATE,                      -- set for scopes that reset daily
+    approval_id TEXT NOT NULL DEFAULT '',  -- pending governance_approvals.id
+    grants      INTEGER NOT NULL DEFAULT 0,
+    denied      BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_budget_scopes_day ON budget_scopes (day);
@@ -0,0 +1,10 @@
+-- 064: Secret store key derivation
+-- The salt the secret store derives its AES key from with scrypt. One row
+-- per store; values sealed before it existed open under the legacy key and
+-- are re-sealed at startup.
+
+CREATE TABLE IF NOT EXISTS secrets_kdf (
+    id         SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    salt       BYTEA NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
@@ -0,0 +1,15 @@
+package protocol
+
+// HeaderChatStreamSubject is the NATS header a chat requester sets on a
+// council/admin request to receive ChatStreamDelta messages while the agent
+// is still generating. The final reply still arrives on the normal inbox.
+const HeaderChatStreamSubject = "Mycelis-Chat-Stream"
+
+// ChatStreamDelta is one incremental text chunk of an in-progress chat turn.
+// Segment increments whenever the agent starts a new completion (tool-loop
+// re-inference or provider recovery); clients replace, not append, the
+// visible draft when the segment changes.
+type ChatStreamDelta struct {
+	Segment int    `json:"segment"`
+	Text    string `json:"text"`
+}
@@ -41,6 +41,9 @@ const (
 	EventTriggerFired   EventType = "trigger.fired"
 	EventTriggerSkipped EventType = "trigger.skipped"
 	EventSchedulerTick  EventType = "scheduler.tick"
+
+	// Budgets (inference usage ledger)
+	EventBudgetExceeded EventType = "budget.exceeded"
 )
 
 // EventSeverity classifies the operational significance of an event.
@@ -1,11 +1,32 @@
 package protocol
 
-// ScheduleConfig defines when a team should auto-trigger on a schedule.
-// V1 supports interval-based scheduling via Go duration strings ("5m", "1h").
+// ScheduleConfig defines when a team (or schedule trigger rule) runs: every
+// Interval, or at the times matched by CronExpr in Timezone. Runs that fall
+// on a holiday or inside a blackout window are skipped.
 type ScheduleConfig struct {
-	Type     string `json:"type" yaml:"type"`                               // "interval" (V1); "cron" reserved for V2
+	Type     string `json:"type" yaml:"type"`                               // "interval" | "cron"; inferred when empty
 	Interval string `json:"interval,omitempty" yaml:"interval,omitempty"`   // Go duration: "5m", "1h", "24h"
-	CronExpr string `json:"cron_expr,omitempty" yaml:"cron_expr,omitempty"` // Reserved for V2
+	CronExpr string `json:"cron_expr,omitempty" yaml:"cron_expr,omitempty"` // "0 8 * * mon-fri", "@daily"
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty"`   // IANA name; default UTC
+
+	Blackouts []ScheduleWindow `json:"blackouts,omitempty" yaml:"blackouts,omitempty"`
+	Holidays  []string         `json:"holidays,omitempty" yaml:"holidays,omitempty"` // "2026-12-24" once, "12-25" yearly
+
+	// Misfire decides what happens to runs missed while the scheduler was
+	// down or late by more than MisfireGrace (default "5m"):
+	// "run_once" (default) fires the latest missed run, "skip" drops them,
+	// "catch_up" fires each one in order (at most 24).
+	Misfire      string `json:"misfire,omitempty" yaml:"misfire,omitempty"`
+	MisfireGrace string `json:"misfire_grace,omitempty" yaml:"misfire_grace,omitempty"`
+}
+
+// ScheduleWindow is a blackout window. Start and End are either "HH:MM"
+// times of day (End before Start wraps past midnight), optionally limited to
+// Days ("mon".."sun"), or absolute local times "YYYY-MM-DD[THH:MM]".
+type ScheduleWindow struct {
+	Start string   `json:"start" yaml:"start"`
+	End   string   `json:"end" yaml:"end"`
+	Days  []string `json:"days,omitempty" yaml:"days,omitempty"`
 }
 
 // VerifyStrategy defines how an agent proves its work.
@@ -40,6 +61,10 @@ type AgentManifest struct {
 	Tools         []string      `json:"tools,omitempty" yaml:"tools,omitempty"`                   // MCP + internal tool names bound to this agent
 	MaxIterations int           `json:"max_iterations,omitempty" yaml:"max_iterations,omitempty"` // ReAct loop limit (0 = DefaultMaxIterations)
 	Verification  *Verification `json:"verification,omitempty" yaml:"verification,omitempty"`
+
+	// Sampling overrides the profile and organization sampling parameters
+	// for this agent's inference calls.
+	Sampling *SamplingParams `json:"sampling,omitempty" yaml:"sampling,omitempty"`
 }
 
 // EffectiveMaxIterations returns the ReAct loop limit, using the default if unset.
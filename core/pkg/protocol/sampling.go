@@ -0,0 +1,48 @@
+package protocol
+
+// SamplingParams tunes generation for a cognitive profile, an organization
+// AI-engine preset, or a single agent manifest. Nil pointers and zero values
+// mean "inherit"; Merge layers a more specific set over a less specific one.
+type SamplingParams struct {
+	Temperature *float64 `json:"temperature,omitempty" yaml:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty" yaml:"top_p,omitempty"`
+	Stop        []string `json:"stop,omitempty" yaml:"stop,omitempty"`
+	MaxTokens   int      `json:"max_tokens,omitempty" yaml:"max_tokens,omitempty"`
+	Seed        *int     `json:"seed,omitempty" yaml:"seed,omitempty"`
+
+	// JSONMode requires a JSON object reply; ResponseSchema further requires
+	// it to match a JSON Schema. Once a layer sets either, more specific
+	// layers can tighten the schema but not drop the requirement.
+	JSONMode       bool           `json:"json_mode,omitempty" yaml:"json_mode,omitempty"`
+	ResponseSchema map[string]any `json:"response_schema,omitempty" yaml:"response_schema,omitempty"`
+}
+
+// Merge returns p with every field set in over taking precedence.
+func (p SamplingParams) Merge(over SamplingParams) SamplingParams {
+	if over.Temperature != nil {
+		p.Temperature = over.Temperature
+	}
+	if over.TopP != nil {
+		p.TopP = over.TopP
+	}
+	if len(over.Stop) > 0 {
+		p.Stop = over.Stop
+	}
+	if over.MaxTokens > 0 {
+		p.MaxTokens = over.MaxTokens
+	}
+	if over.Seed != nil {
+		p.Seed = over.Seed
+	}
+	p.JSONMode = p.JSONMode || over.JSONMode
+	if len(over.ResponseSchema) > 0 {
+		p.ResponseSchema = over.ResponseSchema
+	}
+	return p
+}
+
+// IsZero reports whether no field is set.
+func (p SamplingParams) IsZero() bool {
+	return p.Temperature == nil && p.TopP == nil && len(p.Stop) == 0 && p.MaxTokens == 0 &&
+		p.Seed == nil && !p.JSONMode && len(p.ResponseSchema) == 0
+}
@@ -43,7 +43,7 @@ interface ProviderFormData {
 
 const PROVIDER_PRESETS: Record<string, Partial<ProviderFormData>> = {
     ollama: {
-        type: "openai_compatible",
+        type: "ollama",
         endpoint: "http://localhost:11434/v1",
         location: "local",
         data_boundary: "local_only",
@@ -1,18 +1,39 @@
 export interface MCPServer {
     id: string;
     name: string;
-    transport: 'stdio' | 'sse';
+    transport: 'stdio' | 'streamable_http' | 'sse';
     command?: string;
     args?: string[];
     env?: Record<string, string>;
     url?: string;
     headers?: Record<string, string>;
+    auth?: MCPServerAuth;
+    sandbox?: MCPSandboxProfile;
     status: string;
     error?: string;
     created_at: string;
     capability_ids?: string[];
 }
 
+export interface MCPServerAuth {
+    type: 'oauth_client_credentials' | 'oauth_pkce';
+    client_id: string;
+    client_secret_ref?: string;
+    token_url?: string;
+    auth_server_metadata_url?: string;
+    redirect_uri?: string;
+    scopes?: string[];
+}
+
+export interface MCPSandboxProfile {
+    network?: boolean;
+    workspace?: string;
+    memory_mb?: number;
+    cpu_percent?: number;
+    max_pids?: number;
+    pass_env?: string[];
+}
+
 export interface MCPTool {
     id: string;
     server_id: string;
@@ -214,9 +214,30 @@ export interface CognitiveEngineStatus {
     model?: string;
 }
 
+export interface ProviderHealthEvent {
+    at: string;
+    ok: boolean;
+    attempts: number;
+    latency_ms: number;
+    error?: string;
+    state: CircuitState;
+}
+
+export type CircuitState = 'closed' | 'open' | 'half_open';
+
+export interface ProviderHealth {
+    provider_id: string;
+    state: CircuitState;
+    consecutive_failures: number;
+    opened_at?: string;
+    retry_at?: string;
+    history: ProviderHealthEvent[];
+}
+
 export interface CognitiveStatus {
     text: CognitiveEngineStatus;
     media: CognitiveEngineStatus;
+    providers?: ProviderHealth[];
 }
 
 export interface ServiceHealthStatus {
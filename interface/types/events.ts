@@ -19,7 +19,8 @@ export type EventType =
   | 'memory.recalled'
   | 'trigger.fired'
   | 'trigger.skipped'
-  | 'scheduler.tick';
+  | 'scheduler.tick'
+  | 'budget.exceeded';
 
 export type EventSeverity = 'debug' | 'info' | 'warn' | 'error';
 
@@ -80,6 +81,7 @@ export const EVENT_TYPE_COLORS: Record<string, string> = {
   'trigger.fired':     '#f59e0b',
   'trigger.skipped':   '#71717a',
   'scheduler.tick':    '#71717a',
+  'budget.exceeded':   '#ef4444',
 };
 
 export const SEVERITY_COLORS: Record<EventSeverity, string> = {